@@ -0,0 +1,103 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// AdaptiveOptions controls RQProcessAdaptive
+type AdaptiveOptions struct {
+	Initial []float64 // Starting tau grid (default 0.1, 0.3, 0.5, 0.7, 0.9)
+	Budget  int       // Total number of taus to fit (default 19)
+	MinGap  float64   // Smallest spacing between adjacent taus (default 0.01)
+}
+
+// RQProcessAdaptive fits the quantile process on a tau grid refined where it
+// matters. Starting from opts.Initial, it repeatedly bisects the interval between
+// adjacent taus over which the coefficients change most, measured as the sum over
+// columns of |delta beta_j| times the mean absolute value of column j so that all
+// terms are on the scale of y. Intervals whose fitted quantiles cross are bisected
+// first. Refinement stops once opts.Budget taus are fitted or no interval is wider
+// than twice opts.MinGap.
+func RQProcessAdaptive(y []float64, x [][]float64, opts AdaptiveOptions) (*MultiRQFit, error) {
+	start := time.Now()
+	if opts.Initial == nil {
+		opts.Initial = []float64{0.1, 0.3, 0.5, 0.7, 0.9}
+	}
+	if opts.Budget == 0 {
+		opts.Budget = 19
+	}
+	if opts.MinGap == 0 {
+		opts.MinGap = 0.01
+	}
+	if len(opts.Initial) < 2 {
+		return nil, fmt.Errorf("need at least 2 initial quantile levels, got %d", len(opts.Initial))
+	}
+	if opts.Budget < len(opts.Initial) {
+		return nil, fmt.Errorf("budget %d is smaller than the %d initial quantile levels", opts.Budget, len(opts.Initial))
+	}
+	if opts.MinGap <= 0 {
+		return nil, fmt.Errorf("minimum gap must be positive, got %v", opts.MinGap)
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+
+	m, err := RQProcess(y, x, opts.Initial)
+	if err != nil {
+		return nil, err
+	}
+	scale := make([]float64, m.P)
+	for _, row := range x {
+		for j, v := range row {
+			scale[j] += math.Abs(v) / float64(len(x))
+		}
+	}
+
+	for len(m.Taus) < opts.Budget {
+		best, bestScore := -1, 0.0
+		for k := 1; k < len(m.Taus); k++ {
+			lo, hi := m.Fits[m.Taus[k-1]], m.Fits[m.Taus[k]]
+			if m.Taus[k]-m.Taus[k-1] < 2*opts.MinGap {
+				continue
+			}
+			score := 0.0
+			for j := range scale {
+				score += math.Abs(hi.Coefficients[j]-lo.Coefficients[j]) * scale[j]
+			}
+			if crosses(lo.FittedValues(), hi.FittedValues()) {
+				score = math.Inf(1)
+			}
+			if best < 0 || score > bestScore {
+				best, bestScore = k, score
+			}
+		}
+		if best < 0 {
+			break
+		}
+		tau := (m.Taus[best-1] + m.Taus[best]) / 2
+		fit, err := RQ(y, x, tau)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fit model for tau=%f: %w", tau, err)
+		}
+		m.Fits[tau] = fit
+		m.Taus = append(m.Taus, tau)
+		sort.Float64s(m.Taus)
+	}
+
+	m.Meta = newMeta(m.Method, map[string]float64{"budget": float64(opts.Budget), "min_gap": opts.MinGap},
+		m.Taus, m.N, m.P, start, y, x)
+	return m, nil
+}
+
+// crosses reports whether any fitted value at the upper tau lies below the lower one
+func crosses(lower, upper []float64) bool {
+	for i := range lower {
+		if upper[i] < lower[i] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package quantreg
+
+import (
+	"testing"
+)
+
+func TestRQProcessAdaptive(t *testing.T) {
+	y, x := inferenceData()
+
+	m, err := RQProcessAdaptive(y, x, AdaptiveOptions{Budget: 9})
+	if err != nil {
+		t.Fatalf("Failed to fit adaptive process: %v", err)
+	}
+	if len(m.Taus) != 9 || len(m.Fits) != 9 {
+		t.Fatalf("Expected 9 taus, got %d", len(m.Taus))
+	}
+	for k := 1; k < len(m.Taus); k++ {
+		if m.Taus[k] <= m.Taus[k-1] {
+			t.Fatalf("Expected sorted distinct taus, got %v", m.Taus)
+		}
+	}
+	for _, tau := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+		if m.Fits[tau] == nil {
+			t.Errorf("Expected initial tau %v to be kept", tau)
+		}
+	}
+	if m.Meta.Options["budget"] != 9 {
+		t.Errorf("Expected budget in metadata, got %v", m.Meta.Options)
+	}
+
+	// A wide minimum gap stops refinement before the budget is spent
+	coarse, err := RQProcessAdaptive(y, x, AdaptiveOptions{Initial: []float64{0.25, 0.5, 0.75}, MinGap: 0.1})
+	if err != nil {
+		t.Fatalf("Failed to fit adaptive process: %v", err)
+	}
+	if len(coarse.Taus) != 5 {
+		t.Errorf("Expected refinement to stop at 5 taus, got %v", coarse.Taus)
+	}
+
+	if _, err := RQProcessAdaptive(y, x, AdaptiveOptions{Budget: 3}); err == nil {
+		t.Error("Expected error for budget below the initial grid")
+	}
+	if _, err := RQProcessAdaptive(y, x, AdaptiveOptions{Initial: []float64{0.5}}); err == nil {
+		t.Error("Expected error for a single initial tau")
+	}
+}
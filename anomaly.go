@@ -0,0 +1,114 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// AnomalyOptions controls DetectAnomalies
+type AnomalyOptions struct {
+	Low       float64 // Lower band quantile (default smallest fitted tau)
+	High      float64 // Upper band quantile (default largest fitted tau)
+	MinStreak int     // Minimum run of same-side anomalies reported as a streak (default 3)
+}
+
+// Anomaly is an observation outside the conditional quantile band
+type Anomaly struct {
+	Index     int
+	Value     float64
+	Lower     float64
+	Upper     float64
+	Direction int     // +1 above the upper band, -1 below the lower band
+	Severity  float64 // Distance beyond the band in units of band width
+}
+
+// AnomalyStreak is a run of consecutive anomalies on the same side of the band
+type AnomalyStreak struct {
+	Start       int // Index of the first observation
+	End         int // Index of the last observation
+	Direction   int
+	MaxSeverity float64
+}
+
+// AnomalyReport lists anomalies and streaks found by DetectAnomalies
+type AnomalyReport struct {
+	Low       float64
+	High      float64
+	Anomalies []Anomaly
+	Streaks   []AnomalyStreak
+}
+
+// DetectAnomalies flags observations y at newX falling outside the band between the
+// Low and High conditional quantiles, scores their severity relative to the band
+// width and groups consecutive same-side anomalies into streaks. Rows are assumed
+// to be in time order for streak detection.
+func (m *MultiRQFit) DetectAnomalies(newX [][]float64, y []float64, opts AnomalyOptions) (*AnomalyReport, error) {
+	if len(newX) != len(y) {
+		return nil, fmt.Errorf("%w: newX has %d rows, y has %d", ErrDimensionMismatch, len(newX), len(y))
+	}
+	if len(m.Taus) < 2 {
+		return nil, fmt.Errorf("need at least 2 quantile levels, got %d", len(m.Taus))
+	}
+	if opts.Low == 0 {
+		opts.Low = m.Taus[0]
+	}
+	if opts.High == 0 {
+		opts.High = m.Taus[len(m.Taus)-1]
+	}
+	if opts.MinStreak == 0 {
+		opts.MinStreak = 3
+	}
+	if opts.Low >= opts.High {
+		return nil, fmt.Errorf("lower band quantile %f must be below upper %f", opts.Low, opts.High)
+	}
+
+	lower, err := m.PredictTau(newX, opts.Low)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := m.PredictTau(newX, opts.High)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AnomalyReport{Low: opts.Low, High: opts.High}
+	var run []Anomaly
+	flush := func() {
+		if len(run) >= opts.MinStreak {
+			s := AnomalyStreak{Start: run[0].Index, End: run[len(run)-1].Index, Direction: run[0].Direction}
+			for _, a := range run {
+				s.MaxSeverity = math.Max(s.MaxSeverity, a.Severity)
+			}
+			report.Streaks = append(report.Streaks, s)
+		}
+		run = nil
+	}
+
+	for i, yi := range y {
+		// Rearrange crossed bands
+		if lower[i] > upper[i] {
+			lower[i], upper[i] = upper[i], lower[i]
+		}
+		width := math.Max(upper[i]-lower[i], 1e-12)
+		a := Anomaly{Index: i, Value: yi, Lower: lower[i], Upper: upper[i]}
+		switch {
+		case yi > upper[i]:
+			a.Direction = 1
+			a.Severity = (yi - upper[i]) / width
+		case yi < lower[i]:
+			a.Direction = -1
+			a.Severity = (lower[i] - yi) / width
+		default:
+			flush()
+			continue
+		}
+		report.Anomalies = append(report.Anomalies, a)
+		if len(run) > 0 && (run[len(run)-1].Index != i-1 || run[0].Direction != a.Direction) {
+			flush()
+		}
+		run = append(run, a)
+	}
+	flush()
+
+	return report, nil
+}
@@ -0,0 +1,54 @@
+package quantreg
+
+import "testing"
+
+func TestDetectAnomalies(t *testing.T) {
+	x := [][]float64{{1, 0.5}, {1, 1.0}, {1, 1.5}, {1, 2.0}, {1, 2.5}}
+	y := []float64{1.0, 2.0, 2.5, 3.0, 4.0}
+
+	fits, err := RQProcess(y, x, []float64{0.1, 0.5, 0.9})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	newX := make([][]float64, 8)
+	for i := range newX {
+		newX[i] = []float64{1, 1.5}
+	}
+	band, _ := fits.Predict(newX[:1])
+	lo, hi := band[0.1][0], band[0.9][0]
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	w := hi - lo
+	mid := (lo + hi) / 2
+	obs := []float64{mid, hi + w, hi + 2*w, hi + 0.5*w, mid, lo - w, mid, lo - 3*w}
+
+	report, err := fits.DetectAnomalies(newX, obs, AnomalyOptions{})
+	if err != nil {
+		t.Fatalf("Failed to detect anomalies: %v", err)
+	}
+	if len(report.Anomalies) != 5 {
+		t.Fatalf("Expected 5 anomalies, got %d", len(report.Anomalies))
+	}
+	if report.Anomalies[0].Index != 1 || report.Anomalies[0].Direction != 1 {
+		t.Errorf("Unexpected first anomaly: %+v", report.Anomalies[0])
+	}
+	if last := report.Anomalies[4]; last.Direction != -1 || last.Severity < 2.9 || last.Severity > 3.1 {
+		t.Errorf("Expected severity about 3 below the band, got %+v", last)
+	}
+	if len(report.Streaks) != 1 {
+		t.Fatalf("Expected 1 streak, got %d", len(report.Streaks))
+	}
+	if s := report.Streaks[0]; s.Start != 1 || s.End != 3 || s.Direction != 1 || s.MaxSeverity < 1.9 {
+		t.Errorf("Unexpected streak: %+v", s)
+	}
+
+	// Error cases
+	if _, err := fits.DetectAnomalies(newX, obs[:2], AnomalyOptions{}); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+	if _, err := fits.DetectAnomalies(newX, obs, AnomalyOptions{Low: 0.9, High: 0.1}); err == nil {
+		t.Error("Expected error for inverted band")
+	}
+}
@@ -0,0 +1,117 @@
+package quantreg
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BacktestOptions controls the windows used by Backtest
+type BacktestOptions struct {
+	Window    int  // Training window length; the minimum length when Expanding
+	Expanding bool // Grow the window from the start of the series instead of rolling it
+	Step      int  // Observations forecast per refit (default 1)
+	Workers   int  // Windows fitted in parallel (default runtime.NumCPU())
+}
+
+// BacktestResult holds out-of-sample quantile forecasts and their aggregate scores
+type BacktestResult struct {
+	Taus       []float64
+	Index      []int                 // Time index of every forecast
+	Actual     []float64             // Realized values at Index
+	Forecasts  map[float64][]float64 // Forecasts per tau aligned with Index
+	Origins    []int                 // First forecast index of each window
+	WindowLoss []float64             // Mean pinball loss over taus and steps of each window
+	Scores     *ForecastScores       // Pinball-based CRPS, WIS and coverage over all forecasts
+}
+
+// Backtest refits quantile regressions on rolling or expanding windows of the
+// time-ordered rows of (y, x) and forecasts the following Step observations from
+// each window, so every forecast uses only data before it. Windows are fitted
+// concurrently.
+func Backtest(y []float64, x [][]float64, taus []float64, opts BacktestOptions) (*BacktestResult, error) {
+	n := len(y)
+	if len(x) != n {
+		return nil, fmt.Errorf("%w: y has %d rows, x has %d rows", ErrDimensionMismatch, n, len(x))
+	}
+	if opts.Step == 0 {
+		opts.Step = 1
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Window <= 0 || opts.Window >= n {
+		return nil, fmt.Errorf("window must be between 1 and %d, got %d", n-1, opts.Window)
+	}
+	if opts.Step < 0 {
+		return nil, fmt.Errorf("step must be positive, got %d", opts.Step)
+	}
+
+	var origins []int
+	for t := opts.Window; t < n; t += opts.Step {
+		origins = append(origins, t)
+	}
+
+	preds := make([]map[float64][]float64, len(origins))
+	errs := make([]error, len(origins))
+	sem := make(chan struct{}, opts.Workers)
+	var wg sync.WaitGroup
+	for w, t := range origins {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(w, t int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := 0
+			if !opts.Expanding {
+				start = t - opts.Window
+			}
+			end := t + opts.Step
+			if end > n {
+				end = n
+			}
+			fits, err := RQProcess(y[start:t], x[start:t], taus)
+			if err != nil {
+				errs[w] = fmt.Errorf("window at %d: %w", t, err)
+				return
+			}
+			preds[w], errs[w] = fits.Predict(x[t:end])
+		}(w, t)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &BacktestResult{
+		Origins:   origins,
+		Forecasts: make(map[float64][]float64),
+	}
+	for w, t := range origins {
+		loss := 0.0
+		count := 0
+		for tau, q := range preds[w] {
+			res.Forecasts[tau] = append(res.Forecasts[tau], q...)
+			for j, v := range q {
+				loss += rho(y[t+j]-v, tau)
+				count++
+			}
+		}
+		res.WindowLoss = append(res.WindowLoss, loss/float64(count))
+		for j := range preds[w][taus[0]] {
+			res.Index = append(res.Index, t+j)
+			res.Actual = append(res.Actual, y[t+j])
+		}
+	}
+
+	scores, err := ScoreQuantiles(res.Forecasts, res.Actual)
+	if err != nil {
+		return nil, err
+	}
+	res.Scores = scores
+	res.Taus = scores.Taus
+	return res, nil
+}
@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBacktest(t *testing.T) {
+	n := 16
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = []float64{1, float64(i) / 4}
+		y[i] = 1 + 0.5*float64(i)/4 + 0.2*math.Sin(float64(5*i))
+	}
+	taus := []float64{0.25, 0.75}
+
+	rolling, err := Backtest(y, x, taus, BacktestOptions{Window: 10, Step: 2, Workers: 2})
+	if err != nil {
+		t.Fatalf("Failed to run backtest: %v", err)
+	}
+	if len(rolling.Origins) != 3 || len(rolling.WindowLoss) != 3 {
+		t.Errorf("Expected 3 windows, got %d", len(rolling.Origins))
+	}
+	if len(rolling.Index) != 6 || rolling.Index[0] != 10 || rolling.Index[5] != 15 {
+		t.Errorf("Unexpected forecast index: %v", rolling.Index)
+	}
+	for _, tau := range taus {
+		if len(rolling.Forecasts[tau]) != 6 {
+			t.Errorf("Expected 6 forecasts for tau=%f, got %d", tau, len(rolling.Forecasts[tau]))
+		}
+	}
+	if rolling.Actual[0] != y[10] || rolling.Scores == nil {
+		t.Errorf("Unexpected result: %+v", rolling)
+	}
+
+	// The first window is identical for rolling and expanding backtests
+	expanding, err := Backtest(y, x, taus, BacktestOptions{Window: 10, Step: 2, Expanding: true})
+	if err != nil {
+		t.Fatalf("Failed to run backtest: %v", err)
+	}
+	if expanding.Forecasts[0.25][0] != rolling.Forecasts[0.25][0] {
+		t.Errorf("Expected matching first-window forecasts, got %f and %f",
+			expanding.Forecasts[0.25][0], rolling.Forecasts[0.25][0])
+	}
+
+	// Error cases
+	if _, err := Backtest(y, x, taus, BacktestOptions{Window: n}); err == nil {
+		t.Error("Expected error for window as long as the series")
+	}
+	if _, err := Backtest(y[:5], x, taus, BacktestOptions{Window: 3}); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
@@ -0,0 +1,52 @@
+package quantreg
+
+import (
+	"math"
+	"sort"
+)
+
+// basicObservations returns, in increasing index order, the p observations with
+// the smallest absolute residuals. At an exact vertex solution these are the
+// observations the fit interpolates.
+func basicObservations(residuals []float64, p int) []int {
+	n := len(residuals)
+	if p > n {
+		p = n
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return math.Abs(residuals[idx[a]]) < math.Abs(residuals[idx[b]])
+	})
+	basic := append([]int(nil), idx[:p]...)
+	sort.Ints(basic)
+	return basic
+}
+
+// ZeroResiduals returns the indices of observations whose residual is zero to
+// within tol. A non-positive tol defaults to 1e-8 times the largest absolute
+// response, or 1e-8 when the response is unavailable.
+func (fit *RQFit) ZeroResiduals(tol float64) []int {
+	if tol <= 0 {
+		scale := 1.0
+		for _, v := range fit.Y {
+			scale = math.Max(scale, math.Abs(v))
+		}
+		tol = 1e-8 * scale
+	}
+	var zero []int
+	for i, r := range fit.ResidualValues() {
+		if math.Abs(r) <= tol {
+			zero = append(zero, i)
+		}
+	}
+	return zero
+}
+
+// Degenerate reports whether more than P residuals are zero to within tol, in
+// which case the solution is degenerate and the basis is not unique
+func (fit *RQFit) Degenerate(tol float64) bool {
+	return len(fit.ZeroResiduals(tol)) > fit.P
+}
@@ -0,0 +1,55 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBasicObs(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if len(fit.BasicObs) != fit.P {
+		t.Fatalf("Expected %d basic observations, got %d", fit.P, len(fit.BasicObs))
+	}
+	for k := 1; k < len(fit.BasicObs); k++ {
+		if fit.BasicObs[k] <= fit.BasicObs[k-1] {
+			t.Errorf("Expected basic observations in index order, got %v", fit.BasicObs)
+		}
+	}
+
+	// No other residual may be strictly smaller than a basic one
+	largest := 0.0
+	basic := make(map[int]bool)
+	for _, i := range fit.BasicObs {
+		basic[i] = true
+		if r := math.Abs(fit.Residuals[i]); r > largest {
+			largest = r
+		}
+	}
+	for i, r := range fit.Residuals {
+		if !basic[i] && math.Abs(r) < largest {
+			t.Errorf("Observation %d has residual %v below basic residual %v", i, r, largest)
+		}
+	}
+}
+
+func TestZeroResiduals(t *testing.T) {
+	fit := &RQFit{
+		P:         2,
+		Y:         []float64{1, 2, 3, 4},
+		Residuals: []float64{0, 0.5, 1e-12, 0},
+	}
+	zero := fit.ZeroResiduals(0)
+	if len(zero) != 3 || zero[0] != 0 || zero[1] != 2 || zero[2] != 3 {
+		t.Errorf("Expected zero residuals [0 2 3], got %v", zero)
+	}
+	if !fit.Degenerate(0) {
+		t.Error("Expected degenerate solution with 3 zero residuals and P=2")
+	}
+	if fit.Degenerate(1e-20) {
+		t.Error("Expected non-degenerate solution at a tight tolerance")
+	}
+}
@@ -0,0 +1,127 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// BLBOptions controls RQBLB
+type BLBOptions struct {
+	Subsets   int     // Number of little subsets s (default 10)
+	Resamples int     // Bootstrap resamples r per subset (default 50)
+	Gamma     float64 // Subset size n^Gamma (default 0.7)
+	Level     float64 // Confidence level of the percentile intervals (default 0.95)
+}
+
+// BLBResult holds Bag of Little Bootstraps inference for the coefficients
+type BLBResult struct {
+	Tau        float64
+	SubsetSize int
+	StdErrors  []float64 // Mean over subsets of the bootstrap standard deviations
+	Lower      []float64 // Mean over subsets of the lower percentile bounds
+	Upper      []float64 // Mean over subsets of the upper percentile bounds
+	Level      float64
+}
+
+// RQBLB estimates standard errors and percentile intervals with the Bag of Little
+// Bootstraps (Kleiner et al. 2014). Each of s random subsets of size b = n^gamma is
+// resampled r times with multinomial counts summing to n, which are used as
+// weights in RQWeighted, so every fit involves only b distinct rows. The
+// per-subset quality assessments are averaged.
+func RQBLB(y []float64, x [][]float64, tau float64, opts BLBOptions, rng *rand.Rand) (*BLBResult, error) {
+	start := time.Now()
+	n := len(y)
+	if len(x) != n || n == 0 {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if opts.Subsets == 0 {
+		opts.Subsets = 10
+	}
+	if opts.Resamples == 0 {
+		opts.Resamples = 50
+	}
+	if opts.Gamma == 0 {
+		opts.Gamma = 0.7
+	}
+	if opts.Level == 0 {
+		opts.Level = 0.95
+	}
+	if opts.Gamma <= 0.5 || opts.Gamma > 1 {
+		return nil, fmt.Errorf("gamma must be in (0.5, 1], got %f", opts.Gamma)
+	}
+	if opts.Level <= 0 || opts.Level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	if opts.Subsets < 1 || opts.Resamples < 2 {
+		return nil, fmt.Errorf("need at least 1 subset and 2 resamples")
+	}
+
+	p := len(x[0])
+	b := int(math.Ceil(math.Pow(float64(n), opts.Gamma)))
+	if b <= p {
+		return nil, fmt.Errorf("subset size %d is too small for %d parameters", b, p)
+	}
+
+	rng = randOrDefault(rng)
+	res := &BLBResult{
+		Tau:        tau,
+		SubsetSize: b,
+		StdErrors:  make([]float64, p),
+		Lower:      make([]float64, p),
+		Upper:      make([]float64, p),
+		Level:      opts.Level,
+	}
+	alpha := 1 - opts.Level
+	sy := make([]float64, b)
+	sx := make([][]float64, b)
+	w := make([]float64, b)
+	draws := make([][]float64, p)
+
+	for s := 0; s < opts.Subsets; s++ {
+		for k, i := range rng.Perm(n)[:b] {
+			sy[k], sx[k] = y[i], x[i]
+		}
+		for j := range draws {
+			draws[j] = draws[j][:0]
+		}
+		var err error
+		withPhase(PhaseBootstrap, func() {
+			for r := 0; r < opts.Resamples; r++ {
+				for k := range w {
+					w[k] = 0
+				}
+				for k := 0; k < n; k++ {
+					w[rng.Intn(b)]++
+				}
+				fit, ferr := RQWeighted(sy, sx, w, tau)
+				if ferr != nil {
+					err = fmt.Errorf("subset %d resample %d failed: %w", s, r, ferr)
+					return
+				}
+				for j, c := range fit.Coefficients {
+					draws[j] = append(draws[j], c)
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for j := 0; j < p; j++ {
+			_, se := meanSE(draws[j])
+			sd := se * math.Sqrt(float64(len(draws[j])))
+			sorted := append([]float64(nil), draws[j]...)
+			sort.Float64s(sorted)
+			res.StdErrors[j] += sd / float64(opts.Subsets)
+			res.Lower[j] += empiricalQuantile(sorted, alpha/2) / float64(opts.Subsets)
+			res.Upper[j] += empiricalQuantile(sorted, 1-alpha/2) / float64(opts.Subsets)
+		}
+	}
+
+	currentLogger().Info("bootstrap complete", "tau", tau, "subsets", opts.Subsets, "resamples", opts.Resamples,
+		"duration", time.Since(start))
+	return res, nil
+}
@@ -0,0 +1,35 @@
+package quantreg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRQBLB(t *testing.T) {
+	y, x := inferenceData()
+
+	res, err := RQBLB(y, x, 0.5, BLBOptions{Subsets: 2, Resamples: 5}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to run BLB: %v", err)
+	}
+	// ceil(20^0.7) = 9
+	if res.SubsetSize != 9 || res.Level != 0.95 {
+		t.Errorf("Unexpected subset size or level: %d, %f", res.SubsetSize, res.Level)
+	}
+	for j := range res.StdErrors {
+		if res.StdErrors[j] < 0 || res.Lower[j] > res.Upper[j] {
+			t.Errorf("Unexpected inference for coefficient %d: se=%f [%f, %f]", j, res.StdErrors[j], res.Lower[j], res.Upper[j])
+		}
+	}
+
+	// Error cases
+	if _, err := RQBLB(y, x, 0.5, BLBOptions{Gamma: 0.4}, nil); err == nil {
+		t.Error("Expected error for gamma below 0.5")
+	}
+	if _, err := RQBLB(y, x, 0.5, BLBOptions{Resamples: 1}, nil); err == nil {
+		t.Error("Expected error for a single resample")
+	}
+	if _, err := RQBLB(y[:3], x, 0.5, BLBOptions{}, nil); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
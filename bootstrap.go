@@ -0,0 +1,115 @@
+package quantreg
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// BootstrapOptions controls Bootstrap
+type BootstrapOptions struct {
+	Replications int // Number of bootstrap replications (default 200)
+}
+
+// PredictiveDraws is the bootstrap distribution of predicted quantiles
+type PredictiveDraws struct {
+	Point []float64   // Predictions from the fitted coefficients
+	Draws [][]float64 // Predictions under each draw, one row per new observation
+	Lower []float64   // Lower percentile bounds
+	Upper []float64   // Upper percentile bounds
+	Level float64
+}
+
+// Bootstrap draws coefficient vectors by the pairs bootstrap, refitting with
+// multinomial resampling counts as weights (multiplied into any fit weights),
+// and stores them in fit.Draws for PredictDraws. Draws are replaced on each call.
+func (fit *RQFit) Bootstrap(opts BootstrapOptions, rng *rand.Rand) error {
+	if len(fit.X) == 0 || len(fit.Y) == 0 {
+		return fmt.Errorf("fit does not carry its design matrix")
+	}
+	if opts.Replications == 0 {
+		opts.Replications = 200
+	}
+	if opts.Replications < 2 {
+		return fmt.Errorf("need at least 2 replications, got %d", opts.Replications)
+	}
+
+	rng = randOrDefault(rng)
+	w := make([]float64, len(fit.Y))
+	draws := make([][]float64, 0, opts.Replications)
+	var err error
+	d := withPhase(PhaseBootstrap, func() {
+		for r := 0; r < opts.Replications; r++ {
+			coef, ferr := fit.bootstrapDraw(rng, w)
+			if ferr != nil {
+				err = fmt.Errorf("replication %d failed: %w", r, ferr)
+				return
+			}
+			draws = append(draws, coef)
+		}
+	})
+	fit.recordPhase(PhaseBootstrap, d)
+	if err != nil {
+		return err
+	}
+	currentLogger().Info("bootstrap complete", "tau", fit.Tau, "replications", opts.Replications, "duration", d)
+	fit.Draws = draws
+	return nil
+}
+
+// bootstrapDraw refits on a pairs bootstrap resample drawn from rng, using w as
+// scratch space for the resampling weights
+func (fit *RQFit) bootstrapDraw(rng *rand.Rand, w []float64) ([]float64, error) {
+	n := len(fit.Y)
+	for i := range w {
+		w[i] = 0
+	}
+	for k := 0; k < n; k++ {
+		w[rng.Intn(n)]++
+	}
+	if fit.Weights != nil {
+		for i := range w {
+			w[i] *= fit.Weights[i]
+		}
+	}
+	bf, err := RQWeighted(fit.Y, fit.X, w, fit.Tau)
+	if err != nil {
+		return nil, err
+	}
+	return bf.Coefficients, nil
+}
+
+// PredictDraws propagates the stored bootstrap draws to predictions at newX and
+// returns the full draw matrix with percentile intervals at the given level
+func (fit *RQFit) PredictDraws(newX [][]float64, level float64) (*PredictiveDraws, error) {
+	if len(fit.Draws) == 0 {
+		return nil, fmt.Errorf("fit has no bootstrap draws, call Bootstrap first")
+	}
+	if level <= 0 || level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	point, err := fit.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+
+	alpha := 1 - level
+	pd := &PredictiveDraws{
+		Point: point,
+		Draws: make([][]float64, len(newX)),
+		Lower: make([]float64, len(newX)),
+		Upper: make([]float64, len(newX)),
+		Level: level,
+	}
+	for i, row := range newX {
+		pd.Draws[i] = make([]float64, len(fit.Draws))
+		for r, coef := range fit.Draws {
+			pd.Draws[i][r] = dot(row, coef)
+		}
+		sorted := append([]float64(nil), pd.Draws[i]...)
+		sort.Float64s(sorted)
+		pd.Lower[i] = empiricalQuantile(sorted, alpha/2)
+		pd.Upper[i] = empiricalQuantile(sorted, 1-alpha/2)
+	}
+	return pd, nil
+}
@@ -0,0 +1,50 @@
+package quantreg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPredictDraws(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	newX := [][]float64{{1, 2}, {1, 10}}
+	if _, err := fit.PredictDraws(newX, 0.9); err == nil {
+		t.Error("Expected error before bootstrapping")
+	}
+
+	if err := fit.Bootstrap(BootstrapOptions{Replications: 40}, rand.New(rand.NewSource(1))); err != nil {
+		t.Fatalf("Failed to bootstrap: %v", err)
+	}
+	if len(fit.Draws) != 40 || len(fit.Draws[0]) != fit.P {
+		t.Fatalf("Expected 40 draws of %d coefficients, got %d", fit.P, len(fit.Draws))
+	}
+
+	pd, err := fit.PredictDraws(newX, 0.9)
+	if err != nil {
+		t.Fatalf("Failed to predict draws: %v", err)
+	}
+	for i := range newX {
+		if len(pd.Draws[i]) != 40 {
+			t.Errorf("Expected 40 draws for observation %d, got %d", i, len(pd.Draws[i]))
+		}
+		if pd.Lower[i] > pd.Upper[i] {
+			t.Errorf("Expected ordered bounds, got [%f, %f]", pd.Lower[i], pd.Upper[i])
+		}
+	}
+	// Extrapolation widens the interval
+	if pd.Upper[1]-pd.Lower[1] <= pd.Upper[0]-pd.Lower[0] {
+		t.Errorf("Expected a wider interval away from the data, got %f and %f",
+			pd.Upper[1]-pd.Lower[1], pd.Upper[0]-pd.Lower[0])
+	}
+
+	if _, err := fit.PredictDraws(newX, 1); err == nil {
+		t.Error("Expected error for level 1")
+	}
+	if err := fit.Bootstrap(BootstrapOptions{Replications: 1}, nil); err == nil {
+		t.Error("Expected error for a single replication")
+	}
+}
@@ -0,0 +1,85 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// BoundedOptions controls RQBounded
+type BoundedOptions struct {
+	Lower, Upper float64 // Range of the outcome (default 0 and 1)
+	Epsilon      float64 // Offset applied to observations on a bound, in units of the range (default 0.5/n)
+}
+
+// BoundedFit is a quantile regression of a logit-transformed bounded outcome
+type BoundedFit struct {
+	*RQFit               // Fit on the logit scale
+	Lower, Upper float64 // Outcome range
+	Epsilon      float64 // Offset applied to boundary observations
+	AtBounds     int     // Number of observations moved off a bound
+}
+
+// RQBounded fits Q_tau(logit((y-L)/(U-L)) | x) = x'b for outcomes in [L, U] such as
+// proportions and rates. Observations on a bound are moved Epsilon inside the
+// range before transforming. Since quantiles are equivariant to the monotone
+// logit, back-transformed predictions L + (U-L) logistic(x'b) are conditional
+// quantiles of y and always lie inside the range.
+func RQBounded(y []float64, x [][]float64, tau float64, opts BoundedOptions) (*BoundedFit, error) {
+	if opts.Lower == 0 && opts.Upper == 0 {
+		opts.Upper = 1
+	}
+	if opts.Upper <= opts.Lower {
+		return nil, fmt.Errorf("upper bound %f must exceed lower bound %f", opts.Upper, opts.Lower)
+	}
+	if len(y) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	if opts.Epsilon == 0 {
+		opts.Epsilon = 0.5 / float64(len(y))
+	}
+	if opts.Epsilon < 0 || opts.Epsilon >= 0.5 {
+		return nil, fmt.Errorf("epsilon must be in (0, 0.5), got %f", opts.Epsilon)
+	}
+
+	width := opts.Upper - opts.Lower
+	ty := make([]float64, len(y))
+	atBounds := 0
+	for i, v := range y {
+		if v < opts.Lower || v > opts.Upper {
+			return nil, fmt.Errorf("response %f at %d outside [%f, %f]", v, i, opts.Lower, opts.Upper)
+		}
+		u := (v - opts.Lower) / width
+		if u < opts.Epsilon {
+			u = opts.Epsilon
+			atBounds++
+		} else if u > 1-opts.Epsilon {
+			u = 1 - opts.Epsilon
+			atBounds++
+		}
+		ty[i] = math.Log(u / (1 - u))
+	}
+
+	fit, err := RQ(ty, x, tau)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundedFit{
+		RQFit:    fit,
+		Lower:    opts.Lower,
+		Upper:    opts.Upper,
+		Epsilon:  opts.Epsilon,
+		AtBounds: atBounds,
+	}, nil
+}
+
+// Predict returns the conditional tau-quantiles of the bounded outcome at newX
+func (f *BoundedFit) Predict(newX [][]float64) ([]float64, error) {
+	pred, err := f.RQFit.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+	for i, u := range pred {
+		pred[i] = f.Lower + (f.Upper-f.Lower)/(1+math.Exp(-u))
+	}
+	return pred, nil
+}
@@ -0,0 +1,46 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQBounded(t *testing.T) {
+	n := 100
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		xi := float64(i)/float64(n) - 0.5
+		x[i] = []float64{1, xi}
+		y[i] = 1 / (1 + math.Exp(-(3*xi + 0.3*math.Sin(float64(7*i)))))
+	}
+	y[0], y[n-1] = 0, 1
+
+	fit, err := RQBounded(y, x, 0.5, BoundedOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit bounded model: %v", err)
+	}
+	if fit.AtBounds != 2 {
+		t.Errorf("Expected 2 boundary observations, got %d", fit.AtBounds)
+	}
+	if math.Abs(fit.Coefficients[1]-3) > 1 {
+		t.Errorf("Expected logit slope near 3, got %v", fit.Coefficients[1])
+	}
+
+	pred, err := fit.Predict([][]float64{{1, 0}, {1, 100}, {1, -100}})
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	if math.Abs(pred[0]-0.5) > 0.1 {
+		t.Errorf("Expected median near 0.5, got %v", pred[0])
+	}
+	for _, v := range pred {
+		if v < 0 || v > 1 {
+			t.Errorf("Prediction %v outside [0, 1]", v)
+		}
+	}
+
+	if _, err := RQBounded([]float64{0.5, 1.5}, [][]float64{{1}, {1}}, 0.5, BoundedOptions{}); err == nil {
+		t.Error("Expected error for response outside the range")
+	}
+}
@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoxCoxTransform(t *testing.T) {
+	for _, lambda := range []float64{-0.5, 0, 0.5, 1.5} {
+		for _, y := range []float64{0.2, 1, 7} {
+			if got := boxCoxInverse(boxCox(y, lambda), lambda); math.Abs(got-y) > 1e-10 {
+				t.Errorf("Expected round trip to %v at lambda=%v, got %v", y, lambda, got)
+			}
+		}
+	}
+	if got := boxCoxInverse(-3, 0.5); got != 0 {
+		t.Errorf("Expected censoring at 0, got %v", got)
+	}
+}
+
+func TestRQBoxCox(t *testing.T) {
+	// log y is linear in x, so lambda near 0 should be selected
+	n := 100
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		xi := float64(i) / float64(n)
+		x[i] = []float64{1, xi}
+		y[i] = math.Exp(0.5 + 1.5*xi + 0.2*math.Sin(float64(11*i)))
+	}
+
+	fit, err := RQBoxCox(y, x, 0.5, BoxCoxOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit Box-Cox model: %v", err)
+	}
+	if len(fit.Loss) != len(fit.Lambdas) {
+		t.Errorf("Expected %d losses, got %d", len(fit.Lambdas), len(fit.Loss))
+	}
+	if math.Abs(fit.Lambda) > 0.5 {
+		t.Errorf("Expected lambda near 0, got %v", fit.Lambda)
+	}
+
+	pred, err := fit.Predict([][]float64{{1, 0.5}})
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	if want := math.Exp(1.25); math.Abs(pred[0]-want) > 0.5 {
+		t.Errorf("Expected median near %v, got %v", want, pred[0])
+	}
+
+	if _, err := RQBoxCox([]float64{1, -1}, [][]float64{{1}, {1}}, 0.5, BoxCoxOptions{}); err == nil {
+		t.Error("Expected error for non-positive response")
+	}
+}
@@ -0,0 +1,242 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// BreakOptions controls RQBreaks
+type BreakOptions struct {
+	MaxBreaks    int     // Maximum number of breaks (default 1)
+	Trim         float64 // Minimum regime length as a fraction of the segment tested (default 0.15)
+	Level        float64 // Confidence level of tests and break intervals (default 0.95)
+	SE           string  // Covariance estimator of the Wald statistics (default SEKer)
+	Replications int     // Simulations of the null and break-date distributions (default 1000)
+}
+
+// Break is an estimated change in the coefficients
+type Break struct {
+	Index   int     // First observation of the new regime
+	Lower   int     // Lower confidence bound of Index
+	Upper   int     // Upper confidence bound of Index
+	SupWald float64 // Sup-Wald statistic of the segment the break was found in
+	PValue  float64 // Simulated p-value of SupWald
+}
+
+// BreakResult holds the detected breaks and the per-regime fits
+type BreakResult struct {
+	Tau     float64
+	Breaks  []Break  // Breaks in time order
+	Regimes []*RQFit // Fits of the len(Breaks)+1 regimes
+	Starts  []int    // First observation of each regime
+}
+
+// RQBreaks detects changes in the quantile regression coefficients at unknown
+// dates, with observations in time order. Each segment is tested with the
+// sup-Wald statistic of Qu (2008) over candidate breaks k, testing d = 0 in
+// Q_tau(y_t) = x_t'b + 1{t >= k} x_t'd; its p-value is simulated from the
+// Brownian bridge limit sup ||B(l) - l B(1)||^2 / (l (1-l)). When significant,
+// the break is dated by minimizing the check loss and the segment is split,
+// up to MaxBreaks times (binary segmentation). Break intervals follow Oka and
+// Qu (2011): k_hat +/- c / L with L = d'X'Xd / (n tau (1-tau) s^2) and c the
+// simulated quantile of argmax W(s) - |s|/2.
+func RQBreaks(y []float64, x [][]float64, tau float64, opts BreakOptions, rng *rand.Rand) (*BreakResult, error) {
+	if opts.MaxBreaks == 0 {
+		opts.MaxBreaks = 1
+	}
+	if opts.Trim == 0 {
+		opts.Trim = 0.15
+	}
+	if opts.Level == 0 {
+		opts.Level = 0.95
+	}
+	if opts.SE == "" {
+		opts.SE = SEKer
+	}
+	if opts.Replications == 0 {
+		opts.Replications = 1000
+	}
+	if opts.MaxBreaks < 0 || opts.Trim <= 0 || opts.Trim >= 0.5 || opts.Replications < 10 {
+		return nil, fmt.Errorf("invalid break options %+v", opts)
+	}
+	if opts.Level <= 0 || opts.Level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	if len(x) == 0 || len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+
+	rng = randOrDefault(rng)
+	p := len(x[0])
+	null := supWaldNull(p, opts.Trim, opts.Replications, rng)
+	crit := argmaxQuantile(opts.Level, opts.Replications, rng)
+
+	res := &BreakResult{Tau: tau}
+	segments := [][2]int{{0, len(y)}}
+	for len(res.Breaks) < opts.MaxBreaks && len(segments) > 0 {
+		// Test the longest remaining segment
+		sort.Slice(segments, func(a, b int) bool {
+			return segments[a][1]-segments[a][0] > segments[b][1]-segments[b][0]
+		})
+		seg := segments[0]
+		segments = segments[1:]
+
+		brk, err := segmentBreak(y[seg[0]:seg[1]], x[seg[0]:seg[1]], tau, opts, null, crit)
+		if err != nil {
+			return nil, err
+		}
+		if brk == nil || brk.PValue > 1-opts.Level {
+			continue
+		}
+		brk.Index += seg[0]
+		brk.Lower = max(brk.Lower+seg[0], seg[0])
+		brk.Upper = min(brk.Upper+seg[0], seg[1]-1)
+		res.Breaks = append(res.Breaks, *brk)
+		segments = append(segments, [2]int{seg[0], brk.Index}, [2]int{brk.Index, seg[1]})
+	}
+	sort.Slice(res.Breaks, func(a, b int) bool { return res.Breaks[a].Index < res.Breaks[b].Index })
+
+	start := 0
+	for k := 0; k <= len(res.Breaks); k++ {
+		end := len(y)
+		if k < len(res.Breaks) {
+			end = res.Breaks[k].Index
+		}
+		fit, err := RQ(y[start:end], x[start:end], tau)
+		if err != nil {
+			return nil, fmt.Errorf("fit of regime %d failed: %w", k, err)
+		}
+		res.Regimes = append(res.Regimes, fit)
+		res.Starts = append(res.Starts, start)
+		start = end
+	}
+	return res, nil
+}
+
+// segmentBreak computes the sup-Wald test and the break estimate within one
+// segment, returning nil when the segment is too short to split
+func segmentBreak(y []float64, x [][]float64, tau float64, opts BreakOptions, null []float64, crit float64) (*Break, error) {
+	n, p := len(y), len(x[0])
+	first := int(math.Ceil(opts.Trim * float64(n)))
+	if first <= p {
+		first = p + 1
+	}
+	last := n - first
+	if last < first {
+		return nil, nil
+	}
+
+	terms := make([]int, p)
+	for j := range terms {
+		terms[j] = p + j
+	}
+	design := make([][]float64, n)
+	sup, best := 0.0, math.Inf(1)
+	var brk Break
+	var bestFit *RQFit
+	for k := first; k <= last; k++ {
+		for i, row := range x {
+			d := make([]float64, 2*p)
+			copy(d, row)
+			if i >= k {
+				copy(d[p:], row)
+			}
+			design[i] = d
+		}
+		fit, err := RQ(y, design, tau)
+		if err != nil {
+			return nil, fmt.Errorf("fit failed at candidate break %d: %w", k, err)
+		}
+		if w, err := fit.WaldTest(terms, opts.SE); err == nil {
+			sup = math.Max(sup, w.Statistic)
+		}
+		if loss := fit.Rho(); loss < best {
+			best, brk.Index, bestFit = loss, k, fit
+		}
+	}
+
+	brk.SupWald = sup
+	exceed := sort.SearchFloat64s(null, sup)
+	brk.PValue = float64(len(null)-exceed) / float64(len(null))
+
+	// Oka-Qu scale of the break date
+	sparsity, err := siddiquiSparsity(bestFit.ResidualValues(), tau)
+	if err != nil {
+		return nil, err
+	}
+	delta := bestFit.Coefficients[p:]
+	dxxd := 0.0
+	for _, row := range x {
+		v := 0.0
+		for j, d := range delta {
+			v += row[j] * d
+		}
+		dxxd += v * v
+	}
+	width := n
+	if l := dxxd / (float64(n) * tau * (1 - tau) * sparsity * sparsity); l > 0 {
+		width = int(math.Ceil(crit / l))
+	}
+	brk.Lower, brk.Upper = brk.Index-width, brk.Index+width
+	return &brk, nil
+}
+
+// supWaldNull simulates sorted draws of sup ||B(l) - l B(1)||^2 / (l (1-l)) over
+// l in [trim, 1-trim] for a p-dimensional Brownian motion
+func supWaldNull(p int, trim float64, reps int, rng *rand.Rand) []float64 {
+	const steps = 500
+	null := make([]float64, reps)
+	w := make([][]float64, steps+1)
+	for s := range w {
+		w[s] = make([]float64, p)
+	}
+	for r := range null {
+		for s := 1; s <= steps; s++ {
+			for j := 0; j < p; j++ {
+				w[s][j] = w[s-1][j] + rng.NormFloat64()/math.Sqrt(steps)
+			}
+		}
+		for s := 1; s < steps; s++ {
+			l := float64(s) / steps
+			if l < trim || l > 1-trim {
+				continue
+			}
+			stat := 0.0
+			for j := 0; j < p; j++ {
+				b := w[s][j] - l*w[steps][j]
+				stat += b * b
+			}
+			null[r] = math.Max(null[r], stat/(l*(1-l)))
+		}
+	}
+	sort.Float64s(null)
+	return null
+}
+
+// argmaxQuantile simulates the level quantile of |argmax_s W(s) - |s|/2| for a
+// two-sided Brownian motion W
+func argmaxQuantile(level float64, reps int, rng *rand.Rand) float64 {
+	const (
+		span = 80.0
+		dt   = 0.05
+	)
+	steps := int(span / dt)
+	draws := make([]float64, reps)
+	for r := range draws {
+		best, arg := 0.0, 0.0
+		for _, sign := range []float64{-1, 1} {
+			w := 0.0
+			for s := 1; s <= steps; s++ {
+				w += rng.NormFloat64() * math.Sqrt(dt)
+				if v := w - float64(s)*dt/2; v > best {
+					best, arg = v, sign*float64(s)*dt
+				}
+			}
+		}
+		draws[r] = math.Abs(arg)
+	}
+	sort.Float64s(draws)
+	return empiricalQuantile(draws, level)
+}
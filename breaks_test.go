@@ -0,0 +1,71 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRQBreaks(t *testing.T) {
+	// The intercept shifts by 3 at t = 60
+	n := 120
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		xi := math.Cos(float64(3 * i))
+		x[i] = []float64{1, xi}
+		y[i] = 1 + xi + 0.3*math.Sin(float64(7*i))
+		if i >= 60 {
+			y[i] += 3
+		}
+	}
+
+	res, err := RQBreaks(y, x, 0.5, BreakOptions{Replications: 200}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to detect breaks: %v", err)
+	}
+	if len(res.Breaks) != 1 {
+		t.Fatalf("Expected 1 break, got %d", len(res.Breaks))
+	}
+	b := res.Breaks[0]
+	if b.Index < 55 || b.Index > 65 {
+		t.Errorf("Expected break near 60, got %d", b.Index)
+	}
+	if b.Lower > b.Index || b.Upper < b.Index {
+		t.Errorf("Break %d outside interval [%d, %d]", b.Index, b.Lower, b.Upper)
+	}
+	if b.PValue > 0.05 {
+		t.Errorf("Expected a significant break, got p-value %v", b.PValue)
+	}
+	if len(res.Regimes) != 2 || res.Starts[1] != b.Index {
+		t.Fatalf("Expected 2 regimes split at the break, got %d", len(res.Regimes))
+	}
+	if d := res.Regimes[1].Coefficients[0] - res.Regimes[0].Coefficients[0]; math.Abs(d-3) > 0.7 {
+		t.Errorf("Expected intercept shift near 3, got %v", d)
+	}
+
+	// No break in a stable series
+	for i := range y {
+		y[i] = 1 + x[i][1] + 0.3*math.Sin(float64(7*i))
+	}
+	stable, err := RQBreaks(y, x, 0.5, BreakOptions{Replications: 200}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to test stable series: %v", err)
+	}
+	if len(stable.Breaks) != 0 || len(stable.Regimes) != 1 {
+		t.Errorf("Expected no breaks, got %v", stable.Breaks)
+	}
+}
+
+func TestBreakNullDistributions(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	null := supWaldNull(1, 0.15, 500, rng)
+	// Andrews (1993) 5% critical value for one restriction and 15% trimming is 8.85
+	if c := empiricalQuantile(null, 0.95); c < 7 || c > 11 {
+		t.Errorf("Expected sup-Wald critical value near 8.85, got %v", c)
+	}
+	// Bai (1997) 95% interval constant is about 11
+	if c := argmaxQuantile(0.95, 500, rng); c < 8 || c > 15 {
+		t.Errorf("Expected argmax quantile near 11, got %v", c)
+	}
+}
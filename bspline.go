@@ -0,0 +1,97 @@
+package quantreg
+
+import "sort"
+
+// clampedKnots returns the knot vector on [lo, hi] with the boundary knots
+// repeated degree+1 times around the given interior knots
+func clampedKnots(lo, hi float64, interior []float64, degree int) []float64 {
+	knots := make([]float64, 0, len(interior)+2*degree+2)
+	for i := 0; i <= degree; i++ {
+		knots = append(knots, lo)
+	}
+	knots = append(knots, interior...)
+	for i := 0; i <= degree; i++ {
+		knots = append(knots, hi)
+	}
+	return knots
+}
+
+// quantileKnots places k interior knots at equally spaced sample quantiles of z
+func quantileKnots(z []float64, k int) []float64 {
+	sorted := append([]float64(nil), z...)
+	sort.Float64s(sorted)
+	knots := make([]float64, k)
+	for i := range knots {
+		knots[i] = empiricalQuantile(sorted, float64(i+1)/float64(k+1))
+	}
+	return knots
+}
+
+// bsplineBasis evaluates the len(knots)-degree-1 B-spline basis functions of a
+// clamped knot vector at t, which is clamped to the boundary knots. The basis
+// sums to one everywhere on the range.
+func bsplineBasis(t float64, knots []float64, degree int) []float64 {
+	lo, hi := knots[0], knots[len(knots)-1]
+	if t < lo {
+		t = lo
+	}
+	if t > hi {
+		t = hi
+	}
+
+	// Cox-de Boor recursion; the right boundary belongs to the last interval
+	last := len(knots) - degree - 2
+	b := make([]float64, len(knots)-1)
+	for i := range b {
+		if t == hi {
+			if i == last {
+				b[i] = 1
+			}
+		} else if t >= knots[i] && t < knots[i+1] {
+			b[i] = 1
+		}
+	}
+	for d := 1; d <= degree; d++ {
+		for i := 0; i+d < len(knots)-1; i++ {
+			v := 0.0
+			if w := knots[i+d] - knots[i]; w > 0 {
+				v += (t - knots[i]) / w * b[i]
+			}
+			if w := knots[i+d+1] - knots[i+1]; w > 0 {
+				v += (knots[i+d+1] - t) / w * b[i+1]
+			}
+			b[i] = v
+		}
+	}
+	return b[:len(knots)-degree-1]
+}
+
+// bsplineDerivative evaluates the derivatives of the basis functions returned by
+// bsplineBasis at t, using B'_i,d = d (B_i,d-1 / (t_i+d - t_i) - B_i+1,d-1 / (t_i+d+1 - t_i+1))
+func bsplineDerivative(t float64, knots []float64, degree int) []float64 {
+	k := len(knots) - degree - 1
+	deriv := make([]float64, k)
+	if degree == 0 {
+		return deriv
+	}
+
+	// The lower-degree functions with support inside the range are those of the
+	// knot vector with one boundary knot dropped at each end
+	inner := bsplineBasis(t, knots[1:len(knots)-1], degree-1)
+	lower := func(i int) float64 {
+		if i < 1 || i > len(inner) {
+			return 0
+		}
+		return inner[i-1]
+	}
+	d := float64(degree)
+	for i := range deriv {
+		if w := knots[i+degree] - knots[i]; w > 0 {
+			deriv[i] += d * lower(i) / w
+		}
+		if w := knots[i+degree+1] - knots[i+1]; w > 0 {
+			deriv[i] -= d * lower(i+1) / w
+		}
+	}
+	return deriv
+}
@@ -0,0 +1,70 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBSplineBasis(t *testing.T) {
+	knots := clampedKnots(0, 1, []float64{0.3, 0.5, 0.8}, 3)
+	for _, v := range []float64{-1, 0, 0.1, 0.3, 0.55, 0.99, 1, 2} {
+		b := bsplineBasis(v, knots, 3)
+		if len(b) != 7 {
+			t.Fatalf("Expected 7 basis functions, got %d", len(b))
+		}
+		sum := 0.0
+		for _, bi := range b {
+			if bi < -1e-12 {
+				t.Errorf("Negative basis value %v at t=%v", bi, v)
+			}
+			sum += bi
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("Expected basis to sum to 1 at t=%v, got %v", v, sum)
+		}
+	}
+
+	// Clamped splines interpolate the end coefficients
+	if b := bsplineBasis(0, knots, 3); b[0] != 1 {
+		t.Errorf("Expected first basis 1 at the left boundary, got %v", b[0])
+	}
+	if b := bsplineBasis(1, knots, 3); b[6] != 1 {
+		t.Errorf("Expected last basis 1 at the right boundary, got %v", b[6])
+	}
+
+	k := quantileKnots([]float64{4, 1, 3, 2, 5}, 1)
+	if len(k) != 1 || k[0] != 3 {
+		t.Errorf("Expected median knot 3, got %v", k)
+	}
+}
+
+func TestBSplineDerivative(t *testing.T) {
+	knots := clampedKnots(0, 1, []float64{0.3, 0.5, 0.8}, 3)
+	coef := []float64{0.5, -1, 2, 0.3, 1.7, -0.4, 1}
+	value := func(v float64) float64 {
+		s := 0.0
+		for i, b := range bsplineBasis(v, knots, 3) {
+			s += coef[i] * b
+		}
+		return s
+	}
+	h := 1e-6
+	for _, v := range []float64{0.05, 0.3, 0.42, 0.77, 0.95} {
+		d := 0.0
+		for i, b := range bsplineDerivative(v, knots, 3) {
+			d += coef[i] * b
+		}
+		if want := (value(v+h) - value(v-h)) / (2 * h); math.Abs(d-want) > 1e-5 {
+			t.Errorf("Expected derivative %v at t=%v, got %v", want, v, d)
+		}
+	}
+
+	// The derivatives of a partition of unity sum to zero
+	sum := 0.0
+	for _, b := range bsplineDerivative(1, knots, 3) {
+		sum += b
+	}
+	if math.Abs(sum) > 1e-9 {
+		t.Errorf("Expected basis derivatives to sum to 0, got %v", sum)
+	}
+}
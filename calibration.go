@@ -0,0 +1,127 @@
+package quantreg
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// CalibrationLevel is the empirical coverage of the predicted quantile at one tau
+type CalibrationLevel struct {
+	Tau           float64 // Nominal level
+	Coverage      float64 // Fraction of outcomes at or below the predicted quantile
+	Lower         float64 // Lower Clopper-Pearson bound of the coverage
+	Upper         float64 // Upper Clopper-Pearson bound of the coverage
+	N             int     // Number of outcomes
+	Miscalibrated bool    // Whether Tau lies outside [Lower, Upper]
+}
+
+// CalibrationReport collects the coverage of a quantile grid against realized outcomes
+type CalibrationReport struct {
+	Level  float64            // Confidence level of the coverage intervals
+	Levels []CalibrationLevel // One entry per tau, in tau order
+}
+
+// CalibrationCurve is the data of a calibration plot: observed against nominal
+// coverage with a confidence band; a calibrated model lies on the diagonal
+type CalibrationCurve struct {
+	Nominal  []float64
+	Observed []float64
+	Lower    []float64
+	Upper    []float64
+}
+
+// CheckCalibration compares predicted quantiles, keyed by tau as returned by
+// MultiRQFit.Predict, with outcomes y. A level is flagged as miscalibrated when
+// the exact binomial confidence interval of its coverage at the given level
+// (default 0.95 when zero) excludes the nominal tau. For in-sample predictions the
+// coverage is optimistic by roughly p/n, so a holdout set is preferable.
+func CheckCalibration(pred map[float64][]float64, y []float64, level float64) (*CalibrationReport, error) {
+	if level == 0 {
+		level = 0.95
+	}
+	if level <= 0 || level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	if len(pred) == 0 {
+		return nil, fmt.Errorf("no quantile predictions")
+	}
+	if len(y) == 0 {
+		return nil, fmt.Errorf("no outcomes to check")
+	}
+	taus := make([]float64, 0, len(pred))
+	for tau, q := range pred {
+		if len(q) != len(y) {
+			return nil, fmt.Errorf("%w: %d predictions for tau=%f, %d outcomes", ErrDimensionMismatch, len(q), tau, len(y))
+		}
+		taus = append(taus, tau)
+	}
+	sort.Float64s(taus)
+
+	n := len(y)
+	r := &CalibrationReport{Level: level, Levels: make([]CalibrationLevel, len(taus))}
+	for k, tau := range taus {
+		below := 0
+		for i, yi := range y {
+			if yi <= pred[tau][i] {
+				below++
+			}
+		}
+		lo, hi := clopperPearson(below, n, level)
+		r.Levels[k] = CalibrationLevel{
+			Tau:           tau,
+			Coverage:      float64(below) / float64(n),
+			Lower:         lo,
+			Upper:         hi,
+			N:             n,
+			Miscalibrated: tau < lo || tau > hi,
+		}
+	}
+	return r, nil
+}
+
+// CheckCalibration predicts at newX and checks the coverage against the outcomes y
+func (m *MultiRQFit) CheckCalibration(newX [][]float64, y []float64, level float64) (*CalibrationReport, error) {
+	pred, err := m.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+	return CheckCalibration(pred, y, level)
+}
+
+// Miscalibrated returns the taus whose coverage interval excludes the nominal level
+func (r *CalibrationReport) Miscalibrated() []float64 {
+	var taus []float64
+	for _, l := range r.Levels {
+		if l.Miscalibrated {
+			taus = append(taus, l.Tau)
+		}
+	}
+	return taus
+}
+
+// Curve returns the calibration plot data
+func (r *CalibrationReport) Curve() *CalibrationCurve {
+	c := &CalibrationCurve{}
+	for _, l := range r.Levels {
+		c.Nominal = append(c.Nominal, l.Tau)
+		c.Observed = append(c.Observed, l.Coverage)
+		c.Lower = append(c.Lower, l.Lower)
+		c.Upper = append(c.Upper, l.Upper)
+	}
+	return c
+}
+
+// clopperPearson returns the exact binomial confidence interval for k successes in n trials
+func clopperPearson(k, n int, level float64) (float64, float64) {
+	alpha := 1 - level
+	lo, hi := 0.0, 1.0
+	if k > 0 {
+		lo = distuv.Beta{Alpha: float64(k), Beta: float64(n - k + 1)}.Quantile(alpha / 2)
+	}
+	if k < n {
+		hi = distuv.Beta{Alpha: float64(k + 1), Beta: float64(n - k)}.Quantile(1 - alpha/2)
+	}
+	return lo, hi
+}
@@ -0,0 +1,73 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClopperPearson(t *testing.T) {
+	// Known values for 5 of 20 at 95%: [0.0866, 0.4910]
+	lo, hi := clopperPearson(5, 20, 0.95)
+	if math.Abs(lo-0.0866) > 1e-3 || math.Abs(hi-0.4910) > 1e-3 {
+		t.Errorf("Expected interval [0.0866, 0.4910], got [%f, %f]", lo, hi)
+	}
+	if lo, hi := clopperPearson(0, 10, 0.95); lo != 0 || hi >= 1 {
+		t.Errorf("Expected interval starting at 0, got [%f, %f]", lo, hi)
+	}
+}
+
+func TestCheckCalibration(t *testing.T) {
+	n := 200
+	y := make([]float64, n)
+	for i := range y {
+		y[i] = float64(i)
+	}
+	// The tau=0.5 prediction is the true median, the tau=0.9 prediction sits at the 60th percentile
+	pred := map[float64][]float64{0.5: make([]float64, n), 0.9: make([]float64, n)}
+	for i := range y {
+		pred[0.5][i] = 99.5
+		pred[0.9][i] = 119.5
+	}
+
+	r, err := CheckCalibration(pred, y, 0)
+	if err != nil {
+		t.Fatalf("Failed to check calibration: %v", err)
+	}
+	if r.Level != 0.95 || len(r.Levels) != 2 || r.Levels[0].Tau != 0.5 {
+		t.Fatalf("Unexpected report %+v", r)
+	}
+	if r.Levels[0].Coverage != 0.5 || r.Levels[0].Miscalibrated {
+		t.Errorf("Expected calibrated median, got %+v", r.Levels[0])
+	}
+	if r.Levels[1].Coverage != 0.6 || !r.Levels[1].Miscalibrated {
+		t.Errorf("Expected miscalibrated upper level, got %+v", r.Levels[1])
+	}
+	if got := r.Miscalibrated(); len(got) != 1 || got[0] != 0.9 {
+		t.Errorf("Expected [0.9] flagged, got %v", got)
+	}
+	c := r.Curve()
+	if len(c.Nominal) != 2 || c.Observed[1] != 0.6 || c.Lower[1] >= 0.6 || c.Upper[1] <= 0.6 {
+		t.Errorf("Unexpected curve %+v", c)
+	}
+
+	if _, err := CheckCalibration(pred, y[:10], 0.9); err == nil {
+		t.Error("Expected error for mismatched outcomes")
+	}
+}
+
+func TestMultiRQFitCheckCalibration(t *testing.T) {
+	y, x := inferenceData()
+	m, err := RQProcess(y, x, []float64{0.25, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	r, err := m.CheckCalibration(x, y, 0.9)
+	if err != nil {
+		t.Fatalf("Failed to check calibration: %v", err)
+	}
+	for _, l := range r.Levels {
+		if l.N != len(y) || l.Lower > l.Coverage || l.Upper < l.Coverage {
+			t.Errorf("Expected coverage inside its interval, got %+v", l)
+		}
+	}
+}
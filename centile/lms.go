@@ -0,0 +1,288 @@
+// Package centile builds smoothed reference centile curves with the LMS method of
+// Cole and Green, as an alternative to direct quantile regression centiles
+package centile
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/andreasmuller/quantreg"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// LMSOptions controls binning and smoothing in FitLMS
+type LMSOptions struct {
+	Bins     int     // Number of equal-count bins along t (default 10)
+	Segments int     // Number of B-spline segments for each curve (default 4)
+	Penalty  float64 // Second-difference penalty of the P-splines (default 1)
+}
+
+// LMSFit holds the smoothed Box-Cox power L, median M and coefficient of
+// variation S as functions of the covariate t
+type LMSFit struct {
+	N       int
+	Lo, Hi  float64   // Covariate range used for the spline basis
+	BinT    []float64 // Bin centres
+	BinL    []float64 // Raw per-bin estimates before smoothing
+	BinM    []float64
+	BinS    []float64
+	Options LMSOptions
+
+	CoefL    []float64     // P-spline coefficients of L
+	CoefM    []float64     // P-spline coefficients of M
+	CoefLogS []float64     // P-spline coefficients of log S
+	Meta     quantreg.Meta // Reproducibility metadata
+}
+
+// FitLMS fits LMS centile curves to positive measurements y observed at covariate
+// values t (typically age). Within equal-count bins of t, L is chosen by profile
+// likelihood of the Box-Cox normal model and M and S follow from the transformed
+// mean and standard deviation; the bin estimates are then smoothed over t with
+// penalized cubic B-splines.
+func FitLMS(t, y []float64, opts LMSOptions) (*LMSFit, error) {
+	n := len(y)
+	if len(t) != n {
+		return nil, fmt.Errorf("dimensions mismatch: t has %d values, y has %d", len(t), n)
+	}
+	for _, v := range y {
+		if v <= 0 {
+			return nil, fmt.Errorf("LMS requires positive measurements, got %f", v)
+		}
+	}
+	if opts.Bins == 0 {
+		opts.Bins = 10
+	}
+	if opts.Segments == 0 {
+		opts.Segments = 4
+	}
+	if opts.Penalty == 0 {
+		opts.Penalty = 1
+	}
+	if opts.Bins < 2 || n < 3*opts.Bins {
+		return nil, fmt.Errorf("need at least 2 bins with 3 observations each, got %d bins for %d observations", opts.Bins, n)
+	}
+
+	began := time.Now()
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(a, b int) bool { return t[idx[a]] < t[idx[b]] })
+
+	fit := &LMSFit{N: n, Lo: t[idx[0]], Hi: t[idx[n-1]], Options: opts}
+	if fit.Hi == fit.Lo {
+		return nil, fmt.Errorf("covariate t is constant")
+	}
+
+	counts := make([]float64, opts.Bins)
+	for b := 0; b < opts.Bins; b++ {
+		start, end := b*n/opts.Bins, (b+1)*n/opts.Bins
+		bt := 0.0
+		by := make([]float64, 0, end-start)
+		for _, i := range idx[start:end] {
+			bt += t[i]
+			by = append(by, y[i])
+		}
+		l, m, s := boxCoxMLE(by)
+		fit.BinT = append(fit.BinT, bt/float64(end-start))
+		fit.BinL = append(fit.BinL, l)
+		fit.BinM = append(fit.BinM, m)
+		fit.BinS = append(fit.BinS, s)
+		counts[b] = float64(end - start)
+	}
+
+	logS := make([]float64, len(fit.BinS))
+	for i, s := range fit.BinS {
+		logS[i] = math.Log(s)
+	}
+	var err error
+	if fit.CoefL, err = fit.smooth(fit.BinL, counts); err != nil {
+		return nil, err
+	}
+	if fit.CoefM, err = fit.smooth(fit.BinM, counts); err != nil {
+		return nil, err
+	}
+	if fit.CoefLogS, err = fit.smooth(logS, counts); err != nil {
+		return nil, err
+	}
+
+	tx := make([][]float64, n)
+	for i, v := range t {
+		tx[i] = []float64{v}
+	}
+	fit.Meta = quantreg.NewMeta("lms", map[string]float64{"bins": float64(opts.Bins), "segments": float64(opts.Segments), "penalty": opts.Penalty},
+		nil, n, 3*len(fit.CoefM), began, y, tx)
+
+	return fit, nil
+}
+
+// Params returns the smoothed L, M and S at t. Values outside the fitted range are
+// evaluated at the nearest boundary.
+func (fit *LMSFit) Params(t float64) (float64, float64, float64) {
+	basis := fit.basis(t)
+	return dot(basis, fit.CoefL), dot(basis, fit.CoefM), math.Exp(dot(basis, fit.CoefLogS))
+}
+
+// Centile returns the p-th centile (0 < p < 1) of the measurement at t
+func (fit *LMSFit) Centile(t, p float64) float64 {
+	l, m, s := fit.Params(t)
+	z := distuv.UnitNormal.Quantile(p)
+	if math.Abs(l) < 1e-8 {
+		return m * math.Exp(s*z)
+	}
+	return m * math.Pow(1+l*s*z, 1/l)
+}
+
+// ZScore returns the standard deviation score of measurement y at t
+func (fit *LMSFit) ZScore(t, y float64) float64 {
+	l, m, s := fit.Params(t)
+	if math.Abs(l) < 1e-8 {
+		return math.Log(y/m) / s
+	}
+	return (math.Pow(y/m, l) - 1) / (l * s)
+}
+
+// Centiles evaluates the centile curves at each t for every tau, keyed like
+// MultiRQFit.Predict so LMS and quantile regression centiles can be compared
+// directly
+func (fit *LMSFit) Centiles(t []float64, taus []float64) (map[float64][]float64, error) {
+	out := make(map[float64][]float64, len(taus))
+	for _, tau := range taus {
+		if tau <= 0 || tau >= 1 {
+			return nil, fmt.Errorf("tau must be between 0 and 1, got %f", tau)
+		}
+		c := make([]float64, len(t))
+		for i, ti := range t {
+			c[i] = fit.Centile(ti, tau)
+		}
+		out[tau] = c
+	}
+	return out, nil
+}
+
+// boxCoxMLE returns the profile likelihood estimate of the Box-Cox power and the
+// implied median and coefficient of variation
+func boxCoxMLE(y []float64) (float64, float64, float64) {
+	n := float64(len(y))
+	sumLog := 0.0
+	for _, v := range y {
+		sumLog += math.Log(v)
+	}
+
+	best, bestLL := 0.0, math.Inf(-1)
+	for l := -3.0; l <= 3.0001; l += 0.05 {
+		_, sd := boxCoxMoments(y, l)
+		if sd <= 0 {
+			continue
+		}
+		ll := -n*math.Log(sd) + (l-1)*sumLog
+		if ll > bestLL {
+			best, bestLL = l, ll
+		}
+	}
+	if math.Abs(best) < 1e-8 {
+		best = 0
+	}
+
+	mean, sd := boxCoxMoments(y, best)
+	if best == 0 {
+		return 0, math.Exp(mean), sd
+	}
+	base := 1 + best*mean
+	return best, math.Pow(base, 1/best), sd / base
+}
+
+// boxCoxMoments returns the mean and maximum likelihood standard deviation of the
+// Box-Cox transformed values
+func boxCoxMoments(y []float64, l float64) (float64, float64) {
+	w := make([]float64, len(y))
+	mean := 0.0
+	for i, v := range y {
+		if math.Abs(l) < 1e-8 {
+			w[i] = math.Log(v)
+		} else {
+			w[i] = (math.Pow(v, l) - 1) / l
+		}
+		mean += w[i]
+	}
+	mean /= float64(len(y))
+	ss := 0.0
+	for _, v := range w {
+		ss += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(y)))
+}
+
+// smooth fits a weighted P-spline through the bin values and returns its coefficients
+func (fit *LMSFit) smooth(v, w []float64) ([]float64, error) {
+	k := fit.Options.Segments + 3
+	lhs := mat.NewDense(k, k, nil)
+	rhs := mat.NewVecDense(k, nil)
+	for i, ti := range fit.BinT {
+		b := fit.basis(ti)
+		for a := 0; a < k; a++ {
+			rhs.SetVec(a, rhs.AtVec(a)+w[i]*b[a]*v[i])
+			for c := 0; c < k; c++ {
+				lhs.Set(a, c, lhs.At(a, c)+w[i]*b[a]*b[c])
+			}
+		}
+	}
+
+	// Second-difference penalty D'D
+	for r := 0; r+2 < k; r++ {
+		d := [3]float64{1, -2, 1}
+		for a := 0; a < 3; a++ {
+			for c := 0; c < 3; c++ {
+				lhs.Set(r+a, r+c, lhs.At(r+a, r+c)+fit.Options.Penalty*d[a]*d[c])
+			}
+		}
+	}
+
+	var coef mat.VecDense
+	if err := coef.SolveVec(lhs, rhs); err != nil {
+		return nil, fmt.Errorf("spline smoothing failed: %w", err)
+	}
+	return coef.RawVector().Data, nil
+}
+
+// basis evaluates the cubic B-spline basis on equally spaced knots at t
+func (fit *LMSFit) basis(t float64) []float64 {
+	nseg := fit.Options.Segments
+	t = math.Max(fit.Lo, math.Min(fit.Hi, t))
+	h := (fit.Hi - fit.Lo) / float64(nseg)
+	knots := make([]float64, nseg+7)
+	for i := range knots {
+		knots[i] = fit.Lo + float64(i-3)*h
+	}
+
+	// Cox-de Boor recursion; the right boundary belongs to the last interval
+	b := make([]float64, len(knots)-1)
+	for i := range b {
+		if t == fit.Hi {
+			if i == nseg+2 {
+				b[i] = 1
+			}
+		} else if t >= knots[i] && t < knots[i+1] {
+			b[i] = 1
+		}
+	}
+	for d := 1; d <= 3; d++ {
+		for i := 0; i+d < len(knots)-1; i++ {
+			left := (t - knots[i]) / (knots[i+d] - knots[i]) * b[i]
+			right := (knots[i+d+1] - t) / (knots[i+d+1] - knots[i+1]) * b[i+1]
+			b[i] = left + right
+		}
+	}
+	return b[:nseg+3]
+}
+
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
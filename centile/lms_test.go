@@ -0,0 +1,101 @@
+package centile
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBasisPartitionOfUnity(t *testing.T) {
+	fit := &LMSFit{Lo: 0, Hi: 10, Options: LMSOptions{Segments: 4}}
+	for _, x := range []float64{0, 1.3, 5, 7.77, 10} {
+		b := fit.basis(x)
+		if len(b) != 7 {
+			t.Fatalf("Expected 7 basis functions, got %d", len(b))
+		}
+		sum := 0.0
+		for _, v := range b {
+			sum += v
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("Basis at %f sums to %f, want 1", x, sum)
+		}
+	}
+}
+
+func TestFitLMS(t *testing.T) {
+	// Log-normal measurements whose median grows with age: L = 0, S = 0.1
+	rng := rand.New(rand.NewSource(1))
+	n := 400
+	age := make([]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		age[i] = 10 * float64(i) / float64(n-1)
+		y[i] = (50 + 5*age[i]) * math.Exp(0.1*rng.NormFloat64())
+	}
+
+	fit, err := FitLMS(age, y, LMSOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit LMS model: %v", err)
+	}
+	if len(fit.BinT) != 10 {
+		t.Errorf("Expected 10 bins, got %d", len(fit.BinT))
+	}
+
+	for _, a := range []float64{2, 5, 8} {
+		l, m, s := fit.Params(a)
+		if math.Abs(m-(50+5*a)) > 3 {
+			t.Errorf("M at age %f: got %f, want about %f", a, m, 50+5*a)
+		}
+		if math.Abs(s-0.1) > 0.03 {
+			t.Errorf("S at age %f: got %f, want about 0.1", a, s)
+		}
+		if math.Abs(l) > 3 {
+			t.Errorf("L at age %f out of range: %f", a, l)
+		}
+
+		// Centile and z-score are inverse maps
+		c := fit.Centile(a, 0.9)
+		if z := fit.ZScore(a, c); math.Abs(z-1.2815515655446004) > 1e-6 {
+			t.Errorf("Expected z-score of the 90th centile to be 1.2816, got %f", z)
+		}
+	}
+
+	cents, err := fit.Centiles([]float64{1, 5}, []float64{0.1, 0.5, 0.9})
+	if err != nil {
+		t.Fatalf("Failed to compute centiles: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if !(cents[0.1][i] < cents[0.5][i] && cents[0.5][i] < cents[0.9][i]) {
+			t.Errorf("Centiles are not ordered at row %d", i)
+		}
+	}
+
+	// The fit survives a JSON round trip
+	data, err := json.Marshal(fit)
+	if err != nil {
+		t.Fatalf("Failed to marshal fit: %v", err)
+	}
+	var loaded LMSFit
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Failed to unmarshal fit: %v", err)
+	}
+	if got, want := loaded.Centile(5, 0.9), fit.Centile(5, 0.9); got != want {
+		t.Errorf("Expected centile %f after round trip, got %f", want, got)
+	}
+	if loaded.Meta.Solver != "lms" || loaded.Meta.N != n {
+		t.Errorf("Unexpected metadata %+v", loaded.Meta)
+	}
+
+	// Error cases
+	if _, err := FitLMS(age, append([]float64{-1}, y[1:]...), LMSOptions{}); err == nil {
+		t.Error("Expected error for non-positive measurements")
+	}
+	if _, err := FitLMS(age[:10], y[:10], LMSOptions{}); err == nil {
+		t.Error("Expected error for too few observations")
+	}
+	if _, err := fit.Centiles([]float64{1}, []float64{1}); err == nil {
+		t.Error("Expected error for invalid tau")
+	}
+}
@@ -0,0 +1,291 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// CheckpointOptions controls where and how often long-running jobs save progress
+type CheckpointOptions struct {
+	Path  string // Checkpoint file, created or resumed from
+	Every int    // Completed units between saves (default 1)
+}
+
+// checkpointFile is the serialized progress of a job. Units are the fits at each
+// tau or lambda, or the bootstrap draws, completed in Keys order.
+type checkpointFile struct {
+	Job      string
+	DataHash string
+	Keys     []float64
+	Units    []json.RawMessage
+}
+
+// loadCheckpoint returns the saved progress of the job at opts.Path, or empty
+// progress when there is no file yet. A file written for other data or keys is an
+// error rather than being silently overwritten.
+func loadCheckpoint(opts CheckpointOptions, job, hash string, keys []float64) (*checkpointFile, error) {
+	cp := &checkpointFile{Job: job, DataHash: hash, Keys: keys}
+	data, err := os.ReadFile(opts.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var saved checkpointFile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("reading checkpoint %s: %w", opts.Path, err)
+	}
+	if saved.Job != job || saved.DataHash != hash || len(saved.Keys) != len(keys) || len(saved.Units) > len(keys) {
+		return nil, fmt.Errorf("checkpoint %s was written for a different job", opts.Path)
+	}
+	for i, k := range keys {
+		if saved.Keys[i] != k {
+			return nil, fmt.Errorf("checkpoint %s was written for a different job", opts.Path)
+		}
+	}
+	return &saved, nil
+}
+
+// add appends a completed unit and saves when opts.Every units have accumulated
+// since the last save or the job is complete
+func (cp *checkpointFile) add(opts CheckpointOptions, unit interface{}) error {
+	data, err := json.Marshal(unit)
+	if err != nil {
+		return err
+	}
+	cp.Units = append(cp.Units, data)
+	if len(cp.Units)%opts.Every != 0 && len(cp.Units) != len(cp.Keys) {
+		return nil
+	}
+	return cp.save(opts.Path)
+}
+
+// save writes the checkpoint through a temporary file so that an interrupted
+// write never leaves a truncated checkpoint behind
+func (cp *checkpointFile) save(path string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// prepare validates the options and fills defaults
+func (o *CheckpointOptions) prepare() error {
+	if o.Path == "" {
+		return fmt.Errorf("no checkpoint path given")
+	}
+	if o.Every == 0 {
+		o.Every = 1
+	}
+	if o.Every < 0 {
+		return fmt.Errorf("checkpoint interval must be positive, got %d", o.Every)
+	}
+	return nil
+}
+
+// restoreFit decodes a compacted fit saved in a checkpoint and reattaches its data
+func restoreFit(data json.RawMessage, y []float64, x [][]float64) (*RQFit, error) {
+	var fit RQFit
+	if err := json.Unmarshal(data, &fit); err != nil {
+		return nil, err
+	}
+	fit.X, fit.Y = x, y
+	fit.setFitted(y, x, false)
+	return &fit, nil
+}
+
+// compactCopy returns a shallow copy of fit without its data, as stored in checkpoints
+func compactCopy(fit *RQFit) *RQFit {
+	c := *fit
+	c.Compact()
+	return &c
+}
+
+// RQProcessCheckpointed is RQProcess for long tau grids. The fit at each tau is
+// saved to opts.Path as it completes; rerunning with the same data and taus skips
+// the saved fits and continues with the rest.
+func RQProcessCheckpointed(y []float64, x [][]float64, taus []float64, opts CheckpointOptions) (*MultiRQFit, error) {
+	start := time.Now()
+	if err := opts.prepare(); err != nil {
+		return nil, err
+	}
+	if len(taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels specified")
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	sorted := append([]float64(nil), taus...)
+	sort.Float64s(sorted)
+	for _, tau := range sorted {
+		if tau <= 0 || tau >= 1 {
+			return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+		}
+	}
+
+	cp, err := loadCheckpoint(opts, "process", DataFingerprint(y, x), sorted)
+	if err != nil {
+		return nil, err
+	}
+	fits := make(map[float64]*RQFit, len(sorted))
+	for k, unit := range cp.Units {
+		fit, err := restoreFit(unit, y, x)
+		if err != nil {
+			return nil, fmt.Errorf("restoring fit for tau=%f: %w", sorted[k], err)
+		}
+		fits[sorted[k]] = fit
+	}
+	if len(cp.Units) > 0 {
+		currentLogger().Info("resuming from checkpoint", "job", "process", "completed", len(cp.Units), "total", len(sorted))
+	}
+	for _, tau := range sorted[len(cp.Units):] {
+		fit, err := RQ(y, x, tau)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fit model for tau=%f: %w", tau, err)
+		}
+		fits[tau] = fit
+		if err := cp.add(opts, compactCopy(fit)); err != nil {
+			return nil, fmt.Errorf("saving checkpoint: %w", err)
+		}
+	}
+
+	first := fits[sorted[0]]
+	return &MultiRQFit{
+		Fits:    fits,
+		Taus:    sorted,
+		N:       first.N,
+		P:       first.P,
+		Method:  first.Method,
+		Formula: first.Formula,
+		Meta:    newMeta(first.Method, first.Meta.Options, sorted, first.N, first.P, start, y, x),
+	}, nil
+}
+
+// RQLassoPathCheckpointed is RQLassoPath with the fit at each lambda saved to
+// opts.Path as it completes, resuming from the saved fits when rerun. The warm
+// start of the first refitted lambda is the last saved solution.
+func RQLassoPathCheckpointed(y []float64, x [][]float64, tau float64, lambdas []float64, opts CheckpointOptions) ([]*LassoFit, error) {
+	if err := opts.prepare(); err != nil {
+		return nil, err
+	}
+	if len(lambdas) == 0 {
+		return nil, fmt.Errorf("no penalty values specified")
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	sorted := append([]float64(nil), lambdas...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	cp, err := loadCheckpoint(opts, fmt.Sprintf("lasso_path tau=%g", tau), DataFingerprint(y, x), sorted)
+	if err != nil {
+		return nil, err
+	}
+	path := make([]*LassoFit, 0, len(sorted))
+	for k, unit := range cp.Units {
+		fit, err := restoreFit(unit, y, x)
+		if err != nil {
+			return nil, fmt.Errorf("restoring lasso fit at lambda=%f: %w", sorted[k], err)
+		}
+		path = append(path, &LassoFit{RQFit: fit, Lambda: sorted[k], Penalized: penalizedColumns(x)})
+	}
+	for _, lambda := range sorted[len(cp.Units):] {
+		var beta0 []float64
+		if len(path) > 0 {
+			beta0 = path[len(path)-1].Coefficients
+		}
+		fit, err := lassoFrom(y, x, tau, lambda, beta0)
+		if err != nil {
+			return nil, fmt.Errorf("lasso fit at lambda=%f failed: %w", lambda, err)
+		}
+		path = append(path, fit)
+		if err := cp.add(opts, compactCopy(fit.RQFit)); err != nil {
+			return nil, fmt.Errorf("saving checkpoint: %w", err)
+		}
+	}
+	return path, nil
+}
+
+// BootstrapCheckpointed is Bootstrap for long runs, saving the draws to ck.Path
+// every ck.Every replications and resuming from the saved draws when rerun.
+// Each replication is seeded from a sequence drawn from rng up front, so a resumed
+// run with an identically seeded rng reproduces an uninterrupted one; the draws
+// differ from those of Bootstrap with the same rng.
+func (fit *RQFit) BootstrapCheckpointed(opts BootstrapOptions, ck CheckpointOptions, rng *rand.Rand) error {
+	if len(fit.X) == 0 || len(fit.Y) == 0 {
+		return fmt.Errorf("fit does not carry its design matrix")
+	}
+	if err := ck.prepare(); err != nil {
+		return err
+	}
+	if opts.Replications == 0 {
+		opts.Replications = 200
+	}
+	if opts.Replications < 2 {
+		return fmt.Errorf("need at least 2 replications, got %d", opts.Replications)
+	}
+
+	rng = randOrDefault(rng)
+	seeds := make([]int64, opts.Replications)
+	keys := make([]float64, opts.Replications)
+	for r := range seeds {
+		seeds[r] = rng.Int63()
+		keys[r] = float64(r)
+	}
+	cp, err := loadCheckpoint(ck, fmt.Sprintf("bootstrap tau=%g", fit.Tau), DataFingerprint(fit.Y, fit.X), keys)
+	if err != nil {
+		return err
+	}
+
+	draws := make([][]float64, 0, opts.Replications)
+	for r, unit := range cp.Units {
+		var coef []float64
+		if err := json.Unmarshal(unit, &coef); err != nil {
+			return fmt.Errorf("restoring replication %d: %w", r, err)
+		}
+		draws = append(draws, coef)
+	}
+	w := make([]float64, len(fit.Y))
+	d := withPhase(PhaseBootstrap, func() {
+		for r := len(cp.Units); r < opts.Replications; r++ {
+			coef, ferr := fit.bootstrapDraw(rand.New(rand.NewSource(seeds[r])), w)
+			if ferr != nil {
+				err = fmt.Errorf("replication %d failed: %w", r, ferr)
+				return
+			}
+			draws = append(draws, coef)
+			if ferr := cp.add(ck, coef); ferr != nil {
+				err = fmt.Errorf("saving checkpoint: %w", ferr)
+				return
+			}
+		}
+	})
+	fit.recordPhase(PhaseBootstrap, d)
+	if err != nil {
+		return err
+	}
+	currentLogger().Info("bootstrap complete", "tau", fit.Tau, "replications", opts.Replications, "duration", d)
+	fit.Draws = draws
+	return nil
+}
@@ -0,0 +1,121 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// truncateCheckpoint keeps the first k units of the checkpoint at path, as if the
+// job had been interrupted
+func truncateCheckpoint(t *testing.T, path string, k int) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read checkpoint: %v", err)
+	}
+	var cp checkpointFile
+	if err := json.Unmarshal(data, &cp); err != nil {
+		t.Fatalf("Failed to decode checkpoint: %v", err)
+	}
+	cp.Units = cp.Units[:k]
+	if err := cp.save(path); err != nil {
+		t.Fatalf("Failed to write checkpoint: %v", err)
+	}
+}
+
+func TestRQProcessCheckpointed(t *testing.T) {
+	y, x := inferenceData()
+	taus := []float64{0.75, 0.25, 0.5}
+	opts := CheckpointOptions{Path: filepath.Join(t.TempDir(), "process.json")}
+
+	full, err := RQProcessCheckpointed(y, x, taus, opts)
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	if len(full.Taus) != 3 || full.Taus[0] != 0.25 {
+		t.Errorf("Expected sorted taus, got %v", full.Taus)
+	}
+
+	truncateCheckpoint(t, opts.Path, 1)
+	resumed, err := RQProcessCheckpointed(y, x, taus, opts)
+	if err != nil {
+		t.Fatalf("Failed to resume process: %v", err)
+	}
+	for _, tau := range full.Taus {
+		a, b := full.Fits[tau], resumed.Fits[tau]
+		for j := range a.Coefficients {
+			if a.Coefficients[j] != b.Coefficients[j] {
+				t.Errorf("tau=%f: expected coefficient %d to be %f, got %f", tau, j, a.Coefficients[j], b.Coefficients[j])
+			}
+		}
+		if len(b.ResidualValues()) != len(y) || len(b.X) != len(x) {
+			t.Errorf("tau=%f: expected the restored fit to carry its data", tau)
+		}
+	}
+
+	if _, err := RQProcessCheckpointed(y, x, []float64{0.1, 0.9}, opts); err == nil {
+		t.Error("Expected error when resuming with different taus")
+	}
+	if _, err := RQProcessCheckpointed(y, x, taus, CheckpointOptions{}); err == nil {
+		t.Error("Expected error for missing checkpoint path")
+	}
+}
+
+func TestRQLassoPathCheckpointed(t *testing.T) {
+	y, x := inferenceData()
+	opts := CheckpointOptions{Path: filepath.Join(t.TempDir(), "path.json")}
+	lambdas := []float64{0.1, 1, 10}
+
+	full, err := RQLassoPathCheckpointed(y, x, 0.5, lambdas, opts)
+	if err != nil {
+		t.Fatalf("Failed to fit path: %v", err)
+	}
+	truncateCheckpoint(t, opts.Path, 2)
+	resumed, err := RQLassoPathCheckpointed(y, x, 0.5, lambdas, opts)
+	if err != nil {
+		t.Fatalf("Failed to resume path: %v", err)
+	}
+	for k := range full {
+		if full[k].Lambda != resumed[k].Lambda || full[k].Coefficients[1] != resumed[k].Coefficients[1] {
+			t.Errorf("Expected step %d to match, got lambda %f and %f", k, full[k].Lambda, resumed[k].Lambda)
+		}
+		if len(resumed[k].Penalized) != 2 || resumed[k].Penalized[0] || !resumed[k].Penalized[1] {
+			t.Errorf("Expected only the slope to be penalized, got %v", resumed[k].Penalized)
+		}
+	}
+}
+
+func TestBootstrapCheckpointed(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	ck := CheckpointOptions{Path: filepath.Join(t.TempDir(), "boot.json"), Every: 5}
+
+	if err := fit.BootstrapCheckpointed(BootstrapOptions{Replications: 12}, ck, rand.New(rand.NewSource(4))); err != nil {
+		t.Fatalf("Failed to bootstrap: %v", err)
+	}
+	full := fit.Draws
+	if len(full) != 12 {
+		t.Fatalf("Expected 12 draws, got %d", len(full))
+	}
+
+	truncateCheckpoint(t, ck.Path, 5)
+	if err := fit.BootstrapCheckpointed(BootstrapOptions{Replications: 12}, ck, rand.New(rand.NewSource(4))); err != nil {
+		t.Fatalf("Failed to resume bootstrap: %v", err)
+	}
+	for r := range full {
+		for j := range full[r] {
+			if full[r][j] != fit.Draws[r][j] {
+				t.Errorf("Expected draw %d to be reproduced, got %v and %v", r, full[r], fit.Draws[r])
+			}
+		}
+	}
+
+	if err := fit.BootstrapCheckpointed(BootstrapOptions{Replications: 12}, CheckpointOptions{Path: ck.Path, Every: -1}, nil); err == nil {
+		t.Error("Expected error for negative checkpoint interval")
+	}
+}
@@ -0,0 +1,132 @@
+package quantreg
+
+import (
+	"fmt"
+)
+
+// VcovCluster returns the cluster-robust covariance H^-1 Omega H^-1 where Omega
+// sums the outer products of within-cluster score totals and H = X' diag(f) X uses
+// Powell kernel densities. The small-cluster correction G/(G-1) is applied for G
+// clusters.
+func (fit *RQFit) VcovCluster(cluster []int) ([][]float64, error) {
+	hinv, err := fit.clusterBread(cluster)
+	if err != nil {
+		return nil, err
+	}
+	meat, g := fit.clusterMeat(cluster)
+	if g < 2 {
+		return nil, fmt.Errorf("need at least 2 clusters, got %d", g)
+	}
+	return sandwich(hinv, meat), nil
+}
+
+// VcovCluster2 returns the two-way cluster-robust covariance of Cameron, Gelbach
+// and Miller (2011): the one-way estimates for each dimension minus the estimate
+// clustered on their intersection, each with its own small-cluster correction.
+// The result can fail to be positive semi-definite in small samples; negative
+// variances are reported as an error.
+func (fit *RQFit) VcovCluster2(cluster1, cluster2 []int) ([][]float64, error) {
+	hinv, err := fit.clusterBread(cluster1)
+	if err != nil {
+		return nil, err
+	}
+	if len(cluster2) != fit.N {
+		return nil, fmt.Errorf("%w: %d cluster ids for %d observations", ErrDimensionMismatch, len(cluster2), fit.N)
+	}
+
+	type pair struct{ a, b int }
+	ids := make(map[pair]int)
+	both := make([]int, fit.N)
+	for i := range both {
+		key := pair{cluster1[i], cluster2[i]}
+		id, ok := ids[key]
+		if !ok {
+			id = len(ids)
+			ids[key] = id
+		}
+		both[i] = id
+	}
+
+	m1, g1 := fit.clusterMeat(cluster1)
+	m2, g2 := fit.clusterMeat(cluster2)
+	m12, _ := fit.clusterMeat(both)
+	if g1 < 2 || g2 < 2 {
+		return nil, fmt.Errorf("need at least 2 clusters in each dimension, got %d and %d", g1, g2)
+	}
+
+	meat := make([][]float64, fit.P)
+	for a := range meat {
+		meat[a] = make([]float64, fit.P)
+		for b := range meat[a] {
+			meat[a][b] = m1[a][b] + m2[a][b] - m12[a][b]
+		}
+	}
+	cov := sandwich(hinv, meat)
+	for j := range cov {
+		if cov[j][j] < 0 {
+			return nil, fmt.Errorf("two-way covariance has negative variance for coefficient %d", j)
+		}
+	}
+	return cov, nil
+}
+
+// clusterBread validates the cluster ids and returns H^-1
+func (fit *RQFit) clusterBread(cluster []int) ([][]float64, error) {
+	if len(fit.X) == 0 || len(fit.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	if len(cluster) != fit.N {
+		return nil, fmt.Errorf("%w: %d cluster ids for %d observations", ErrDimensionMismatch, len(cluster), fit.N)
+	}
+	f, err := fit.kernelDensities()
+	if err != nil {
+		return nil, err
+	}
+	hinv, err := invert(crossprod(fit.X, fit.weighted(f)))
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+	return hinv, nil
+}
+
+// clusterMeat returns G/(G-1) times the sum over clusters of the outer product of
+// the cluster score totals, and the number of clusters G
+func (fit *RQFit) clusterMeat(cluster []int) ([][]float64, int) {
+	p := fit.P
+	totals := make(map[int][]float64)
+	resid := fit.ResidualValues()
+	for i, row := range fit.X {
+		s := fit.Tau
+		if resid[i] < 0 {
+			s = fit.Tau - 1
+		}
+		if fit.Weights != nil {
+			s *= fit.Weights[i]
+		}
+		tot, ok := totals[cluster[i]]
+		if !ok {
+			tot = make([]float64, p)
+			totals[cluster[i]] = tot
+		}
+		for j, v := range row {
+			tot[j] += v * s
+		}
+	}
+
+	g := len(totals)
+	meat := make([][]float64, p)
+	for a := range meat {
+		meat[a] = make([]float64, p)
+	}
+	for _, tot := range totals {
+		for a := 0; a < p; a++ {
+			for b := 0; b < p; b++ {
+				meat[a][b] += tot[a] * tot[b]
+			}
+		}
+	}
+	if g > 1 {
+		meat = scaleMatrix(meat, float64(g)/float64(g-1))
+	}
+	return meat, g
+}
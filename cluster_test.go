@@ -0,0 +1,59 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVcovCluster(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	firm := make([]int, fit.N)
+	year := make([]int, fit.N)
+	single := make([]int, fit.N)
+	for i := range firm {
+		firm[i] = i / 4
+		year[i] = i % 4
+		single[i] = i
+	}
+
+	cov, err := fit.VcovCluster(firm)
+	if err != nil {
+		t.Fatalf("Failed to compute clustered covariance: %v", err)
+	}
+	for j := range cov {
+		if cov[j][j] <= 0 {
+			t.Errorf("Expected positive variance for coefficient %d, got %f", j, cov[j][j])
+		}
+	}
+
+	// Two-way clustering where the second dimension is the observation itself
+	// reduces to the one-way estimate: V(firm) + V(obs) - V(obs)
+	two, err := fit.VcovCluster2(firm, single)
+	if err != nil {
+		t.Fatalf("Failed to compute two-way covariance: %v", err)
+	}
+	for a := range cov {
+		for b := range cov {
+			if math.Abs(two[a][b]-cov[a][b]) > 1e-9*math.Max(1, math.Abs(cov[a][b])) {
+				t.Errorf("Two-way (%d, %d): got %f, want %f", a, b, two[a][b], cov[a][b])
+			}
+		}
+	}
+
+	if _, err := fit.VcovCluster2(firm, year); err != nil {
+		t.Errorf("Failed to compute two-way covariance: %v", err)
+	}
+
+	// Error cases
+	if _, err := fit.VcovCluster(make([]int, fit.N)); err == nil {
+		t.Error("Expected error for a single cluster")
+	}
+	if _, err := fit.VcovCluster(firm[:3]); err == nil {
+		t.Error("Expected error for mismatched cluster ids")
+	}
+}
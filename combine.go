@@ -0,0 +1,165 @@
+package quantreg
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Forecast combination methods
+const (
+	CombineMean     = "mean"     // Equal-weight average of the models
+	CombineTrimmed  = "trimmed"  // Average after dropping the most extreme models
+	CombineWeighted = "weighted" // Per-tau convex weights fitted on a validation window
+)
+
+// Combiner merges quantile predictions from several models, each keyed by tau as
+// returned by MultiRQFit.Predict, into one forecast. The combined quantiles are
+// sorted per row so the result never crosses.
+type Combiner struct {
+	Method  string
+	Trim    float64               // Fraction trimmed from each end for CombineTrimmed
+	Weights map[float64][]float64 // Model weights per tau for CombineWeighted
+}
+
+// FitCombiner learns, for every tau, convex model weights that minimize the pinball
+// loss of the combined forecast on a validation window with outcomes y, solved
+// exactly as in Stack
+func FitCombiner(preds []map[float64][]float64, y []float64) (*Combiner, error) {
+	taus, err := combineTaus(preds, len(y))
+	if err != nil {
+		return nil, err
+	}
+	if len(y) == 0 {
+		return nil, fmt.Errorf("empty validation window")
+	}
+
+	c := &Combiner{Method: CombineWeighted, Weights: make(map[float64][]float64)}
+	q := make([][]float64, len(y))
+	for _, tau := range taus {
+		for i := range q {
+			q[i] = make([]float64, len(preds))
+			for j, p := range preds {
+				q[i][j] = p[tau][i]
+			}
+		}
+		w, err := simplexWeights(y, q, tau)
+		if err != nil {
+			return nil, fmt.Errorf("combination weights failed for tau=%f: %w", tau, err)
+		}
+		c.Weights[tau] = w
+	}
+	return c, nil
+}
+
+// Combine merges the model predictions into a single non-crossing quantile forecast
+func (c *Combiner) Combine(preds []map[float64][]float64) (map[float64][]float64, error) {
+	if len(preds) == 0 {
+		return nil, fmt.Errorf("no model predictions")
+	}
+	var n int
+	for _, q := range preds[0] {
+		n = len(q)
+		break
+	}
+	taus, err := combineTaus(preds, n)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Method {
+	case CombineMean, "":
+	case CombineTrimmed:
+		if c.Trim < 0 || c.Trim >= 0.5 {
+			return nil, fmt.Errorf("trim fraction must be in [0, 0.5), got %f", c.Trim)
+		}
+	case CombineWeighted:
+		for _, tau := range taus {
+			if w, ok := c.Weights[tau]; !ok || len(w) != len(preds) {
+				return nil, fmt.Errorf("no weights for %d models at tau=%f", len(preds), tau)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown combination method: %s", c.Method)
+	}
+
+	out := make(map[float64][]float64, len(taus))
+	vals := make([]float64, len(preds))
+	for _, tau := range taus {
+		comb := make([]float64, n)
+		for i := 0; i < n; i++ {
+			for j, p := range preds {
+				vals[j] = p[tau][i]
+			}
+			switch c.Method {
+			case CombineTrimmed:
+				comb[i] = trimmedMean(vals, c.Trim)
+			case CombineWeighted:
+				for j, v := range vals {
+					comb[i] += c.Weights[tau][j] * v
+				}
+			default:
+				comb[i] = trimmedMean(vals, 0)
+			}
+		}
+		out[tau] = comb
+	}
+
+	// Rearrange each row so the combined quantiles are monotone in tau
+	rearrangeRows(out, taus, n)
+
+	return out, nil
+}
+
+// rearrangeRows sorts the n predicted quantiles of each row across the sorted taus
+func rearrangeRows(pred map[float64][]float64, taus []float64, n int) {
+	q := make([]float64, len(taus))
+	for i := 0; i < n; i++ {
+		for k, tau := range taus {
+			q[k] = pred[tau][i]
+		}
+		sort.Float64s(q)
+		for k, tau := range taus {
+			pred[tau][i] = q[k]
+		}
+	}
+}
+
+// combineTaus checks that all models predict the same taus for n rows and returns them sorted
+func combineTaus(preds []map[float64][]float64, n int) ([]float64, error) {
+	if len(preds) == 0 {
+		return nil, fmt.Errorf("no model predictions")
+	}
+	var taus []float64
+	for tau := range preds[0] {
+		taus = append(taus, tau)
+	}
+	sort.Float64s(taus)
+	for j, p := range preds {
+		if len(p) != len(taus) {
+			return nil, fmt.Errorf("model %d predicts %d quantile levels, want %d", j, len(p), len(taus))
+		}
+		for _, tau := range taus {
+			q, ok := p[tau]
+			if !ok {
+				return nil, fmt.Errorf("model %d has no prediction for tau=%f", j, tau)
+			}
+			if len(q) != n {
+				return nil, fmt.Errorf("model %d has %d predictions for tau=%f, want %d", j, len(q), tau, n)
+			}
+		}
+	}
+	return taus, nil
+}
+
+// trimmedMean averages v after dropping floor(trim*len(v)) values from each end
+func trimmedMean(v []float64, trim float64) float64 {
+	s := append([]float64(nil), v...)
+	sort.Float64s(s)
+	k := int(trim * float64(len(s)))
+	s = s[k : len(s)-k]
+	sum := 0.0
+	for _, x := range s {
+		sum += x
+	}
+	return sum / float64(len(s))
+}
@@ -0,0 +1,117 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCombine(t *testing.T) {
+	preds := []map[float64][]float64{
+		{0.1: {0, 1}, 0.9: {2, 3}},
+		{0.1: {1, 2}, 0.9: {3, 4}},
+		{0.1: {10, 0}, 0.9: {12, 1}},
+	}
+
+	mean, err := (&Combiner{Method: CombineMean}).Combine(preds)
+	if err != nil {
+		t.Fatalf("Failed to combine forecasts: %v", err)
+	}
+	if math.Abs(mean[0.1][0]-11.0/3) > 1e-12 || math.Abs(mean[0.9][1]-8.0/3) > 1e-12 {
+		t.Errorf("Unexpected mean combination: %v", mean)
+	}
+
+	trimmed, err := (&Combiner{Method: CombineTrimmed, Trim: 0.34}).Combine(preds)
+	if err != nil {
+		t.Fatalf("Failed to combine forecasts: %v", err)
+	}
+	if trimmed[0.1][0] != 1 || trimmed[0.9][0] != 3 {
+		t.Errorf("Expected the median model after trimming, got %v", trimmed)
+	}
+
+	// Combined quantiles never cross
+	crossing := []map[float64][]float64{{0.1: {5}, 0.9: {1}}}
+	out, err := (&Combiner{}).Combine(crossing)
+	if err != nil {
+		t.Fatalf("Failed to combine forecasts: %v", err)
+	}
+	if out[0.1][0] != 1 || out[0.9][0] != 5 {
+		t.Errorf("Expected rearranged quantiles, got %v", out)
+	}
+
+	// Error cases
+	if _, err := (&Combiner{Method: "median"}).Combine(preds); err == nil {
+		t.Error("Expected error for unknown method")
+	}
+	if _, err := (&Combiner{}).Combine(append(preds, map[float64][]float64{0.1: {0, 0}})); err == nil {
+		t.Error("Expected error for mismatched quantile levels")
+	}
+
+	// An invalid configuration is rejected even without rows to combine
+	empty := []map[float64][]float64{{0.5: {}}}
+	for _, c := range []*Combiner{{Method: "median"}, {Method: CombineTrimmed, Trim: 0.5}, {Method: CombineWeighted}} {
+		if _, err := c.Combine(empty); err == nil {
+			t.Errorf("Expected error for combiner %+v with no rows", c)
+		}
+	}
+}
+
+func TestFitCombiner(t *testing.T) {
+	// Model 0 tracks the outcome, model 1 is noise
+	n := 40
+	y := make([]float64, n)
+	good := map[float64][]float64{0.5: make([]float64, n)}
+	bad := map[float64][]float64{0.5: make([]float64, n)}
+	for i := 0; i < n; i++ {
+		y[i] = float64(i)
+		good[0.5][i] = float64(i) + 0.1*math.Sin(float64(i))
+		bad[0.5][i] = 20 + 10*math.Cos(float64(3*i))
+	}
+	preds := []map[float64][]float64{good, bad}
+
+	c, err := FitCombiner(preds, y)
+	if err != nil {
+		t.Fatalf("Failed to fit combiner: %v", err)
+	}
+	w := c.Weights[0.5]
+	if math.Abs(w[0]+w[1]-1) > 1e-9 || w[0] < 0 || w[1] < 0 {
+		t.Errorf("Weights are not convex: %v", w)
+	}
+	if w[0] < 0.9 {
+		t.Errorf("Expected most weight on the accurate model, got %v", w)
+	}
+
+	// The weights are the exact minimizer over the simplex
+	loss := func(a float64) float64 {
+		total := 0.0
+		for i := range y {
+			total += rho(y[i]-a*good[0.5][i]-(1-a)*bad[0.5][i], 0.5)
+		}
+		return total
+	}
+	for a := 0.0; a <= 1; a += 0.01 {
+		if loss(w[0]) > loss(a)+1e-9 {
+			t.Errorf("Weight %f has lower loss than the fitted %f", a, w[0])
+		}
+	}
+
+	out, err := c.Combine(preds)
+	if err != nil {
+		t.Fatalf("Failed to combine forecasts: %v", err)
+	}
+	if sumRho(residualsOf(y, out[0.5]), 0.5) > sumRho(residualsOf(y, equalWeightPreds(preds)), 0.5) {
+		t.Error("Expected fitted weights to beat the equal-weight average")
+	}
+}
+
+func residualsOf(y, q []float64) []float64 {
+	r := make([]float64, len(y))
+	for i := range y {
+		r[i] = y[i] - q[i]
+	}
+	return r
+}
+
+func equalWeightPreds(preds []map[float64][]float64) []float64 {
+	out, _ := (&Combiner{}).Combine(preds)
+	return out[0.5]
+}
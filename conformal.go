@@ -0,0 +1,165 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ConformalInterval is a conformalized quantile regression (CQR) interval of
+// Romano, Patterson and Candes (2019): the band between lower and upper quantile
+// fits widened by a correction computed on a held-out calibration set
+type ConformalInterval struct {
+	Alpha        float64 // Target miscoverage
+	Lower        *RQFit  // Lower quantile fit
+	Upper        *RQFit  // Upper quantile fit
+	Correction   float64 // Conformal adjustment added to both ends (may be negative)
+	CalibrationN int     // Size of the calibration set
+	Scores       []float64
+}
+
+// IntervalCoverage summarizes how an interval performed on labelled data
+type IntervalCoverage struct {
+	Coverage  float64 // Fraction of outcomes inside the interval
+	MeanWidth float64
+	N         int
+}
+
+// CalibrateCQR computes the conformity scores max(q_lo - y, y - q_hi) of the fitted
+// quantile models on the calibration set and sets the correction to their
+// ceil((n+1)(1-alpha))-th smallest value, which guarantees marginal coverage of at
+// least 1-alpha for exchangeable data
+func CalibrateCQR(lower, upper *RQFit, xCal [][]float64, yCal []float64, alpha float64) (*ConformalInterval, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return nil, fmt.Errorf("alpha must be between 0 and 1")
+	}
+	if len(xCal) != len(yCal) {
+		return nil, fmt.Errorf("%w: xCal has %d rows, yCal has %d", ErrDimensionMismatch, len(xCal), len(yCal))
+	}
+	if len(yCal) == 0 {
+		return nil, fmt.Errorf("empty calibration set")
+	}
+	lo, err := lower.Predict(xCal)
+	if err != nil {
+		return nil, err
+	}
+	hi, err := upper.Predict(xCal)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(yCal)
+	scores := make([]float64, n)
+	for i, y := range yCal {
+		scores[i] = math.Max(lo[i]-y, y-hi[i])
+	}
+	sort.Float64s(scores)
+
+	k := int(math.Ceil(float64(n+1) * (1 - alpha)))
+	correction := math.Inf(1)
+	if k <= n {
+		correction = scores[k-1]
+	}
+
+	return &ConformalInterval{
+		Alpha:        alpha,
+		Lower:        lower,
+		Upper:        upper,
+		Correction:   correction,
+		CalibrationN: n,
+		Scores:       scores,
+	}, nil
+}
+
+// CQR randomly splits (y, x) into a proper training set and a calibration set of
+// the given fraction, fits the alpha/2 and 1-alpha/2 quantiles on the former and
+// calibrates on the latter
+func CQR(y []float64, x [][]float64, alpha, calFraction float64, rng *rand.Rand) (*ConformalInterval, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("%w: y has %d rows, x has %d rows", ErrDimensionMismatch, len(y), len(x))
+	}
+	if calFraction <= 0 || calFraction >= 1 {
+		return nil, fmt.Errorf("calibration fraction must be between 0 and 1")
+	}
+	if alpha <= 0 || alpha >= 1 {
+		return nil, fmt.Errorf("alpha must be between 0 and 1")
+	}
+
+	rng = randOrDefault(rng)
+	perm := rng.Perm(len(y))
+	nCal := int(math.Round(calFraction * float64(len(y))))
+	if nCal < 1 || nCal >= len(y) {
+		return nil, fmt.Errorf("calibration split leaves an empty set")
+	}
+
+	var yTrain, yCal []float64
+	var xTrain, xCal [][]float64
+	for j, i := range perm {
+		if j < nCal {
+			yCal = append(yCal, y[i])
+			xCal = append(xCal, x[i])
+		} else {
+			yTrain = append(yTrain, y[i])
+			xTrain = append(xTrain, x[i])
+		}
+	}
+
+	lower, err := RQ(yTrain, xTrain, alpha/2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fit lower quantile: %w", err)
+	}
+	upper, err := RQ(yTrain, xTrain, 1-alpha/2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fit upper quantile: %w", err)
+	}
+	return CalibrateCQR(lower, upper, xCal, yCal, alpha)
+}
+
+// Predict returns the calibrated interval bounds at newX
+func (c *ConformalInterval) Predict(newX [][]float64) ([]float64, []float64, error) {
+	lo, err := c.Lower.Predict(newX)
+	if err != nil {
+		return nil, nil, err
+	}
+	hi, err := c.Upper.Predict(newX)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range lo {
+		lo[i] -= c.Correction
+		hi[i] += c.Correction
+	}
+	return lo, hi, nil
+}
+
+// Coverage evaluates the calibrated interval on labelled test data
+func (c *ConformalInterval) Coverage(x [][]float64, y []float64) (*IntervalCoverage, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("%w: x has %d rows, y has %d", ErrDimensionMismatch, len(x), len(y))
+	}
+	if len(y) == 0 {
+		return nil, fmt.Errorf("no test observations")
+	}
+	lo, hi, err := c.Predict(x)
+	if err != nil {
+		return nil, err
+	}
+	res := &IntervalCoverage{N: len(y)}
+	for i, v := range y {
+		if v >= lo[i] && v <= hi[i] {
+			res.Coverage++
+		}
+		res.MeanWidth += hi[i] - lo[i]
+	}
+	res.Coverage /= float64(len(y))
+	res.MeanWidth /= float64(len(y))
+	return res, nil
+}
+
+// CoverageBounds returns the finite-sample bounds on the marginal coverage of a
+// split-conformal interval with n calibration points: at least 1-alpha and, for
+// continuous scores, at most 1-alpha+1/(n+1)
+func CoverageBounds(n int, alpha float64) (float64, float64) {
+	return 1 - alpha, math.Min(1, 1-alpha+1/float64(n+1))
+}
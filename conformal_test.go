@@ -0,0 +1,97 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCalibrateCQR(t *testing.T) {
+	x := [][]float64{{1, 0.5}, {1, 1.0}, {1, 1.5}, {1, 2.0}, {1, 2.5}}
+	y := []float64{1.0, 2.0, 2.5, 3.0, 4.0}
+
+	lower, err := RQ(y, x, 0.1)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	upper, err := RQ(y, x, 0.9)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	xCal := make([][]float64, 19)
+	yCal := make([]float64, 19)
+	for i := range xCal {
+		xCal[i] = []float64{1, 0.5 + float64(i)/9}
+		yCal[i] = 0.5 + xCal[i][1] + math.Sin(float64(3*i))
+	}
+
+	c, err := CalibrateCQR(lower, upper, xCal, yCal, 0.1)
+	if err != nil {
+		t.Fatalf("Failed to calibrate: %v", err)
+	}
+	// ceil(20 * 0.9) = 18th smallest score
+	if c.Correction != c.Scores[17] || c.CalibrationN != 19 {
+		t.Errorf("Expected the 18th smallest score as correction, got %f", c.Correction)
+	}
+
+	// The calibrated interval covers all but at most one calibration point
+	cov, err := c.Coverage(xCal, yCal)
+	if err != nil {
+		t.Fatalf("Failed to evaluate coverage: %v", err)
+	}
+	if cov.Coverage < 18.0/19 || cov.N != 19 {
+		t.Errorf("Expected coverage at least 18/19, got %f", cov.Coverage)
+	}
+
+	// Tiny calibration sets give infinite intervals
+	c, err = CalibrateCQR(lower, upper, xCal[:3], yCal[:3], 0.1)
+	if err != nil {
+		t.Fatalf("Failed to calibrate: %v", err)
+	}
+	if !math.IsInf(c.Correction, 1) {
+		t.Errorf("Expected infinite correction, got %f", c.Correction)
+	}
+
+	lo, hi := CoverageBounds(19, 0.1)
+	if lo != 0.9 || math.Abs(hi-0.95) > 1e-12 {
+		t.Errorf("Expected bounds [0.9, 0.95], got [%f, %f]", lo, hi)
+	}
+
+	// Error cases
+	if _, err := CalibrateCQR(lower, upper, xCal, yCal, 0); err == nil {
+		t.Error("Expected error for invalid alpha")
+	}
+	if _, err := CalibrateCQR(lower, upper, xCal, yCal[:2], 0.1); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
+
+func TestCQR(t *testing.T) {
+	n := 20
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = []float64{1, float64(i) / 5}
+		y[i] = 1 + x[i][1] + 0.5*math.Cos(float64(7*i))
+	}
+
+	c, err := CQR(y, x, 0.2, 0.5, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to fit CQR: %v", err)
+	}
+	if c.CalibrationN != 10 || c.Lower.Tau != 0.1 || c.Upper.Tau != 0.9 {
+		t.Errorf("Unexpected split or levels: n=%d, taus %f/%f", c.CalibrationN, c.Lower.Tau, c.Upper.Tau)
+	}
+	lo, hi, err := c.Predict(x[:3])
+	if err != nil {
+		t.Fatalf("Failed to predict intervals: %v", err)
+	}
+	if len(lo) != 3 || len(hi) != 3 {
+		t.Errorf("Expected 3 intervals, got %d", len(lo))
+	}
+
+	if _, err := CQR(y, x, 0.2, 1, nil); err == nil {
+		t.Error("Expected error for invalid calibration fraction")
+	}
+}
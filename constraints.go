@@ -0,0 +1,233 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Constraints restricts the coefficients to A b = B and C b <= D. Either pair
+// may be empty.
+type Constraints struct {
+	A [][]float64 // Equality constraint rows
+	B []float64   // Equality right-hand sides
+	C [][]float64 // Inequality constraint rows
+	D []float64   // Inequality upper bounds
+}
+
+// ConstrainedFit is a quantile regression fitted under linear constraints
+type ConstrainedFit struct {
+	*RQFit
+	Constraints           Constraints
+	EqualityMultipliers   []float64 // Change in the minimized check loss per unit increase of each B
+	InequalityMultipliers []float64 // Reduction in the minimized check loss per unit relaxation of each D, non-negative
+	Slack                 []float64 // D - C b at the solution
+	Active                []int     // Inequality constraints binding at the solution
+}
+
+// validate checks the constraint dimensions against p coefficients
+func (c Constraints) validate(p int) error {
+	if len(c.A) != len(c.B) {
+		return fmt.Errorf("expected %d equality right-hand sides, got %d", len(c.A), len(c.B))
+	}
+	if len(c.C) != len(c.D) {
+		return fmt.Errorf("expected %d inequality bounds, got %d", len(c.C), len(c.D))
+	}
+	for k, row := range c.A {
+		if len(row) != p {
+			return fmt.Errorf("equality constraint %d has %d columns, expected %d", k, len(row), p)
+		}
+	}
+	for k, row := range c.C {
+		if len(row) != p {
+			return fmt.Errorf("inequality constraint %d has %d columns, expected %d", k, len(row), p)
+		}
+	}
+	return nil
+}
+
+// RQConstrained fits a linear quantile regression subject to A b = B and C b <= D,
+// e.g. coefficients summing to one or ordered effects. The primal linear program
+//
+//	min tau 1'u + (1-tau) 1'v  s.t.  X b + u - v = y,  A b = B,  C b + s = D,  u, v, s >= 0
+//
+// is solved by the simplex of RQ, so the solution is an exact vertex. The
+// multipliers are the constraints' parts of the dual solution
+//
+//	max y'l + B'm + D'n  s.t.  X'l + A'm + C'n = 0,  tau-1 <= l <= tau,  n <= 0
+//
+// and are the sensitivities of the minimized check loss to the right-hand sides.
+// Constraints no coefficients satisfy give an error wrapping ErrInfeasible.
+func RQConstrained(y []float64, x [][]float64, tau float64, cons Constraints) (*ConstrainedFit, error) {
+	if len(y) == 0 || len(x) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if tau <= 0 || tau >= 1 {
+		return nil, ErrInvalidTau
+	}
+	if err := cons.validate(len(x[0])); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var coef, slack, mult []float64
+	var iterations int
+	var err error
+	d := withPhase(PhaseSolve, func() {
+		coef, slack, mult, iterations, err = constrainedSimplex(y, x, nil, tau, cons)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("constrained fit failed: %w", err)
+	}
+	fit := lpFit(y, x, tau, coef, "constrained", start)
+	fit.Iterations = iterations
+	fit.recordPhase(PhaseSolve, d)
+
+	meq := len(cons.B)
+	cf := &ConstrainedFit{
+		RQFit:                 fit,
+		Constraints:           cons,
+		EqualityMultipliers:   mult[:meq],
+		InequalityMultipliers: mult[meq:],
+		Slack:                 slack,
+	}
+	for k, s := range slack {
+		if s <= 1e-8*math.Max(1, math.Abs(cons.D[k])) {
+			cf.Active = append(cf.Active, k)
+		}
+	}
+	return cf, nil
+}
+
+// rqSimplex fits an unconstrained quantile regression exactly by the simplex
+// method, minimizing sum w_i rho_tau(r_i) with unit weights when w is nil.
+// Estimators that compare check losses or coefficients across a grid of nearby
+// problems use it so the comparisons are not swamped by solver error.
+func rqSimplex(y []float64, x [][]float64, w []float64, tau float64) (*RQFit, error) {
+	start := time.Now()
+	var coef []float64
+	var iterations int
+	var err error
+	d := withPhase(PhaseSolve, func() {
+		coef, _, _, iterations, err = constrainedSimplex(y, x, w, tau, Constraints{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("simplex fit failed: %w", err)
+	}
+	fit := lpFit(y, x, tau, coef, "simplex", start)
+	fit.Iterations = iterations
+	fit.recordPhase(PhaseSolve, d)
+	return fit, nil
+}
+
+// lpFit assembles the fit for coefficients from an exact linear programming solution
+func lpFit(y []float64, x [][]float64, tau float64, coef []float64, method string, start time.Time) *RQFit {
+	n, p := len(y), len(x[0])
+	fit := &RQFit{
+		Coefficients: coef,
+		Fitted:       make([]float64, n),
+		Residuals:    make([]float64, n),
+		Tau:          tau,
+		N:            n,
+		P:            p,
+		Method:       method,
+		X:            x,
+		Y:            y,
+		Converged:    true,
+	}
+	for i := range y {
+		for j, b := range coef {
+			fit.Fitted[i] += x[i][j] * b
+		}
+		fit.Residuals[i] = y[i] - fit.Fitted[i]
+	}
+	fit.BasicObs = basicObservations(fit.Residuals, p)
+	fit.Meta = newMeta(method, map[string]float64{"tolerance": 1e-10}, []float64{tau}, n, p, start, y, x)
+	logFit(method, tau, n, p, 0, true, fit.Meta)
+	return fit
+}
+
+// constrainedSimplex minimizes sum w_i rho_tau(r_i), with unit weights when w is
+// nil, subject to cons. It runs the simplex of RQ on the observations followed by
+// one pseudo-observation per constraint: row A_k with response B_k, whose
+// residual costs penalty |r|, and row C_k with response D_k, whose residual costs
+// penalty |r| when negative. The penalty is exact, giving the constrained
+// solution, once it exceeds the multipliers, so it grows until the constraints
+// hold. It returns b, the inequality slacks D - C b, the multipliers of the
+// equality then the inequality constraints and the number of simplex iterations.
+func constrainedSimplex(y []float64, x [][]float64, w []float64, tau float64, cons Constraints) ([]float64, []float64, []float64, int, error) {
+	n := len(y)
+	meq, nin := len(cons.B), len(cons.D)
+	m := n + meq + nin
+	ya := append(append(append(make([]float64, 0, m), y...), cons.B...), cons.D...)
+	xa := append(append(append(make([][]float64, 0, m), x...), cons.A...), cons.C...)
+
+	above := make([]float64, m)
+	below := make([]float64, m)
+	penalty := 0.0
+	for i := 0; i < n; i++ {
+		wi := 1.0
+		if w != nil {
+			wi = w[i]
+		}
+		above[i], below[i] = wi*tau, wi*(1-tau)
+		penalty += wi
+	}
+	penalty = math.Max(penalty, 1)
+
+	iterations := 0
+	for try := 0; try < penaltyTries; try++ {
+		for k := n; k < m; k++ {
+			above[k], below[k] = penalty, penalty
+			if k >= n+meq {
+				above[k] = 0
+			}
+		}
+		solver := &RQFit{Tau: tau, Method: MethodBR}
+		coef, dual, err := solver.simplex(ya, xa, above, below, nil)
+		iterations += solver.Iterations
+		if err != nil {
+			return nil, nil, nil, iterations, err
+		}
+		if err := convergenceError(solver.Converged, iterations); err != nil {
+			return nil, nil, nil, iterations, err
+		}
+
+		feasible := true
+		for k, row := range cons.A {
+			if math.Abs(cons.B[k]-dot(row, coef)) > 1e-8*math.Max(1, math.Abs(cons.B[k])) {
+				feasible = false
+			}
+		}
+		slack := make([]float64, nin)
+		for k, row := range cons.C {
+			slack[k] = cons.D[k] - dot(row, coef)
+			if slack[k] < -1e-8*math.Max(1, math.Abs(cons.D[k])) {
+				feasible = false
+			}
+		}
+		if !feasible {
+			penalty *= 100
+			continue
+		}
+
+		// The dual of a pseudo-observation is the derivative of the minimized
+		// loss in its response
+		mult := make([]float64, meq+nin)
+		for k := range mult {
+			mult[k] = dual[n+k]
+			if k >= meq {
+				mult[k] = math.Max(0, -mult[k])
+			}
+		}
+		return coef, slack, mult, iterations, nil
+	}
+	return nil, nil, nil, iterations, fmt.Errorf("%w: violated at a penalty of %g", ErrInfeasible, penalty/100)
+}
+
+// penaltyTries bounds the growth of the constraint penalty in constrainedSimplex
+// to a factor of 1e10
+const penaltyTries = 6
@@ -0,0 +1,113 @@
+package quantreg
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRQConstrained(t *testing.T) {
+	y, x := inferenceData()
+
+	free, err := RQConstrained(y, x, 0.5, Constraints{})
+	if err != nil {
+		t.Fatalf("Failed to fit unconstrained model: %v", err)
+	}
+	if math.Abs(free.Coefficients[1]-0.5) > 0.3 {
+		t.Errorf("Expected slope near 0.5, got %v", free.Coefficients[1])
+	}
+
+	// The slope bound below the unconstrained estimate must bind
+	bound := free.Coefficients[1] - 0.2
+	cons := Constraints{C: [][]float64{{0, 1}}, D: []float64{bound}}
+	fit, err := RQConstrained(y, x, 0.5, cons)
+	if err != nil {
+		t.Fatalf("Failed to fit constrained model: %v", err)
+	}
+	if math.Abs(fit.Coefficients[1]-bound) > 1e-8 {
+		t.Errorf("Expected slope at the bound %v, got %v", bound, fit.Coefficients[1])
+	}
+	if len(fit.Active) != 1 || fit.Active[0] != 0 {
+		t.Errorf("Expected constraint 0 to be active, got %v", fit.Active)
+	}
+	if fit.Rho() < free.Rho()-1e-9 {
+		t.Errorf("Constrained loss %v below unconstrained loss %v", fit.Rho(), free.Rho())
+	}
+	w := fit.InequalityMultipliers[0]
+	if w <= 0 {
+		t.Fatalf("Expected positive multiplier on a binding constraint, got %v", w)
+	}
+
+	// The multiplier is the loss reduction per unit relaxation
+	eps := 1e-3
+	cons.D = []float64{bound + eps}
+	relaxed, err := RQConstrained(y, x, 0.5, cons)
+	if err != nil {
+		t.Fatalf("Failed to fit relaxed model: %v", err)
+	}
+	if got := (fit.Rho() - relaxed.Rho()) / eps; math.Abs(got-w) > 1e-4*math.Max(1, w) {
+		t.Errorf("Expected loss reduction rate %v, got %v", w, got)
+	}
+}
+
+func TestRQConstrainedEquality(t *testing.T) {
+	y, x := inferenceData()
+
+	cons := Constraints{A: [][]float64{{1, 1}}, B: []float64{1}}
+	fit, err := RQConstrained(y, x, 0.25, cons)
+	if err != nil {
+		t.Fatalf("Failed to fit constrained model: %v", err)
+	}
+	if sum := fit.Coefficients[0] + fit.Coefficients[1]; math.Abs(sum-1) > 1e-8 {
+		t.Errorf("Expected coefficients summing to 1, got %v", sum)
+	}
+	if len(fit.EqualityMultipliers) != 1 {
+		t.Errorf("Expected 1 equality multiplier, got %d", len(fit.EqualityMultipliers))
+	}
+
+	if _, err := RQConstrained(y, x, 0.5, Constraints{A: [][]float64{{1}}, B: []float64{1}}); err == nil {
+		t.Error("Expected error for mismatched constraint columns")
+	}
+}
+
+func TestRQConstrainedTies(t *testing.T) {
+	// Counts on discrete covariates, where the simplex meets degenerate vertices
+	rng := rand.New(rand.NewSource(6))
+	n := 200
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := range y {
+		x[i] = []float64{1, float64(rng.Intn(4)), float64(rng.Intn(3))}
+		y[i] = float64(rng.Intn(6)) + x[i][1]
+	}
+
+	for _, tau := range []float64{0.25, 0.5, 0.75} {
+		free, err := RQConstrained(y, x, tau, Constraints{})
+		if err != nil {
+			t.Fatalf("Failed to fit unconstrained model: %v", err)
+		}
+		ref, err := RQWithOptions(y, x, tau, RQOptions{Method: MethodFN})
+		if err != nil {
+			t.Fatalf("Failed to fit reference model: %v", err)
+		}
+		if loss, best := free.Rho(), sumRho(ref.Residuals, tau); loss > best+1e-6*best {
+			t.Errorf("Expected check loss at most %v at tau=%v, got %v", best, tau, loss)
+		}
+
+		cons := Constraints{C: [][]float64{{0, 0, 1}}, D: []float64{-0.5}}
+		fit, err := RQConstrained(y, x, tau, cons)
+		if err != nil {
+			t.Fatalf("Failed to fit constrained model: %v", err)
+		}
+		if fit.Coefficients[2] > -0.5+1e-8 {
+			t.Errorf("Expected coefficient 2 at most -0.5, got %v", fit.Coefficients[2])
+		}
+	}
+
+	// b1 <= 0 and b1 >= 1 cannot both hold
+	cons := Constraints{C: [][]float64{{0, 1, 0}, {0, -1, 0}}, D: []float64{0, -1}}
+	if _, err := RQConstrained(y, x, 0.5, cons); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("Expected ErrInfeasible, got %v", err)
+	}
+}
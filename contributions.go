@@ -0,0 +1,113 @@
+package quantreg
+
+import "fmt"
+
+// ContributionTable decomposes predictions into additive per-term contributions
+type ContributionTable struct {
+	Terms      []string
+	Values     [][]float64 // Contribution of each term to each prediction, one row per observation
+	Prediction []float64   // Predicted quantile, the sum of each row of Values
+}
+
+// Contributions decomposes each prediction x'b into the terms x_j b_j, so that an
+// individual quantile prediction can be explained coefficient by coefficient
+func (fit *RQFit) Contributions(newX [][]float64) (*ContributionTable, error) {
+	if len(newX) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	terms := make([]string, fit.P)
+	for j := range terms {
+		terms[j] = fmt.Sprintf("Beta[%d]", j)
+	}
+	ct := &ContributionTable{Terms: terms, Values: make([][]float64, len(newX)), Prediction: make([]float64, len(newX))}
+	for i, row := range newX {
+		if len(row) != fit.P {
+			return nil, fmt.Errorf("number of variables in new data does not match model")
+		}
+		ct.Values[i] = make([]float64, fit.P)
+		for j, v := range row {
+			ct.Values[i][j] = v * fit.Coefficients[j]
+			ct.Prediction[i] += ct.Values[i][j]
+		}
+	}
+	return ct, nil
+}
+
+// Contributions decomposes each prediction into the intercept, one term per
+// numeric column and one term per factor, the latter summing its dummy columns
+func (f *SchemaFit) Contributions(newData Frame) (*ContributionTable, error) {
+	x, err := f.Schema.Design(newData)
+	if err != nil {
+		return nil, err
+	}
+	cols, err := f.RQFit.Contributions(x)
+	if err != nil {
+		return nil, err
+	}
+	// group[j] is the term of design column j
+	terms := append([]string{"(Intercept)"}, f.Schema.Numeric...)
+	group := make([]int, 0, f.P)
+	for j := range terms {
+		group = append(group, j)
+	}
+	for _, fac := range f.Schema.Factors {
+		for range fac.Levels[1:] {
+			group = append(group, len(terms))
+		}
+		terms = append(terms, fac.Name)
+	}
+	ct := &ContributionTable{Terms: terms, Values: make([][]float64, len(x)), Prediction: cols.Prediction}
+	for i, row := range cols.Values {
+		ct.Values[i] = make([]float64, len(terms))
+		for j, v := range row {
+			ct.Values[i][group[j]] += v
+		}
+	}
+	return ct, nil
+}
+
+// Contributions decomposes each prediction into the parametric terms x_j b_j and
+// the smooth term g(z), named "s(z)"
+func (f *PartiallyLinearFit) Contributions(newX [][]float64, newZ []float64) (*ContributionTable, error) {
+	if len(newX) != len(newZ) {
+		return nil, fmt.Errorf("x and z %w", ErrDimensionMismatch)
+	}
+	terms := make([]string, f.Linear+1)
+	for j := 0; j < f.Linear; j++ {
+		terms[j] = fmt.Sprintf("Beta[%d]", j)
+	}
+	terms[f.Linear] = "s(z)"
+	g := f.SmoothAt(newZ)
+	ct := &ContributionTable{Terms: terms, Values: make([][]float64, len(newX)), Prediction: make([]float64, len(newX))}
+	for i, row := range newX {
+		if len(row) != f.Linear {
+			return nil, fmt.Errorf("expected %d columns, got %d", f.Linear, len(row))
+		}
+		ct.Values[i] = make([]float64, f.Linear+1)
+		for j, v := range row {
+			ct.Values[i][j] = v * f.Coefficients[j]
+		}
+		ct.Values[i][f.Linear] = g[i]
+		for _, v := range ct.Values[i] {
+			ct.Prediction[i] += v
+		}
+	}
+	return ct, nil
+}
+
+// Table returns the contributions as a formatted text table, one row per observation
+func (ct *ContributionTable) Table() string {
+	result := fmt.Sprintf("%-4s", "Obs")
+	for _, term := range ct.Terms {
+		result += fmt.Sprintf(" %12s", term)
+	}
+	result += fmt.Sprintf(" %12s\n", "Prediction")
+	for i, row := range ct.Values {
+		result += fmt.Sprintf("%-4d", i+1)
+		for _, v := range row {
+			result += fmt.Sprintf(" %12.6f", v)
+		}
+		result += fmt.Sprintf(" %12.6f\n", ct.Prediction[i])
+	}
+	return result
+}
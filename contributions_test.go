@@ -0,0 +1,92 @@
+package quantreg
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestContributions(t *testing.T) {
+	fit := &RQFit{Tau: 0.5, P: 2, Coefficients: []float64{1, 2}}
+	ct, err := fit.Contributions([][]float64{{1, 3}, {1, -1}})
+	if err != nil {
+		t.Fatalf("Failed to compute contributions: %v", err)
+	}
+	if !reflect.DeepEqual(ct.Terms, []string{"Beta[0]", "Beta[1]"}) {
+		t.Errorf("Unexpected terms %v", ct.Terms)
+	}
+	if ct.Values[0][1] != 6 || ct.Prediction[0] != 7 || ct.Prediction[1] != -1 {
+		t.Errorf("Expected contributions [1 6] summing to 7, got %v and %v", ct.Values[0], ct.Prediction)
+	}
+	if _, err := fit.Contributions([][]float64{{1}}); err == nil {
+		t.Error("Expected error for wrong number of columns")
+	}
+
+	schema := &Schema{Numeric: []string{"x"}, Factors: []Factor{{Name: "region", Levels: []string{"east", "north", "south"}}}}
+	sf := &SchemaFit{RQFit: &RQFit{Tau: 0.5, P: 4, Coefficients: []float64{1, 2, 3, 4}}, Schema: schema}
+	frame := Frame{
+		Numeric: map[string][]float64{"x": {0.5, 1}},
+		Factors: map[string][]string{"region": {"south", "east"}},
+	}
+	sc, err := sf.Contributions(frame)
+	if err != nil {
+		t.Fatalf("Failed to compute schema contributions: %v", err)
+	}
+	if !reflect.DeepEqual(sc.Terms, []string{"(Intercept)", "x", "region"}) {
+		t.Errorf("Unexpected terms %v", sc.Terms)
+	}
+	if !reflect.DeepEqual(sc.Values[0], []float64{1, 1, 4}) || !reflect.DeepEqual(sc.Values[1], []float64{1, 2, 0}) {
+		t.Errorf("Unexpected contributions %v", sc.Values)
+	}
+	pred, err := sf.Predict(frame)
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	for i := range pred {
+		if math.Abs(pred[i]-sc.Prediction[i]) > 1e-12 {
+			t.Errorf("Expected contributions to sum to prediction %v, got %v", pred[i], sc.Prediction[i])
+		}
+	}
+}
+
+func TestPartiallyLinearContributions(t *testing.T) {
+	n := 100
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	z := make([]float64, n)
+	for i := 0; i < n; i++ {
+		d := float64(i % 2)
+		z[i] = float64(i) / float64(n-1)
+		x[i] = []float64{1, d}
+		y[i] = 1 + 2*d + math.Sin(2*math.Pi*z[i]) + 0.1*math.Sin(float64(13*i))
+	}
+	fit, err := RQPartiallyLinear(y, x, z, 0.5, PartiallyLinearOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit partially linear model: %v", err)
+	}
+
+	newX, newZ := [][]float64{{1, 1}, {1, 0}}, []float64{0.25, 0.75}
+	ct, err := fit.Contributions(newX, newZ)
+	if err != nil {
+		t.Fatalf("Failed to compute contributions: %v", err)
+	}
+	if !reflect.DeepEqual(ct.Terms, []string{"Beta[0]", "Beta[1]", "s(z)"}) {
+		t.Errorf("Unexpected terms %v", ct.Terms)
+	}
+	pred, err := fit.Predict(newX, newZ)
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	for i := range pred {
+		if math.Abs(pred[i]-ct.Prediction[i]) > 1e-12 {
+			t.Errorf("Expected contributions to sum to prediction %v, got %v", pred[i], ct.Prediction[i])
+		}
+	}
+	if ct.Values[1][1] != 0 {
+		t.Errorf("Expected zero contribution from a zero covariate, got %v", ct.Values[1][1])
+	}
+
+	if _, err := fit.Contributions(newX, newZ[:1]); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
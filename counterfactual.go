@@ -0,0 +1,175 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// CounterfactualOptions controls MultiRQFit.Counterfactual
+type CounterfactualOptions struct {
+	Grid         []float64 // Outcome values at which the distribution is evaluated (default 100 points over the predicted range)
+	Replications int       // Multiplier bootstrap replications (default 100)
+	Level        float64   // Confidence level of the uniform band (default 0.95)
+}
+
+// CounterfactualDistribution is the outcome distribution implied by the fitted
+// conditional quantile process under an alternative covariate distribution
+type CounterfactualDistribution struct {
+	Y            []float64 // Evaluation grid
+	CDF          []float64 // Counterfactual distribution function on the grid
+	Lower        []float64 // Lower uniform confidence band
+	Upper        []float64 // Upper uniform confidence band
+	Critical     float64   // Bootstrap critical value of the sup-norm deviation
+	Level        float64
+	Replications int
+}
+
+// Counterfactual estimates the distribution F(y) = mean_j int 1{x_j'b(u) <= y} du
+// of the outcome when the covariates are drawn from the rows of newX
+// (Chernozhukov, Fernandez-Val and Melly 2013). The integral runs over the fitted
+// taus, so the process should cover (0, 1) finely; each row's quantile curve is
+// rearranged before integrating. A uniform band over the grid comes from the
+// exponential-weight multiplier bootstrap of the whole quantile process.
+func (m *MultiRQFit) Counterfactual(newX [][]float64, opts CounterfactualOptions, rng *rand.Rand) (*CounterfactualDistribution, error) {
+	if len(m.Taus) < 2 {
+		return nil, fmt.Errorf("need at least 2 quantile levels, got %d", len(m.Taus))
+	}
+	if len(newX) == 0 {
+		return nil, fmt.Errorf("empty counterfactual covariates")
+	}
+	first := m.Fits[m.Taus[0]]
+	if len(first.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its response")
+	}
+	if opts.Replications == 0 {
+		opts.Replications = 100
+	}
+	if opts.Level == 0 {
+		opts.Level = 0.95
+	}
+	if opts.Replications < 2 {
+		return nil, fmt.Errorf("need at least 2 replications, got %d", opts.Replications)
+	}
+	if opts.Level <= 0 || opts.Level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+
+	coefs := make([][]float64, len(m.Taus))
+	for k, tau := range m.Taus {
+		coefs[k] = m.Fits[tau].Coefficients
+	}
+	q, err := counterfactualQuantiles(coefs, newX)
+	if err != nil {
+		return nil, err
+	}
+	grid := opts.Grid
+	if len(grid) == 0 {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, row := range q {
+			lo, hi = math.Min(lo, row[0]), math.Max(hi, row[len(row)-1])
+		}
+		grid = make([]float64, 100)
+		for g := range grid {
+			grid[g] = lo + (hi-lo)*float64(g)/float64(len(grid)-1)
+		}
+	}
+	cdf := counterfactualCDF(m.Taus, q, grid)
+
+	rng = randOrDefault(rng)
+	w := make([]float64, len(first.Y))
+	sup := make([]float64, opts.Replications)
+	for r := range sup {
+		for i := range w {
+			w[i] = rng.ExpFloat64()
+		}
+		for k, tau := range m.Taus {
+			fit, err := RQWeighted(first.Y, first.X, w, tau)
+			if err != nil {
+				return nil, fmt.Errorf("bootstrap replication %d failed: %w", r, err)
+			}
+			coefs[k] = fit.Coefficients
+		}
+		qb, err := counterfactualQuantiles(coefs, newX)
+		if err != nil {
+			return nil, err
+		}
+		for g, f := range counterfactualCDF(m.Taus, qb, grid) {
+			sup[r] = math.Max(sup[r], math.Abs(f-cdf[g]))
+		}
+	}
+	sort.Float64s(sup)
+	crit := empiricalQuantile(sup, opts.Level)
+
+	cd := &CounterfactualDistribution{
+		Y:            grid,
+		CDF:          cdf,
+		Lower:        make([]float64, len(grid)),
+		Upper:        make([]float64, len(grid)),
+		Critical:     crit,
+		Level:        opts.Level,
+		Replications: opts.Replications,
+	}
+	for g, f := range cdf {
+		cd.Lower[g] = math.Max(0, f-crit)
+		cd.Upper[g] = math.Min(1, f+crit)
+	}
+	return cd, nil
+}
+
+// counterfactualQuantiles returns the rearranged quantile curve of every row of newX
+func counterfactualQuantiles(coefs [][]float64, newX [][]float64) ([][]float64, error) {
+	q := make([][]float64, len(newX))
+	for i, row := range newX {
+		q[i] = make([]float64, len(coefs))
+		for k, b := range coefs {
+			if len(row) != len(b) {
+				return nil, fmt.Errorf("expected %d columns, got %d", len(b), len(row))
+			}
+			for j, v := range row {
+				q[i][k] += v * b[j]
+			}
+		}
+		sort.Float64s(q[i])
+	}
+	return q, nil
+}
+
+// counterfactualCDF averages over rows the step function that rises by
+// tau_k - tau_{k-1} at each sorted quantile q_k, with tau_0 = 0
+func counterfactualCDF(taus []float64, q [][]float64, grid []float64) []float64 {
+	cdf := make([]float64, len(grid))
+	for _, row := range q {
+		for g, y := range grid {
+			k := sort.Search(len(row), func(k int) bool { return row[k] > y })
+			if k > 0 {
+				cdf[g] += taus[k-1]
+			}
+		}
+	}
+	for g := range cdf {
+		cdf[g] /= float64(len(q))
+	}
+	return cdf
+}
+
+// Quantile inverts the counterfactual distribution, returning the smallest grid
+// value whose CDF reaches p, or the largest grid value if none does
+func (cd *CounterfactualDistribution) Quantile(p float64) float64 {
+	return invertCDF(cd.Y, cd.CDF, p)
+}
+
+// QuantileBand returns the counterfactual p-quantile with the uniform band
+// obtained by inverting the CDF band
+func (cd *CounterfactualDistribution) QuantileBand(p float64) (q, lo, hi float64) {
+	return invertCDF(cd.Y, cd.CDF, p), invertCDF(cd.Y, cd.Upper, p), invertCDF(cd.Y, cd.Lower, p)
+}
+
+func invertCDF(y, cdf []float64, p float64) float64 {
+	k := sort.Search(len(cdf), func(k int) bool { return cdf[k] >= p })
+	if k == len(cdf) {
+		k--
+	}
+	return y[k]
+}
@@ -0,0 +1,67 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCounterfactual(t *testing.T) {
+	n := 80
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		xi := float64(i % 2)
+		x[i] = []float64{1, xi}
+		y[i] = 2*xi + math.Sin(float64(7*i))
+	}
+	var taus []float64
+	for k := 1; k < 20; k++ {
+		taus = append(taus, float64(k)/20)
+	}
+	m, err := RQProcess(y, x, taus)
+	if err != nil {
+		t.Fatalf("Failed to fit quantile process: %v", err)
+	}
+
+	// Shifting everyone to x=1 moves the distribution up
+	treated := make([][]float64, n)
+	for i := range treated {
+		treated[i] = []float64{1, 1}
+	}
+	opts := CounterfactualOptions{Replications: 20}
+	obs, err := m.Counterfactual(x, opts, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to estimate observed distribution: %v", err)
+	}
+	cf, err := m.Counterfactual(treated, opts, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to estimate counterfactual distribution: %v", err)
+	}
+
+	for g := 1; g < len(cf.CDF); g++ {
+		if cf.CDF[g] < cf.CDF[g-1] {
+			t.Fatalf("Counterfactual CDF decreases at %d", g)
+		}
+	}
+	for g := range cf.CDF {
+		if cf.Lower[g] > cf.CDF[g] || cf.Upper[g] < cf.CDF[g] {
+			t.Errorf("CDF %v outside band [%v, %v]", cf.CDF[g], cf.Lower[g], cf.Upper[g])
+		}
+	}
+	if cf.Critical <= 0 {
+		t.Errorf("Expected positive critical value, got %v", cf.Critical)
+	}
+
+	if shift := cf.Quantile(0.5) - obs.Quantile(0.5); shift < 0.3 {
+		t.Errorf("Expected the median to rise under treatment, got shift %v", shift)
+	}
+	q, lo, hi := cf.QuantileBand(0.5)
+	if lo > q || hi < q {
+		t.Errorf("Median %v outside band [%v, %v]", q, lo, hi)
+	}
+
+	if _, err := m.Counterfactual([][]float64{{1}}, opts, nil); err == nil {
+		t.Error("Expected error for mismatched covariates")
+	}
+}
@@ -0,0 +1,179 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Candidate is a model specification compared by CrossValidate. Build maps the
+// raw covariate rows to the design matrix of the candidate, including any
+// intercept column.
+type Candidate struct {
+	Name  string
+	Build func(data [][]float64) ([][]float64, error)
+}
+
+// CVResult holds the cross-validated loss of one candidate
+type CVResult struct {
+	Name     string              // Candidate name
+	Rank     int                 // 1 for the lowest mean loss
+	Loss     float64             // Mean pinball loss averaged over taus
+	SE       float64             // Standard error of the mean loss
+	TauLoss  map[float64]float64 // Mean pinball loss at each tau
+	Diff     float64             // Mean loss difference to the best candidate
+	DiffSE   float64             // Standard error of the paired loss difference
+	PerPoint []float64           // Out-of-fold loss for each observation
+}
+
+// CVComparison is a ranked table of cross-validated candidates
+type CVComparison struct {
+	Taus    []float64
+	Folds   int
+	Results []CVResult // Sorted by increasing mean loss
+}
+
+// CrossValidate runs k-fold cross-validation of every candidate with the pinball
+// loss at the given taus and ranks them by mean out-of-fold loss. All candidates
+// share the same fold assignment, so loss differences are paired by observation.
+func CrossValidate(y []float64, data [][]float64, candidates []Candidate, taus []float64, folds int, rng *rand.Rand) (*CVComparison, error) {
+	n := len(y)
+	if len(data) != n {
+		return nil, fmt.Errorf("%w: y has %d rows, data has %d rows", ErrDimensionMismatch, n, len(data))
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no candidate models")
+	}
+	if len(taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels specified")
+	}
+	if folds < 2 || folds > n {
+		return nil, fmt.Errorf("number of folds must be between 2 and %d, got %d", n, folds)
+	}
+
+	fold := foldAssignment(n, folds, rng)
+
+	results := make([]CVResult, len(candidates))
+	for c, cand := range candidates {
+		x, err := cand.Build(data)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: failed to build design: %w", cand.Name, err)
+		}
+		if len(x) != n {
+			return nil, fmt.Errorf("candidate %q: design has %d rows, want %d", cand.Name, len(x), n)
+		}
+
+		res := CVResult{
+			Name:     cand.Name,
+			TauLoss:  make(map[float64]float64),
+			PerPoint: make([]float64, n),
+		}
+		pred, err := outOfFold(y, x, fold, folds, func(trainY []float64, trainX, testX [][]float64) (map[float64][]float64, error) {
+			fits, err := RQProcess(trainY, trainX, taus)
+			if err != nil {
+				return nil, fmt.Errorf("fit failed: %w", err)
+			}
+			return fits.Predict(testX)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: %w", cand.Name, err)
+		}
+		for _, tau := range taus {
+			for i, q := range pred[tau] {
+				l := rho(y[i]-q, tau)
+				res.TauLoss[tau] += l / float64(n)
+				res.PerPoint[i] += l / float64(len(taus))
+			}
+		}
+		res.Loss, res.SE = meanSE(res.PerPoint)
+		results[c] = res
+	}
+
+	sort.SliceStable(results, func(a, b int) bool { return results[a].Loss < results[b].Loss })
+	best := results[0].PerPoint
+	for c := range results {
+		results[c].Rank = c + 1
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = results[c].PerPoint[i] - best[i]
+		}
+		results[c].Diff, results[c].DiffSE = meanSE(d)
+	}
+
+	sortedTaus := append([]float64(nil), taus...)
+	sort.Float64s(sortedTaus)
+	return &CVComparison{Taus: sortedTaus, Folds: folds, Results: results}, nil
+}
+
+// Table returns the comparison as a formatted text table
+func (cv *CVComparison) Table() string {
+	result := fmt.Sprintf("%d-fold cross-validated pinball loss (taus = %v)\n\n", cv.Folds, cv.Taus)
+	result += fmt.Sprintf("%-4s %-20s %12s %12s %12s %12s\n", "Rank", "Model", "Loss", "SE", "Diff", "Diff SE")
+	for _, r := range cv.Results {
+		result += fmt.Sprintf("%-4d %-20s %12.6f %12.6f %12.6f %12.6f\n", r.Rank, r.Name, r.Loss, r.SE, r.Diff, r.DiffSE)
+	}
+	return result
+}
+
+// foldAssignment randomly assigns n observations to folds of near-equal size
+func foldAssignment(n, folds int, rng *rand.Rand) []int {
+	rng = randOrDefault(rng)
+	fold := make([]int, n)
+	for i, idx := range rng.Perm(n) {
+		fold[idx] = i % folds
+	}
+	return fold
+}
+
+// outOfFold returns, for every tau, out-of-fold predictions of all observations:
+// predict is given the rows outside fold k and returns its predictions per tau
+// for the rows in fold k
+func outOfFold(y []float64, x [][]float64, fold []int, folds int, predict func(trainY []float64, trainX, testX [][]float64) (map[float64][]float64, error)) (map[float64][]float64, error) {
+	out := make(map[float64][]float64)
+	for k := 0; k < folds; k++ {
+		var trainY []float64
+		var trainX, testX [][]float64
+		var testIdx []int
+		for i := range y {
+			if fold[i] == k {
+				testX = append(testX, x[i])
+				testIdx = append(testIdx, i)
+			} else {
+				trainY = append(trainY, y[i])
+				trainX = append(trainX, x[i])
+			}
+		}
+		pred, err := predict(trainY, trainX, testX)
+		if err != nil {
+			return nil, fmt.Errorf("fold %d: %w", k, err)
+		}
+		for tau, q := range pred {
+			if out[tau] == nil {
+				out[tau] = make([]float64, len(y))
+			}
+			for t, i := range testIdx {
+				out[tau][i] = q[t]
+			}
+		}
+	}
+	return out, nil
+}
+
+// meanSE returns the mean of v and its standard error
+func meanSE(v []float64) (float64, float64) {
+	n := float64(len(v))
+	mean := 0.0
+	for _, x := range v {
+		mean += x
+	}
+	mean /= n
+	if len(v) < 2 {
+		return mean, 0
+	}
+	ss := 0.0
+	for _, x := range v {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / (n - 1) / n)
+}
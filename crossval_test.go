@@ -0,0 +1,77 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCrossValidate(t *testing.T) {
+	n := 20
+	data := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		data[i] = []float64{float64(i) / 4}
+		y[i] = 1 + 2*data[i][0] + 0.3*math.Sin(float64(7*i))
+	}
+
+	candidates := []Candidate{
+		{Name: "intercept", Build: func(d [][]float64) ([][]float64, error) {
+			x := make([][]float64, len(d))
+			for i := range d {
+				x[i] = []float64{1}
+			}
+			return x, nil
+		}},
+		{Name: "linear", Build: func(d [][]float64) ([][]float64, error) {
+			x := make([][]float64, len(d))
+			for i := range d {
+				x[i] = []float64{1, d[i][0]}
+			}
+			return x, nil
+		}},
+	}
+
+	cv, err := CrossValidate(y, data, candidates, []float64{0.5, 0.25}, 4, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to cross-validate: %v", err)
+	}
+
+	if len(cv.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(cv.Results))
+	}
+	if cv.Taus[0] != 0.25 {
+		t.Errorf("Expected sorted taus, got %v", cv.Taus)
+	}
+	if cv.Results[0].Loss > cv.Results[1].Loss || cv.Results[0].Rank != 1 || cv.Results[1].Rank != 2 {
+		t.Errorf("Results are not ranked by loss: %+v", cv.Results)
+	}
+	if cv.Results[0].Diff != 0 || cv.Results[0].DiffSE != 0 {
+		t.Errorf("Expected zero difference for the best model, got %f (%f)", cv.Results[0].Diff, cv.Results[0].DiffSE)
+	}
+	for _, r := range cv.Results {
+		if r.Loss <= 0 || r.SE <= 0 || len(r.PerPoint) != n || len(r.TauLoss) != 2 {
+			t.Errorf("Unexpected result for %s: %+v", r.Name, r)
+		}
+		mean := (r.TauLoss[0.25] + r.TauLoss[0.5]) / 2
+		if math.Abs(mean-r.Loss) > 1e-9 {
+			t.Errorf("Expected mean loss %f to equal average of tau losses %f", r.Loss, mean)
+		}
+	}
+
+	if !strings.Contains(cv.Table(), "linear") {
+		t.Error("Expected table to list candidate names")
+	}
+
+	// Error cases
+	if _, err := CrossValidate(y, data, candidates, []float64{0.5}, 1, nil); err == nil {
+		t.Error("Expected error for fewer than 2 folds")
+	}
+	if _, err := CrossValidate(y, data, nil, []float64{0.5}, 4, nil); err == nil {
+		t.Error("Expected error for no candidates")
+	}
+	if _, err := CrossValidate(y[:5], data, candidates, []float64{0.5}, 4, nil); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
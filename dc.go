@@ -0,0 +1,203 @@
+package quantreg
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Divide-and-conquer combination rules
+const (
+	DCAverage  = "average"  // Plain mean of block estimates
+	DCWeighted = "weighted" // Inverse-covariance weighted mean of block estimates
+	DCOneStep  = "onestep"  // Newton step on the full data from the block average
+)
+
+// DCOptions controls RQDivideConquer
+type DCOptions struct {
+	Blocks  int    // Number of row blocks
+	Workers int    // Blocks fitted in parallel (default runtime.NumCPU())
+	Combine string // Combination rule (default DCOneStep)
+}
+
+// DCFit is a divide-and-conquer quantile regression estimate
+type DCFit struct {
+	Tau          float64
+	N            int
+	P            int
+	Combine      string
+	Coefficients []float64
+	Cov          [][]float64 // Covariance of the combined estimate
+	BlockFits    []*RQFit
+	Meta         Meta // Reproducibility metadata
+}
+
+// RQDivideConquer splits the rows into contiguous blocks, fits each block
+// concurrently, and combines the block estimates. Averaging uses the mean of the
+// block kernel covariances divided by the number of blocks; weighted averaging
+// uses (sum V_k^-1)^-1; the one-step rule takes a Newton step
+// b + H^-1 sum x_i psi_tau(r_i) from the block average with Powell densities on
+// the full data and returns the kernel sandwich covariance.
+func RQDivideConquer(y []float64, x [][]float64, tau float64, opts DCOptions) (*DCFit, error) {
+	start := time.Now()
+	n := len(y)
+	if len(x) != n || n == 0 {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if opts.Combine == "" {
+		opts.Combine = DCOneStep
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	p := len(x[0])
+	if opts.Blocks < 1 || n/opts.Blocks <= p {
+		return nil, fmt.Errorf("each of %d blocks needs more than %d rows", opts.Blocks, p)
+	}
+
+	fits, covs, err := fitBlocks(y, x, tau, opts.Blocks, opts.Workers)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &DCFit{Tau: tau, N: n, P: p, Combine: opts.Combine, BlockFits: fits}
+	k := float64(len(fits))
+	avg := make([]float64, p)
+	for _, f := range fits {
+		for j, b := range f.Coefficients {
+			avg[j] += b / k
+		}
+	}
+
+	switch opts.Combine {
+	case DCAverage:
+		res.Coefficients = avg
+		res.Cov = make([][]float64, p)
+		for a := range res.Cov {
+			res.Cov[a] = make([]float64, p)
+			for b := range res.Cov[a] {
+				for _, c := range covs {
+					res.Cov[a][b] += c[a][b] / (k * k)
+				}
+			}
+		}
+	case DCWeighted:
+		prec := make([][]float64, p)
+		for a := range prec {
+			prec[a] = make([]float64, p)
+		}
+		rhs := make([]float64, p)
+		for i, c := range covs {
+			ci, err := invert(c)
+			if err != nil {
+				return nil, fmt.Errorf("block %d covariance is singular: %w", i, err)
+			}
+			wb := matVec(ci, fits[i].Coefficients)
+			for a := 0; a < p; a++ {
+				rhs[a] += wb[a]
+				for b := 0; b < p; b++ {
+					prec[a][b] += ci[a][b]
+				}
+			}
+		}
+		cov, err := invert(prec)
+		if err != nil {
+			return nil, fmt.Errorf("combined precision is singular: %w", err)
+		}
+		res.Cov = cov
+		res.Coefficients = matVec(cov, rhs)
+	case DCOneStep:
+		coef, cov, err := oneStep(y, x, tau, avg)
+		if err != nil {
+			return nil, err
+		}
+		res.Coefficients, res.Cov = coef, cov
+	default:
+		return nil, fmt.Errorf("unknown combination rule: %s", opts.Combine)
+	}
+
+	res.Meta = newMeta(opts.Combine, map[string]float64{"blocks": float64(opts.Blocks)}, []float64{tau}, n, p, start, y, x)
+	return res, nil
+}
+
+// Predict returns predictions from the combined coefficients
+func (f *DCFit) Predict(newX [][]float64) ([]float64, error) {
+	out := make([]float64, len(newX))
+	for i, row := range newX {
+		if len(row) != f.P {
+			return nil, fmt.Errorf("%w: expected %d features, got %d", ErrDimensionMismatch, f.P, len(row))
+		}
+		for j, v := range row {
+			out[i] += v * f.Coefficients[j]
+		}
+	}
+	return out, nil
+}
+
+// fitBlocks fits contiguous row blocks concurrently and returns the fits with their kernel covariances
+func fitBlocks(y []float64, x [][]float64, tau float64, blocks, workers int) ([]*RQFit, [][][]float64, error) {
+	n := len(y)
+	fits := make([]*RQFit, blocks)
+	covs := make([][][]float64, blocks)
+	errs := make([]error, blocks)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for b := 0; b < blocks; b++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			lo, hi := b*n/blocks, (b+1)*n/blocks
+			fit, err := RQ(y[lo:hi], x[lo:hi], tau)
+			if err != nil {
+				errs[b] = fmt.Errorf("block %d: %w", b, err)
+				return
+			}
+			fits[b] = fit
+			covs[b], errs[b] = fit.Vcov(SEKer)
+		}(b)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return fits, covs, nil
+}
+
+// oneStep takes a Newton step for the check loss from start using Powell densities
+func oneStep(y []float64, x [][]float64, tau float64, start []float64) ([]float64, [][]float64, error) {
+	full := &RQFit{Tau: tau, N: len(y), P: len(start), X: x, Y: y, Residuals: make([]float64, len(y))}
+	score := make([]float64, len(start))
+	for i, row := range x {
+		fitted := 0.0
+		for j, v := range row {
+			fitted += v * start[j]
+		}
+		full.Residuals[i] = y[i] - fitted
+		s := tau
+		if full.Residuals[i] < 0 {
+			s = tau - 1
+		}
+		for j, v := range row {
+			score[j] += v * s
+		}
+	}
+	f, err := full.kernelDensities()
+	if err != nil {
+		return nil, nil, err
+	}
+	step, hinv, err := weightedSolve(x, f, score)
+	if err != nil {
+		return nil, nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+	coef := make([]float64, len(start))
+	for j := range coef {
+		coef[j] = start[j] + step[j]
+	}
+	cov := scaleMatrix(sandwich(hinv, crossprod(x, nil)), tau*(1-tau))
+	return coef, cov, nil
+}
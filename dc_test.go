@@ -0,0 +1,56 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQDivideConquer(t *testing.T) {
+	n := 24
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = []float64{1, float64(i%8) / 2}
+		y[i] = 1 + x[i][1] + 0.5*math.Sin(float64(7*i))
+	}
+
+	for _, rule := range []string{DCAverage, DCWeighted, DCOneStep} {
+		fit, err := RQDivideConquer(y, x, 0.5, DCOptions{Blocks: 3, Combine: rule})
+		if err != nil {
+			t.Fatalf("Failed to fit %s combination: %v", rule, err)
+		}
+		if len(fit.BlockFits) != 3 || len(fit.Coefficients) != 2 {
+			t.Errorf("%s: unexpected fit %+v", rule, fit)
+		}
+		for j := range fit.Cov {
+			if fit.Cov[j][j] <= 0 {
+				t.Errorf("%s: expected positive variance for coefficient %d, got %f", rule, j, fit.Cov[j][j])
+			}
+		}
+		if rule == DCAverage {
+			mean := 0.0
+			for _, b := range fit.BlockFits {
+				mean += b.Coefficients[1] / 3
+			}
+			if math.Abs(fit.Coefficients[1]-mean) > 1e-12 {
+				t.Errorf("Expected block average %f, got %f", mean, fit.Coefficients[1])
+			}
+		}
+	}
+
+	fit, _ := RQDivideConquer(y, x, 0.5, DCOptions{Blocks: 2})
+	if fit.Combine != DCOneStep {
+		t.Errorf("Expected one-step combination by default, got %s", fit.Combine)
+	}
+	if pred, err := fit.Predict(x[:2]); err != nil || len(pred) != 2 {
+		t.Errorf("Failed to predict: %v", err)
+	}
+
+	// Error cases
+	if _, err := RQDivideConquer(y, x, 0.5, DCOptions{Blocks: 12}); err == nil {
+		t.Error("Expected error for blocks too small to fit")
+	}
+	if _, err := RQDivideConquer(y, x, 0.5, DCOptions{Blocks: 2, Combine: "median"}); err == nil {
+		t.Error("Expected error for unknown combination rule")
+	}
+}
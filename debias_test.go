@@ -0,0 +1,62 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNodewiseLasso(t *testing.T) {
+	_, x := inferenceData()
+
+	// Without penalty Theta is the inverse of X'X/n
+	theta, err := nodewiseLasso(x, 0)
+	if err != nil {
+		t.Fatalf("Failed to compute nodewise lasso: %v", err)
+	}
+	sigma := scaleMatrix(crossprod(x, nil), 1/float64(len(x)))
+	prod := matMul(theta, sigma)
+	for a := range prod {
+		for b := range prod {
+			want := 0.0
+			if a == b {
+				want = 1
+			}
+			if math.Abs(prod[a][b]-want) > 1e-6 {
+				t.Errorf("Theta Sigma (%d, %d): got %f, want %f", a, b, prod[a][b], want)
+			}
+		}
+	}
+
+	if softThreshold(3, 1) != 2 || softThreshold(-3, 1) != -2 || softThreshold(0.5, 1) != 0 {
+		t.Error("Unexpected soft thresholding")
+	}
+}
+
+func TestDebias(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQLasso(y, x, 0.5, 2)
+	if err != nil {
+		t.Fatalf("Failed to fit lasso: %v", err)
+	}
+
+	d, err := fit.Debias(0)
+	if err != nil {
+		t.Fatalf("Failed to debias: %v", err)
+	}
+	if d.NodeLambda <= 0 || d.Sparsity <= 0 || len(d.Coefficients) != fit.P {
+		t.Errorf("Unexpected debiased fit: %+v", d)
+	}
+	lower, upper, err := d.ConfInt(0.95)
+	if err != nil {
+		t.Fatalf("Failed to compute intervals: %v", err)
+	}
+	for j := range d.Coefficients {
+		if d.StdErrors[j] <= 0 || !(lower[j] < d.Coefficients[j] && d.Coefficients[j] < upper[j]) {
+			t.Errorf("Unexpected interval for coefficient %d: [%f, %f] around %f", j, lower[j], upper[j], d.Coefficients[j])
+		}
+	}
+
+	if _, _, err := d.ConfInt(1); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+}
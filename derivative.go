@@ -0,0 +1,81 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// DerivativeEstimate holds pointwise estimates of dQ_tau(y|x)/dx with Wald intervals
+type DerivativeEstimate struct {
+	At        []float64 // Evaluation points, nil for a linear effect
+	Estimate  []float64
+	StdErrors []float64
+	Lower     []float64
+	Upper     []float64
+	Level     float64
+}
+
+// Derivative returns the derivative of a linear fit in covariate j, which is the
+// coefficient itself, with its Wald interval
+func (fit *RQFit) Derivative(j int, se string, level float64) (*DerivativeEstimate, error) {
+	if j < 0 || j >= fit.P {
+		return nil, fmt.Errorf("coefficient index %d out of range [0, %d)", j, fit.P)
+	}
+	cov, err := fit.Vcov(se)
+	if err != nil {
+		return nil, err
+	}
+	grad := make([]float64, fit.P)
+	grad[j] = 1
+	return derivativeEstimate(nil, [][]float64{grad}, fit.Coefficients, cov, level)
+}
+
+// Derivative returns g'(z) of the smooth term with pointwise Wald intervals from
+// the covariance of the spline coefficients
+func (f *PartiallyLinearFit) Derivative(z []float64, level float64) (*DerivativeEstimate, error) {
+	grads := make([][]float64, len(z))
+	for i, v := range z {
+		grads[i] = make([]float64, f.P)
+		copy(grads[i][f.Linear:], bsplineDerivative(v, f.Knots, f.Degree)[1:])
+	}
+	return derivativeEstimate(z, grads, f.Coefficients, f.cov, level)
+}
+
+// Derivative returns the derivative of the fitted quantile curve at z. Intervals
+// use the covariance estimator se of the unpenalized fit at the penalized
+// solution, which ignores the smoothing bias and is conservative where the
+// penalty binds.
+func (f *PSplineFit) Derivative(z []float64, se string, level float64) (*DerivativeEstimate, error) {
+	cov, err := f.Vcov(se)
+	if err != nil {
+		return nil, err
+	}
+	grads := make([][]float64, len(z))
+	for i, v := range z {
+		grads[i] = bsplineDerivative(v, f.Knots, f.Degree)
+	}
+	return derivativeEstimate(z, grads, f.Coefficients, cov, level)
+}
+
+// derivativeEstimate evaluates the linear functionals grad'b with delta-method intervals
+func derivativeEstimate(at []float64, grads [][]float64, coef []float64, cov [][]float64, level float64) (*DerivativeEstimate, error) {
+	if level <= 0 || level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	z := normQuantile(1 - (1-level)/2)
+	d := &DerivativeEstimate{
+		At:        at,
+		Estimate:  make([]float64, len(grads)),
+		StdErrors: make([]float64, len(grads)),
+		Lower:     make([]float64, len(grads)),
+		Upper:     make([]float64, len(grads)),
+		Level:     level,
+	}
+	for i, g := range grads {
+		d.Estimate[i] = dot(g, coef)
+		d.StdErrors[i] = math.Sqrt(math.Max(quadForm(cov, g), 0))
+		d.Lower[i] = d.Estimate[i] - z*d.StdErrors[i]
+		d.Upper[i] = d.Estimate[i] + z*d.StdErrors[i]
+	}
+	return d, nil
+}
@@ -0,0 +1,61 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDerivative(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	d, err := fit.Derivative(1, SEKer, 0.95)
+	if err != nil {
+		t.Fatalf("Failed to compute derivative: %v", err)
+	}
+	if d.Estimate[0] != fit.Coefficients[1] || d.Lower[0] >= d.Upper[0] {
+		t.Errorf("Expected slope %v inside a proper interval, got %+v", fit.Coefficients[1], d)
+	}
+	if _, err := fit.Derivative(5, SEKer, 0.95); err == nil {
+		t.Error("Expected error for out-of-range coefficient")
+	}
+
+	// Q(y | z) = 1 + sin(2 pi z) has derivative 2 pi cos(2 pi z)
+	n := 200
+	ys := make([]float64, n)
+	xs := make([][]float64, n)
+	z := make([]float64, n)
+	for i := 0; i < n; i++ {
+		z[i] = float64(i) / float64(n-1)
+		xs[i] = []float64{1}
+		ys[i] = 1 + math.Sin(2*math.Pi*z[i]) + 0.05*math.Sin(float64(13*i))
+	}
+	pl, err := RQPartiallyLinear(ys, xs, z, 0.5, PartiallyLinearOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit partially linear model: %v", err)
+	}
+	at := []float64{0.25, 0.5}
+	pd, err := pl.Derivative(at, 0.95)
+	if err != nil {
+		t.Fatalf("Failed to compute smooth derivative: %v", err)
+	}
+	for i, v := range at {
+		want := 2 * math.Pi * math.Cos(2*math.Pi*v)
+		if math.Abs(pd.Estimate[i]-want) > 1.5 {
+			t.Errorf("Expected derivative near %v at z=%v, got %v", want, v, pd.Estimate[i])
+		}
+		if pd.StdErrors[i] <= 0 {
+			t.Errorf("Expected positive standard error at z=%v, got %v", v, pd.StdErrors[i])
+		}
+	}
+
+	ps, err := RQPSpline(ys[:80], z[:80], 0.5, PSplineOptions{Lambdas: []float64{0.1}})
+	if err != nil {
+		t.Fatalf("Failed to fit P-spline: %v", err)
+	}
+	if _, err := ps.Derivative([]float64{0.1}, SEKer, 0.9); err != nil {
+		t.Errorf("Failed to compute P-spline derivative: %v", err)
+	}
+}
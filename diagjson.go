@@ -0,0 +1,144 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// diagnosticsJSON is the serialized form of Diagnostics. Maps keyed by tau
+// become arrays in tau order and NaN values become nulls.
+type diagnosticsJSON struct {
+	PseudoRSquared float64          `json:"pseudo_r_squared"`
+	Quantiles      []tauDiagnostics `json:"quantiles"`
+	Crossings      []crossingReport `json:"crossings"`
+	Flagged        []flaggedObsJSON `json:"flagged"`
+}
+
+type tauDiagnostics struct {
+	Tau       float64  `json:"tau"`
+	R1        *float64 `json:"r1"`
+	Residuals Stats    `json:"residuals"`
+}
+
+// crossingReport counts crossings between the fits at two quantile levels
+type crossingReport struct {
+	LowerTau float64 `json:"lower_tau"`
+	UpperTau float64 `json:"upper_tau"`
+	Count    int     `json:"count"`
+}
+
+type flaggedObsJSON struct {
+	Index       int      `json:"index"`
+	Tau         float64  `json:"tau"`
+	Reasons     []string `json:"reasons"`
+	StdResidual *float64 `json:"std_residual"`
+	Leverage    *float64 `json:"leverage"`
+	Distance    *float64 `json:"distance"`
+}
+
+// nullable returns nil for NaN so that it encodes as null
+func nullable(v float64) *float64 {
+	if math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+// orNaN dereferences v, returning NaN for nil
+func orNaN(v *float64) float64 {
+	if v == nil {
+		return math.NaN()
+	}
+	return *v
+}
+
+// MarshalJSON implements json.Marshaler
+func (d *Diagnostics) MarshalJSON() ([]byte, error) {
+	taus := d.Taus
+	if taus == nil {
+		for tau := range d.ResidualStats {
+			taus = append(taus, tau)
+		}
+		sort.Float64s(taus)
+	}
+	enc := diagnosticsJSON{
+		PseudoRSquared: d.PseudoRSquared,
+		Quantiles:      make([]tauDiagnostics, len(taus)),
+		Crossings:      []crossingReport{},
+		Flagged:        make([]flaggedObsJSON, len(d.Flagged)),
+	}
+	for k, tau := range taus {
+		enc.Quantiles[k] = tauDiagnostics{Tau: tau, Residuals: d.ResidualStats[tau]}
+		if r1, ok := d.R1[tau]; ok {
+			enc.Quantiles[k].R1 = nullable(r1)
+		}
+	}
+	for i := range d.CrossingMatrix {
+		for j := i + 1; j < len(d.CrossingMatrix[i]) && j < len(taus); j++ {
+			enc.Crossings = append(enc.Crossings, crossingReport{LowerTau: taus[i], UpperTau: taus[j], Count: d.CrossingMatrix[i][j]})
+		}
+	}
+	for k, f := range d.Flagged {
+		enc.Flagged[k] = flaggedObsJSON{
+			Index:       f.Index,
+			Tau:         f.Tau,
+			Reasons:     f.Reasons,
+			StdResidual: nullable(f.StdResidual),
+			Leverage:    nullable(f.Leverage),
+			Distance:    nullable(f.Distance),
+		}
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Diagnostics) UnmarshalJSON(data []byte) error {
+	var dec diagnosticsJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	index := make(map[float64]int, len(dec.Quantiles))
+	*d = Diagnostics{
+		PseudoRSquared: dec.PseudoRSquared,
+		R1:             make(map[float64]float64),
+		ResidualStats:  make(map[float64]Stats),
+		CrossingMatrix: make([][]int, len(dec.Quantiles)),
+	}
+	for k, q := range dec.Quantiles {
+		d.Taus = append(d.Taus, q.Tau)
+		index[q.Tau] = k
+		if q.R1 != nil {
+			d.R1[q.Tau] = *q.R1
+		}
+		d.ResidualStats[q.Tau] = q.Residuals
+		d.CrossingMatrix[k] = make([]int, len(dec.Quantiles))
+	}
+	for _, c := range dec.Crossings {
+		i, ok1 := index[c.LowerTau]
+		j, ok2 := index[c.UpperTau]
+		if !ok1 || !ok2 {
+			return fmt.Errorf("crossing between unknown quantile levels %f and %f", c.LowerTau, c.UpperTau)
+		}
+		d.CrossingMatrix[i][j], d.CrossingMatrix[j][i] = c.Count, c.Count
+	}
+	for _, f := range dec.Flagged {
+		d.Flagged = append(d.Flagged, FlaggedObservation{
+			Index:       f.Index,
+			Tau:         f.Tau,
+			Reasons:     f.Reasons,
+			StdResidual: orNaN(f.StdResidual),
+			Leverage:    orNaN(f.Leverage),
+			Distance:    orNaN(f.Distance),
+		})
+	}
+	return nil
+}
+
+// ExportDiagnostics computes the diagnostics of the fits and writes them to w
+// as JSON, for ingestion by monitoring systems
+func (m *MultiRQFit) ExportDiagnostics(w io.Writer) error {
+	return json.NewEncoder(w).Encode(m.ComputeDiagnostics())
+}
@@ -0,0 +1,55 @@
+package quantreg
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestExportDiagnostics(t *testing.T) {
+	y, x := inferenceData()
+	multi, err := RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := multi.ExportDiagnostics(&buf); err != nil {
+		t.Fatalf("Failed to export diagnostics: %v", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatalf("Failed to decode export: %v", err)
+	}
+	for _, key := range []string{"pseudo_r_squared", "quantiles", "crossings", "flagged"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("Expected field %q in export", key)
+		}
+	}
+
+	var dec Diagnostics
+	if err := json.Unmarshal(buf.Bytes(), &dec); err != nil {
+		t.Fatalf("Failed to unmarshal diagnostics: %v", err)
+	}
+	want := multi.ComputeDiagnostics()
+	if !reflect.DeepEqual(dec.Taus, want.Taus) || !reflect.DeepEqual(dec.CrossingMatrix, want.CrossingMatrix) {
+		t.Errorf("Expected crossings %v, got %v", want.CrossingMatrix, dec.CrossingMatrix)
+	}
+	if !reflect.DeepEqual(dec.ResidualStats, want.ResidualStats) || !reflect.DeepEqual(dec.R1, want.R1) {
+		t.Errorf("Expected residual stats and R1 to survive the round trip")
+	}
+
+	// NaN diagnostics encode as null
+	d := &Diagnostics{Taus: []float64{0.5}, R1: map[float64]float64{0.5: math.NaN()},
+		ResidualStats: map[float64]Stats{0.5: {}}, CrossingMatrix: [][]int{{0}},
+		Flagged: []FlaggedObservation{{Index: 3, Tau: 0.5, Leverage: math.NaN(), Distance: math.NaN()}}}
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Failed to marshal NaN diagnostics: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"r1":null`)) || !bytes.Contains(data, []byte(`"leverage":null`)) {
+		t.Errorf("Expected nulls for NaN values, got %s", data)
+	}
+}
@@ -0,0 +1,70 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// normPDF is the standard normal density
+func normPDF(z float64) float64 {
+	return math.Exp(-0.5*z*z) / math.Sqrt(2*math.Pi)
+}
+
+// normCDF is the standard normal distribution function
+func normCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+// normQuantile is the inverse of the standard normal distribution function
+func normQuantile(p float64) float64 {
+	switch {
+	case p <= 0:
+		return math.Inf(-1)
+	case p >= 1:
+		return math.Inf(1)
+	}
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
+
+// empiricalQuantile returns the p-th sample quantile of sorted data using
+// linear interpolation between order statistics
+func empiricalQuantile(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN()
+	}
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 1 {
+		return sorted[n-1]
+	}
+	h := p * float64(n-1)
+	lo := int(math.Floor(h))
+	if lo >= n-1 {
+		return sorted[n-1]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// DefaultSeed seeds the generator that stochastic functions (bootstrap,
+// dithering, simulation, sample splitting, network initialization) use when
+// called with a nil *rand.Rand, so such calls give the same results on every run
+// and machine. Pass rand.New(src) with any rand.Source for other streams, e.g.
+// rand.NewSource(time.Now().UnixNano()) for fresh draws on each call.
+const DefaultSeed int64 = 1
+
+// randOrDefault returns rng, or a new generator seeded with DefaultSeed when rng is nil
+func randOrDefault(rng *rand.Rand) *rand.Rand {
+	if rng != nil {
+		return rng
+	}
+	return rand.New(rand.NewSource(DefaultSeed))
+}
+
+// chiSquareSF returns the upper tail probability of a chi-square variable with df degrees of freedom
+func chiSquareSF(x float64, df int) float64 {
+	return distuv.ChiSquared{K: float64(df)}.Survival(x)
+}
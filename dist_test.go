@@ -0,0 +1,84 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNormQuantile(t *testing.T) {
+	cases := map[float64]float64{
+		0.5:   0,
+		0.975: 1.959963984540054,
+		0.05:  -1.6448536269514722,
+	}
+	for p, want := range cases {
+		if got := normQuantile(p); math.Abs(got-want) > 1e-9 {
+			t.Errorf("normQuantile(%v) = %v, want %v", p, got, want)
+		}
+		if got := normCDF(want); math.Abs(got-p) > 1e-9 {
+			t.Errorf("normCDF(%v) = %v, want %v", want, got, p)
+		}
+	}
+}
+
+func TestEmpiricalQuantile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5}
+
+	if got := empiricalQuantile(sorted, 0.5); got != 3 {
+		t.Errorf("Expected median 3, got %v", got)
+	}
+	if got := empiricalQuantile(sorted, 0.1); math.Abs(got-1.4) > 1e-12 {
+		t.Errorf("Expected 1.4, got %v", got)
+	}
+	if got := empiricalQuantile(sorted, 1); got != 5 {
+		t.Errorf("Expected maximum 5, got %v", got)
+	}
+}
+
+func TestChiSquareSF(t *testing.T) {
+	cases := []struct {
+		x    float64
+		df   int
+		want float64
+	}{
+		{3.841458820694124, 1, 0.05},
+		{5.991464547107979, 2, 0.05},
+		{1, 3, 0.8012519569012008},
+		{30, 10, 0.0008566412},
+	}
+	for _, c := range cases {
+		if got := chiSquareSF(c.x, c.df); math.Abs(got-c.want) > 1e-8 {
+			t.Errorf("chiSquareSF(%v, %d) = %v, want %v", c.x, c.df, got, c.want)
+		}
+	}
+	if got := chiSquareSF(0, 2); got != 1 {
+		t.Errorf("Expected upper tail 1 at zero, got %v", got)
+	}
+}
+
+func TestDefaultRandIsDeterministic(t *testing.T) {
+	y, x := inferenceData()
+	first, err := RQDither(y, x, 0.5, DitherOptions{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to fit dithered model: %v", err)
+	}
+	second, err := RQDither(y, x, 0.5, DitherOptions{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to fit dithered model: %v", err)
+	}
+	for j := range first.Coefficients {
+		if first.Coefficients[j] != second.Coefficients[j] {
+			t.Errorf("Expected identical coefficients with the default generator, got %v and %v",
+				first.Coefficients, second.Coefficients)
+			break
+		}
+	}
+
+	a, b := randOrDefault(nil), rand.New(rand.NewSource(DefaultSeed))
+	for k := 0; k < 5; k++ {
+		if u, v := a.Float64(), b.Float64(); u != v {
+			t.Errorf("Expected the DefaultSeed stream, got %f and %f", u, v)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// DistributedLag builds the design of a distributed-lag term of a time-series
+// covariate z, whose lag coefficients beta_0..beta_MaxLag are constrained to
+// beta = C theta for a (MaxLag+1) x K lag basis C
+type DistributedLag struct {
+	MaxLag int
+	Basis  [][]float64 // Lag basis C, one row per lag
+}
+
+// LagEffects are the lag-specific and cumulative effects of a distributed-lag term
+type LagEffects struct {
+	Tau          float64
+	Lags         []float64 // Effect of z at each lag
+	SE           []float64
+	Cumulative   []float64 // Sum of the effects up to each lag
+	CumulativeSE []float64
+}
+
+// NewDistributedLag returns an unconstrained distributed lag, one coefficient per lag
+func NewDistributedLag(maxLag int) (*DistributedLag, error) {
+	if maxLag < 0 {
+		return nil, fmt.Errorf("maximum lag must be non-negative, got %d", maxLag)
+	}
+	c := make([][]float64, maxLag+1)
+	for l := range c {
+		c[l] = make([]float64, maxLag+1)
+		c[l][l] = 1
+	}
+	return &DistributedLag{MaxLag: maxLag, Basis: c}, nil
+}
+
+// NewPolynomialLag returns an Almon distributed lag whose effects are a polynomial
+// of the given degree in the lag
+func NewPolynomialLag(maxLag, degree int) (*DistributedLag, error) {
+	if maxLag < 1 {
+		return nil, fmt.Errorf("maximum lag must be positive, got %d", maxLag)
+	}
+	if degree < 0 || degree > maxLag {
+		return nil, fmt.Errorf("polynomial degree must be between 0 and %d, got %d", maxLag, degree)
+	}
+	c := make([][]float64, maxLag+1)
+	for l := range c {
+		c[l] = make([]float64, degree+1)
+		for k := range c[l] {
+			// Lags are scaled to [0, 1] to keep the basis well conditioned
+			c[l][k] = math.Pow(float64(l)/float64(maxLag), float64(k))
+		}
+	}
+	return &DistributedLag{MaxLag: maxLag, Basis: c}, nil
+}
+
+// NewSplineLag returns a distributed lag whose effects are a B-spline of the given
+// degree in the lag with df basis functions and equally spaced interior knots
+func NewSplineLag(maxLag, df, degree int) (*DistributedLag, error) {
+	if degree < 1 {
+		return nil, fmt.Errorf("spline degree must be positive, got %d", degree)
+	}
+	if df < degree+1 || df > maxLag+1 {
+		return nil, fmt.Errorf("spline df must be between %d and %d, got %d", degree+1, maxLag+1, df)
+	}
+	interior := make([]float64, df-degree-1)
+	for i := range interior {
+		interior[i] = float64(maxLag) * float64(i+1) / float64(len(interior)+1)
+	}
+	knots := clampedKnots(0, float64(maxLag), interior, degree)
+	c := make([][]float64, maxLag+1)
+	for l := range c {
+		c[l] = bsplineBasis(float64(l), knots, degree)
+	}
+	return &DistributedLag{MaxLag: maxLag, Basis: c}, nil
+}
+
+// Design returns the lag columns sum_l C[l][k] z[t-l] for t = MaxLag..len(z)-1;
+// row i belongs to time point MaxLag+i, so responses must be aligned with z[MaxLag:]
+func (d *DistributedLag) Design(z []float64) ([][]float64, error) {
+	if len(z) <= d.MaxLag {
+		return nil, fmt.Errorf("need more than %d observations, got %d", d.MaxLag, len(z))
+	}
+	k := len(d.Basis[0])
+	x := make([][]float64, len(z)-d.MaxLag)
+	for i := range x {
+		t := d.MaxLag + i
+		x[i] = make([]float64, k)
+		for l, row := range d.Basis {
+			for j, c := range row {
+				x[i][j] += c * z[t-l]
+			}
+		}
+	}
+	return x, nil
+}
+
+// Effects maps the coefficients of the lag columns, which start at column offset
+// of the fit design, back to lag-specific and cumulative effects with standard
+// errors from the covariance estimator se
+func (d *DistributedLag) Effects(fit *RQFit, offset int, se string) (*LagEffects, error) {
+	k := len(d.Basis[0])
+	if offset < 0 || offset+k > fit.P {
+		return nil, fmt.Errorf("lag columns [%d, %d) out of range [0, %d)", offset, offset+k, fit.P)
+	}
+	cov, err := fit.Vcov(se)
+	if err != nil {
+		return nil, err
+	}
+	theta := fit.Coefficients[offset : offset+k]
+	v := make([][]float64, k)
+	for a := range v {
+		v[a] = cov[offset+a][offset : offset+k]
+	}
+
+	e := &LagEffects{Tau: fit.Tau}
+	cum := make([]float64, k)
+	for _, row := range d.Basis {
+		for j, c := range row {
+			cum[j] += c
+		}
+		e.Lags = append(e.Lags, dot(row, theta))
+		e.SE = append(e.SE, math.Sqrt(math.Max(quadForm(v, row), 0)))
+		e.Cumulative = append(e.Cumulative, dot(cum, theta))
+		e.CumulativeSE = append(e.CumulativeSE, math.Sqrt(math.Max(quadForm(v, cum), 0)))
+	}
+	return e, nil
+}
+
+// ProcessEffects returns the lag effects at every tau of the quantile process
+func (d *DistributedLag) ProcessEffects(m *MultiRQFit, offset int, se string) ([]*LagEffects, error) {
+	out := make([]*LagEffects, len(m.Taus))
+	for t, tau := range m.Taus {
+		e, err := d.Effects(m.Fits[tau], offset, se)
+		if err != nil {
+			return nil, fmt.Errorf("lag effects failed for tau=%f: %w", tau, err)
+		}
+		out[t] = e
+	}
+	return out, nil
+}
+
+// Total returns the cumulative effect over all lags and its standard error
+func (e *LagEffects) Total() (float64, float64) {
+	last := len(e.Cumulative) - 1
+	return e.Cumulative[last], e.CumulativeSE[last]
+}
@@ -0,0 +1,88 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistributedLag(t *testing.T) {
+	n := 200
+	z := make([]float64, n)
+	for i := range z {
+		z[i] = math.Sin(float64(i)/3) + 0.5*math.Cos(float64(7*i))
+	}
+	// Quadratic lag shape over lags 0..4
+	want := []float64{0.8, 0.6, 0.4, 0.2, 0}
+
+	poly, err := NewPolynomialLag(4, 2)
+	if err != nil {
+		t.Fatalf("Failed to build polynomial lag: %v", err)
+	}
+	lags, err := poly.Design(z)
+	if err != nil {
+		t.Fatalf("Failed to build lag design: %v", err)
+	}
+	if len(lags) != n-4 || len(lags[0]) != 3 {
+		t.Fatalf("Expected %dx3 lag design, got %dx%d", n-4, len(lags), len(lags[0]))
+	}
+	x := WithIntercept(lags)
+	y := make([]float64, len(x))
+	for i := range y {
+		tt := i + 4
+		y[i] = 1 + 0.1*math.Sin(float64(11*tt))
+		for l, b := range want {
+			y[i] += b * z[tt-l]
+		}
+	}
+
+	// The lag shape 0.8 - 0.8 l/4 in the scaled basis has theta = (0.8, -0.8, 0)
+	fit := &RQFit{Coefficients: []float64{1, 0.8, -0.8, 0}, Tau: 0.5, N: len(y), P: 4, X: x, Y: y}
+	e, err := poly.Effects(fit, 1, SEKer)
+	if err != nil {
+		t.Fatalf("Failed to compute lag effects: %v", err)
+	}
+	for l, b := range want {
+		if math.Abs(e.Lags[l]-b) > 1e-12 {
+			t.Errorf("Expected effect %v at lag %d, got %v", b, l, e.Lags[l])
+		}
+		if !(e.SE[l] >= 0) {
+			t.Errorf("Expected a standard error at lag %d, got %v", l, e.SE[l])
+		}
+	}
+	if total, se := e.Total(); math.Abs(total-2) > 1e-12 || !(se > 0) {
+		t.Errorf("Expected cumulative effect 2 with positive SE, got %v (%v)", total, se)
+	}
+
+	free, err := NewDistributedLag(4)
+	if err != nil {
+		t.Fatalf("Failed to build unconstrained lag: %v", err)
+	}
+	flags, err := free.Design(z)
+	if err != nil {
+		t.Fatalf("Failed to build lag design: %v", err)
+	}
+	if flags[0][3] != z[1] {
+		t.Errorf("Expected lag 3 of time 4 to be z[1], got %v", flags[0][3])
+	}
+
+	spline, err := NewSplineLag(10, 4, 2)
+	if err != nil {
+		t.Fatalf("Failed to build spline lag: %v", err)
+	}
+	for l, row := range spline.Basis {
+		sum := 0.0
+		for _, v := range row {
+			sum += v
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("Expected spline basis to sum to one at lag %d, got %v", l, sum)
+		}
+	}
+
+	if _, err := NewPolynomialLag(3, 4); err == nil {
+		t.Error("Expected error for degree above the maximum lag")
+	}
+	if _, err := free.Design(z[:4]); err == nil {
+		t.Error("Expected error for too short a series")
+	}
+}
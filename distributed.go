@@ -0,0 +1,322 @@
+package quantreg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Shard is a block of rows handled by one worker task
+type Shard struct {
+	Y []float64
+	X [][]float64
+}
+
+// ShardGradient is a worker's contribution to a smoothed Newton step
+type ShardGradient struct {
+	N    int
+	Grad []float64   // Gradient of the smoothed check loss
+	Hess [][]float64 // Hessian of the smoothed check loss
+	XtX  [][]float64 // Cross-product of the shard design
+}
+
+// Worker executes shard tasks for a Coordinator. LocalWorker runs them in
+// process; a remote implementation can forward them over RPC. Workers must be
+// safe for concurrent use when shared.
+type Worker interface {
+	FitShard(ctx context.Context, s Shard, tau float64) ([]float64, error)
+	SmoothedGradient(ctx context.Context, s Shard, beta []float64, tau, h float64) (*ShardGradient, error)
+}
+
+// LocalWorker computes shard tasks in the current process
+type LocalWorker struct{}
+
+var _ Worker = LocalWorker{}
+
+// FitShard fits a quantile regression to the shard and returns its coefficients
+func (LocalWorker) FitShard(ctx context.Context, s Shard, tau float64) ([]float64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	fit, err := RQ(s.Y, s.X, tau)
+	if err != nil {
+		return nil, err
+	}
+	return fit.Coefficients, nil
+}
+
+// SmoothedGradient returns the gradient and Hessian at beta of the check loss
+// convolved with a Gaussian kernel of bandwidth h
+func (LocalWorker) SmoothedGradient(ctx context.Context, s Shard, beta []float64, tau, h float64) (*ShardGradient, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p := len(beta)
+	g := &ShardGradient{N: len(s.Y), Grad: make([]float64, p)}
+	w := make([]float64, len(s.Y))
+	for i, row := range s.X {
+		r := s.Y[i]
+		for j, v := range row {
+			r -= v * beta[j]
+		}
+		// d/dbeta of the smoothed loss is -x (tau - Phi(-r/h))
+		d := tau - normCDF(-r/h)
+		for j, v := range row {
+			g.Grad[j] -= v * d
+		}
+		w[i] = normPDF(r/h) / h
+	}
+	g.Hess = crossprod(s.X, w)
+	g.XtX = crossprod(s.X, nil)
+	return g, nil
+}
+
+// Coordinator orchestrates a distributed fit over shards. Tasks whose worker
+// fails with ErrWorkerUnavailable or a deadline are retried on other workers,
+// and that worker is not used again; any other task error ends the fit.
+type Coordinator struct {
+	Workers     []Worker
+	MaxAttempts int     // Attempts per task before the fit fails (default 3)
+	MaxIter     int     // Smoothed Newton iterations (default 50)
+	Tol         float64 // Convergence tolerance on the step size (default 1e-8)
+	Bandwidth   float64 // Smoothing bandwidth; 0 selects a rule of thumb
+}
+
+// DistributedFit is the result of Coordinator.Fit
+type DistributedFit struct {
+	Tau          float64
+	N            int
+	Coefficients []float64
+	Cov          [][]float64 // tau(1-tau) H^-1 X'X H^-1 from the aggregated smoothed Hessian
+	Bandwidth    float64
+	Iterations   int
+	Converged    bool
+	Failures     int  // Failed task attempts that were retried
+	Meta         Meta // Reproducibility metadata
+}
+
+// Fit fits the shards' block quantile regressions, starts from their average and
+// refines it with Newton steps on the convolution-smoothed check loss, summing
+// shard gradients and Hessians each iteration
+func (c *Coordinator) Fit(ctx context.Context, shards []Shard, tau float64) (*DistributedFit, error) {
+	if len(c.Workers) == 0 {
+		return nil, fmt.Errorf("no workers")
+	}
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("no shards")
+	}
+	if tau <= 0 || tau >= 1 {
+		return nil, ErrInvalidTau
+	}
+	maxIter := c.MaxIter
+	if maxIter == 0 {
+		maxIter = 50
+	}
+	tol := c.Tol
+	if tol == 0 {
+		tol = 1e-8
+	}
+
+	start := time.Now()
+	res := &DistributedFit{Tau: tau}
+	alive := make([]bool, len(c.Workers))
+	for i := range alive {
+		alive[i] = true
+	}
+
+	// Block fits
+	blocks := make([][]float64, len(shards))
+	fails, err := c.dispatch(ctx, alive, len(shards), func(ctx context.Context, w Worker, k int) error {
+		b, err := w.FitShard(ctx, shards[k], tau)
+		blocks[k] = b
+		return err
+	})
+	res.Failures += fails
+	if err != nil {
+		return nil, err
+	}
+
+	p := len(blocks[0])
+	for k, b := range blocks {
+		if len(b) != p {
+			return nil, fmt.Errorf("shard %d returned %d coefficients, shard 0 returned %d: %w", k, len(b), p, ErrDimensionMismatch)
+		}
+	}
+	beta := make([]float64, p)
+	for _, b := range blocks {
+		for j, v := range b {
+			beta[j] += v / float64(len(blocks))
+		}
+	}
+	for _, s := range shards {
+		res.N += len(s.Y)
+	}
+
+	h := c.Bandwidth
+	if h <= 0 {
+		h = smoothingBandwidth(shards, beta, res.N, p)
+	}
+	res.Bandwidth = h
+
+	var hinv, xtx [][]float64
+	for iter := 1; iter <= maxIter; iter++ {
+		grads := make([]*ShardGradient, len(shards))
+		fails, err := c.dispatch(ctx, alive, len(shards), func(ctx context.Context, w Worker, k int) error {
+			g, err := w.SmoothedGradient(ctx, shards[k], beta, tau, h)
+			grads[k] = g
+			return err
+		})
+		res.Failures += fails
+		if err != nil {
+			return nil, err
+		}
+
+		grad := make([]float64, p)
+		hess := make([][]float64, p)
+		xtx = make([][]float64, p)
+		for a := range hess {
+			hess[a] = make([]float64, p)
+			xtx[a] = make([]float64, p)
+		}
+		for _, g := range grads {
+			for a := 0; a < p; a++ {
+				grad[a] += g.Grad[a]
+				for b := 0; b < p; b++ {
+					hess[a][b] += g.Hess[a][b]
+					xtx[a][b] += g.XtX[a][b]
+				}
+			}
+		}
+		hinv, err = invert(hess)
+		if err != nil {
+			return nil, fmt.Errorf("aggregated Hessian is singular: %w", err)
+		}
+
+		step := matVec(hinv, grad)
+		size := 0.0
+		for j := range beta {
+			beta[j] -= step[j]
+			size = math.Max(size, math.Abs(step[j]))
+		}
+		res.Iterations = iter
+		if size < tol {
+			res.Converged = true
+			break
+		}
+	}
+
+	res.Coefficients = beta
+	res.Cov = scaleMatrix(sandwich(hinv, xtx), tau*(1-tau))
+	res.Meta = newMeta("distributed", map[string]float64{"max_iter": float64(maxIter), "tolerance": tol, "bandwidth": res.Bandwidth},
+		[]float64{tau}, res.N, len(beta), start, nil, nil)
+	return res, nil
+}
+
+// dispatch runs count tasks on the live workers, retrying the tasks of
+// unavailable workers on the remaining ones, and returns the number of failed
+// attempts. A task error that is not a worker failure is returned as is.
+func (c *Coordinator) dispatch(ctx context.Context, alive []bool, count int, run func(context.Context, Worker, int) error) (int, error) {
+	maxAttempts := c.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 3
+	}
+	attempts := make([]int, count)
+	pending := make([]int, count)
+	for k := range pending {
+		pending[k] = k
+	}
+
+	failures := 0
+	for len(pending) > 0 {
+		var live []int
+		for w, ok := range alive {
+			if ok {
+				live = append(live, w)
+			}
+		}
+		if len(live) == 0 {
+			return failures, fmt.Errorf("all workers failed")
+		}
+
+		// Round-robin assignment; each worker processes its tasks sequentially
+		assigned := make(map[int][]int)
+		for i, k := range pending {
+			w := live[i%len(live)]
+			assigned[w] = append(assigned[w], k)
+		}
+
+		var mu sync.Mutex
+		var failed []int
+		var lastErr, taskErr error
+		var wg sync.WaitGroup
+		for w, tasks := range assigned {
+			wg.Add(1)
+			go func(w int, tasks []int) {
+				defer wg.Done()
+				for i, k := range tasks {
+					if err := run(ctx, c.Workers[w], k); err != nil {
+						mu.Lock()
+						defer mu.Unlock()
+						if !workerFailure(ctx, err) {
+							taskErr = fmt.Errorf("task %d: %w", k, err)
+							return
+						}
+						alive[w] = false
+						failed = append(failed, tasks[i:]...)
+						attempts[k]++
+						lastErr = err
+						return
+					}
+				}
+			}(w, tasks)
+		}
+		wg.Wait()
+
+		if err := ctx.Err(); err != nil {
+			return failures, err
+		}
+		if taskErr != nil {
+			return failures, taskErr
+		}
+		for _, k := range failed {
+			if attempts[k] >= maxAttempts {
+				return failures, fmt.Errorf("task %d failed after %d attempts: %w", k, attempts[k], lastErr)
+			}
+		}
+		failures += len(failed)
+		pending = failed
+	}
+	return failures, nil
+}
+
+// workerFailure reports whether err is the worker's failure rather than the
+// task's: ErrWorkerUnavailable, or a deadline that is not the caller's
+func workerFailure(ctx context.Context, err error) bool {
+	if errors.Is(err, ErrWorkerUnavailable) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil
+}
+
+// smoothingBandwidth returns max(((p + log n)/n)^(2/5), 0.05) times the mean
+// absolute residual of the starting estimate
+func smoothingBandwidth(shards []Shard, beta []float64, n, p int) float64 {
+	mad := 0.0
+	for _, s := range shards {
+		for i, row := range s.X {
+			r := s.Y[i]
+			for j, v := range row {
+				r -= v * beta[j]
+			}
+			mad += math.Abs(r) / float64(n)
+		}
+	}
+	if mad == 0 {
+		mad = 1
+	}
+	rate := math.Pow((float64(p)+math.Log(float64(n)))/float64(n), 0.4)
+	return math.Max(rate, 0.05) * mad
+}
@@ -0,0 +1,141 @@
+package quantreg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"testing"
+)
+
+// failingWorker fails its first calls and then delegates to LocalWorker
+type failingWorker struct {
+	LocalWorker
+	remaining int32
+}
+
+func (w *failingWorker) FitShard(ctx context.Context, s Shard, tau float64) ([]float64, error) {
+	if atomic.AddInt32(&w.remaining, -1) >= 0 {
+		return nil, fmt.Errorf("connection reset: %w", ErrWorkerUnavailable)
+	}
+	return w.LocalWorker.FitShard(ctx, s, tau)
+}
+
+// shardWorker returns fixed shard coefficients and a fixed error
+type shardWorker struct {
+	LocalWorker
+	coef []float64
+	err  error
+}
+
+func (w shardWorker) FitShard(ctx context.Context, s Shard, tau float64) ([]float64, error) {
+	return w.coef, w.err
+}
+
+func distributedShards() []Shard {
+	var shards []Shard
+	for k := 0; k < 3; k++ {
+		var s Shard
+		for i := 0; i < 8; i++ {
+			xi := float64(i) / 2
+			s.X = append(s.X, []float64{1, xi})
+			s.Y = append(s.Y, 1+2*xi+0.3*math.Sin(float64(5*(8*k+i))))
+		}
+		shards = append(shards, s)
+	}
+	return shards
+}
+
+func TestSmoothedGradient(t *testing.T) {
+	s := distributedShards()[0]
+	beta := []float64{1, 2}
+	g, err := LocalWorker{}.SmoothedGradient(context.Background(), s, beta, 0.5, 0.1)
+	if err != nil {
+		t.Fatalf("Failed to compute gradient: %v", err)
+	}
+
+	// Finite-difference check of the smoothed loss
+	loss := func(b []float64) float64 {
+		total := 0.0
+		for i, row := range s.X {
+			r := s.Y[i] - row[0]*b[0] - row[1]*b[1]
+			// E rho(r + h Z) for Gaussian Z
+			total += 0.1*normPDF(r/0.1) + r*(0.5-normCDF(-r/0.1))
+		}
+		return total
+	}
+	eps := 1e-6
+	for j := range beta {
+		up := append([]float64(nil), beta...)
+		dn := append([]float64(nil), beta...)
+		up[j] += eps
+		dn[j] -= eps
+		fd := (loss(up) - loss(dn)) / (2 * eps)
+		if math.Abs(fd-g.Grad[j]) > 1e-5 {
+			t.Errorf("Gradient %d: got %f, finite difference %f", j, g.Grad[j], fd)
+		}
+	}
+}
+
+func TestCoordinator(t *testing.T) {
+	shards := distributedShards()
+	c := &Coordinator{Workers: []Worker{LocalWorker{}, LocalWorker{}}}
+
+	fit, err := c.Fit(context.Background(), shards, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit distributed model: %v", err)
+	}
+	if fit.N != 24 || !fit.Converged || fit.Failures != 0 {
+		t.Errorf("Unexpected fit: %+v", fit)
+	}
+	if math.Abs(fit.Coefficients[1]-2) > 0.5 {
+		t.Errorf("Expected slope near 2, got %f", fit.Coefficients[1])
+	}
+	for j := range fit.Cov {
+		if fit.Cov[j][j] <= 0 {
+			t.Errorf("Expected positive variance for coefficient %d", j)
+		}
+	}
+
+	// A failing worker is dropped and its tasks are retried elsewhere
+	flaky := &failingWorker{remaining: 1}
+	c = &Coordinator{Workers: []Worker{flaky, LocalWorker{}}}
+	retried, err := c.Fit(context.Background(), shards, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit with a failing worker: %v", err)
+	}
+	if retried.Failures == 0 {
+		t.Error("Expected failed attempts to be recorded")
+	}
+	for j := range fit.Coefficients {
+		if math.Abs(retried.Coefficients[j]-fit.Coefficients[j]) > 1e-9 {
+			t.Errorf("Retried fit differs at coefficient %d: %f vs %f", j, retried.Coefficients[j], fit.Coefficients[j])
+		}
+	}
+
+	// All workers failing is an error
+	c = &Coordinator{Workers: []Worker{&failingWorker{remaining: 100}}}
+	if _, err := c.Fit(context.Background(), shards, 0.5); err == nil {
+		t.Error("Expected error when every worker fails")
+	}
+
+	// A task error that is not a worker failure is returned without retries
+	c = &Coordinator{Workers: []Worker{shardWorker{err: ErrSingularDesign}, LocalWorker{}}}
+	if _, err := c.Fit(context.Background(), shards, 0.5); !errors.Is(err, ErrSingularDesign) {
+		t.Errorf("Expected the shard's ErrSingularDesign, got %v", err)
+	}
+
+	// Block fits of different lengths cannot be averaged
+	c = &Coordinator{Workers: []Worker{shardWorker{coef: []float64{1, 2, 3}}, LocalWorker{}}}
+	if _, err := c.Fit(context.Background(), shards, 0.5); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch for mismatched block fits, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c = &Coordinator{Workers: []Worker{LocalWorker{}}}
+	if _, err := c.Fit(ctx, shards, 0.5); err == nil {
+		t.Error("Expected error for a cancelled context")
+	}
+}
@@ -0,0 +1,110 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// DitherOptions controls RQDither
+type DitherOptions struct {
+	Replications int     // Number of dithered fits to average (default 20)
+	Width        float64 // Width of the symmetric uniform noise (default smallest gap between distinct y values)
+}
+
+// DitheredFit is the average of quantile regressions fitted to dithered responses
+type DitheredFit struct {
+	*RQFit
+	Replications int
+	Width        float64
+	CoefSD       []float64 // Standard deviation of each coefficient across replications
+}
+
+// RQDither stabilizes quantile regression for discrete or heavily tied responses by
+// adding uniform noise on [-Width/2, Width/2] to y, refitting, and averaging the
+// coefficients over replications. Fitted values, residuals, BasicObs and Meta of
+// the returned fit refer to the averaged coefficients and the original,
+// undithered response.
+func RQDither(y []float64, x [][]float64, tau float64, opts DitherOptions, rng *rand.Rand) (*DitheredFit, error) {
+	if opts.Replications == 0 {
+		opts.Replications = 20
+	}
+	if opts.Replications < 0 {
+		return nil, fmt.Errorf("replications must be positive, got %d", opts.Replications)
+	}
+	if opts.Width == 0 {
+		opts.Width = minGap(y)
+	}
+	if opts.Width < 0 {
+		return nil, fmt.Errorf("dither width must be positive, got %f", opts.Width)
+	}
+
+	start := time.Now()
+	rng = randOrDefault(rng)
+	yd := make([]float64, len(y))
+	var coefs [][]float64
+	var fit *RQFit
+	for r := 0; r < opts.Replications; r++ {
+		for i, v := range y {
+			yd[i] = v + opts.Width*(rng.Float64()-0.5)
+		}
+		f, err := RQ(yd, x, tau)
+		if err != nil {
+			return nil, fmt.Errorf("dithered fit %d failed: %w", r, err)
+		}
+		coefs = append(coefs, f.Coefficients)
+		fit = f
+	}
+
+	p := fit.P
+	mean := make([]float64, p)
+	sd := make([]float64, p)
+	for _, c := range coefs {
+		for j := range c {
+			mean[j] += c[j] / float64(len(coefs))
+		}
+	}
+	if len(coefs) > 1 {
+		for _, c := range coefs {
+			for j := range c {
+				sd[j] += (c[j] - mean[j]) * (c[j] - mean[j])
+			}
+		}
+		for j := range sd {
+			sd[j] = math.Sqrt(sd[j] / float64(len(coefs)-1))
+		}
+	}
+
+	fit.Coefficients = mean
+	fit.Y = y
+	fit.setFitted(y, x, false)
+	r := make([]float64, len(y))
+	for i := range y {
+		r[i] = y[i] - dot(x[i], mean)
+	}
+	fit.BasicObs = basicObservations(r, p)
+	fit.Meta = newMeta(fit.Method, map[string]float64{"replications": float64(opts.Replications), "width": opts.Width},
+		[]float64{tau}, len(y), p, start, y, x)
+	fit.Meta.Note = fmt.Sprintf("coefficients averaged over %d dithered fits", opts.Replications)
+
+	return &DitheredFit{RQFit: fit, Replications: opts.Replications, Width: opts.Width, CoefSD: sd}, nil
+}
+
+// minGap returns the smallest positive difference between distinct values, or 1
+// when all values are equal
+func minGap(y []float64) float64 {
+	s := append([]float64(nil), y...)
+	sort.Float64s(s)
+	gap := math.Inf(1)
+	for i := 1; i < len(s); i++ {
+		if d := s[i] - s[i-1]; d > 0 && d < gap {
+			gap = d
+		}
+	}
+	if math.IsInf(gap, 1) {
+		return 1
+	}
+	return gap
+}
@@ -0,0 +1,60 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRQDither(t *testing.T) {
+	// Count response with many ties
+	x := make([][]float64, 12)
+	y := make([]float64, 12)
+	for i := range x {
+		x[i] = []float64{1, float64(i % 4)}
+		y[i] = float64(i%4/2 + i%3/2)
+	}
+
+	fit, err := RQDither(y, x, 0.5, DitherOptions{Replications: 5}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to fit dithered model: %v", err)
+	}
+	if fit.Replications != 5 || fit.Width != 1 {
+		t.Errorf("Expected 5 replications of width 1, got %d and %f", fit.Replications, fit.Width)
+	}
+	if len(fit.CoefSD) != 2 || fit.CoefSD[0] <= 0 {
+		t.Errorf("Expected positive coefficient spread, got %v", fit.CoefSD)
+	}
+
+	// Residuals refer to the original response
+	for i := range y {
+		if math.Abs(fit.Residuals[i]-(y[i]-fit.Fitted[i])) > 1e-12 || fit.Y[i] != y[i] {
+			t.Fatalf("Residual %d does not match the undithered response", i)
+		}
+	}
+	// The basis and metadata describe the averaged fit, not the last replication
+	basic := basicObservations(fit.Residuals, fit.P)
+	if len(fit.BasicObs) != len(basic) || fit.BasicObs[0] != basic[0] || fit.BasicObs[1] != basic[1] {
+		t.Errorf("Expected BasicObs %v of the averaged fit, got %v", basic, fit.BasicObs)
+	}
+	if fit.Meta.Options["replications"] != 5 || fit.Meta.Note == "" {
+		t.Errorf("Expected dithering metadata, got %+v", fit.Meta)
+	}
+
+	pred, err := fit.Predict(x[:1])
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	if math.Abs(pred[0]-fit.Fitted[0]) > 1e-12 {
+		t.Errorf("Expected prediction %f, got %f", fit.Fitted[0], pred[0])
+	}
+
+	if g := minGap([]float64{3, 1, 1, 1.5}); g != 0.5 {
+		t.Errorf("Expected smallest gap 0.5, got %f", g)
+	}
+
+	// Error cases
+	if _, err := RQDither(y, x, 0.5, DitherOptions{Width: -1}, nil); err == nil {
+		t.Error("Expected error for negative width")
+	}
+}
@@ -0,0 +1,105 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Elasticities returns the elasticities d log Q_tau(y|x) / d log x_j of the
+// continuous raw covariates, x_j (dQ/dx_j) / Q, averaged over the rows of at:
+// pass the estimation sample for average elasticities or a single row for the
+// elasticity at that point. Factors listed in opts.Discrete are skipped unless
+// named in opts.Terms, which is an error. The elasticity is a ratio of linear
+// forms in the coefficients, so its standard error uses the delta method with
+// the analytic gradient x_j (g/Q - (g'b) d/Q^2), where d is the design row and
+// g its derivative in x_j. The fitted quantile must be away from zero at every row.
+func Elasticities(fit *RQFit, at [][]float64, opts MarginalOptions) ([]MarginalEffect, error) {
+	explicit := opts.Terms != nil
+	terms, err := opts.prepare(at)
+	if err != nil {
+		return nil, err
+	}
+	cov, err := fit.Vcov(opts.SE)
+	if err != nil {
+		return nil, err
+	}
+	z := normQuantile(1 - (1-opts.Level)/2)
+
+	design := func(row []float64) ([]float64, error) {
+		d, err := opts.Design(row)
+		if err != nil {
+			return nil, err
+		}
+		if len(d) != fit.P {
+			return nil, fmt.Errorf("design has %d columns, fit has %d", len(d), fit.P)
+		}
+		return append([]float64(nil), d...), nil
+	}
+
+	var effects []MarginalEffect
+	for _, j := range terms {
+		if _, ok := opts.Discrete[j]; ok {
+			if explicit {
+				return nil, fmt.Errorf("elasticity of factor %d is undefined", j)
+			}
+			continue
+		}
+		grad := make([]float64, fit.P)
+		est := 0.0
+		row := make([]float64, len(at[0]))
+		for _, r := range at {
+			if len(r) != len(row) {
+				return nil, fmt.Errorf("expected %d raw columns, got %d", len(row), len(r))
+			}
+			copy(row, r)
+			d, err := design(row)
+			if err != nil {
+				return nil, err
+			}
+			h := opts.Step * math.Max(1, math.Abs(r[j]))
+			row[j] = r[j] + h
+			hi, err := design(row)
+			if err != nil {
+				return nil, err
+			}
+			row[j] = r[j] - h
+			lo, err := design(row)
+			if err != nil {
+				return nil, err
+			}
+
+			g := make([]float64, fit.P)
+			for k := range g {
+				g[k] = (hi[k] - lo[k]) / (2 * h)
+			}
+			q := dot(d, fit.Coefficients)
+			if math.Abs(q) < 1e-12 {
+				return nil, fmt.Errorf("fitted quantile is zero at x = %v", r)
+			}
+			slope := dot(g, fit.Coefficients)
+			est += r[j] * slope / q / float64(len(at))
+			for k := range grad {
+				grad[k] += r[j] * (g[k]/q - slope*d[k]/(q*q)) / float64(len(at))
+			}
+		}
+		se := math.Sqrt(math.Max(quadForm(cov, grad), 0))
+		effects = append(effects, MarginalEffect{
+			Term: j, Level: math.NaN(), Estimate: est, StdError: se,
+			Lower: est - z*se, Upper: est + z*se,
+		})
+	}
+	return effects, nil
+}
+
+// Elasticities returns the elasticities at each tau
+func (m *MultiRQFit) Elasticities(at [][]float64, opts MarginalOptions) (map[float64][]MarginalEffect, error) {
+	effects := make(map[float64][]MarginalEffect, len(m.Taus))
+	for _, tau := range m.Taus {
+		e, err := Elasticities(m.Fits[tau], at, opts)
+		if err != nil {
+			return nil, fmt.Errorf("elasticities at tau=%.3f failed: %w", tau, err)
+		}
+		effects[tau] = e
+	}
+	return effects, nil
+}
@@ -0,0 +1,56 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestElasticities(t *testing.T) {
+	n := 60
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		v := 1 + float64(i)/10
+		x[i] = []float64{1, v, float64(i % 2)}
+		y[i] = 5 + 2*v + x[i][2] + 0.3*math.Sin(float64(11*i))
+	}
+	fit, err := rqSimplex(y, x, nil, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	b := fit.Coefficients
+	opts := MarginalOptions{Discrete: map[int][]float64{0: {1}, 2: {0, 1}}}
+
+	at := [][]float64{{1, 3, 0}}
+	el, err := Elasticities(fit, at, opts)
+	if err != nil {
+		t.Fatalf("Failed to compute elasticities: %v", err)
+	}
+	if len(el) != 1 || el[0].Term != 1 {
+		t.Fatalf("Expected one elasticity for column 1, got %+v", el)
+	}
+	want := 3 * b[1] / (b[0] + 3*b[1])
+	if math.Abs(el[0].Estimate-want) > 1e-6 {
+		t.Errorf("Expected elasticity %f, got %f", want, el[0].Estimate)
+	}
+	if el[0].StdError <= 0 {
+		t.Errorf("Expected a positive standard error, got %f", el[0].StdError)
+	}
+
+	avg, err := Elasticities(fit, x, opts)
+	if err != nil {
+		t.Fatalf("Failed to compute average elasticities: %v", err)
+	}
+	mean := 0.0
+	for _, r := range x {
+		mean += r[1] * b[1] / dot(r, b) / float64(n)
+	}
+	if math.Abs(avg[0].Estimate-mean) > 1e-6 {
+		t.Errorf("Expected average elasticity %f, got %f", mean, avg[0].Estimate)
+	}
+
+	opts.Terms = []int{2}
+	if _, err := Elasticities(fit, at, opts); err == nil {
+		t.Error("Expected error for the elasticity of a factor")
+	}
+}
@@ -0,0 +1,69 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// multiRQFitJSON is the serialized form of MultiRQFit; fits are stored in tau order
+// because JSON objects cannot be keyed by float64
+type multiRQFitJSON struct {
+	Taus    []float64
+	N       int
+	P       int
+	Method  string
+	Formula string
+	Meta    Meta
+	Fits    []*RQFit
+}
+
+// MarshalJSON implements json.Marshaler
+func (m *MultiRQFit) MarshalJSON() ([]byte, error) {
+	enc := multiRQFitJSON{
+		Taus:    m.Taus,
+		N:       m.N,
+		P:       m.P,
+		Method:  m.Method,
+		Formula: m.Formula,
+		Meta:    m.Meta,
+		Fits:    make([]*RQFit, len(m.Taus)),
+	}
+	for i, tau := range m.Taus {
+		enc.Fits[i] = m.Fits[tau]
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (m *MultiRQFit) UnmarshalJSON(data []byte) error {
+	var dec multiRQFitJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	if len(dec.Fits) != len(dec.Taus) {
+		return fmt.Errorf("expected %d fits, got %d", len(dec.Taus), len(dec.Fits))
+	}
+
+	fits := make(map[float64]*RQFit, len(dec.Fits))
+	for i, fit := range dec.Fits {
+		if fit == nil || fit.Tau != dec.Taus[i] {
+			return fmt.Errorf("fit %d does not match tau=%f", i, dec.Taus[i])
+		}
+		fits[fit.Tau] = fit
+	}
+	if !sort.Float64sAreSorted(dec.Taus) {
+		return fmt.Errorf("quantile levels are not sorted")
+	}
+
+	*m = MultiRQFit{
+		Fits:    fits,
+		Taus:    dec.Taus,
+		N:       dec.N,
+		P:       dec.P,
+		Method:  dec.Method,
+		Formula: dec.Formula,
+		Meta:    dec.Meta,
+	}
+	return nil
+}
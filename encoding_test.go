@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMultiRQFitJSON(t *testing.T) {
+	x := [][]float64{
+		{1, 0.5},
+		{1, 1.0},
+		{1, 1.5},
+		{1, 2.0},
+	}
+	y := []float64{1.0, 2.0, 2.5, 3.0}
+
+	fits, err := RQProcess(y, x, []float64{0.75, 0.25})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	data, err := json.Marshal(fits)
+	if err != nil {
+		t.Fatalf("Failed to encode fit: %v", err)
+	}
+
+	var decoded MultiRQFit
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode fit: %v", err)
+	}
+
+	if len(decoded.Taus) != 2 || decoded.N != fits.N || decoded.P != fits.P {
+		t.Errorf("Decoded fit does not match: %+v", decoded)
+	}
+
+	want, _ := fits.Predict(x)
+	got, err := decoded.Predict(x)
+	if err != nil {
+		t.Fatalf("Failed to predict with decoded fit: %v", err)
+	}
+	for _, tau := range fits.Taus {
+		for i := range want[tau] {
+			if got[tau][i] != want[tau][i] {
+				t.Errorf("tau=%f row %d: got %f, want %f", tau, i, got[tau][i], want[tau][i])
+			}
+		}
+	}
+
+	// Mismatched taus must be rejected
+	bad := []byte(`{"Taus":[0.5],"Fits":[{"Tau":0.25}]}`)
+	if err := json.Unmarshal(bad, &decoded); err == nil {
+		t.Error("Expected error for mismatched tau")
+	}
+}
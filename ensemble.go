@@ -0,0 +1,91 @@
+package quantreg
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Ensemble averages the quantile predictions of several models at every tau
+type Ensemble struct {
+	Models    []Model
+	Rearrange bool // Sort each row of the averaged quantiles so they do not cross
+}
+
+// Predict averages the member predictions per tau. Every member must predict the
+// same quantile levels.
+func (e *Ensemble) Predict(newX [][]float64) (map[float64][]float64, error) {
+	if len(e.Models) == 0 {
+		return nil, fmt.Errorf("ensemble has no models")
+	}
+	preds := make([]map[float64][]float64, len(e.Models))
+	for j, m := range e.Models {
+		p, err := m.Predict(newX)
+		if err != nil {
+			return nil, fmt.Errorf("model %d prediction failed: %w", j, err)
+		}
+		preds[j] = p
+	}
+	taus, err := combineTaus(preds, len(newX))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[float64][]float64, len(taus))
+	for _, tau := range taus {
+		avg := make([]float64, len(newX))
+		for _, p := range preds {
+			for i, v := range p[tau] {
+				avg[i] += v / float64(len(preds))
+			}
+		}
+		out[tau] = avg
+	}
+	if e.Rearrange {
+		rearrangeRows(out, taus, len(newX))
+	}
+	return out, nil
+}
+
+// BagRQProcess fits the quantile process on bags pairs-bootstrap resamples of
+// (y, x) and returns them as a rearranged ensemble. Bagging smooths the
+// piecewise-constant dependence of the estimates on the data.
+func BagRQProcess(y []float64, x [][]float64, taus []float64, bags int, rng *rand.Rand) (*Ensemble, error) {
+	if bags < 1 {
+		return nil, fmt.Errorf("need at least one bag, got %d", bags)
+	}
+	if len(taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels specified")
+	}
+	if len(x) == 0 || len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	sorted := append([]float64(nil), taus...)
+	sort.Float64s(sorted)
+
+	rng = randOrDefault(rng)
+	n := len(y)
+	w := make([]float64, n)
+	e := &Ensemble{Rearrange: true}
+	for b := 0; b < bags; b++ {
+		start := time.Now()
+		for i := range w {
+			w[i] = 0
+		}
+		for k := 0; k < n; k++ {
+			w[rng.Intn(n)]++
+		}
+		m := &MultiRQFit{Fits: make(map[float64]*RQFit, len(sorted)), Taus: sorted, N: n, P: len(x[0]), Method: "br"}
+		for _, tau := range sorted {
+			fit, err := RQWeighted(y, x, w, tau)
+			if err != nil {
+				return nil, fmt.Errorf("bag %d failed for tau=%f: %w", b, tau, err)
+			}
+			m.Fits[tau] = fit
+		}
+		m.Meta = newMeta("br", m.Fits[sorted[0]].Meta.Options, sorted, n, m.P, start, y, x)
+		e.Models = append(e.Models, m)
+	}
+	return e, nil
+}
@@ -0,0 +1,66 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEnsemble(t *testing.T) {
+	y, x := inferenceData()
+	a, err := RQProcess(y, x, []float64{0.25, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	b := &MultiRQFit{Fits: make(map[float64]*RQFit), Taus: a.Taus, P: 2}
+	for _, tau := range a.Taus {
+		b.Fits[tau] = &RQFit{Tau: tau, P: 2, Coefficients: []float64{10 * tau, 0}}
+	}
+
+	e := &Ensemble{Models: []Model{a, b}}
+	pred, err := e.Predict(x)
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	pa, _ := a.Predict(x)
+	for _, tau := range a.Taus {
+		for i := range x {
+			want := (pa[tau][i] + 10*tau) / 2
+			if math.Abs(pred[tau][i]-want) > 1e-12 {
+				t.Fatalf("Expected average %f at tau=%f, got %f", want, tau, pred[tau][i])
+			}
+		}
+	}
+
+	// A member predicting other levels is rejected
+	c := &MultiRQFit{Fits: map[float64]*RQFit{0.5: a.Fits[0.25]}, Taus: []float64{0.5}, P: 2}
+	if _, err := (&Ensemble{Models: []Model{a, c}}).Predict(x); err == nil {
+		t.Error("Expected error for mismatched quantile levels")
+	}
+	if _, err := (&Ensemble{}).Predict(x); err == nil {
+		t.Error("Expected error for empty ensemble")
+	}
+}
+
+func TestBagRQProcess(t *testing.T) {
+	y, x := inferenceData()
+	e, err := BagRQProcess(y, x, []float64{0.75, 0.25}, 5, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Failed to bag process: %v", err)
+	}
+	if len(e.Models) != 5 || !e.Rearrange {
+		t.Fatalf("Unexpected ensemble %+v", e)
+	}
+	pred, err := e.Predict(x)
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	for i := range x {
+		if pred[0.25][i] > pred[0.75][i] {
+			t.Errorf("Expected rearranged quantiles at row %d, got %f > %f", i, pred[0.25][i], pred[0.75][i])
+		}
+	}
+	if _, err := BagRQProcess(y, x, []float64{0.5}, 0, nil); err == nil {
+		t.Error("Expected error for zero bags")
+	}
+}
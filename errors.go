@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds returned, possibly wrapped with context, by the fitting and
+// inference functions; test for them with errors.Is
+var (
+	ErrInvalidTau        = errors.New("tau must be between 0 and 1")
+	ErrDimensionMismatch = errors.New("dimensions do not match")
+	ErrNotConverged      = errors.New("solver did not converge")
+	ErrSingularDesign    = errors.New("matrix is singular")
+	ErrInfeasible        = errors.New("constraints are infeasible")
+
+	// ErrWorkerUnavailable marks a Worker failure that is not the task's
+	// fault, such as a lost connection; Coordinator retries such tasks on
+	// other workers and returns any other task error unchanged
+	ErrWorkerUnavailable = errors.New("worker unavailable")
+)
+
+// convergenceError returns nil when converged and otherwise ErrNotConverged
+// wrapped with the iteration count
+func convergenceError(converged bool, iterations int) error {
+	if converged {
+		return nil
+	}
+	return fmt.Errorf("%w after %d iterations", ErrNotConverged, iterations)
+}
+
+// CheckConvergence returns an error wrapping ErrNotConverged when the solver
+// stopped at its iteration limit
+func (fit *RQFit) CheckConvergence() error {
+	return convergenceError(fit.Converged, fit.Iterations)
+}
+
+// CheckConvergence returns an error wrapping ErrNotConverged when the solver
+// stopped at its iteration limit
+func (fit *NLRQFit) CheckConvergence() error {
+	return convergenceError(fit.Converged, fit.Iterations)
+}
+
+// CheckConvergence returns an error wrapping ErrNotConverged when SMO stopped
+// at MaxIter
+func (f *KernelQRFit) CheckConvergence() error {
+	return convergenceError(f.Converged, f.Iterations)
+}
+
+// CheckConvergence returns an error wrapping ErrNotConverged when the Newton
+// refinement stopped at MaxIter
+func (f *DistributedFit) CheckConvergence() error {
+	return convergenceError(f.Converged, f.Iterations)
+}
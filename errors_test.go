@@ -0,0 +1,45 @@
+package quantreg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorKinds(t *testing.T) {
+	y, x := inferenceData()
+
+	if _, err := RQ(y, x, 1.5); !errors.Is(err, ErrInvalidTau) {
+		t.Errorf("Expected ErrInvalidTau, got %v", err)
+	}
+	if _, err := RQProcess(y, x, []float64{0.5, 1.5}); !errors.Is(err, ErrInvalidTau) {
+		t.Errorf("Expected ErrInvalidTau from the process, got %v", err)
+	}
+	if _, err := RQ(y[:5], x, 0.5); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := RQWeighted(y, x, []float64{1}, 0.5); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch for weights, got %v", err)
+	}
+
+	// Duplicated column makes the design singular
+	xs := make([][]float64, len(x))
+	for i, row := range x {
+		xs[i] = []float64{row[0], row[1], row[1]}
+	}
+	fit, err := RQ(y, xs, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if _, err := fit.Vcov(SEIID); !errors.Is(err, ErrSingularDesign) {
+		t.Errorf("Expected ErrSingularDesign, got %v", err)
+	}
+
+	fit.Converged, fit.Iterations = false, 1000
+	if err := fit.CheckConvergence(); !errors.Is(err, ErrNotConverged) {
+		t.Errorf("Expected ErrNotConverged, got %v", err)
+	}
+	fit.Converged = true
+	if err := fit.CheckConvergence(); err != nil {
+		t.Errorf("Expected no error for a converged fit, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package quantreg
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+)
+
+// Settings of the Frisch-Newton solver, as in rq.fit.fnb of the R package quantreg
+const (
+	fnMaxIter   = 100     // Maximum predictor-corrector iterations
+	fnTolerance = 1e-6    // Duality gap at convergence
+	fnStep      = 0.99995 // Fraction of the distance to the boundary taken per step
+)
+
+// solveFrischNewton solves the quantile regression linear program by the
+// Frisch-Newton primal-dual interior point method of Portnoy and Koenker (1997)
+// with Mehrotra's predictor-corrector steps. It works on the dual program
+// min -y'a subject to X'a = (1-tau)X'1 and 0 <= a <= 1, whose equality
+// multipliers are minus the coefficients. Each iteration solves one or two
+// weighted least-squares systems X'QX, so the cost grows linearly in the number
+// of observations, where the simplex grows faster. The solution is optimal to the
+// duality gap fnTolerance rather than an exact vertex.
+func (fit *RQFit) solveFrischNewton(y []float64, x [][]float64) ([]float64, error) {
+	n := len(y)
+	tau := fit.Tau
+	cols, dense, err := independentDesign(x)
+	if err != nil {
+		return nil, err
+	}
+	p := len(cols)
+	xt := transpose(dense)
+	log := currentLogger()
+	debug := log.Enabled(context.Background(), slog.LevelDebug)
+
+	// Start from the interior point a = 1-tau, which satisfies the equality
+	// constraints, with multipliers from least squares and dual slacks z - w
+	// matching the least-squares residuals
+	a := make([]float64, n)
+	s := make([]float64, n)
+	ones := make([]float64, n)
+	for i := range a {
+		a[i], s[i], ones[i] = 1-tau, tau, 1
+	}
+	b := make([]float64, p)
+	for j, col := range xt {
+		b[j] = (1 - tau) * dot(col, ones)
+	}
+	neg := make([]float64, n)
+	for i, v := range y {
+		neg[i] = -v
+	}
+	d, err := fnSolve(dense, ones, matVec(xt, neg))
+	if err != nil {
+		return nil, err
+	}
+	z := make([]float64, n)
+	w := make([]float64, n)
+	for i, row := range dense {
+		r := neg[i] - dot(row, d)
+		if r == 0 {
+			r = 0.001
+		}
+		z[i] = math.Max(r, 0)
+		w[i] = z[i] - r
+	}
+	gap := func() float64 {
+		return dot(neg, a) - dot(d, b) + dot(w, ones)
+	}
+	coefficients := func() []float64 {
+		beta := make([]float64, p)
+		for j, v := range d {
+			beta[j] = -v
+		}
+		return expandCoefficients(beta, cols, len(x[0]))
+	}
+
+	q := make([]float64, n)
+	r := make([]float64, n)
+	qr := make([]float64, n)
+	dx := make([]float64, n)
+	ds := make([]float64, n)
+	dz := make([]float64, n)
+	dw := make([]float64, n)
+	rhs := make([]float64, n)
+	xi := make([]float64, n)
+	for g := gap(); g > fnTolerance; g = gap() {
+		if fit.Iterations == fnMaxIter {
+			return coefficients(), nil
+		}
+		fit.Iterations++
+		if debug {
+			log.Debug("solver iteration", "method", fit.Method, "tau", tau, "iteration", fit.Iterations, "gap", g)
+		}
+
+		// Affine scaling (predictor) direction
+		for i := range q {
+			q[i] = 1 / (z[i]/a[i] + w[i]/s[i])
+			r[i] = z[i] - w[i]
+			qr[i] = q[i] * r[i]
+		}
+		dd, err := fnSolve(dense, q, matVec(xt, qr))
+		if err != nil {
+			return nil, err
+		}
+		for i, row := range dense {
+			dx[i] = q[i] * (dot(row, dd) - r[i])
+			ds[i] = -dx[i]
+			dz[i] = -z[i] * (dx[i]/a[i] + 1)
+			dw[i] = -w[i] * (ds[i]/s[i] + 1)
+		}
+		fp, fd := fnSteps(a, s, z, w, dx, ds, dz, dw)
+
+		// Short of a full step, re-centre with Mehrotra's corrector
+		if math.Min(fp, fd) < 1 {
+			mu := dot(z, a) + dot(w, s)
+			next := 0.0
+			for i := range a {
+				next += (z[i]+fd*dz[i])*(a[i]+fp*dx[i]) + (w[i]+fd*dw[i])*(s[i]+fp*ds[i])
+			}
+			mu *= math.Pow(next/mu, 3) / float64(2*n)
+			for i := range rhs {
+				xi[i] = mu * (1/a[i] - 1/s[i])
+				rhs[i] = q[i] * (r[i] + dx[i]*dz[i] - ds[i]*dw[i] - xi[i])
+			}
+			if dd, err = fnSolve(dense, q, matVec(xt, rhs)); err != nil {
+				return nil, err
+			}
+			for i, row := range dense {
+				dxdz, dsdw := dx[i]*dz[i], ds[i]*dw[i]
+				dx[i] = q[i] * (dot(row, dd) + xi[i] - r[i] - dxdz + dsdw)
+				ds[i] = -dx[i]
+				dz[i] = mu/a[i] - z[i] - z[i]*dx[i]/a[i] - dxdz
+				dw[i] = mu/s[i] - w[i] - w[i]*ds[i]/s[i] - dsdw
+			}
+			fp, fd = fnSteps(a, s, z, w, dx, ds, dz, dw)
+		}
+
+		for i := range a {
+			a[i] += fp * dx[i]
+			s[i] += fp * ds[i]
+			z[i] += fd * dz[i]
+			w[i] += fd * dw[i]
+		}
+		for j := range d {
+			d[j] += fd * dd[j]
+		}
+	}
+	fit.Converged = true
+	return coefficients(), nil
+}
+
+// fnSolve solves (X'QX) d = g for a Newton step. Near the solution q spans many
+// orders of magnitude, so X'QX can be numerically singular for a full-rank X,
+// and a failed solve with the selected linear solver is retried by QR. When the
+// response does not determine the coefficients uniquely, the weights of too few
+// observations stay large to span them, and the last retry floors the weights at
+// 1e-20 of the largest.
+func fnSolve(x [][]float64, q, g []float64) ([]float64, error) {
+	d, _, err := weightedSolve(x, q, g)
+	if errors.Is(err, ErrSingularDesign) && currentLinearSolver() != SolverQR {
+		d, _, err = weightedSolveQR(x, q, g)
+	}
+	if errors.Is(err, ErrSingularDesign) {
+		top := 0.0
+		for _, v := range q {
+			top = math.Max(top, v)
+		}
+		floored := make([]float64, len(q))
+		for i, v := range q {
+			floored[i] = math.Max(v, 1e-20*top)
+		}
+		d, _, err = weightedSolveQR(x, floored, g)
+	}
+	return d, err
+}
+
+// fnSteps returns the primal and dual step lengths, a fraction fnStep of the
+// distance to the boundary of the positive orthant capped at one
+func fnSteps(a, s, z, w, dx, ds, dz, dw []float64) (float64, float64) {
+	bound := func(v, dv []float64) float64 {
+		t := math.Inf(1)
+		for i, d := range dv {
+			if d < 0 {
+				t = math.Min(t, -v[i]/d)
+			}
+		}
+		return t
+	}
+	fp := math.Min(fnStep*math.Min(bound(a, dx), bound(s, ds)), 1)
+	fd := math.Min(fnStep*math.Min(bound(z, dz), bound(w, dw)), 1)
+	return fp, fd
+}
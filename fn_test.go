@@ -0,0 +1,95 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFrischNewton(t *testing.T) {
+	y, x := inferenceData()
+
+	for _, tau := range []float64{0.1, 0.5, 0.8} {
+		fit, err := RQWithOptions(y, x, tau, RQOptions{Method: MethodFN})
+		if err != nil {
+			t.Fatalf("Failed to fit model: %v", err)
+		}
+		exact, err := RQ(y, x, tau)
+		if err != nil {
+			t.Fatalf("Failed to fit exact model: %v", err)
+		}
+		if fit.Method != MethodFN || !fit.Converged {
+			t.Errorf("Expected a converged fn fit, got method=%q converged=%v", fit.Method, fit.Converged)
+		}
+		for j, b := range exact.Coefficients {
+			if math.Abs(fit.Coefficients[j]-b) > 1e-5 {
+				t.Errorf("Expected coefficient %d = %v at tau=%v, got %v", j, b, tau, fit.Coefficients[j])
+			}
+		}
+		if fit.Meta.Solver != MethodFN || fit.Meta.Options["step"] != fnStep {
+			t.Errorf("Unexpected metadata: %v, %v", fit.Meta.Solver, fit.Meta.Options)
+		}
+	}
+
+	// Small problems go to the simplex under MethodAuto
+	fit, err := RQWithOptions(y, x, 0.5, RQOptions{Method: MethodAuto})
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if fit.Method != MethodBR {
+		t.Errorf("Expected auto to choose br, got %q", fit.Method)
+	}
+
+	// An aliased column gets a zero coefficient
+	xs := make([][]float64, len(x))
+	for i, row := range x {
+		xs[i] = []float64{row[0], row[1], 2 * row[1]}
+	}
+	fit, err = RQWithOptions(y, xs, 0.5, RQOptions{Method: MethodFN})
+	if err != nil {
+		t.Fatalf("Failed to fit rank-deficient model: %v", err)
+	}
+	if fit.Coefficients[2] != 0 {
+		t.Errorf("Expected zero coefficient for the aliased column, got %v", fit.Coefficients[2])
+	}
+
+	if _, err := RQWithOptions(y, x, 0.5, RQOptions{Method: "lp"}); err == nil {
+		t.Error("Expected error for unknown method")
+	}
+}
+
+func TestFrischNewtonSkewedWeights(t *testing.T) {
+	// Counts on discrete covariates with a non-unique median fit; near the
+	// solution the Newton weights span more than thirty orders of magnitude
+	rng := rand.New(rand.NewSource(153))
+	n := 200
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := range y {
+		x[i] = []float64{1, float64(rng.Intn(4)), float64(rng.Intn(3))}
+		y[i] = float64(rng.Intn(6)) + x[i][1]
+	}
+	for _, tau := range []float64{0.25, 0.5} {
+		fit, err := RQWithOptions(y, x, tau, RQOptions{Method: MethodFN})
+		if err != nil {
+			t.Fatalf("Failed to fit model at tau=%v: %v", tau, err)
+		}
+		exact, err := RQ(y, x, tau)
+		if err != nil {
+			t.Fatalf("Failed to fit exact model: %v", err)
+		}
+		if loss, best := sumRho(fit.Residuals, tau), sumRho(exact.Residuals, tau); math.Abs(loss-best) > 1e-5*best {
+			t.Errorf("Expected check loss %v at tau=%v, got %v", best, tau, loss)
+		}
+	}
+
+	// Two dominant weights leave the normal equations singular
+	q := make([]float64, n)
+	for i := range q {
+		q[i] = 1e-18
+	}
+	q[0], q[1] = 1e14, 1e14
+	if _, err := fnSolve(x, q, []float64{1, 1, 1}); err != nil {
+		t.Errorf("Expected a Newton step for skewed weights, got %v", err)
+	}
+}
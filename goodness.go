@@ -0,0 +1,133 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// rho is the quantile regression check function
+func rho(u, tau float64) float64 {
+	if u < 0 {
+		return u * (tau - 1)
+	}
+	return u * tau
+}
+
+// sumRho returns the total check loss of residuals at tau
+func sumRho(residuals []float64, tau float64) float64 {
+	return checkLossSum(residuals, tau)
+}
+
+// weightedRho returns the check loss sum w_i rho_tau(r_i), with unit weights
+// when w is nil
+func weightedRho(residuals, w []float64, tau float64) float64 {
+	if w == nil {
+		return sumRho(residuals, tau)
+	}
+	total := 0.0
+	for i, r := range residuals {
+		total += w[i] * rho(r, tau)
+	}
+	return total
+}
+
+// weightedRestrictedRho returns the weighted check loss of the intercept-only
+// fit, whose solution is the weighted tau-th quantile of y
+func weightedRestrictedRho(y, w []float64, tau float64) float64 {
+	idx := make([]int, len(y))
+	total := 0.0
+	for i := range idx {
+		idx[i] = i
+		total += w[i]
+	}
+	sort.Slice(idx, func(a, b int) bool { return y[idx[a]] < y[idx[b]] })
+	q, cum := y[idx[len(idx)-1]], 0.0
+	for _, i := range idx {
+		if cum += w[i]; cum >= tau*total {
+			q = y[i]
+			break
+		}
+	}
+	loss := 0.0
+	for i, v := range y {
+		loss += w[i] * rho(v-q, tau)
+	}
+	return loss
+}
+
+// restrictedRho returns the check loss of the intercept-only fit at tau,
+// whose solution is the tau-th sample quantile of y
+func restrictedRho(y []float64, tau float64) float64 {
+	sorted := make([]float64, len(y))
+	copy(sorted, y)
+	sort.Float64s(sorted)
+
+	k := int(math.Ceil(tau*float64(len(sorted)))) - 1
+	if k < 0 {
+		k = 0
+	}
+	q := sorted[k]
+
+	total := 0.0
+	for _, v := range y {
+		total += rho(v-q, tau)
+	}
+	return total
+}
+
+// Rho returns the minimized check loss of the fit
+func (fit *RQFit) Rho() float64 {
+	return sumRho(fit.ResidualValues(), fit.Tau)
+}
+
+// weightedLoss returns the check loss of the fit with the observation weights of
+// a weighted fit
+func (fit *RQFit) weightedLoss() float64 {
+	return weightedRho(fit.ResidualValues(), fit.Weights, fit.Tau)
+}
+
+// R1 returns the Koenker-Machado goodness of fit 1 - V/V0, where V is the check
+// loss of the fit and V0 the check loss of the intercept-only quantile fit. Both
+// losses carry the observation weights of a weighted fit.
+func (fit *RQFit) R1() (float64, error) {
+	if len(fit.Y) == 0 {
+		return 0, fmt.Errorf("fit does not carry its response")
+	}
+	v, v0 := fit.weightedLoss(), 0.0
+	if fit.Weights == nil {
+		v0 = restrictedRho(fit.Y, fit.Tau)
+	} else {
+		v0 = weightedRestrictedRho(fit.Y, fit.Weights, fit.Tau)
+	}
+	if v0 == 0 {
+		return 0, fmt.Errorf("restricted check loss is zero")
+	}
+	return 1 - v/v0, nil
+}
+
+// LogLik returns the asymmetric Laplace quasi log-likelihood of the fit with the
+// scale parameter profiled out, n(log(tau(1-tau)) - 1 - log(rho/n)). For a
+// weighted fit rho is the weighted check loss and n the sum of the weights, as
+// in R1. A fit with zero check loss, such as one interpolating every
+// observation, has LogLik +Inf and AIC and BIC -Inf.
+func (fit *RQFit) LogLik() float64 {
+	n := float64(fit.N)
+	if fit.Weights != nil {
+		n = 0
+		for _, w := range fit.Weights {
+			n += w
+		}
+	}
+	return n * (math.Log(fit.Tau*(1-fit.Tau)) - 1 - math.Log(fit.weightedLoss()/n))
+}
+
+// AIC returns the Akaike information criterion -2 LogLik + 2p
+func (fit *RQFit) AIC() float64 {
+	return -2*fit.LogLik() + 2*float64(fit.P)
+}
+
+// BIC returns the Schwarz information criterion -2 LogLik + log(n) p
+func (fit *RQFit) BIC() float64 {
+	return -2*fit.LogLik() + math.Log(float64(fit.N))*float64(fit.P)
+}
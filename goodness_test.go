@@ -0,0 +1,125 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRho(t *testing.T) {
+	if got := rho(2, 0.25); got != 0.5 {
+		t.Errorf("rho(2, 0.25) = %f, want 0.5", got)
+	}
+	if got := rho(-2, 0.25); got != 1.5 {
+		t.Errorf("rho(-2, 0.25) = %f, want 1.5", got)
+	}
+
+	// The intercept-only solution is the 0.25 sample quantile, here 2
+	y := []float64{4, 1, 3, 2, 5, 6, 8, 7}
+	if got := restrictedRho(y, 0.25); math.Abs(got-6) > 1e-12 {
+		t.Errorf("restrictedRho = %f, want 6", got)
+	}
+}
+
+func TestR1(t *testing.T) {
+	y := []float64{1, 2, 3, 4, 5}
+
+	// A perfect fit has R1 = 1
+	fit := &RQFit{Tau: 0.5, Y: y, Residuals: make([]float64, len(y))}
+	r1, err := fit.R1()
+	if err != nil {
+		t.Fatalf("Failed to compute R1: %v", err)
+	}
+	if r1 != 1 {
+		t.Errorf("Expected R1 = 1 for a perfect fit, got %f", r1)
+	}
+
+	// The intercept-only fit has R1 = 0
+	fit.Residuals = []float64{-2, -1, 0, 1, 2}
+	if r1, _ := fit.R1(); math.Abs(r1) > 1e-12 {
+		t.Errorf("Expected R1 = 0 for the intercept-only fit, got %f", r1)
+	}
+
+	if _, err := (&RQFit{Tau: 0.5}).R1(); err == nil {
+		t.Error("Expected error for fit without response")
+	}
+	if _, err := (&RQFit{Tau: 0.5, Y: []float64{2, 2}, Residuals: []float64{0, 0}}).R1(); err == nil {
+		t.Error("Expected error for constant response")
+	}
+}
+
+func TestDiagnosticsR1(t *testing.T) {
+	x := [][]float64{
+		{1, 0.5},
+		{1, 1.0},
+		{1, 1.5},
+		{1, 2.0},
+		{1, 2.5},
+	}
+	y := []float64{1.0, 2.0, 2.5, 3.0, 4.0}
+
+	fits, err := RQProcess(y, x, []float64{0.25, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	diag := fits.ComputeDiagnostics()
+	for _, tau := range fits.Taus {
+		r1, ok := diag.R1[tau]
+		if !ok {
+			t.Errorf("Missing R1 for tau=%f", tau)
+			continue
+		}
+		if r1 > 1 || math.IsNaN(r1) {
+			t.Errorf("Invalid R1 for tau=%f: %f", tau, r1)
+		}
+	}
+
+	// Without a median fit there is no overall pseudo R-squared
+	if diag.PseudoRSquared != 0 {
+		t.Errorf("Expected zero pseudo R-squared without tau=0.5, got %f", diag.PseudoRSquared)
+	}
+}
+
+func TestInformationCriteria(t *testing.T) {
+	fit := &RQFit{
+		Tau:       0.5,
+		N:         4,
+		P:         2,
+		Residuals: []float64{-1, 1, -1, 1},
+	}
+
+	// rho = 2, so logLik = 4 * (log(0.25) - 1 - log(0.5))
+	want := 4 * (math.Log(0.25) - 1 - math.Log(0.5))
+	if got := fit.LogLik(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogLik = %f, want %f", got, want)
+	}
+	if got := fit.AIC(); math.Abs(got-(-2*want+4)) > 1e-12 {
+		t.Errorf("AIC = %f, want %f", got, -2*want+4)
+	}
+	if got := fit.BIC(); math.Abs(got-(-2*want+2*math.Log(4))) > 1e-12 {
+		t.Errorf("BIC = %f, want %f", got, -2*want+2*math.Log(4))
+	}
+
+	// A better fit with the same dimension has a lower AIC
+	better := *fit
+	better.Residuals = []float64{-0.5, 0.5, -0.5, 0.5}
+	if better.AIC() >= fit.AIC() {
+		t.Errorf("Expected lower AIC for smaller check loss: %f >= %f", better.AIC(), fit.AIC())
+	}
+
+	// Weights enter the check loss and the effective sample size:
+	// rho = 0.5 (2 + 1 + 2 + 1) = 3 over total weight 6
+	weighted := *fit
+	weighted.Weights = []float64{2, 1, 2, 1}
+	want = 6 * (math.Log(0.25) - 1 - math.Log(3.0/6))
+	if got := weighted.LogLik(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Weighted LogLik = %f, want %f", got, want)
+	}
+
+	// An exact fit has infinite quasi log-likelihood
+	exact := *fit
+	exact.Residuals = make([]float64, 4)
+	if !math.IsInf(exact.LogLik(), 1) || !math.IsInf(exact.AIC(), -1) {
+		t.Errorf("Expected LogLik +Inf and AIC -Inf for an exact fit, got %f and %f", exact.LogLik(), exact.AIC())
+	}
+}
@@ -0,0 +1,67 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// gradientTolerance is the relative error above which a gradient is flagged
+const gradientTolerance = 1e-4
+
+// GradientCheck compares the analytic gradient of one parameter with central
+// finite differences over the rows of x
+type GradientCheck struct {
+	Param       int
+	MaxRelError float64 // Largest relative error over the rows
+	Row         int     // Row attaining MaxRelError
+	Analytic    float64 // Analytic derivative at Row
+	Numeric     float64 // Finite difference derivative at Row
+	Suspect     bool    // Whether MaxRelError exceeds 1e-4
+}
+
+// CheckGradient evaluates model.Gradient at beta for every row of x and compares
+// it with central differences of model.F, returning one check per parameter. The
+// relative error is |analytic - numeric| / max(|analytic|, |numeric|, 1e-8). A
+// wrong gradient does not stop NLRQ from running; it silently steers the
+// optimizer to a wrong solution, so check new models before fitting them.
+func CheckGradient(model NonLinearModel, beta []float64, x [][]float64) ([]GradientCheck, error) {
+	if len(x) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	p := len(beta)
+	checks := make([]GradientCheck, p)
+	for j := range checks {
+		checks[j].Param = j
+	}
+	shifted := append([]float64(nil), beta...)
+	for i, row := range x {
+		grad := model.Gradient(beta, row)
+		if len(grad) != p {
+			return nil, fmt.Errorf("%w: gradient has %d entries for %d parameters at row %d", ErrDimensionMismatch, len(grad), p, i)
+		}
+		for j := range beta {
+			h := math.Cbrt(2.2e-16) * math.Max(1, math.Abs(beta[j]))
+			shifted[j] = beta[j] + h
+			up := model.F(shifted, row)
+			shifted[j] = beta[j] - h
+			down := model.F(shifted, row)
+			shifted[j] = beta[j]
+
+			numeric := (up - down) / (2 * h)
+			rel := math.Abs(grad[j]-numeric) / math.Max(math.Max(math.Abs(grad[j]), math.Abs(numeric)), 1e-8)
+			if math.IsNaN(rel) {
+				rel = math.Inf(1)
+			}
+			if i == 0 || rel > checks[j].MaxRelError {
+				checks[j].MaxRelError = rel
+				checks[j].Row = i
+				checks[j].Analytic = grad[j]
+				checks[j].Numeric = numeric
+			}
+		}
+	}
+	for j := range checks {
+		checks[j].Suspect = checks[j].MaxRelError > gradientTolerance
+	}
+	return checks, nil
+}
@@ -0,0 +1,52 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckGradient(t *testing.T) {
+	f := func(beta []float64, x []float64) float64 {
+		return beta[0] * math.Exp(beta[1]*x[0])
+	}
+	x := [][]float64{{0}, {0.5}, {1}, {2}}
+	beta := []float64{1.5, 0.4}
+
+	good := NonLinearModel{F: f, Gradient: func(beta []float64, x []float64) []float64 {
+		exp := math.Exp(beta[1] * x[0])
+		return []float64{exp, beta[0] * x[0] * exp}
+	}}
+	checks, err := CheckGradient(good, beta, x)
+	if err != nil {
+		t.Fatalf("Failed to check gradient: %v", err)
+	}
+	if len(checks) != 2 {
+		t.Fatalf("Expected 2 checks, got %d", len(checks))
+	}
+	for _, c := range checks {
+		if c.Suspect || c.MaxRelError > 1e-6 {
+			t.Errorf("Expected a correct gradient for parameter %d, got relative error %v", c.Param, c.MaxRelError)
+		}
+	}
+
+	// The second partial derivative misses the factor x
+	bad := NonLinearModel{F: f, Gradient: func(beta []float64, x []float64) []float64 {
+		exp := math.Exp(beta[1] * x[0])
+		return []float64{exp, beta[0] * exp}
+	}}
+	checks, err = CheckGradient(bad, beta, x)
+	if err != nil {
+		t.Fatalf("Failed to check gradient: %v", err)
+	}
+	if checks[0].Suspect {
+		t.Errorf("Expected the first parameter to pass, got relative error %v", checks[0].MaxRelError)
+	}
+	if !checks[1].Suspect || checks[1].Row != 0 {
+		t.Errorf("Expected the second parameter flagged at row 0, got %+v", checks[1])
+	}
+
+	short := NonLinearModel{F: f, Gradient: func(beta []float64, x []float64) []float64 { return []float64{1} }}
+	if _, err := CheckGradient(short, beta, x); err == nil {
+		t.Error("Expected error for a gradient of the wrong length")
+	}
+}
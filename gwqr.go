@@ -0,0 +1,179 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Spatial kernels for geographically weighted quantile regression
+const (
+	KernelGaussian = "gaussian" // exp(-(d/h)^2/2)
+	KernelBisquare = "bisquare" // (1 - (d/h)^2)^2 for d < h, zero beyond
+)
+
+// GWOptions controls RQGeographic
+type GWOptions struct {
+	Kernel     string      // KernelGaussian or KernelBisquare (default KernelGaussian)
+	Bandwidth  float64     // Kernel bandwidth; zero selects it by leave-one-out cross-validation
+	Bandwidths []float64   // Candidates for cross-validation (default deciles 1-9 of the pairwise distances)
+	Targets    [][]float64 // Locations at which to fit (default the observation locations)
+}
+
+// GWFit holds geographically weighted quantile regression coefficients
+type GWFit struct {
+	Tau          float64
+	Kernel       string
+	Bandwidth    float64     // Bandwidth used for the local fits
+	Locations    [][]float64 // Target locations
+	Coefficients [][]float64 // Local coefficients at each target
+	EffectiveN   []float64   // Kish effective sample size of the kernel weights at each target
+	Bandwidths   []float64   // Cross-validated candidates, empty when Bandwidth was given
+	CVLoss       []float64   // Mean leave-one-out check loss of each candidate
+	Meta         Meta        // Reproducibility metadata
+}
+
+// RQGeographic fits, at each target location, a quantile regression whose
+// observations are weighted by a spatial kernel in the Euclidean distance between
+// their coordinates and the target (geographically weighted quantile regression).
+// Without a bandwidth, each candidate is scored by the mean check loss of
+// predicting every observation from a local fit at its location that excludes it.
+func RQGeographic(y []float64, x [][]float64, coords [][]float64, tau float64, opts GWOptions) (*GWFit, error) {
+	start := time.Now()
+	if opts.Kernel == "" {
+		opts.Kernel = KernelGaussian
+	}
+	if opts.Kernel != KernelGaussian && opts.Kernel != KernelBisquare {
+		return nil, fmt.Errorf("unknown kernel %q", opts.Kernel)
+	}
+	if opts.Bandwidth < 0 {
+		return nil, fmt.Errorf("bandwidth must be positive, got %f", opts.Bandwidth)
+	}
+	n := len(y)
+	if len(x) != n || len(coords) != n || n == 0 {
+		return nil, fmt.Errorf("x, coords and y %w", ErrDimensionMismatch)
+	}
+	if opts.Targets == nil {
+		opts.Targets = coords
+	}
+
+	res := &GWFit{Tau: tau, Kernel: opts.Kernel, Bandwidth: opts.Bandwidth, Locations: opts.Targets}
+	if res.Bandwidth == 0 {
+		res.Bandwidths = opts.Bandwidths
+		if len(res.Bandwidths) == 0 {
+			res.Bandwidths = distanceDeciles(coords)
+		}
+		best := math.Inf(1)
+		for _, h := range res.Bandwidths {
+			loss, err := gwCVLoss(y, x, coords, tau, h, opts.Kernel)
+			if err != nil {
+				return nil, err
+			}
+			res.CVLoss = append(res.CVLoss, loss)
+			if loss < best {
+				best, res.Bandwidth = loss, h
+			}
+		}
+		if math.IsInf(best, 1) {
+			return nil, fmt.Errorf("no bandwidth candidate gave a usable fit")
+		}
+	}
+
+	for _, s := range opts.Targets {
+		w := gwWeights(coords, s, res.Bandwidth, opts.Kernel)
+		fit, err := RQWeighted(y, x, w, tau)
+		if err != nil {
+			return nil, fmt.Errorf("local fit at %v failed: %w", s, err)
+		}
+		sum, sumSq := 0.0, 0.0
+		for _, v := range w {
+			sum += v
+			sumSq += v * v
+		}
+		res.Coefficients = append(res.Coefficients, fit.Coefficients)
+		res.EffectiveN = append(res.EffectiveN, sum*sum/sumSq)
+	}
+	res.Meta = newMeta("br", map[string]float64{"bandwidth": res.Bandwidth}, []float64{tau}, n, len(x[0]), start, y, x)
+	return res, nil
+}
+
+// gwWeights returns the kernel weights of every observation for the target s
+func gwWeights(coords [][]float64, s []float64, h float64, kernel string) []float64 {
+	w := make([]float64, len(coords))
+	for i, c := range coords {
+		u := euclidean(c, s) / h
+		if kernel == KernelBisquare {
+			if u < 1 {
+				w[i] = (1 - u*u) * (1 - u*u)
+			}
+		} else {
+			w[i] = math.Exp(-u * u / 2)
+		}
+	}
+	return w
+}
+
+// gwCVLoss returns the mean leave-one-out check loss of bandwidth h, or +Inf when
+// a bisquare kernel leaves an observation with no neighbours
+func gwCVLoss(y []float64, x [][]float64, coords [][]float64, tau, h float64, kernel string) (float64, error) {
+	loss := 0.0
+	for i := range y {
+		w := gwWeights(coords, coords[i], h, kernel)
+		w[i] = 0
+		used := 0
+		for _, v := range w {
+			if v > 0 {
+				used++
+			}
+		}
+		if used <= len(x[0]) {
+			return math.Inf(1), nil
+		}
+		fit, err := RQWeighted(y, x, w, tau)
+		if err != nil {
+			return 0, fmt.Errorf("cross-validation fit with bandwidth %f failed: %w", h, err)
+		}
+		pred := 0.0
+		for j, b := range fit.Coefficients {
+			pred += x[i][j] * b
+		}
+		loss += rho(y[i]-pred, tau)
+	}
+	return loss / float64(len(y)), nil
+}
+
+// distanceDeciles returns deciles 1 to 9 of the pairwise distances
+func distanceDeciles(coords [][]float64) []float64 {
+	var d []float64
+	for i := range coords {
+		for j := i + 1; j < len(coords); j++ {
+			d = append(d, euclidean(coords[i], coords[j]))
+		}
+	}
+	sort.Float64s(d)
+	var out []float64
+	for k := 1; k <= 9; k++ {
+		if q := empiricalQuantile(d, float64(k)/10); q > 0 {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+func euclidean(a, b []float64) float64 {
+	s := 0.0
+	for k := range a {
+		s += (a[k] - b[k]) * (a[k] - b[k])
+	}
+	return math.Sqrt(s)
+}
+
+// Surface returns coefficient j across the target locations
+func (f *GWFit) Surface(j int) []float64 {
+	out := make([]float64, len(f.Coefficients))
+	for k, c := range f.Coefficients {
+		out[k] = c[j]
+	}
+	return out
+}
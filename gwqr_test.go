@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQGeographic(t *testing.T) {
+	// The slope rises from west to east
+	var y []float64
+	var x, coords [][]float64
+	for a := 0; a < 8; a++ {
+		for b := 0; b < 8; b++ {
+			i := len(y)
+			xi := math.Cos(float64(3 * i))
+			slope := 1 + float64(a)/4
+			coords = append(coords, []float64{float64(a), float64(b)})
+			x = append(x, []float64{1, xi})
+			y = append(y, 2+slope*xi+0.1*math.Sin(float64(7*i)))
+		}
+	}
+
+	fit, err := RQGeographic(y, x, coords, 0.5, GWOptions{Bandwidths: []float64{0.5, 1.5, 3, 20}})
+	if err != nil {
+		t.Fatalf("Failed to fit geographically weighted model: %v", err)
+	}
+	if len(fit.CVLoss) != 4 {
+		t.Fatalf("Expected 4 cross-validation losses, got %d", len(fit.CVLoss))
+	}
+	if fit.Bandwidth == 20 {
+		t.Errorf("Expected a local bandwidth, got the global one")
+	}
+
+	surface := fit.Surface(1)
+	if len(surface) != 64 || len(fit.EffectiveN) != 64 {
+		t.Fatalf("Expected 64 local fits, got %d", len(surface))
+	}
+	west, east := surface[8*1+3], surface[8*6+3]
+	if east-west < 0.5 {
+		t.Errorf("Expected slope to rise eastward, got %v to %v", west, east)
+	}
+
+	fixed, err := RQGeographic(y, x, coords, 0.5, GWOptions{Kernel: KernelBisquare, Bandwidth: 3, Targets: [][]float64{{0, 0}}})
+	if err != nil {
+		t.Fatalf("Failed to fit with fixed bandwidth: %v", err)
+	}
+	if len(fixed.Coefficients) != 1 || fixed.CVLoss != nil {
+		t.Errorf("Expected a single fit without cross-validation, got %d", len(fixed.Coefficients))
+	}
+
+	if _, err := RQGeographic(y, x, coords[:3], 0.5, GWOptions{}); err == nil {
+		t.Error("Expected error for mismatched coordinates")
+	}
+}
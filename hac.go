@@ -0,0 +1,89 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// VcovHAC returns a serial-correlation-robust covariance H^-1 Omega H^-1 for fits
+// to time-ordered data. H = X' diag(f) X uses Powell kernel densities and Omega is
+// the Bartlett-weighted long-run variance of the scores x_t (tau - I(r_t < 0)) up
+// to the given lag. A lag <= 0 selects floor(4 (n/100)^(2/9)).
+func (fit *RQFit) VcovHAC(lag int) ([][]float64, error) {
+	if len(fit.X) == 0 || len(fit.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	if fit.N <= fit.P {
+		return nil, fmt.Errorf("need more observations than parameters for inference")
+	}
+	if lag <= 0 {
+		lag = neweyWestLag(fit.N)
+	}
+	if lag >= fit.N {
+		return nil, fmt.Errorf("lag must be below the number of observations, got %d", lag)
+	}
+
+	f, err := fit.kernelDensities()
+	if err != nil {
+		return nil, err
+	}
+	hinv, err := invert(crossprod(fit.X, fit.weighted(f)))
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+
+	return sandwich(hinv, fit.longRunScoreVariance(lag)), nil
+}
+
+// longRunScoreVariance returns sum over |l| <= lag of (1 - |l|/(lag+1)) Gamma_l
+// with Gamma_l = sum_t psi_t psi_{t-l}'
+func (fit *RQFit) longRunScoreVariance(lag int) [][]float64 {
+	p := fit.P
+	psi := make([][]float64, fit.N)
+	resid := fit.ResidualValues()
+	for t, row := range fit.X {
+		s := fit.Tau
+		if resid[t] < 0 {
+			s = fit.Tau - 1
+		}
+		if fit.Weights != nil {
+			s *= fit.Weights[t]
+		}
+		psi[t] = make([]float64, p)
+		for j, v := range row {
+			psi[t][j] = v * s
+		}
+	}
+
+	omega := make([][]float64, p)
+	for a := range omega {
+		omega[a] = make([]float64, p)
+	}
+	for l := 0; l <= lag; l++ {
+		w := 1 - float64(l)/float64(lag+1)
+		for t := l; t < fit.N; t++ {
+			for a := 0; a < p; a++ {
+				for b := 0; b < p; b++ {
+					g := psi[t][a] * psi[t-l][b]
+					if l == 0 {
+						omega[a][b] += g
+					} else {
+						// Gamma_l + Gamma_l'
+						omega[a][b] += w * g
+						omega[b][a] += w * g
+					}
+				}
+			}
+		}
+	}
+	return omega
+}
+
+// neweyWestLag returns the Newey-West (1994) rule-of-thumb truncation lag
+func neweyWestLag(n int) int {
+	lag := int(math.Floor(4 * math.Pow(float64(n)/100, 2.0/9.0)))
+	if lag < 1 {
+		lag = 1
+	}
+	return lag
+}
@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVcovHAC(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	cov, err := fit.Vcov(SEHAC)
+	if err != nil {
+		t.Fatalf("Failed to compute HAC covariance: %v", err)
+	}
+	for j := range cov {
+		if cov[j][j] <= 0 || math.IsNaN(cov[j][j]) {
+			t.Errorf("Expected positive variance for coefficient %d, got %f", j, cov[j][j])
+		}
+		for k := range cov {
+			if math.Abs(cov[j][k]-cov[k][j]) > 1e-12 {
+				t.Errorf("Covariance is not symmetric at (%d, %d)", j, k)
+			}
+		}
+	}
+
+	// The lag-zero long-run variance is the plain score outer product
+	omega := fit.longRunScoreVariance(0)
+	for a := range omega {
+		for b := range omega {
+			want := 0.0
+			for i, row := range fit.X {
+				s := fit.Tau
+				if fit.Residuals[i] < 0 {
+					s = fit.Tau - 1
+				}
+				want += row[a] * row[b] * s * s
+			}
+			if math.Abs(omega[a][b]-want) > 1e-9 {
+				t.Errorf("Omega(%d, %d): got %f, want %f", a, b, omega[a][b], want)
+			}
+		}
+	}
+
+	if lag := neweyWestLag(100); lag != 4 {
+		t.Errorf("Expected lag 4 for n=100, got %d", lag)
+	}
+	if _, err := fit.VcovHAC(fit.N); err == nil {
+		t.Error("Expected error for lag as long as the series")
+	}
+}
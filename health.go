@@ -0,0 +1,108 @@
+package quantreg
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Thresholds beyond which a design is reported as numerically fragile
+const (
+	fragileCondition = 1e8 // Condition number of X
+	fragileSpread    = 1e6 // Ratio of the largest to the smallest column norm
+)
+
+// NumericalHealth describes the conditioning of a design matrix
+type NumericalHealth struct {
+	ConditionNumber float64   // Ratio of the largest to the smallest singular value of X, +Inf when rank deficient
+	ScaleSpread     float64   // Ratio of the largest to the smallest nonzero column norm
+	EffectiveRank   int       // Singular values above max(n, p) eps times the largest
+	SingularValues  []float64 // In decreasing order
+	Fragile         bool      // Whether any measure crosses its warning threshold or the rank is deficient
+}
+
+// designHealth computes the conditioning of x from its singular values
+func designHealth(x [][]float64) (*NumericalHealth, error) {
+	n := len(x)
+	if n == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	p := len(x[0])
+	a := mat.NewDense(n, p, nil)
+	norms := make([]float64, p)
+	for i, row := range x {
+		if len(row) != p {
+			return nil, fmt.Errorf("%w: row %d has %d columns, want %d", ErrDimensionMismatch, i, len(row), p)
+		}
+		for j, v := range row {
+			a.Set(i, j, v)
+			norms[j] += v * v
+		}
+	}
+
+	var svd mat.SVD
+	if !svd.Factorize(a, mat.SVDNone) {
+		return nil, fmt.Errorf("singular value decomposition failed")
+	}
+	sv := svd.Values(nil)
+	h := &NumericalHealth{SingularValues: sv, ScaleSpread: 1}
+
+	tol := float64(max(n, p)) * 2.220446e-16 * sv[0]
+	for _, s := range sv {
+		if s > tol {
+			h.EffectiveRank++
+		}
+	}
+	h.ConditionNumber = math.Inf(1)
+	if h.EffectiveRank == p {
+		h.ConditionNumber = sv[0] / sv[len(sv)-1]
+	}
+
+	lo, hi := math.Inf(1), 0.0
+	for _, s := range norms {
+		if s > 0 {
+			lo, hi = math.Min(lo, math.Sqrt(s)), math.Max(hi, math.Sqrt(s))
+		}
+	}
+	if hi > 0 {
+		h.ScaleSpread = hi / lo
+	}
+	h.Fragile = h.EffectiveRank < p || h.ConditionNumber > fragileCondition || h.ScaleSpread > fragileSpread
+	return h, nil
+}
+
+// NumericalHealth returns the conditioning of the design and logs a warning
+// when it is fragile
+func (fit *RQFit) NumericalHealth() (*NumericalHealth, error) {
+	if len(fit.X) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	h, err := designHealth(fit.X)
+	if err != nil {
+		return nil, err
+	}
+	logHealth(fit.Method, fit.Tau, h)
+	return h, nil
+}
+
+// logHealth warns about a fragile design
+func logHealth(method string, tau float64, h *NumericalHealth) {
+	if h.Fragile {
+		currentLogger().Warn("numerically fragile design", "method", method, "tau", tau,
+			"condition_number", h.ConditionNumber, "scale_spread", h.ScaleSpread, "effective_rank", h.EffectiveRank)
+	}
+}
+
+// checkDesignHealth logs a warning for a fragile design when warnings are
+// enabled, so the decomposition costs nothing with logging off
+func checkDesignHealth(method string, tau float64, x [][]float64) {
+	if !currentLogger().Enabled(context.Background(), slog.LevelWarn) {
+		return
+	}
+	if h, err := designHealth(x); err == nil {
+		logHealth(method, tau, h)
+	}
+}
@@ -0,0 +1,50 @@
+package quantreg
+
+import (
+	"bytes"
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNumericalHealth(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	h, err := fit.NumericalHealth()
+	if err != nil {
+		t.Fatalf("Failed to compute numerical health: %v", err)
+	}
+	if h.EffectiveRank != 2 || h.Fragile || h.ConditionNumber < 1 || h.ConditionNumber > 100 {
+		t.Errorf("Expected a well-conditioned full-rank design, got %+v", h)
+	}
+	if h.SingularValues[0] < h.SingularValues[1] {
+		t.Errorf("Expected decreasing singular values, got %v", h.SingularValues)
+	}
+
+	// Orthogonal columns of norms 1 and 1e7
+	h, err = designHealth([][]float64{{1, 0}, {0, 1e7}})
+	if err != nil {
+		t.Fatalf("Failed to compute numerical health: %v", err)
+	}
+	if math.Abs(h.ScaleSpread-1e7) > 1e-3 || math.Abs(h.ConditionNumber-1e7) > 1e-3 || !h.Fragile {
+		t.Errorf("Expected spread and condition 1e7, got %+v", h)
+	}
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer SetLogger(nil)
+	xs := make([][]float64, len(x))
+	for i, row := range x {
+		xs[i] = []float64{row[0], row[1], 2 * row[1]}
+	}
+	if _, err := RQ(y, xs, 0.5); err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if !strings.Contains(buf.String(), "numerically fragile design") || !strings.Contains(buf.String(), "effective_rank=2") {
+		t.Errorf("Expected a fragility warning with rank 2, got:\n%s", buf.String())
+	}
+}
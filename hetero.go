@@ -0,0 +1,137 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// SlopeVariation reports how one coefficient changes across taus
+type SlopeVariation struct {
+	Term        int       // Coefficient index
+	Estimates   []float64 // Estimates at each tau
+	Range       float64   // Largest minus smallest estimate
+	Statistic   float64   // Wald statistic for equality across taus
+	DF          int       // Degrees of freedom
+	PValue      float64   // Asymptotic p-value
+	ScaleEffect bool      // Whether equality is rejected at the report's significance level
+}
+
+// HeteroskedasticityReport summarizes slope variation across taus. Covariates with
+// slopes that vary over tau act on the scale or shape of the conditional
+// distribution; constant slopes indicate a pure location effect.
+type HeteroskedasticityReport struct {
+	Taus   []float64        // Quantile levels compared
+	Alpha  float64          // Significance level used for classification
+	Slopes []SlopeVariation // One entry per non-constant covariate
+	Joint  SlopeVariation   // Joint test that all slopes are equal across taus (Term is -1)
+}
+
+// SlopeHeterogeneity tests, for every non-constant covariate, the null that its
+// coefficient is the same at all fitted taus using the joint covariance of the
+// quantile process, plus a joint test over all slopes
+func (m *MultiRQFit) SlopeHeterogeneity(alpha float64) (*HeteroskedasticityReport, error) {
+	if len(m.Taus) < 2 {
+		return nil, fmt.Errorf("need at least 2 quantile levels, got %d", len(m.Taus))
+	}
+	if alpha <= 0 || alpha >= 1 {
+		return nil, fmt.Errorf("significance level must be between 0 and 1")
+	}
+
+	cov, err := m.JointVcov()
+	if err != nil {
+		return nil, err
+	}
+
+	x := m.Fits[m.Taus[0]].X
+	var slopes []int
+	for j := 0; j < m.P; j++ {
+		for i := 1; i < len(x); i++ {
+			if x[i][j] != x[0][j] {
+				slopes = append(slopes, j)
+				break
+			}
+		}
+	}
+	if len(slopes) == 0 {
+		return nil, fmt.Errorf("design has no varying covariates")
+	}
+
+	report := &HeteroskedasticityReport{Taus: m.Taus, Alpha: alpha}
+	for _, j := range slopes {
+		sv, err := m.slopeEquality([]int{j}, cov, alpha)
+		if err != nil {
+			return nil, fmt.Errorf("test failed for coefficient %d: %w", j, err)
+		}
+		report.Slopes = append(report.Slopes, *sv)
+	}
+
+	joint, err := m.slopeEquality(slopes, cov, alpha)
+	if err != nil {
+		return nil, fmt.Errorf("joint test failed: %w", err)
+	}
+	joint.Term = -1
+	report.Joint = *joint
+
+	return report, nil
+}
+
+// slopeEquality tests beta_j(tau_k) = beta_j(tau_1) for all k > 1 and all j in terms
+func (m *MultiRQFit) slopeEquality(terms []int, cov [][]float64, alpha float64) (*SlopeVariation, error) {
+	p := m.P
+	K := len(m.Taus)
+
+	// Rows of the contrast matrix R pick differences from the first tau
+	var rows [][]float64
+	for _, j := range terms {
+		for k := 1; k < K; k++ {
+			r := make([]float64, K*p)
+			r[k*p+j] = 1
+			r[j] = -1
+			rows = append(rows, r)
+		}
+	}
+
+	b := make([]float64, K*p)
+	for k, tau := range m.Taus {
+		copy(b[k*p:], m.Fits[tau].Coefficients)
+	}
+
+	rb := matVec(rows, b)
+	rv := matMul(rows, cov)
+	rvr := make([][]float64, len(rows))
+	for a := range rows {
+		rvr[a] = make([]float64, len(rows))
+		for c := range rows {
+			for i, v := range rows[c] {
+				rvr[a][c] += rv[a][i] * v
+			}
+		}
+	}
+	inv, err := invert(rvr)
+	if err != nil {
+		return nil, fmt.Errorf("contrast covariance is singular: %w", err)
+	}
+
+	stat := math.Max(quadForm(inv, rb), 0)
+	sv := &SlopeVariation{
+		Term:      terms[0],
+		Statistic: stat,
+		DF:        len(rows),
+		PValue:    chiSquareSF(stat, len(rows)),
+	}
+	sv.ScaleEffect = sv.PValue < alpha
+
+	if len(terms) == 1 {
+		j := terms[0]
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, tau := range m.Taus {
+			est := m.Fits[tau].Coefficients[j]
+			sv.Estimates = append(sv.Estimates, est)
+			lo = math.Min(lo, est)
+			hi = math.Max(hi, est)
+		}
+		sv.Range = hi - lo
+	}
+
+	return sv, nil
+}
@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSlopeHeterogeneity(t *testing.T) {
+	// x1 shifts location only, x2 scales the error
+	n := 24
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x1 := float64(i%6) / 2
+		x2 := float64(i/6) / 2
+		e := math.Sin(float64(5 * i))
+		x[i] = []float64{1, x1, x2}
+		y[i] = 1 + x1 + (0.2+2*x2)*e
+	}
+
+	fits, err := RQProcess(y, x, []float64{0.2, 0.5, 0.8})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	report, err := fits.SlopeHeterogeneity(0.05)
+	if err != nil {
+		t.Fatalf("Failed to test slope heterogeneity: %v", err)
+	}
+
+	if len(report.Slopes) != 2 {
+		t.Fatalf("Expected 2 slope tests, got %d", len(report.Slopes))
+	}
+	for _, sv := range report.Slopes {
+		if sv.DF != 2 || len(sv.Estimates) != 3 || sv.PValue < 0 || sv.PValue > 1 {
+			t.Errorf("Unexpected slope test: %+v", sv)
+		}
+	}
+	if report.Slopes[0].Term != 1 || report.Slopes[1].Term != 2 {
+		t.Errorf("Expected tests for coefficients 1 and 2, got %d and %d", report.Slopes[0].Term, report.Slopes[1].Term)
+	}
+	if report.Joint.Term != -1 || report.Joint.DF != 4 {
+		t.Errorf("Unexpected joint test: %+v", report.Joint)
+	}
+
+	// Error cases
+	if _, err := fits.SlopeHeterogeneity(0); err == nil {
+		t.Error("Expected error for invalid significance level")
+	}
+	single, _ := RQProcess(y, x, []float64{0.5})
+	if _, err := single.SlopeHeterogeneity(0.05); err == nil {
+		t.Error("Expected error for a single quantile level")
+	}
+}
@@ -0,0 +1,150 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ImportanceOptions controls FeatureImportance
+type ImportanceOptions struct {
+	Columns []int // Columns to permute (default every non-constant column)
+	Repeats int   // Permutations per column (default 5)
+}
+
+// ColumnImportance is the permutation importance of one column
+type ColumnImportance struct {
+	Column      int
+	Rank        int                 // 1 for the most important column
+	Increase    float64             // Mean increase in pinball loss, averaged over taus
+	SD          float64             // Standard deviation of Increase over the repeats
+	TauIncrease map[float64]float64 // Mean increase in pinball loss at each tau
+}
+
+// FeatureImportance measures how much the mean pinball loss of model on (x, y)
+// grows when a column of x is randomly permuted, breaking its link with the
+// outcome while keeping its marginal distribution. The data should be a holdout
+// set; in-sample importances favour overfitted columns. Results are sorted by
+// decreasing importance.
+func FeatureImportance(model Model, x [][]float64, y []float64, opts ImportanceOptions, rng *rand.Rand) ([]ColumnImportance, error) {
+	if len(x) == 0 || len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if opts.Repeats == 0 {
+		opts.Repeats = 5
+	}
+	if opts.Repeats < 1 {
+		return nil, fmt.Errorf("repeats must be positive, got %d", opts.Repeats)
+	}
+	p := len(x[0])
+	if opts.Columns == nil {
+		for j, varies := range penalizedColumns(x) {
+			if varies {
+				opts.Columns = append(opts.Columns, j)
+			}
+		}
+	}
+	for _, j := range opts.Columns {
+		if j < 0 || j >= p {
+			return nil, fmt.Errorf("column index %d out of range [0, %d)", j, p)
+		}
+	}
+
+	base, err := pinballByTau(model, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	rng = randOrDefault(rng)
+	n := len(y)
+	perm := make([][]float64, n)
+	for i, row := range x {
+		perm[i] = append([]float64(nil), row...)
+	}
+	out := make([]ColumnImportance, len(opts.Columns))
+	for c, j := range opts.Columns {
+		imp := ColumnImportance{Column: j, TauIncrease: make(map[float64]float64, len(base))}
+		increases := make([]float64, opts.Repeats)
+		for r := range increases {
+			for i, k := range rng.Perm(n) {
+				perm[i][j] = x[k][j]
+			}
+			loss, err := pinballByTau(model, perm, y)
+			if err != nil {
+				return nil, fmt.Errorf("column %d: %w", j, err)
+			}
+			for tau, l := range loss {
+				d := l - base[tau]
+				imp.TauIncrease[tau] += d / float64(opts.Repeats)
+				increases[r] += d / float64(len(loss))
+			}
+		}
+		for i := range perm {
+			perm[i][j] = x[i][j]
+		}
+		imp.Increase, imp.SD = meanSD(increases)
+		out[c] = imp
+	}
+
+	sort.SliceStable(out, func(a, b int) bool { return out[a].Increase > out[b].Increase })
+	for r := range out {
+		out[r].Rank = r + 1
+	}
+	return out, nil
+}
+
+// pinballByTau returns the mean pinball loss of the model predictions at each tau
+func pinballByTau(model Model, x [][]float64, y []float64) (map[float64]float64, error) {
+	pred, err := model.Predict(x)
+	if err != nil {
+		return nil, err
+	}
+	loss := make(map[float64]float64, len(pred))
+	for tau, q := range pred {
+		if len(q) != len(y) {
+			return nil, fmt.Errorf("%w: %d predictions for tau=%f, %d outcomes", ErrDimensionMismatch, len(q), tau, len(y))
+		}
+		for i, v := range y {
+			loss[tau] += rho(v-q[i], tau) / float64(len(y))
+		}
+	}
+	return loss, nil
+}
+
+// meanSD returns the mean of v and its sample standard deviation, zero for one value
+func meanSD(v []float64) (float64, float64) {
+	mean := 0.0
+	for _, x := range v {
+		mean += x / float64(len(v))
+	}
+	if len(v) < 2 {
+		return mean, 0
+	}
+	ss := 0.0
+	for _, x := range v {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(v)-1))
+}
+
+// SingleQuantile adapts a single-quantile model to the Model interface, keying its
+// predictions by tau
+func SingleQuantile(p Predictor, tau float64) Model {
+	return singleQuantile{p, tau}
+}
+
+// singleQuantile is the Model returned by SingleQuantile
+type singleQuantile struct {
+	p   Predictor
+	tau float64
+}
+
+// Predict returns the predictions keyed by the model's tau
+func (s singleQuantile) Predict(newX [][]float64) (map[float64][]float64, error) {
+	pred, err := s.p.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+	return map[float64][]float64{s.tau: pred}, nil
+}
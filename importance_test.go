@@ -0,0 +1,47 @@
+package quantreg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFeatureImportance(t *testing.T) {
+	y, x := inferenceData()
+	for i := range x {
+		x[i] = append(x[i], float64((3*i)%7))
+	}
+	// Known model: the second column drives the quantiles, the third is ignored
+	m := &MultiRQFit{Fits: make(map[float64]*RQFit), Taus: []float64{0.25, 0.75}, P: 3}
+	for _, tau := range m.Taus {
+		m.Fits[tau] = &RQFit{Tau: tau, P: 3, Coefficients: []float64{1, 0.5, 0}}
+	}
+
+	imp, err := FeatureImportance(m, x, y, ImportanceOptions{}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to compute importance: %v", err)
+	}
+	if len(imp) != 2 {
+		t.Fatalf("Expected the two non-constant columns, got %+v", imp)
+	}
+	if imp[0].Column != 1 || imp[0].Rank != 1 || imp[0].Increase <= 0 {
+		t.Errorf("Expected column 1 to rank first with a positive increase, got %+v", imp[0])
+	}
+	if imp[1].Column != 2 || imp[1].Increase != 0 || imp[1].SD != 0 {
+		t.Errorf("Expected zero importance for the unused column, got %+v", imp[1])
+	}
+	if len(imp[0].TauIncrease) != 2 {
+		t.Errorf("Expected an increase per tau, got %v", imp[0].TauIncrease)
+	}
+
+	single, err := FeatureImportance(SingleQuantile(m.Fits[0.25], 0.25), x, y, ImportanceOptions{Columns: []int{1}, Repeats: 2}, nil)
+	if err != nil {
+		t.Fatalf("Failed to compute single-quantile importance: %v", err)
+	}
+	if len(single) != 1 || len(single[0].TauIncrease) != 1 {
+		t.Errorf("Unexpected single-quantile importance %+v", single)
+	}
+
+	if _, err := FeatureImportance(m, x, y, ImportanceOptions{Columns: []int{5}}, nil); err == nil {
+		t.Error("Expected error for out-of-range column")
+	}
+}
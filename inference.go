@@ -0,0 +1,283 @@
+package quantreg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Covariance estimators accepted by Vcov, StdErrors and ConfInt
+const (
+	SEIID = "iid" // Koenker-Bassett sandwich assuming iid errors
+	SENID = "nid" // Hendricks-Koenker sandwich with local sparsity estimates
+	SEKer = "ker" // Powell kernel sandwich
+	SEHAC = "hac" // Powell kernel sandwich with a Newey-West long-run score variance
+)
+
+// Vcov returns the estimated covariance matrix of the coefficients. For a fit with
+// observation weights w the sandwich estimators use H = X' diag(w f) X and the
+// score variance tau(1-tau) X' diag(w^2) X.
+func (fit *RQFit) Vcov(se string) ([][]float64, error) {
+	if len(fit.X) == 0 || len(fit.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	if fit.N <= fit.P {
+		return nil, fmt.Errorf("need more observations than parameters for inference")
+	}
+
+	// The phase is labelled for profiles but not recorded in Timings, so that
+	// concurrent inference on one fit does not write to it
+	var cov [][]float64
+	var err error
+	withPhase(PhaseCovariance, func() {
+		switch se {
+		case SEIID:
+			cov, err = fit.vcovIID()
+		case SENID:
+			cov, err = fit.vcovNID()
+		case SEKer:
+			cov, err = fit.vcovKernel()
+		case SEHAC:
+			cov, err = fit.VcovHAC(0)
+		default:
+			err = fmt.Errorf("unknown standard error method %q", se)
+		}
+	})
+	if errors.Is(err, ErrSingularDesign) {
+		currentLogger().Warn("rank-deficient design", "method", fit.Method, "tau", fit.Tau, "se", se, "error", err)
+	}
+	return cov, err
+}
+
+// StdErrors returns the coefficient standard errors
+func (fit *RQFit) StdErrors(se string) ([]float64, error) {
+	cov, err := fit.Vcov(se)
+	if err != nil {
+		return nil, err
+	}
+	stdErr := make([]float64, fit.P)
+	for j := range stdErr {
+		stdErr[j] = math.Sqrt(math.Max(cov[j][j], 0))
+	}
+	return stdErr, nil
+}
+
+// ConfInt returns Wald confidence intervals for the coefficients at the given level
+func (fit *RQFit) ConfInt(se string, level float64) ([]float64, []float64, error) {
+	if level <= 0 || level >= 1 {
+		return nil, nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	stdErr, err := fit.StdErrors(se)
+	if err != nil {
+		return nil, nil, err
+	}
+	z := normQuantile(1 - (1-level)/2)
+	lower := make([]float64, fit.P)
+	upper := make([]float64, fit.P)
+	for j, coef := range fit.Coefficients {
+		lower[j] = coef - z*stdErr[j]
+		upper[j] = coef + z*stdErr[j]
+	}
+	return lower, upper, nil
+}
+
+// vcovIID uses a Siddiqui difference quotient of the residual quantiles for the sparsity
+func (fit *RQFit) vcovIID() ([][]float64, error) {
+	xxinv, err := invert(crossprod(fit.X, fit.weighted(nil)))
+	if err != nil {
+		return nil, fmt.Errorf("design matrix is singular: %w", err)
+	}
+
+	sparsity, err := siddiquiSparsity(fit.ResidualValues(), fit.Tau)
+	if err != nil {
+		return nil, err
+	}
+
+	cov := xxinv
+	if fit.Weights != nil {
+		cov = sandwich(xxinv, crossprod(fit.X, fit.weighted(fit.Weights)))
+	}
+	return scaleMatrix(cov, sparsity*sparsity*fit.Tau*(1-fit.Tau)), nil
+}
+
+// weighted returns w_i v_i with the observation weights of the fit, or v itself
+// for an unweighted fit. A nil v stands for ones, so fit.weighted(nil) is nil or
+// the weights.
+func (fit *RQFit) weighted(v []float64) []float64 {
+	if fit.Weights == nil {
+		return v
+	}
+	out := make([]float64, len(fit.Weights))
+	for i, w := range fit.Weights {
+		out[i] = w
+		if v != nil {
+			out[i] *= v[i]
+		}
+	}
+	return out
+}
+
+// refit fits the model of fit, with its weights if any, at another tau
+func (fit *RQFit) refit(tau float64) (*RQFit, error) {
+	if fit.Weights != nil {
+		return RQWeighted(fit.Y, fit.X, fit.Weights, tau)
+	}
+	return RQ(fit.Y, fit.X, tau)
+}
+
+// siddiquiSparsity estimates 1/f(F^-1(tau)) of the residual distribution by a
+// difference quotient of residual quantiles with the Hall-Sheather bandwidth
+func siddiquiSparsity(residuals []float64, tau float64) (float64, error) {
+	sorted := make([]float64, len(residuals))
+	copy(sorted, residuals)
+	sort.Float64s(sorted)
+
+	h := clampBandwidth(tau, bandwidth(tau, len(residuals), true))
+	sparsity := (empiricalQuantile(sorted, tau+h) - empiricalQuantile(sorted, tau-h)) / (2 * h)
+	if sparsity <= 0 {
+		return 0, fmt.Errorf("non-positive sparsity estimate")
+	}
+	return sparsity, nil
+}
+
+// vcovNID estimates local densities from fits at tau +/- h
+func (fit *RQFit) vcovNID() ([][]float64, error) {
+	h := clampBandwidth(fit.Tau, bandwidth(fit.Tau, fit.N, true))
+
+	hi, err := fit.refit(fit.Tau + h)
+	if err != nil {
+		return nil, err
+	}
+	lo, err := fit.refit(fit.Tau - h)
+	if err != nil {
+		return nil, err
+	}
+
+	eps := math.Pow(2.220446e-16, 2.0/3.0)
+	f := make([]float64, fit.N)
+	for i, row := range fit.X {
+		dyhat := 0.0
+		for j, v := range row {
+			dyhat += v * (hi.Coefficients[j] - lo.Coefficients[j])
+		}
+		f[i] = math.Max(0, 2*h/(dyhat-eps))
+	}
+
+	return fit.densitySandwich(f)
+}
+
+// vcovKernel uses a Powell kernel estimate of the conditional densities
+func (fit *RQFit) vcovKernel() ([][]float64, error) {
+	f, err := fit.kernelDensities()
+	if err != nil {
+		return nil, err
+	}
+	return fit.densitySandwich(f)
+}
+
+// kernelDensities returns Powell kernel estimates of the conditional density of
+// each observation at its fitted quantile
+func (fit *RQFit) kernelDensities() ([]float64, error) {
+	return powellDensities(fit.ResidualValues(), fit.Tau)
+}
+
+// powellDensities returns Gaussian kernel estimates of the residual density at
+// zero for each observation, with a Hall-Sheather bandwidth on the tau scale
+func powellDensities(resid []float64, tau float64) ([]float64, error) {
+	n := len(resid)
+	h := clampBandwidth(tau, bandwidth(tau, n, true))
+
+	stats := computeStats(resid)
+	sorted := make([]float64, n)
+	copy(sorted, resid)
+	sort.Float64s(sorted)
+	iqr := empiricalQuantile(sorted, 0.75) - empiricalQuantile(sorted, 0.25)
+	scale := math.Min(stats.StdDev, iqr/1.34)
+	if scale <= 0 {
+		scale = stats.StdDev
+	}
+	hn := (normQuantile(tau+h) - normQuantile(tau-h)) * scale
+	if hn <= 0 {
+		return nil, fmt.Errorf("degenerate kernel bandwidth")
+	}
+
+	f := make([]float64, n)
+	for i, r := range resid {
+		f[i] = normPDF(r/hn) / hn
+	}
+	return f, nil
+}
+
+// densitySandwich returns tau(1-tau) H^-1 X'X H^-1 with H = X' diag(f) X, or
+// with H = X' diag(w f) X and X' diag(w^2) X for observation weights w
+func (fit *RQFit) densitySandwich(f []float64) ([][]float64, error) {
+	hinv, err := invert(crossprod(fit.X, fit.weighted(f)))
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+	cov := sandwich(hinv, crossprod(fit.X, fit.weighted(fit.Weights)))
+	return scaleMatrix(cov, fit.Tau*(1-fit.Tau)), nil
+}
+
+// bandwidth returns the Hall-Sheather (hs) or Bofinger bandwidth for sparsity estimation
+func bandwidth(tau float64, n int, hs bool) float64 {
+	x0 := normQuantile(tau)
+	f0 := normPDF(x0)
+	nf := float64(n)
+	if hs {
+		alpha := 0.05
+		return math.Pow(nf, -1.0/3.0) * math.Pow(normQuantile(1-alpha/2), 2.0/3.0) *
+			math.Pow(1.5*f0*f0/(2*x0*x0+1), 1.0/3.0)
+	}
+	return math.Pow(nf, -0.2) * math.Pow(4.5*math.Pow(f0, 4)/math.Pow(2*x0*x0+1, 2), 0.2)
+}
+
+// clampBandwidth shrinks h so that tau +/- h stays inside (0, 1)
+func clampBandwidth(tau, h float64) float64 {
+	limit := 0.99 * math.Min(tau, 1-tau)
+	return math.Min(h, limit)
+}
+
+// Vcov returns the sandwich covariance tau(1-tau) H^-1 G'G H^-1 of the NLRQ
+// parameters, where the rows of G are the model gradients at the solution and
+// H = G' diag(f) G uses Powell kernel densities of the residuals, the Hessian of
+// the kernel-smoothed check loss. A ridge penalty adds its Hessian to H.
+func (fit *NLRQFit) Vcov() ([][]float64, error) {
+	if len(fit.X) == 0 || len(fit.Residuals) == 0 {
+		return nil, fmt.Errorf("fit does not carry its data")
+	}
+	if fit.N <= fit.P {
+		return nil, fmt.Errorf("need more observations than parameters for inference")
+	}
+	f, err := powellDensities(fit.Residuals, fit.Tau)
+	if err != nil {
+		return nil, err
+	}
+	g := make([][]float64, fit.N)
+	for i, row := range fit.X {
+		g[i] = fit.Model.Gradient(fit.Coefficients, row)
+	}
+	h := crossprod(g, f)
+	for j := range h {
+		h[j][j] += 2 * fit.Ridge * fit.RidgeWeights[j]
+	}
+	hinv, err := invert(h)
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted gradient matrix is singular: %w", err)
+	}
+	return scaleMatrix(sandwich(hinv, crossprod(g, nil)), fit.Tau*(1-fit.Tau)), nil
+}
+
+// StdErrors returns the parameter standard errors from Vcov
+func (fit *NLRQFit) StdErrors() ([]float64, error) {
+	cov, err := fit.Vcov()
+	if err != nil {
+		return nil, err
+	}
+	stdErr := make([]float64, fit.P)
+	for j := range stdErr {
+		stdErr[j] = math.Sqrt(math.Max(cov[j][j], 0))
+	}
+	return stdErr, nil
+}
@@ -0,0 +1,142 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+// inferenceData returns a small heteroskedastic linear dataset
+func inferenceData() ([]float64, [][]float64) {
+	n := 20
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xi := float64(i) / 5
+		x[i] = []float64{1, xi}
+		y[i] = 1 + 0.5*xi + 0.4*math.Sin(float64(7*i))*(1+0.5*xi)
+	}
+	return y, x
+}
+
+func TestStdErrors(t *testing.T) {
+	y, x := inferenceData()
+
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	for _, se := range []string{SEIID, SEKer} {
+		stdErr, err := fit.StdErrors(se)
+		if err != nil {
+			t.Fatalf("Failed to compute %s standard errors: %v", se, err)
+		}
+		for j, s := range stdErr {
+			if !(s > 0) || math.IsInf(s, 0) {
+				t.Errorf("%s: invalid standard error for coefficient %d: %v", se, j, s)
+			}
+		}
+
+		lower, upper, err := fit.ConfInt(se, 0.9)
+		if err != nil {
+			t.Fatalf("Failed to compute %s confidence intervals: %v", se, err)
+		}
+		for j, coef := range fit.Coefficients {
+			if lower[j] >= coef || upper[j] <= coef {
+				t.Errorf("%s: interval [%f, %f] does not contain estimate %f", se, lower[j], upper[j], coef)
+			}
+		}
+	}
+
+	// Error cases
+	if _, err := fit.Vcov("bogus"); err == nil {
+		t.Error("Expected error for unknown standard error method")
+	}
+
+	if _, _, err := fit.ConfInt(SEIID, 1.5); err == nil {
+		t.Error("Expected error for invalid confidence level")
+	}
+
+	if _, err := (&RQFit{}).Vcov(SEIID); err == nil {
+		t.Error("Expected error for fit without design matrix")
+	}
+}
+
+func TestBandwidth(t *testing.T) {
+	// Hall-Sheather bandwidth shrinks with n and is largest at the median
+	if bandwidth(0.5, 100, true) <= bandwidth(0.5, 1000, true) {
+		t.Error("Expected bandwidth to decrease with sample size")
+	}
+	if bandwidth(0.5, 100, true) <= bandwidth(0.9, 100, true) {
+		t.Error("Expected bandwidth to be largest at the median")
+	}
+	if h := clampBandwidth(0.05, 0.2); 0.05-h <= 0 {
+		t.Errorf("Clamped bandwidth %f leaves the unit interval", h)
+	}
+}
+
+func TestNLRQVcov(t *testing.T) {
+	y, x := inferenceData()
+	linear := NonLinearModel{
+		F: func(beta []float64, x []float64) float64 {
+			return beta[0]*x[0] + beta[1]*x[1]
+		},
+		Gradient: func(beta []float64, x []float64) []float64 {
+			return []float64{x[0], x[1]}
+		},
+	}
+
+	// A linear model fitted by NLRQ has the Powell sandwich of RQ
+	coef := []float64{1.1, 0.45}
+	rq := &RQFit{Coefficients: coef, Tau: 0.5, N: len(y), P: 2, X: x, Y: y}
+	nl := &NLRQFit{Coefficients: coef, Tau: 0.5, N: len(y), P: 2, Model: linear, X: x, RidgeWeights: []float64{1, 1}}
+	for i, row := range x {
+		nl.Residuals = append(nl.Residuals, y[i]-dot(row, coef))
+	}
+	want, err := rq.Vcov(SEKer)
+	if err != nil {
+		t.Fatalf("Failed to compute RQ covariance: %v", err)
+	}
+	got, err := nl.Vcov()
+	if err != nil {
+		t.Fatalf("Failed to compute NLRQ covariance: %v", err)
+	}
+	for j := range want {
+		for k := range want[j] {
+			if math.Abs(got[j][k]-want[j][k]) > 1e-10*math.Max(1, math.Abs(want[j][k])) {
+				t.Errorf("Expected covariance %v at [%d][%d], got %v", want[j][k], j, k, got[j][k])
+			}
+		}
+	}
+
+	exp := NonLinearModel{
+		F: func(beta []float64, x []float64) float64 {
+			return beta[0] * math.Exp(beta[1]*x[1])
+		},
+		Gradient: func(beta []float64, x []float64) []float64 {
+			e := math.Exp(beta[1] * x[1])
+			return []float64{e, beta[0] * x[1] * e}
+		},
+	}
+	fit, err := NLRQ(y, x, exp, []float64{1, 0.1}, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	se, err := fit.StdErrors()
+	if err != nil {
+		t.Fatalf("Failed to compute standard errors: %v", err)
+	}
+	for j, s := range se {
+		if !(s > 0) {
+			t.Errorf("Expected positive standard error for parameter %d, got %v", j, s)
+		}
+	}
+	if rows := fit.Tidy(); rows[1].StdErr != se[1] || math.IsNaN(rows[1].PValue) {
+		t.Errorf("Expected tidy rows to carry the standard errors, got %+v", rows[1])
+	}
+
+	fit.X = nil
+	if _, err := fit.Vcov(); err == nil {
+		t.Error("Expected error for a fit without data")
+	}
+}
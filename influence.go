@@ -0,0 +1,94 @@
+package quantreg
+
+import (
+	"fmt"
+)
+
+// Influence holds per-observation influence measures for a quantile fit
+type Influence struct {
+	Tau      float64     // Quantile level
+	Leverage []float64   // Hat values x_i'(X'X)^-1 x_i
+	DFBeta   [][]float64 // One-step approximation of the coefficient change when observation i is deleted
+	Distance []float64   // Cook-type distance DFBeta_i' V^-1 DFBeta_i / p using the kernel covariance V
+}
+
+// Influence computes leverage and one-step deletion diagnostics. Deleting
+// observation i moves the coefficients by approximately -H^-1 x_i psi(r_i), where
+// psi(u) = tau - I(u < 0) and H = X' diag(f) X uses Powell kernel density estimates.
+func (fit *RQFit) Influence() (*Influence, error) {
+	if len(fit.X) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+
+	xxinv, err := invert(crossprod(fit.X, nil))
+	if err != nil {
+		return nil, fmt.Errorf("design matrix is singular: %w", err)
+	}
+
+	f, err := fit.kernelDensities()
+	if err != nil {
+		return nil, err
+	}
+	hinv, err := invert(crossprod(fit.X, f))
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+
+	cov, err := fit.densitySandwich(f)
+	if err != nil {
+		return nil, err
+	}
+	covinv, err := invert(cov)
+	if err != nil {
+		return nil, fmt.Errorf("covariance matrix is singular: %w", err)
+	}
+
+	inf := &Influence{
+		Tau:      fit.Tau,
+		Leverage: make([]float64, fit.N),
+		DFBeta:   make([][]float64, fit.N),
+		Distance: make([]float64, fit.N),
+	}
+
+	resid := fit.ResidualValues()
+	for i, row := range fit.X {
+		inf.Leverage[i] = quadForm(xxinv, row)
+
+		psi := fit.Tau
+		if resid[i] < 0 {
+			psi = fit.Tau - 1
+		}
+		delta := matVec(hinv, row)
+		for j := range delta {
+			delta[j] *= -psi
+		}
+		inf.DFBeta[i] = delta
+		inf.Distance[i] = quadForm(covinv, delta) / float64(fit.P)
+	}
+
+	return inf, nil
+}
+
+// Influence computes influence diagnostics for every tau
+func (m *MultiRQFit) Influence() (map[float64]*Influence, error) {
+	result := make(map[float64]*Influence)
+	for _, tau := range m.Taus {
+		inf, err := m.Fits[tau].Influence()
+		if err != nil {
+			return nil, fmt.Errorf("influence failed for tau=%f: %w", tau, err)
+		}
+		result[tau] = inf
+	}
+	return result, nil
+}
+
+// quadForm returns v'Av
+func quadForm(a [][]float64, v []float64) float64 {
+	total := 0.0
+	for i, row := range a {
+		for j, aij := range row {
+			total += v[i] * aij * v[j]
+		}
+	}
+	return total
+}
@@ -0,0 +1,63 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInfluence(t *testing.T) {
+	y, x := inferenceData()
+
+	// Move the last observation far out in the design space
+	x[len(x)-1] = []float64{1, 12}
+	y[len(y)-1] = 20
+
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	inf, err := fit.Influence()
+	if err != nil {
+		t.Fatalf("Failed to compute influence: %v", err)
+	}
+
+	if len(inf.Leverage) != fit.N || len(inf.DFBeta) != fit.N || len(inf.Distance) != fit.N {
+		t.Fatalf("Unexpected influence dimensions")
+	}
+
+	// Hat values sum to p and the outlying design point has the largest one
+	sum, maxIdx := 0.0, 0
+	for i, h := range inf.Leverage {
+		sum += h
+		if h > inf.Leverage[maxIdx] {
+			maxIdx = i
+		}
+	}
+	if math.Abs(sum-float64(fit.P)) > 1e-8 {
+		t.Errorf("Expected leverages to sum to %d, got %f", fit.P, sum)
+	}
+	if maxIdx != fit.N-1 {
+		t.Errorf("Expected observation %d to have the largest leverage, got %d", fit.N-1, maxIdx)
+	}
+
+	for i, d := range inf.Distance {
+		if d < 0 || math.IsNaN(d) {
+			t.Errorf("Invalid distance for observation %d: %f", i, d)
+		}
+		if len(inf.DFBeta[i]) != fit.P {
+			t.Errorf("Expected %d coefficient changes, got %d", fit.P, len(inf.DFBeta[i]))
+		}
+	}
+
+	if _, err := (&RQFit{}).Influence(); err == nil {
+		t.Error("Expected error for fit without design matrix")
+	}
+}
+
+func TestQuadForm(t *testing.T) {
+	a := [][]float64{{2, 1}, {1, 3}}
+	if got := quadForm(a, []float64{1, 2}); got != 18 {
+		t.Errorf("quadForm = %f, want 18", got)
+	}
+}
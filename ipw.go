@@ -0,0 +1,223 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// IPWFit is a quantile regression on complete cases weighted by inverse
+// probabilities of being observed
+type IPWFit struct {
+	*RQFit
+	Observed      []bool      // Complete-case indicator for every observation
+	Probabilities []float64   // Probability of being observed for every observation
+	Gamma         []float64   // Logistic coefficients, nil when probabilities were supplied
+	Z             [][]float64 // Missingness model design, nil when probabilities were supplied
+}
+
+// RQIPW fits a quantile regression with covariates missing at random. Complete
+// cases are weighted by 1/pi_i where pi_i is supplied in probs or, when probs is
+// nil, estimated by a logistic regression of the complete-case indicator on z
+// (which must be fully observed and should include an intercept). Rows of x for
+// incomplete cases are ignored.
+func RQIPW(y []float64, x [][]float64, observed []bool, z [][]float64, probs []float64, tau float64) (*IPWFit, error) {
+	n := len(y)
+	if len(x) != n || len(observed) != n {
+		return nil, fmt.Errorf("%w: y has %d rows, x %d, observed %d", ErrDimensionMismatch, n, len(x), len(observed))
+	}
+
+	res := &IPWFit{Observed: observed}
+	if probs == nil {
+		if len(z) != n {
+			return nil, fmt.Errorf("%w: z has %d rows, want %d", ErrDimensionMismatch, len(z), n)
+		}
+		r := make([]float64, n)
+		for i, o := range observed {
+			if o {
+				r[i] = 1
+			}
+		}
+		gamma, p, err := logisticRegression(z, r)
+		if err != nil {
+			return nil, fmt.Errorf("missingness model failed: %w", err)
+		}
+		res.Gamma, res.Z, probs = gamma, z, p
+	} else if len(probs) != n {
+		return nil, fmt.Errorf("%w: %d probabilities for %d observations", ErrDimensionMismatch, len(probs), n)
+	}
+	res.Probabilities = probs
+
+	var cy []float64
+	var cx [][]float64
+	var cw []float64
+	for i := 0; i < n; i++ {
+		if !observed[i] {
+			continue
+		}
+		if probs[i] <= 0 || probs[i] > 1 {
+			return nil, fmt.Errorf("observation probability must be in (0, 1], got %f", probs[i])
+		}
+		cy = append(cy, y[i])
+		cx = append(cx, x[i])
+		cw = append(cw, 1/probs[i])
+	}
+	if len(cy) == 0 {
+		return nil, fmt.Errorf("no complete cases")
+	}
+
+	fit, err := RQWeighted(cy, cx, cw, tau)
+	if err != nil {
+		return nil, err
+	}
+	res.RQFit = fit
+	return res, nil
+}
+
+// Vcov returns the IPW sandwich H^-1 Omega H^-1 with H = sum w_i f_i x_i x_i' over
+// complete cases. Omega is the outer product of the weighted scores
+// R_i psi_i / pi_i; when the probabilities were estimated, the scores are first
+// residualized on the logistic scores, which accounts for estimating pi.
+func (f *IPWFit) Vcov() ([][]float64, error) {
+	dens, err := f.kernelDensities()
+	if err != nil {
+		return nil, err
+	}
+	wf := make([]float64, f.N)
+	for i := range wf {
+		wf[i] = f.Weights[i] * dens[i]
+	}
+	hinv, err := invert(crossprod(f.X, wf))
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+
+	// Weighted scores on the full sample; incomplete cases contribute zero
+	p := f.P
+	n := len(f.Observed)
+	s := make([][]float64, n)
+	c := 0
+	resid := f.ResidualValues()
+	for i := 0; i < n; i++ {
+		s[i] = make([]float64, p)
+		if !f.Observed[i] {
+			continue
+		}
+		sc := f.Tau
+		if resid[c] < 0 {
+			sc = f.Tau - 1
+		}
+		for j, v := range f.X[c] {
+			s[i][j] = v * sc / f.Probabilities[i]
+		}
+		c++
+	}
+
+	if f.Gamma != nil {
+		// Residualize on logistic scores d_i = z_i (R_i - pi_i)
+		q := len(f.Gamma)
+		d := make([][]float64, n)
+		for i := range d {
+			r := 0.0
+			if f.Observed[i] {
+				r = 1
+			}
+			d[i] = make([]float64, q)
+			for k, v := range f.Z[i] {
+				d[i][k] = v * (r - f.Probabilities[i])
+			}
+		}
+		ddinv, err := invert(crossprod(d, nil))
+		if err != nil {
+			return nil, fmt.Errorf("logistic score matrix is singular: %w", err)
+		}
+		sd := make([][]float64, p)
+		for a := range sd {
+			sd[a] = make([]float64, q)
+			for i := range s {
+				for k := 0; k < q; k++ {
+					sd[a][k] += s[i][a] * d[i][k]
+				}
+			}
+		}
+		proj := matMul(sd, ddinv)
+		for i := range s {
+			adj := matVec(proj, d[i])
+			for j := range s[i] {
+				s[i][j] -= adj[j]
+			}
+		}
+	}
+
+	return sandwich(hinv, crossprod(s, nil)), nil
+}
+
+// StdErrors returns the coefficient standard errors from the IPW sandwich
+func (f *IPWFit) StdErrors() ([]float64, error) {
+	cov, err := f.Vcov()
+	if err != nil {
+		return nil, err
+	}
+	se := make([]float64, f.P)
+	for j := range se {
+		se[j] = math.Sqrt(math.Max(cov[j][j], 0))
+	}
+	return se, nil
+}
+
+// SummaryTable returns the summary of the fit with IPW sandwich standard errors
+func (f *IPWFit) SummaryTable() *SummaryTable {
+	se, _ := f.StdErrors()
+	return f.summaryTable("Inverse Probability Weighted Quantile Regression", se)
+}
+
+// Summary prints a summary of the fit with IPW sandwich standard errors
+func (f *IPWFit) Summary() string {
+	return f.SummaryTable().String()
+}
+
+// logisticRegression fits P(r = 1 | z) by iteratively reweighted least squares and
+// returns the coefficients and fitted probabilities
+func logisticRegression(z [][]float64, r []float64) ([]float64, []float64, error) {
+	n := len(r)
+	if n == 0 {
+		return nil, nil, fmt.Errorf("empty input data")
+	}
+	q := len(z[0])
+	gamma := make([]float64, q)
+	p := make([]float64, n)
+	for iter := 0; iter < 100; iter++ {
+		w := make([]float64, n)
+		grad := make([]float64, q)
+		for i, row := range z {
+			eta := 0.0
+			for k, v := range row {
+				eta += v * gamma[k]
+			}
+			p[i] = 1 / (1 + math.Exp(-eta))
+			w[i] = math.Max(p[i]*(1-p[i]), 1e-10)
+			for k, v := range row {
+				grad[k] += v * (r[i] - p[i])
+			}
+		}
+		step, _, err := weightedSolve(z, w, grad)
+		if err != nil {
+			return nil, nil, fmt.Errorf("information matrix is singular: %w", err)
+		}
+		change := 0.0
+		for k := range gamma {
+			gamma[k] += step[k]
+			change = math.Max(change, math.Abs(step[k]))
+		}
+		if change < 1e-10 {
+			break
+		}
+	}
+	for i, row := range z {
+		eta := 0.0
+		for k, v := range row {
+			eta += v * gamma[k]
+		}
+		p[i] = 1 / (1 + math.Exp(-eta))
+	}
+	return gamma, p, nil
+}
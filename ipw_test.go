@@ -0,0 +1,93 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogisticRegression(t *testing.T) {
+	z := make([][]float64, 40)
+	r := make([]float64, 40)
+	for i := range z {
+		z[i] = []float64{1, float64(i%8) / 4}
+		if (i*7)%10 < 3+i%8 {
+			r[i] = 1
+		}
+	}
+	gamma, p, err := logisticRegression(z, r)
+	if err != nil {
+		t.Fatalf("Failed to fit logistic regression: %v", err)
+	}
+
+	// Score equations hold at the maximum
+	for k := range gamma {
+		score := 0.0
+		for i := range z {
+			score += z[i][k] * (r[i] - p[i])
+		}
+		if math.Abs(score) > 1e-6 {
+			t.Errorf("Score %d is not zero: %f", k, score)
+		}
+	}
+}
+
+func TestRQIPW(t *testing.T) {
+	y, x := inferenceData()
+	n := len(y)
+	observed := make([]bool, n)
+	z := make([][]float64, n)
+	for i := range observed {
+		observed[i] = i%4 != 1 && i != 6
+		z[i] = []float64{1, y[i]}
+	}
+
+	fit, err := RQIPW(y, x, observed, z, nil, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit IPW model: %v", err)
+	}
+	if fit.N != 14 || fit.Gamma == nil || len(fit.Probabilities) != n {
+		t.Errorf("Unexpected fit: n=%d gamma=%v", fit.N, fit.Gamma)
+	}
+	for i, c := range fit.Weights {
+		if c < 1 {
+			t.Errorf("Expected inverse probability weight at least 1, got %f at %d", c, i)
+		}
+	}
+	cov, err := fit.Vcov()
+	if err != nil {
+		t.Fatalf("Failed to compute IPW covariance: %v", err)
+	}
+	for j := range cov {
+		if cov[j][j] <= 0 {
+			t.Errorf("Expected positive variance for coefficient %d, got %f", j, cov[j][j])
+		}
+		if got := fit.SummaryTable().Coefficients[j].StdErr; math.Abs(got-math.Sqrt(cov[j][j])) > 1e-12 {
+			t.Errorf("Expected IPW standard error %f in the summary, got %f", math.Sqrt(cov[j][j]), got)
+		}
+	}
+
+	// Known probabilities skip the missingness model
+	probs := make([]float64, n)
+	for i := range probs {
+		probs[i] = 0.75
+	}
+	known, err := RQIPW(y, x, observed, nil, probs, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit IPW model: %v", err)
+	}
+	if known.Gamma != nil || known.Weights[0] != 1/0.75 {
+		t.Errorf("Expected supplied probabilities to be used")
+	}
+	if _, err := known.Vcov(); err != nil {
+		t.Errorf("Failed to compute IPW covariance: %v", err)
+	}
+
+	// Error cases
+	if _, err := RQIPW(y, x, observed[:3], z, nil, 0.5); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+	probs[0] = 0
+	if _, err := RQIPW(y, x, observed, nil, probs, 0.5); err == nil {
+		t.Error("Expected error for zero probability on a complete case")
+	}
+}
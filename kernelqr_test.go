@@ -0,0 +1,74 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKernelQR(t *testing.T) {
+	n := 150
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		xi := 6 * float64(i) / float64(n)
+		x[i] = []float64{xi}
+		y[i] = math.Sin(xi) + 0.2*math.Sin(float64(17*i))
+	}
+
+	for _, tau := range []float64{0.5, 0.9} {
+		fit, err := KernelQR(y, x, tau, KernelQROptions{Sigma: 1})
+		if err != nil {
+			t.Fatalf("Failed to fit kernel quantile regression: %v", err)
+		}
+		if !fit.Converged {
+			t.Errorf("Expected convergence at tau=%v", tau)
+		}
+		sum := 0.0
+		for _, a := range fit.Alpha {
+			if a < tau-1-1e-12 || a > tau+1e-12 {
+				t.Fatalf("Dual coefficient %v outside [%v, %v]", a, tau-1, tau)
+			}
+			sum += a
+		}
+		if math.Abs(sum) > 1e-9 {
+			t.Errorf("Expected dual coefficients summing to 0, got %v", sum)
+		}
+
+		// Quantile property: about tau of the observations lie at or below the fit
+		below := 0
+		for _, r := range fit.Residuals {
+			if r <= 1e-9 {
+				below++
+			}
+		}
+		if frac := float64(below) / float64(n); math.Abs(frac-tau) > 0.1 {
+			t.Errorf("Expected fraction below near %v, got %v", tau, frac)
+		}
+	}
+
+	fit, err := KernelQR(y, x, 0.5, KernelQROptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit with default options: %v", err)
+	}
+	pred, err := fit.Predict([][]float64{{1.5}, {4.7}})
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	for i, xi := range []float64{1.5, 4.7} {
+		if math.Abs(pred[i]-math.Sin(xi)) > 0.3 {
+			t.Errorf("Expected median near %v at x=%v, got %v", math.Sin(xi), xi, pred[i])
+		}
+	}
+
+	poly, err := KernelQR(y, x, 0.5, KernelQROptions{Kernel: KernelPolynomial, Degree: 3})
+	if err != nil {
+		t.Fatalf("Failed to fit polynomial kernel: %v", err)
+	}
+	if len(poly.Fitted) != n {
+		t.Errorf("Expected %d fitted values, got %d", n, len(poly.Fitted))
+	}
+
+	if _, err := KernelQR(y, x, 0.5, KernelQROptions{Kernel: "linear"}); err == nil {
+		t.Error("Expected error for unknown kernel")
+	}
+}
@@ -0,0 +1,56 @@
+package quantreg
+
+import "math"
+
+// The kernels below operate on row-major flattened designs so the hot loops of
+// the solvers run over contiguous memory with no per-element indirection.
+
+// flatten copies x into a row-major slice of length n*p
+func flatten(x [][]float64) ([]float64, int) {
+	if len(x) == 0 {
+		return nil, 0
+	}
+	p := len(x[0])
+	flat := make([]float64, len(x)*p)
+	for i, row := range x {
+		copy(flat[i*p:(i+1)*p], row)
+	}
+	return flat, p
+}
+
+// residualsInto sets dst[i] = y[i] - x_i'beta for a flattened design with p columns
+func residualsInto(dst, y, x []float64, p int, beta []float64) {
+	beta = beta[:p]
+	for i := range dst {
+		row := x[i*p : i*p+p]
+		pred := 0.0
+		for j, v := range row {
+			pred += v * beta[j]
+		}
+		dst[i] = y[i] - pred
+	}
+}
+
+// checkScores sets dst[i] = tau - I(r[i] < 0), the check loss subgradient in the residual
+func checkScores(dst, r []float64, tau float64) {
+	dst = dst[:len(r)]
+	for i, v := range r {
+		// Sign bit is 1 for negative residuals
+		neg := float64(math.Float64bits(v) >> 63)
+		if v == 0 {
+			neg = 0
+		}
+		dst[i] = tau - neg
+	}
+}
+
+// checkLossSum returns sum rho_tau(r_i) using the branch-free identity
+// rho_tau(u) = |u|/2 + (tau - 1/2) u
+func checkLossSum(r []float64, tau float64) float64 {
+	abs, sum := 0.0, 0.0
+	for _, v := range r {
+		abs += math.Abs(v)
+		sum += v
+	}
+	return abs/2 + (tau-0.5)*sum
+}
@@ -0,0 +1,92 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func kernelData(n, p int) ([]float64, [][]float64, []float64) {
+	rng := rand.New(rand.NewSource(1))
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := range x {
+		x[i] = make([]float64, p)
+		for j := range x[i] {
+			x[i][j] = rng.NormFloat64()
+		}
+		y[i] = rng.NormFloat64()
+	}
+	beta := make([]float64, p)
+	for j := range beta {
+		beta[j] = rng.NormFloat64()
+	}
+	return y, x, beta
+}
+
+func TestKernels(t *testing.T) {
+	y, x, beta := kernelData(50, 4)
+	flat, p := flatten(x)
+
+	r := make([]float64, len(y))
+	residualsInto(r, y, flat, p, beta)
+	for i := range y {
+		want := y[i]
+		for j := range beta {
+			want -= x[i][j] * beta[j]
+		}
+		if math.Abs(r[i]-want) > 1e-12 {
+			t.Fatalf("Residual %d: got %f, want %f", i, r[i], want)
+		}
+	}
+
+	if got, want := checkLossSum(r, 0.3), sumRho(r, 0.3); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Check loss: got %f, want %f", got, want)
+	}
+
+	s := make([]float64, len(r))
+	checkScores(s, r, 0.3)
+	for i := range r {
+		want := 0.3
+		if r[i] < 0 {
+			want = -0.7
+		}
+		if s[i] != want {
+			t.Errorf("Score %d: got %f, want %f", i, s[i], want)
+		}
+	}
+
+	zero := make([]float64, 1)
+	checkScores(zero, []float64{0}, 0.3)
+	if zero[0] != 0.3 {
+		t.Errorf("Expected score tau at a zero residual, got %f", zero[0])
+	}
+}
+
+func BenchmarkResiduals(b *testing.B) {
+	y, x, beta := kernelData(100000, 8)
+	flat, p := flatten(x)
+	r := make([]float64, len(y))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		residualsInto(r, y, flat, p, beta)
+	}
+}
+
+func BenchmarkCheckLoss(b *testing.B) {
+	r, _, _ := kernelData(100000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		checkLossSum(r, 0.3)
+	}
+}
+
+func BenchmarkRQ(b *testing.B) {
+	y, x, _ := kernelData(1000, 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := RQ(y, x, 0.5); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSelectKnots(t *testing.T) {
+	// Flat, then a sharp rise after z = 0.7
+	n := 60
+	y := make([]float64, n)
+	z := make([]float64, n)
+	for i := 0; i < n; i++ {
+		z[i] = float64(i) / float64(n-1)
+		y[i] = 10*math.Pow(math.Max(z[i]-0.7, 0), 2) + 0.05*math.Sin(float64(13*i))
+	}
+
+	sel, err := SelectKnots(y, nil, z, 0.5, KnotOptions{Candidates: 9, MaxKnots: 3})
+	if err != nil {
+		t.Fatalf("Failed to select knots: %v", err)
+	}
+	if len(sel.SIC) != len(sel.Path)+1 {
+		t.Fatalf("Expected %d criterion values, got %d", len(sel.Path)+1, len(sel.SIC))
+	}
+	for k := 1; k < len(sel.SIC); k++ {
+		if sel.SIC[k] >= sel.SIC[k-1] {
+			t.Errorf("Expected the criterion to fall at step %d, got %v", k, sel.SIC)
+		}
+	}
+	if len(sel.Interior) == 0 || len(sel.Interior) > 3 {
+		t.Fatalf("Expected 1 to 3 knots, got %v", sel.Interior)
+	}
+
+	fit, err := RQPartiallyLinear(y, constantColumn(n), z, 0.5, PartiallyLinearOptions{Interior: sel.Interior})
+	if err != nil {
+		t.Fatalf("Failed to fit with selected knots: %v", err)
+	}
+	if len(fit.Knots) != len(sel.Interior)+8 {
+		t.Errorf("Expected %d knots, got %d", len(sel.Interior)+8, len(fit.Knots))
+	}
+
+	if _, err := RQPartiallyLinear(y, constantColumn(n), z, 0.5, PartiallyLinearOptions{Interior: []float64{2}}); err == nil {
+		t.Error("Expected error for a knot outside the range")
+	}
+}
+
+func constantColumn(n int) [][]float64 {
+	x := make([][]float64, n)
+	for i := range x {
+		x[i] = []float64{1}
+	}
+	return x
+}
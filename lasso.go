@@ -0,0 +1,160 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// LassoFit is an L1-penalized quantile regression. Its BasicObs lists only the
+// observations of y in the solution basis; the rest of the basis lies in the
+// penalty rows of the coefficients at zero.
+type LassoFit struct {
+	*RQFit
+	Lambda    float64 // Penalty weight on the sum of absolute coefficients
+	Penalized []bool  // Which coefficients carry the penalty
+}
+
+// RQLasso minimizes sum rho_tau(y_i - x_i'b) + lambda sum_j |b_j| over the
+// penalized coefficients. Constant columns such as the intercept are left
+// unpenalized. The penalty is imposed exactly by augmenting the data with the
+// pseudo-observations (0, +lambda e_j) and (0, -lambda e_j), whose check losses
+// sum to lambda |b_j| at any tau.
+func RQLasso(y []float64, x [][]float64, tau, lambda float64) (*LassoFit, error) {
+	return lassoFrom(y, x, tau, lambda, nil)
+}
+
+// lassoFrom fits like RQLasso with the solver started at beta0
+func lassoFrom(y []float64, x [][]float64, tau, lambda float64, beta0 []float64) (*LassoFit, error) {
+	if lambda < 0 {
+		return nil, fmt.Errorf("lambda must be non-negative, got %f", lambda)
+	}
+	if len(x) == 0 || len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	p := len(x[0])
+	penalized := penalizedColumns(x)
+
+	ya := append([]float64(nil), y...)
+	xa := append([][]float64(nil), x...)
+	if lambda > 0 {
+		for j, pen := range penalized {
+			if !pen {
+				continue
+			}
+			for _, sign := range []float64{1, -1} {
+				row := make([]float64, p)
+				row[j] = sign * lambda
+				xa = append(xa, row)
+				ya = append(ya, 0)
+			}
+		}
+	}
+
+	start := time.Now()
+	fit, options, err := rqSolve(ya, xa, tau, MethodBR, beta0)
+	if err != nil {
+		return nil, err
+	}
+	n := len(y)
+	fit.N = n
+	fit.X = x
+	fit.Y = y
+	fit.Method = "lasso"
+	fit.setFitted(y, x, false)
+	// A basic pseudo-observation pins its coefficient at zero and is not an
+	// observation of y, so BasicObs may hold fewer than P entries
+	basic := fit.BasicObs[:0]
+	for _, i := range fit.BasicObs {
+		if i < n {
+			basic = append(basic, i)
+		}
+	}
+	fit.BasicObs = basic
+	options["lambda"] = lambda
+	fit.Meta = newMeta(fit.Method, options, []float64{tau}, n, p, start, y, x)
+	logFit(fit.Method, tau, n, p, fit.Iterations, fit.Converged, fit.Meta)
+	currentMetrics().ObserveFit(fit.Method, time.Since(start), fit.Iterations, fit.Converged)
+
+	return &LassoFit{RQFit: fit, Lambda: lambda, Penalized: penalized}, nil
+}
+
+// penalizedColumns reports which columns of x vary and so carry the lasso penalty
+func penalizedColumns(x [][]float64) []bool {
+	penalized := make([]bool, len(x[0]))
+	for j := range penalized {
+		for i := 1; i < len(x); i++ {
+			if x[i][j] != x[0][j] {
+				penalized[j] = true
+				break
+			}
+		}
+	}
+	return penalized
+}
+
+// Active returns the indices of penalized coefficients with absolute value above tol
+func (f *LassoFit) Active(tol float64) []int {
+	var active []int
+	for j, b := range f.Coefficients {
+		if f.Penalized[j] && math.Abs(b) > tol {
+			active = append(active, j)
+		}
+	}
+	return active
+}
+
+// LassoPath is a lasso fitted over a sequence of penalties, each fit started
+// from the solution at the previous, larger penalty
+type LassoPath struct {
+	Tau     float64
+	Lambdas []float64       // Penalties in decreasing order
+	Fits    []*LassoFit     // Fit at each lambda
+	Active  [][]int         // Active penalized coefficients at each lambda
+	Timings []time.Duration // Wall time of the fit at each lambda
+	Meta    Meta            // Reproducibility metadata
+}
+
+// activeTolerance is the magnitude below which a penalized coefficient counts as zero in LassoPath.Active
+const activeTolerance = 1e-6
+
+// RQLassoPathFit fits the lasso at each lambda in decreasing order with warm
+// starts: the solver for each penalty begins at the previous solution, so
+// coefficients outside the previous active set start at zero and the solution
+// moves only as far as the smaller penalty requires.
+func RQLassoPathFit(y []float64, x [][]float64, tau float64, lambdas []float64) (*LassoPath, error) {
+	if len(lambdas) == 0 {
+		return nil, fmt.Errorf("no penalty values specified")
+	}
+	start := time.Now()
+	sorted := append([]float64(nil), lambdas...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	path := &LassoPath{Tau: tau, Lambdas: sorted}
+	var beta0 []float64
+	for _, lambda := range sorted {
+		began := time.Now()
+		fit, err := lassoFrom(y, x, tau, lambda, beta0)
+		if err != nil {
+			return nil, fmt.Errorf("lasso fit at lambda=%f failed: %w", lambda, err)
+		}
+		path.Fits = append(path.Fits, fit)
+		path.Active = append(path.Active, fit.Active(activeTolerance))
+		path.Timings = append(path.Timings, time.Since(began))
+		beta0 = fit.Coefficients
+	}
+	first := path.Fits[0]
+	path.Meta = newMeta("lasso", nil, []float64{tau}, first.N, first.P, start, y, x)
+	return path, nil
+}
+
+// RQLassoPath fits the lasso at each lambda with warm starts, returned in
+// decreasing order of lambda
+func RQLassoPath(y []float64, x [][]float64, tau float64, lambdas []float64) ([]*LassoFit, error) {
+	path, err := RQLassoPathFit(y, x, tau, lambdas)
+	if err != nil {
+		return nil, err
+	}
+	return path.Fits, nil
+}
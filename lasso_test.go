@@ -0,0 +1,106 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQLasso(t *testing.T) {
+	y, x := inferenceData()
+
+	// Zero penalty is the plain fit
+	fit, err := RQLasso(y, x, 0.5, 0)
+	if err != nil {
+		t.Fatalf("Failed to fit lasso: %v", err)
+	}
+	plain, _ := RQ(y, x, 0.5)
+	for j := range fit.Coefficients {
+		if math.Abs(fit.Coefficients[j]-plain.Coefficients[j]) > 1e-12 {
+			t.Errorf("Coefficient %d: got %f, want %f", j, fit.Coefficients[j], plain.Coefficients[j])
+		}
+	}
+	if fit.Penalized[0] || !fit.Penalized[1] {
+		t.Errorf("Expected only the slope to be penalized, got %v", fit.Penalized)
+	}
+
+	fit, err = RQLasso(y, x, 0.5, 5)
+	if err != nil {
+		t.Fatalf("Failed to fit lasso: %v", err)
+	}
+	if fit.N != len(y) || len(fit.Residuals) != len(y) || fit.Method != "lasso" {
+		t.Errorf("Expected fit on the original %d observations, got %d", len(y), fit.N)
+	}
+	for i := range y {
+		if math.Abs(fit.Residuals[i]-(y[i]-fit.Fitted[i])) > 1e-9 {
+			t.Fatalf("Residual %d does not match the original response", i)
+		}
+	}
+	if fit.Meta.Solver != "lasso" || fit.Meta.N != len(y) || fit.Meta.Options["lambda"] != 5 {
+		t.Errorf("Expected lasso metadata on %d observations, got %+v", len(y), fit.Meta)
+	}
+	if fit.Meta.DataHash != "" && fit.Meta.DataHash != DataFingerprint(y, x) {
+		t.Error("Expected the data fingerprint of the original observations")
+	}
+
+	// A penalty large enough to zero the slope puts a penalty row in the basis
+	fit, err = RQLasso(y, x, 0.5, 100)
+	if err != nil {
+		t.Fatalf("Failed to fit lasso: %v", err)
+	}
+	if math.Abs(fit.Coefficients[1]) > 1e-12 || len(fit.BasicObs) != 1 {
+		t.Errorf("Expected a zero slope and one basic observation, got %v and %v", fit.Coefficients[1], fit.BasicObs)
+	}
+	for _, i := range fit.BasicObs {
+		if i >= len(y) {
+			t.Errorf("Expected basic observations of y, got pseudo-observation %d", i)
+		}
+	}
+
+	if _, err := RQLasso(y, x, 0.5, -1); err == nil {
+		t.Error("Expected error for negative lambda")
+	}
+}
+
+func TestRQLassoPath(t *testing.T) {
+	y, x := inferenceData()
+	path, err := RQLassoPath(y, x, 0.5, []float64{0.1, 10, 1})
+	if err != nil {
+		t.Fatalf("Failed to fit lasso path: %v", err)
+	}
+	if len(path) != 3 || path[0].Lambda != 10 || path[2].Lambda != 0.1 {
+		t.Errorf("Expected path in decreasing lambda order")
+	}
+	if _, err := RQLassoPath(y, x, 0.5, nil); err == nil {
+		t.Error("Expected error for empty lambda grid")
+	}
+}
+
+func TestRQLassoPathFit(t *testing.T) {
+	y, x := inferenceData()
+	path, err := RQLassoPathFit(y, x, 0.5, []float64{0.1, 10, 1})
+	if err != nil {
+		t.Fatalf("Failed to fit lasso path: %v", err)
+	}
+	if len(path.Fits) != 3 || len(path.Active) != 3 || len(path.Timings) != 3 || path.Lambdas[0] != 10 {
+		t.Fatalf("Unexpected path %+v", path)
+	}
+	for k, d := range path.Timings {
+		if d <= 0 {
+			t.Errorf("Expected positive timing at lambda=%f, got %v", path.Lambdas[k], d)
+		}
+	}
+
+	// The warm-started fit must be at least as good as a cold solve
+	objective := func(f *LassoFit) float64 {
+		return f.Rho() + f.Lambda*math.Abs(f.Coefficients[1])
+	}
+	for k, warm := range path.Fits {
+		cold, err := RQLasso(y, x, 0.5, warm.Lambda)
+		if err != nil {
+			t.Fatalf("Failed to fit lasso: %v", err)
+		}
+		if objective(warm) > objective(cold)+1e-6 {
+			t.Errorf("Expected warm start at step %d to reach objective <= %f, got %f", k, objective(cold), objective(warm))
+		}
+	}
+}
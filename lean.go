@@ -0,0 +1,61 @@
+package quantreg
+
+// setFitted stores the fitted values and residuals of the coefficients on (y, x),
+// or clears them for a lean fit. Lean fits, requested through the Lean field of
+// RQOptions, QuickOptions or MMOptions, do not store Fitted and Residuals;
+// FittedValues and ResidualValues recompute them from the design on demand,
+// trading time for memory when n is large.
+func (fit *RQFit) setFitted(y []float64, x [][]float64, lean bool) {
+	if lean {
+		fit.Fitted, fit.Residuals = nil, nil
+		return
+	}
+	fit.Fitted = make([]float64, len(y))
+	fit.Residuals = make([]float64, len(y))
+	for i := range y {
+		fit.Fitted[i] = dot(x[i], fit.Coefficients)
+		fit.Residuals[i] = y[i] - fit.Fitted[i]
+	}
+}
+
+// FittedValues returns the fitted values, recomputing them from the design when
+// they are not stored. It returns nil for a compacted fit.
+func (fit *RQFit) FittedValues() []float64 {
+	if fit.Fitted != nil || len(fit.X) == 0 {
+		return fit.Fitted
+	}
+	fitted := make([]float64, len(fit.X))
+	for i, row := range fit.X {
+		fitted[i] = dot(row, fit.Coefficients)
+	}
+	return fitted
+}
+
+// ResidualValues returns the residuals, recomputing them from the data when they
+// are not stored. It returns nil for a compacted fit.
+func (fit *RQFit) ResidualValues() []float64 {
+	if fit.Residuals != nil || len(fit.X) == 0 || len(fit.Y) == 0 {
+		return fit.Residuals
+	}
+	resid := fit.FittedValues()
+	for i, y := range fit.Y {
+		resid[i] = y - resid[i]
+	}
+	return resid
+}
+
+// Compact drops the data, fitted values, residuals and bootstrap draws so that
+// the fit serializes to little more than its coefficients and metadata. Predict
+// still works; inference and diagnostics that need the data return errors.
+func (fit *RQFit) Compact() {
+	fit.X, fit.Y, fit.Weights = nil, nil, nil
+	fit.Fitted, fit.Residuals = nil, nil
+	fit.Draws = nil
+}
+
+// Compact compacts the fit at every tau
+func (m *MultiRQFit) Compact() {
+	for _, fit := range m.Fits {
+		fit.Compact()
+	}
+}
@@ -0,0 +1,100 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestLeanFits(t *testing.T) {
+	y, x := inferenceData()
+	full, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	fullSE, err := full.StdErrors(SEKer)
+	if err != nil {
+		t.Fatalf("Failed to compute standard errors: %v", err)
+	}
+
+	lean, err := RQWithOptions(y, x, 0.5, RQOptions{Lean: true})
+	if err != nil {
+		t.Fatalf("Failed to fit lean model: %v", err)
+	}
+	if lean.Fitted != nil || lean.Residuals != nil {
+		t.Fatal("Expected a lean fit to store no fitted values or residuals")
+	}
+	fitted, resid := lean.FittedValues(), lean.ResidualValues()
+	for i := range y {
+		if math.Abs(fitted[i]-full.Fitted[i]) > 1e-12 || math.Abs(resid[i]-full.Residuals[i]) > 1e-12 {
+			t.Fatalf("Expected recomputed values at %d to match, got %f, %f", i, fitted[i], resid[i])
+		}
+	}
+	leanSE, err := lean.StdErrors(SEKer)
+	if err != nil {
+		t.Fatalf("Failed to compute lean standard errors: %v", err)
+	}
+	for j := range fullSE {
+		if math.Abs(leanSE[j]-fullSE[j]) > 1e-12 {
+			t.Errorf("Expected standard error %f, got %f", fullSE[j], leanSE[j])
+		}
+	}
+
+	// Lean is per fit: other fits keep their fitted values and residuals
+	if again, err := RQ(y, x, 0.5); err != nil || again.Fitted == nil || again.Residuals == nil {
+		t.Errorf("Expected a fit without Lean to store its values, got %v", err)
+	}
+
+	// The interior point method takes its basis from recomputed residuals
+	fn, err := RQWithOptions(y, x, 0.5, RQOptions{Method: MethodFN, Lean: true})
+	if err != nil {
+		t.Fatalf("Failed to fit lean interior point model: %v", err)
+	}
+	if fn.Fitted != nil || len(fn.BasicObs) != fn.P {
+		t.Errorf("Expected a lean fit with %d basic observations, got %v", fn.P, fn.BasicObs)
+	}
+	quick, err := RQQuick(y, x, 0.5, QuickOptions{Lean: true})
+	if err != nil || quick.Residuals != nil {
+		t.Errorf("Expected a lean quick fit, got %v", err)
+	}
+	mm, err := RQMM(y, x, 0.5, MMOptions{Lean: true})
+	if err != nil || mm.Residuals != nil {
+		t.Errorf("Expected a lean MM fit, got %v", err)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	y, x := inferenceData()
+	multi, err := RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	before, _ := json.Marshal(multi)
+	want, _ := multi.Predict([][]float64{{1, 2}})
+
+	multi.Compact()
+	after, err := json.Marshal(multi)
+	if err != nil {
+		t.Fatalf("Failed to marshal compact fit: %v", err)
+	}
+	if len(after) >= len(before)/2 {
+		t.Errorf("Expected compaction to shrink the artifact, got %d from %d bytes", len(after), len(before))
+	}
+	got, err := multi.Predict([][]float64{{1, 2}})
+	if err != nil {
+		t.Fatalf("Failed to predict from compact fit: %v", err)
+	}
+	for tau, p := range want {
+		if got[tau][0] != p[0] {
+			t.Errorf("Expected prediction %f at tau=%f, got %f", p[0], tau, got[tau][0])
+		}
+	}
+	fit := multi.Fits[0.5]
+	if _, err := fit.Vcov(SEKer); err == nil {
+		t.Error("Expected error for inference on a compact fit")
+	}
+	if fit.ResidualValues() != nil {
+		t.Error("Expected no residuals for a compact fit")
+	}
+	_ = fit.Summary()
+}
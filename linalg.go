@@ -0,0 +1,159 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// crossprod returns X'WX for a design x and optional row weights w
+func crossprod(x [][]float64, w []float64) [][]float64 {
+	p := len(x[0])
+	out := make([][]float64, p)
+	for j := range out {
+		out[j] = make([]float64, p)
+	}
+	for i, row := range x {
+		wi := 1.0
+		if w != nil {
+			wi = w[i]
+		}
+		for j := 0; j < p; j++ {
+			v := wi * row[j]
+			for k := j; k < p; k++ {
+				out[j][k] += v * row[k]
+			}
+		}
+	}
+	for j := 0; j < p; j++ {
+		for k := 0; k < j; k++ {
+			out[j][k] = out[k][j]
+		}
+	}
+	return out
+}
+
+// matMul returns the product of a and b
+func matMul(a, b [][]float64) [][]float64 {
+	out := make([][]float64, len(a))
+	for i := range a {
+		out[i] = make([]float64, len(b[0]))
+		for k, aik := range a[i] {
+			if aik == 0 {
+				continue
+			}
+			for j, bkj := range b[k] {
+				out[i][j] += aik * bkj
+			}
+		}
+	}
+	return out
+}
+
+// matVec returns the product of a and v
+func matVec(a [][]float64, v []float64) []float64 {
+	out := make([]float64, len(a))
+	for i, row := range a {
+		for j, aij := range row {
+			out[i] += aij * v[j]
+		}
+	}
+	return out
+}
+
+// dot returns the inner product of a and b
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i, v := range a {
+		s += v * b[i]
+	}
+	return s
+}
+
+// scaleMatrix multiplies every entry of a by s in place and returns a
+func scaleMatrix(a [][]float64, s float64) [][]float64 {
+	for i := range a {
+		for j := range a[i] {
+			a[i][j] *= s
+		}
+	}
+	return a
+}
+
+// invert returns the inverse of the square matrix a using Gauss-Jordan
+// elimination with partial pivoting
+func invert(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	aug := make([][]float64, n)
+	for i := range a {
+		if len(a[i]) != n {
+			return nil, fmt.Errorf("matrix is not square")
+		}
+		aug[i] = make([]float64, 2*n)
+		copy(aug[i], a[i])
+		aug[i][n+i] = 1
+	}
+
+	// Scale-aware singularity threshold
+	maxAbs := 0.0
+	for i := range a {
+		for _, v := range a[i] {
+			maxAbs = math.Max(maxAbs, math.Abs(v))
+		}
+	}
+	eps := 1e-12 * math.Max(maxAbs, 1e-300)
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(aug[r][col]) > math.Abs(aug[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(aug[pivot][col]) <= eps {
+			return nil, ErrSingularDesign
+		}
+		aug[col], aug[pivot] = aug[pivot], aug[col]
+
+		inv := 1 / aug[col][col]
+		for j := range aug[col] {
+			aug[col][j] *= inv
+		}
+		for r := 0; r < n; r++ {
+			if r == col || aug[r][col] == 0 {
+				continue
+			}
+			f := aug[r][col]
+			for j := range aug[r] {
+				aug[r][j] -= f * aug[col][j]
+			}
+		}
+	}
+
+	out := make([][]float64, n)
+	for i := range aug {
+		out[i] = aug[i][n:]
+	}
+	return out, nil
+}
+
+// sandwich returns A B A for square matrices a and b
+func sandwich(a, b [][]float64) [][]float64 {
+	return matMul(matMul(a, b), a)
+}
+
+// hasIntercept reports whether some column of x is a nonzero constant
+func hasIntercept(x [][]float64) bool {
+	if len(x) == 0 {
+		return false
+	}
+	for j := range x[0] {
+		intercept := x[0][j] != 0
+		for i := 1; i < len(x) && intercept; i++ {
+			intercept = x[i][j] == x[0][j]
+		}
+		if intercept {
+			return true
+		}
+	}
+	return false
+}
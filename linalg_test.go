@@ -0,0 +1,52 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInvert(t *testing.T) {
+	a := [][]float64{
+		{4, 7},
+		{2, 6},
+	}
+
+	inv, err := invert(a)
+	if err != nil {
+		t.Fatalf("Failed to invert matrix: %v", err)
+	}
+
+	prod := matMul(a, inv)
+	for i := range prod {
+		for j := range prod[i] {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(prod[i][j]-want) > 1e-12 {
+				t.Errorf("A*inv(A)[%d][%d] = %f, want %f", i, j, prod[i][j], want)
+			}
+		}
+	}
+
+	if _, err := invert([][]float64{{1, 2}, {2, 4}}); err == nil {
+		t.Error("Expected error for singular matrix")
+	}
+}
+
+func TestCrossprod(t *testing.T) {
+	x := [][]float64{
+		{1, 2},
+		{1, 3},
+	}
+
+	xtx := crossprod(x, nil)
+	if xtx[0][0] != 2 || xtx[0][1] != 5 || xtx[1][0] != 5 || xtx[1][1] != 13 {
+		t.Errorf("Unexpected X'X: %v", xtx)
+	}
+
+	xwx := crossprod(x, []float64{2, 0})
+	if xwx[0][0] != 2 || xwx[0][1] != 4 || xwx[1][1] != 8 {
+		t.Errorf("Unexpected X'WX: %v", xwx)
+	}
+}
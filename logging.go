@@ -0,0 +1,71 @@
+package quantreg
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+var (
+	loggerMu sync.RWMutex
+	logger   = slog.New(discardHandler{})
+)
+
+// SetLogger installs l as the package-wide structured logger. Solvers log
+// iteration summaries and single fits at Debug, quantile processes and
+// bootstraps at Info, and
+// non-convergence, quantile crossings and rank deficiency at Warn; the level is
+// chosen through l's handler. Passing nil disables logging.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(discardHandler{})
+	}
+	loggerMu.Lock()
+	logger = l
+	loggerMu.Unlock()
+}
+
+// currentLogger returns the installed logger
+func currentLogger() *slog.Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return logger
+}
+
+// discardHandler drops all records
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// logFit reports a completed fit, warning when the solver did not converge
+func logFit(method string, tau float64, n, p, iterations int, converged bool, meta Meta) {
+	l := currentLogger()
+	attrs := []any{"method", method, "tau", tau, "n", n, "p", p,
+		"iterations", iterations, "converged", converged, "duration", meta.WallTime}
+	l.Debug("fit complete", attrs...)
+	if !converged {
+		l.Warn("solver did not converge", attrs...)
+	}
+}
+
+// logCrossings warns when adjacent quantile fits cross at the observations
+func logCrossings(taus []float64, fitted [][]float64) {
+	l := currentLogger()
+	if !l.Enabled(context.Background(), slog.LevelWarn) {
+		return
+	}
+	for k := 1; k < len(taus); k++ {
+		count := 0
+		for i := range fitted[k] {
+			if fitted[k][i] < fitted[k-1][i] {
+				count++
+			}
+		}
+		if count > 0 {
+			l.Warn("quantile crossings", "lower_tau", taus[k-1], "upper_tau", taus[k], "observations", count)
+		}
+	}
+}
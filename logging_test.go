@@ -0,0 +1,55 @@
+package quantreg
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	y, x := inferenceData()
+	if _, err := RQProcess(y, x, []float64{0.25, 0.75}); err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	out := buf.String()
+	for _, msg := range []string{"solver iteration", "fit complete", "process complete"} {
+		if !strings.Contains(out, msg) {
+			t.Errorf("Expected %q in log output, got:\n%s", msg, out)
+		}
+	}
+
+	buf.Reset()
+	logCrossings([]float64{0.25, 0.75}, [][]float64{{1, 2, 3}, {2, 1, 2}})
+	if !strings.Contains(buf.String(), "quantile crossings") || !strings.Contains(buf.String(), "observations=2") {
+		t.Errorf("Expected a crossing warning for 2 observations, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	xs := make([][]float64, len(x))
+	for i, row := range x {
+		xs[i] = []float64{row[0], row[1], row[1]}
+	}
+	fit, err := RQ(y, xs, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	fit.Vcov(SEIID)
+	if !strings.Contains(buf.String(), "rank-deficient design") {
+		t.Errorf("Expected a rank deficiency warning, got:\n%s", buf.String())
+	}
+
+	// Info level hides the per-fit records
+	buf.Reset()
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	if _, err := RQ(y, x, 0.5); err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if strings.Contains(buf.String(), "fit complete") || strings.Contains(buf.String(), "solver iteration") {
+		t.Errorf("Expected no debug records at info level, got:\n%s", buf.String())
+	}
+}
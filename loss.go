@@ -0,0 +1,63 @@
+package quantreg
+
+import "fmt"
+
+// CheckLoss returns rho_tau(u_i) = u_i (tau - I(u_i < 0)) for every residual
+func CheckLoss(u []float64, tau float64) []float64 {
+	loss := make([]float64, len(u))
+	for i, v := range u {
+		loss[i] = rho(v, tau)
+	}
+	return loss
+}
+
+// CheckLossSubgradient returns tau - I(u_i < 0) for every residual, the
+// subgradient the solvers use; at u_i = 0 it takes the value tau
+func CheckLossSubgradient(u []float64, tau float64) []float64 {
+	g := make([]float64, len(u))
+	checkScores(g, u, tau)
+	return g
+}
+
+// PinballLoss returns the pinball loss rho_tau(y_i - q_i) of quantile forecasts q
+func PinballLoss(y, q []float64, tau float64) ([]float64, error) {
+	if len(y) != len(q) {
+		return nil, fmt.Errorf("expected %d forecasts, got %d", len(y), len(q))
+	}
+	loss := make([]float64, len(y))
+	for i := range y {
+		loss[i] = rho(y[i]-q[i], tau)
+	}
+	return loss, nil
+}
+
+// PinballSubgradient returns the subgradient of the pinball loss with respect to
+// the forecasts, I(y_i < q_i) - tau, consistent with CheckLossSubgradient
+func PinballSubgradient(y, q []float64, tau float64) ([]float64, error) {
+	if len(y) != len(q) {
+		return nil, fmt.Errorf("expected %d forecasts, got %d", len(y), len(q))
+	}
+	g := make([]float64, len(y))
+	for i := range y {
+		g[i] = -tau
+		if y[i] < q[i] {
+			g[i] = 1 - tau
+		}
+	}
+	return g, nil
+}
+
+// MeanPinballLoss returns the average pinball loss of quantile forecasts q
+func MeanPinballLoss(y, q []float64, tau float64) (float64, error) {
+	if len(y) != len(q) {
+		return 0, fmt.Errorf("expected %d forecasts, got %d", len(y), len(q))
+	}
+	if len(y) == 0 {
+		return 0, fmt.Errorf("empty input data")
+	}
+	r := make([]float64, len(y))
+	for i := range y {
+		r[i] = y[i] - q[i]
+	}
+	return checkLossSum(r, tau) / float64(len(y)), nil
+}
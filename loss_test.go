@@ -0,0 +1,66 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckLoss(t *testing.T) {
+	u := []float64{-2, 0, 3}
+	loss := CheckLoss(u, 0.25)
+	want := []float64{1.5, 0, 0.75}
+	for i := range want {
+		if math.Abs(loss[i]-want[i]) > 1e-12 {
+			t.Errorf("Expected loss %v at %d, got %v", want[i], i, loss[i])
+		}
+	}
+
+	g := CheckLossSubgradient(u, 0.25)
+	wantG := []float64{-0.75, 0.25, 0.25}
+	for i := range wantG {
+		if g[i] != wantG[i] {
+			t.Errorf("Expected subgradient %v at %d, got %v", wantG[i], i, g[i])
+		}
+	}
+}
+
+func TestPinballLoss(t *testing.T) {
+	y := []float64{1, 2, 3}
+	q := []float64{2, 2, 1}
+	loss, err := PinballLoss(y, q, 0.9)
+	if err != nil {
+		t.Fatalf("Failed to compute pinball loss: %v", err)
+	}
+	want := []float64{0.1, 0, 1.8}
+	total := 0.0
+	for i := range want {
+		total += loss[i]
+		if math.Abs(loss[i]-want[i]) > 1e-12 {
+			t.Errorf("Expected loss %v at %d, got %v", want[i], i, loss[i])
+		}
+	}
+
+	mean, err := MeanPinballLoss(y, q, 0.9)
+	if err != nil {
+		t.Fatalf("Failed to compute mean pinball loss: %v", err)
+	}
+	if math.Abs(mean-total/3) > 1e-12 {
+		t.Errorf("Expected mean loss %v, got %v", total/3, mean)
+	}
+
+	// The subgradient in q is the negated residual subgradient
+	g, err := PinballSubgradient(y, q, 0.9)
+	if err != nil {
+		t.Fatalf("Failed to compute pinball subgradient: %v", err)
+	}
+	wantG := []float64{0.1, -0.9, -0.9}
+	for i := range wantG {
+		if math.Abs(g[i]-wantG[i]) > 1e-12 {
+			t.Errorf("Expected subgradient %v at %d, got %v", wantG[i], i, g[i])
+		}
+	}
+
+	if _, err := PinballLoss(y, q[:2], 0.9); err == nil {
+		t.Error("Expected error for mismatched lengths")
+	}
+}
@@ -0,0 +1,166 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LSFit is an ordinary least-squares fit, the conditional mean baseline for
+// quantile regression estimates
+type LSFit struct {
+	Coefficients []float64 // Regression coefficients
+	StdErrors    []float64 // Classical standard errors, the square roots of diag(sigma^2 (X'X)^-1)
+	Residuals    []float64 // Model residuals
+	Fitted       []float64 // Fitted values
+	Sigma        float64   // Residual standard error with n-p degrees of freedom
+	RSquared     float64   // Coefficient of determination, NaN for a constant response
+	N            int       // Number of observations
+	P            int       // Number of parameters
+	Meta         Meta      // Reproducibility metadata
+}
+
+// LS fits y on x by least squares using a QR factorization of the design
+func LS(y []float64, x [][]float64) (*LSFit, error) {
+	if len(y) == 0 || len(x) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	n, p := len(y), len(x[0])
+	if n < p {
+		return nil, fmt.Errorf("%w: %d observations for %d parameters", ErrSingularDesign, n, p)
+	}
+
+	start := time.Now()
+	a := mat.NewDense(n, p, nil)
+	for i, row := range x {
+		if len(row) != p {
+			return nil, fmt.Errorf("%w: row %d has %d columns, expected %d", ErrDimensionMismatch, i, len(row), p)
+		}
+		a.SetRow(i, row)
+	}
+	xxinv, err := invert(crossprod(x, nil))
+	if err != nil {
+		return nil, fmt.Errorf("least squares fit failed: %w", err)
+	}
+	var beta mat.VecDense
+	if err := beta.SolveVec(a, mat.NewVecDense(n, append([]float64(nil), y...))); err != nil {
+		return nil, fmt.Errorf("least squares fit failed: %w", ErrSingularDesign)
+	}
+
+	fit := &LSFit{
+		Coefficients: append([]float64(nil), beta.RawVector().Data...),
+		Fitted:       make([]float64, n),
+		Residuals:    make([]float64, n),
+		N:            n,
+		P:            p,
+	}
+	var rss, mean float64
+	for i, row := range x {
+		fit.Fitted[i] = dot(row, fit.Coefficients)
+		fit.Residuals[i] = y[i] - fit.Fitted[i]
+		rss += fit.Residuals[i] * fit.Residuals[i]
+		mean += y[i] / float64(n)
+	}
+	tss := 0.0
+	for _, v := range y {
+		tss += (v - mean) * (v - mean)
+	}
+	fit.RSquared = math.NaN()
+	if tss > 0 {
+		fit.RSquared = 1 - rss/tss
+	}
+
+	fit.Sigma = math.NaN()
+	fit.StdErrors = make([]float64, p)
+	for j := range fit.StdErrors {
+		fit.StdErrors[j] = math.NaN()
+	}
+	if n > p {
+		fit.Sigma = math.Sqrt(rss / float64(n-p))
+		for j := range fit.StdErrors {
+			fit.StdErrors[j] = fit.Sigma * math.Sqrt(math.Max(xxinv[j][j], 0))
+		}
+	}
+
+	fit.Meta = newMeta("ls", nil, nil, n, p, start, y, x)
+	return fit, nil
+}
+
+// Predict returns the fitted conditional means at newX
+func (fit *LSFit) Predict(newX [][]float64) ([]float64, error) {
+	out := make([]float64, len(newX))
+	for i, row := range newX {
+		if len(row) != fit.P {
+			return nil, fmt.Errorf("%w: expected %d features, got %d", ErrDimensionMismatch, fit.P, len(row))
+		}
+		out[i] = dot(row, fit.Coefficients)
+	}
+	return out, nil
+}
+
+// Tidy returns one row per coefficient with classical inference; Tau is NaN
+// since the fit is of the mean
+func (fit *LSFit) Tidy() []CoefRow {
+	return coefRows(math.NaN(), fit.Coefficients, fit.StdErrors)
+}
+
+// OLSComparison contrasts least-squares and quantile regression estimates
+type OLSComparison struct {
+	Taus     []float64   // Quantile levels
+	OLS      []CoefRow   // Least-squares rows, one per coefficient
+	Quantile [][]CoefRow // Quantile regression rows, indexed by tau then coefficient
+}
+
+// CompareOLS fits least squares to the data of the process and pairs its
+// coefficients with the quantile estimates at every tau
+func (m *MultiRQFit) CompareOLS() (*OLSComparison, error) {
+	if len(m.Taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels fitted")
+	}
+	first := m.Fits[m.Taus[0]]
+	if len(first.X) == 0 || len(first.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	ls, err := LS(first.Y, first.X)
+	if err != nil {
+		return nil, err
+	}
+	c := &OLSComparison{Taus: m.Taus, OLS: ls.Tidy()}
+	for _, tau := range m.Taus {
+		c.Quantile = append(c.Quantile, m.Fits[tau].Tidy())
+	}
+	return c, nil
+}
+
+// Tidy returns the least-squares rows followed by the quantile rows in tau order
+func (c *OLSComparison) Tidy() []CoefRow {
+	rows := append([]CoefRow(nil), c.OLS...)
+	for _, q := range c.Quantile {
+		rows = append(rows, q...)
+	}
+	return rows
+}
+
+// String renders one line per coefficient with the OLS estimate followed by the
+// estimate at each tau, standard errors in parentheses
+func (c *OLSComparison) String() string {
+	result := fmt.Sprintf("%-10s %20s", "Term", "OLS")
+	for _, tau := range c.Taus {
+		result += fmt.Sprintf(" %20s", fmt.Sprintf("tau=%.2f", tau))
+	}
+	result += "\n"
+	cell := func(r CoefRow) string { return fmt.Sprintf("%.4f (%.4f)", r.Estimate, r.StdErr) }
+	for j, row := range c.OLS {
+		result += fmt.Sprintf("%-10s %20s", row.Term, cell(row))
+		for k := range c.Taus {
+			result += fmt.Sprintf(" %20s", cell(c.Quantile[k][j]))
+		}
+		result += "\n"
+	}
+	return result
+}
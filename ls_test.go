@@ -0,0 +1,66 @@
+package quantreg
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLS(t *testing.T) {
+	x := [][]float64{{1, 0}, {1, 1}, {1, 2}, {1, 3}}
+	y := []float64{1, 3.5, 5, 6.5}
+
+	fit, err := LS(y, x)
+	if err != nil {
+		t.Fatalf("Failed to fit least squares: %v", err)
+	}
+	if math.Abs(fit.Coefficients[0]-1.3) > 1e-10 || math.Abs(fit.Coefficients[1]-1.8) > 1e-10 {
+		t.Errorf("Expected coefficients [1.3 1.8], got %v", fit.Coefficients)
+	}
+	// rss = 0.3, sigma^2 = 0.15, (X'X)^-1 slope entry = 1/5
+	if math.Abs(fit.StdErrors[1]-math.Sqrt(0.15/5)) > 1e-10 {
+		t.Errorf("Expected slope standard error %f, got %f", math.Sqrt(0.15/5), fit.StdErrors[1])
+	}
+	if fit.RSquared <= 0.9 || fit.RSquared >= 1 {
+		t.Errorf("Expected R-squared near 1, got %f", fit.RSquared)
+	}
+
+	rows := fit.Tidy()
+	if len(rows) != 2 || !math.IsNaN(rows[0].Tau) || rows[1].Estimate != fit.Coefficients[1] {
+		t.Errorf("Unexpected tidy rows %+v", rows)
+	}
+	pred, err := fit.Predict([][]float64{{1, 4}})
+	if err != nil || math.Abs(pred[0]-8.5) > 1e-10 {
+		t.Errorf("Expected prediction 8.5, got %v (%v)", pred, err)
+	}
+
+	if _, err := LS(y[:3], x); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+	if _, err := LS(y, [][]float64{{1, 1}, {2, 2}, {3, 3}, {4, 4}}); err == nil {
+		t.Error("Expected error for collinear design")
+	}
+}
+
+func TestCompareOLS(t *testing.T) {
+	y, x := inferenceData()
+	m, err := RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+
+	c, err := m.CompareOLS()
+	if err != nil {
+		t.Fatalf("Failed to compare with OLS: %v", err)
+	}
+	if len(c.OLS) != 2 || len(c.Quantile) != 3 || len(c.Tidy()) != 8 {
+		t.Errorf("Unexpected comparison shape %+v", c)
+	}
+	if c.Quantile[1][1].Tau != 0.5 {
+		t.Errorf("Expected tau 0.5, got %f", c.Quantile[1][1].Tau)
+	}
+	out := c.String()
+	if !strings.Contains(out, "OLS") || !strings.Contains(out, "tau=0.75") || strings.Count(out, "\n") != 3 {
+		t.Errorf("Unexpected rendering:\n%s", out)
+	}
+}
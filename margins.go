@@ -0,0 +1,166 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// DesignFunc maps a row of raw covariates to the corresponding design row, for
+// example expanding factor codes into dummies or adding interactions and powers
+type DesignFunc func(raw []float64) ([]float64, error)
+
+// MarginalOptions controls MarginalEffects
+type MarginalOptions struct {
+	Design   DesignFunc        // Raw-to-design mapping (default identity)
+	Discrete map[int][]float64 // Raw columns that are factors, with their levels; the first level is the reference
+	Terms    []int             // Raw columns to report (default all)
+	SE       string            // Covariance estimator (default SEKer)
+	Level    float64           // Confidence level (default 0.95)
+	Step     float64           // Relative step of the numerical derivative (default 1e-6)
+}
+
+// MarginalEffect is the effect of one raw covariate on the conditional quantile
+type MarginalEffect struct {
+	Term     int     // Raw column
+	Level    float64 // Factor level contrasted with the reference, NaN for continuous covariates
+	Estimate float64
+	StdError float64
+	Lower    float64
+	Upper    float64
+}
+
+// MarginalEffects returns the effects of the raw covariates on Q_tau(y|x),
+// averaged over the rows of at: pass the estimation sample for average marginal
+// effects or a single representative row for effects at representative values.
+// Continuous covariates get the derivative of the design row, so powers and
+// interactions contribute through the chain rule; factors get the difference
+// between each level and the reference. Effects are linear in the coefficients,
+// g'b, and their standard errors sqrt(g'Vg) are exact delta-method values.
+func MarginalEffects(fit *RQFit, at [][]float64, opts MarginalOptions) ([]MarginalEffect, error) {
+	terms, err := opts.prepare(at)
+	if err != nil {
+		return nil, err
+	}
+	cov, err := fit.Vcov(opts.SE)
+	if err != nil {
+		return nil, err
+	}
+	z := normQuantile(1 - (1-opts.Level)/2)
+
+	// contrast returns the mean over rows of design(x_j = b) - design(x_j = a),
+	// divided by b - a for a difference quotient
+	contrast := func(j int, a, b func(v float64) float64, quotient bool) ([]float64, error) {
+		g := make([]float64, fit.P)
+		row := make([]float64, len(at[0]))
+		for _, r := range at {
+			if len(r) != len(row) {
+				return nil, fmt.Errorf("expected %d raw columns, got %d", len(row), len(r))
+			}
+			copy(row, r)
+			s := 1.0
+			if quotient {
+				s = b(r[j]) - a(r[j])
+			}
+			row[j] = a(r[j])
+			lo, err := opts.Design(row)
+			if err != nil {
+				return nil, err
+			}
+			lo = append([]float64(nil), lo...)
+			row[j] = b(r[j])
+			hi, err := opts.Design(row)
+			if err != nil {
+				return nil, err
+			}
+			if len(hi) != fit.P || len(lo) != fit.P {
+				return nil, fmt.Errorf("design has %d columns, fit has %d", len(hi), fit.P)
+			}
+			for k := range g {
+				g[k] += (hi[k] - lo[k]) / (s * float64(len(at)))
+			}
+		}
+		return g, nil
+	}
+
+	var effects []MarginalEffect
+	add := func(j int, level float64, g []float64) {
+		est := dot(g, fit.Coefficients)
+		se := math.Sqrt(math.Max(quadForm(cov, g), 0))
+		effects = append(effects, MarginalEffect{
+			Term: j, Level: level, Estimate: est, StdError: se,
+			Lower: est - z*se, Upper: est + z*se,
+		})
+	}
+	for _, j := range terms {
+		if levels, ok := opts.Discrete[j]; ok {
+			if len(levels) < 2 {
+				return nil, fmt.Errorf("factor %d needs at least 2 levels", j)
+			}
+			for _, lv := range levels[1:] {
+				ref, lv := levels[0], lv
+				g, err := contrast(j, func(float64) float64 { return ref }, func(float64) float64 { return lv }, false)
+				if err != nil {
+					return nil, err
+				}
+				add(j, lv, g)
+			}
+			continue
+		}
+		h := opts.Step
+		g, err := contrast(j,
+			func(v float64) float64 { return v - h*math.Max(1, math.Abs(v)) },
+			func(v float64) float64 { return v + h*math.Max(1, math.Abs(v)) }, true)
+		if err != nil {
+			return nil, err
+		}
+		add(j, math.NaN(), g)
+	}
+	return effects, nil
+}
+
+// prepare fills in the option defaults and returns the raw columns to report
+func (o *MarginalOptions) prepare(at [][]float64) ([]int, error) {
+	if o.Design == nil {
+		o.Design = func(raw []float64) ([]float64, error) { return raw, nil }
+	}
+	if o.SE == "" {
+		o.SE = SEKer
+	}
+	if o.Level == 0 {
+		o.Level = 0.95
+	}
+	if o.Step == 0 {
+		o.Step = 1e-6
+	}
+	if o.Level <= 0 || o.Level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	if len(at) == 0 {
+		return nil, fmt.Errorf("no evaluation points")
+	}
+	terms := o.Terms
+	if terms == nil {
+		for j := range at[0] {
+			terms = append(terms, j)
+		}
+	}
+	for _, j := range terms {
+		if j < 0 || j >= len(at[0]) {
+			return nil, fmt.Errorf("raw column %d out of range [0, %d)", j, len(at[0]))
+		}
+	}
+	return terms, nil
+}
+
+// MarginalEffects returns the marginal effects at each tau
+func (m *MultiRQFit) MarginalEffects(at [][]float64, opts MarginalOptions) (map[float64][]MarginalEffect, error) {
+	effects := make(map[float64][]MarginalEffect, len(m.Taus))
+	for _, tau := range m.Taus {
+		e, err := MarginalEffects(m.Fits[tau], at, opts)
+		if err != nil {
+			return nil, fmt.Errorf("marginal effects at tau=%.3f failed: %w", tau, err)
+		}
+		effects[tau] = e
+	}
+	return effects, nil
+}
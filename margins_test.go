@@ -0,0 +1,91 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMarginalEffects(t *testing.T) {
+	// Raw covariates: x continuous, g a factor with levels 0, 1, 2
+	design := func(raw []float64) ([]float64, error) {
+		x, g := raw[0], raw[1]
+		d1, d2 := 0.0, 0.0
+		if g == 1 {
+			d1 = 1
+		}
+		if g == 2 {
+			d2 = 1
+		}
+		return []float64{1, x, x * x, d1, d2, x * d1}, nil
+	}
+	n := 60
+	raw := make([][]float64, n)
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		raw[i] = []float64{float64(i) / float64(n-1), float64(i % 3)}
+		x[i], _ = design(raw[i])
+		y[i] = 1 + 2*x[i][1] - x[i][2] + 0.5*x[i][3] - 0.3*x[i][4] + x[i][5] + 0.2*math.Sin(float64(7*i))
+	}
+	fit, err := rqSimplex(y, x, nil, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	b := fit.Coefficients
+
+	effects, err := MarginalEffects(fit, raw, MarginalOptions{
+		Design:   design,
+		Discrete: map[int][]float64{1: {0, 1, 2}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to compute marginal effects: %v", err)
+	}
+	if len(effects) != 3 {
+		t.Fatalf("Expected 3 effects, got %d", len(effects))
+	}
+
+	// Average derivative of b1 x + b2 x^2 + b5 x d1
+	ame := 0.0
+	for _, r := range raw {
+		d1 := 0.0
+		if r[1] == 1 {
+			d1 = 1
+		}
+		ame += (b[1] + 2*b[2]*r[0] + b[5]*d1) / float64(n)
+	}
+	if math.Abs(effects[0].Estimate-ame) > 1e-6 {
+		t.Errorf("Expected average effect %f, got %f", ame, effects[0].Estimate)
+	}
+	if !math.IsNaN(effects[0].Level) {
+		t.Errorf("Expected NaN level for a continuous covariate, got %f", effects[0].Level)
+	}
+	for _, e := range effects {
+		if e.StdError <= 0 || e.Lower >= e.Estimate || e.Upper <= e.Estimate {
+			t.Errorf("Expected a positive standard error and bracketing interval, got %+v", e)
+		}
+	}
+
+	// At a representative row the factor contrast is b3 + b5 x
+	at := [][]float64{{0.4, 0}}
+	rep, err := MarginalEffects(fit, at, MarginalOptions{
+		Design:   design,
+		Discrete: map[int][]float64{1: {0, 1, 2}},
+		Terms:    []int{1},
+	})
+	if err != nil {
+		t.Fatalf("Failed to compute effects at representative values: %v", err)
+	}
+	if len(rep) != 2 || rep[0].Level != 1 || rep[1].Level != 2 {
+		t.Fatalf("Expected contrasts for levels 1 and 2, got %+v", rep)
+	}
+	if want := b[3] + 0.4*b[5]; math.Abs(rep[0].Estimate-want) > 1e-10 {
+		t.Errorf("Expected contrast %f, got %f", want, rep[0].Estimate)
+	}
+	if want := b[4]; math.Abs(rep[1].Estimate-want) > 1e-10 {
+		t.Errorf("Expected contrast %f, got %f", want, rep[1].Estimate)
+	}
+
+	if _, err := MarginalEffects(fit, raw, MarginalOptions{}); err == nil {
+		t.Error("Expected error when the design does not match the fit")
+	}
+}
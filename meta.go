@@ -0,0 +1,83 @@
+package quantreg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// Version is the package version recorded in fit metadata
+const Version = "0.1.0"
+
+// Meta records how a fit was produced, for reproducibility and model governance.
+// It is serialized with the model.
+type Meta struct {
+	Version  string             // Package version
+	FittedAt time.Time          // When the fit finished
+	WallTime time.Duration      // Time taken by the fit
+	Solver   string             // Solver used
+	Options  map[string]float64 // Solver options used
+	Taus     []float64          // Quantile levels
+	N        int                // Number of observations
+	P        int                // Number of parameters
+	DataHash string             // SHA-256 of the training data when fingerprinting is enabled and the data are at hand
+	Note     string             // Caveat about the accuracy of the fit, empty for solvers that reach the optimum
+}
+
+var fingerprintEnabled atomic.Bool
+
+// EnableFingerprints turns hashing of the training data into Meta.DataHash on or
+// off. Hashing reads every value once, so it is off by default.
+func EnableFingerprints(on bool) {
+	fingerprintEnabled.Store(on)
+}
+
+// newMeta returns the metadata of a fit of y on x that started at start
+func newMeta(solver string, options map[string]float64, taus []float64, n, p int, start time.Time, y []float64, x [][]float64) Meta {
+	m := Meta{
+		Version:  Version,
+		FittedAt: time.Now(),
+		Solver:   solver,
+		Options:  options,
+		Taus:     append([]float64(nil), taus...),
+		N:        n,
+		P:        p,
+	}
+	m.WallTime = m.FittedAt.Sub(start)
+	if fingerprintEnabled.Load() && y != nil {
+		m.DataHash = DataFingerprint(y, x)
+	}
+	return m
+}
+
+// NewMeta returns the metadata of a fit of y on x that started at start, for
+// fitting functions outside this package such as centile.FitLMS
+func NewMeta(solver string, options map[string]float64, taus []float64, n, p int, start time.Time, y []float64, x [][]float64) Meta {
+	return newMeta(solver, options, taus, n, p, start, y, x)
+}
+
+// DataFingerprint returns the hex SHA-256 of y and the rows of x, with the
+// dimensions included so that reshaped data hash differently
+func DataFingerprint(y []float64, x [][]float64) string {
+	h := sha256.New()
+	buf := make([]byte, 8)
+	put := func(v uint64) {
+		binary.LittleEndian.PutUint64(buf, v)
+		h.Write(buf)
+	}
+	put(uint64(len(y)))
+	for _, v := range y {
+		put(math.Float64bits(v))
+	}
+	put(uint64(len(x)))
+	for _, row := range x {
+		put(uint64(len(row)))
+		for _, v := range row {
+			put(math.Float64bits(v))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,59 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMeta(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	m := fit.Meta
+	if m.Version != Version || m.Solver != "br" || m.N != 20 || m.P != 2 {
+		t.Errorf("Unexpected metadata: %+v", m)
+	}
+	if !reflect.DeepEqual(m.Taus, []float64{0.5}) || m.Options["max_iter"] != 1000 {
+		t.Errorf("Unexpected taus or options: %v, %v", m.Taus, m.Options)
+	}
+	if m.FittedAt.IsZero() || m.WallTime < 0 {
+		t.Errorf("Unexpected timestamps: %v, %v", m.FittedAt, m.WallTime)
+	}
+	if m.DataHash != "" {
+		t.Errorf("Expected no data hash by default, got %q", m.DataHash)
+	}
+
+	EnableFingerprints(true)
+	defer EnableFingerprints(false)
+	multi, err := RQProcess(y, x, []float64{0.25, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	if multi.Meta.DataHash != DataFingerprint(y, x) || len(multi.Meta.DataHash) != 64 {
+		t.Errorf("Expected the data fingerprint, got %q", multi.Meta.DataHash)
+	}
+	y2 := append([]float64(nil), y...)
+	y2[3] += 1e-9
+	if DataFingerprint(y2, x) == multi.Meta.DataHash {
+		t.Error("Expected a different fingerprint for different data")
+	}
+
+	data, err := json.Marshal(multi)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var dec MultiRQFit
+	if err := json.Unmarshal(data, &dec); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if dec.Meta.DataHash != multi.Meta.DataHash || !reflect.DeepEqual(dec.Meta.Taus, multi.Meta.Taus) ||
+		!dec.Meta.FittedAt.Equal(multi.Meta.FittedAt) {
+		t.Errorf("Expected metadata to survive serialization, got %+v", dec.Meta)
+	}
+	if dec.Fits[0.25].Meta.Solver != "br" {
+		t.Errorf("Expected per-tau metadata to survive serialization, got %+v", dec.Fits[0.25].Meta)
+	}
+}
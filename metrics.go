@@ -0,0 +1,48 @@
+package quantreg
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics receives instrumentation events from fitting and prediction.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveFit is called after every completed fit
+	ObserveFit(method string, duration time.Duration, iterations int, converged bool)
+
+	// ObservePredict is called after every Predict call with the number of rows predicted
+	ObservePredict(rows int, duration time.Duration)
+
+	// ObserveCacheLookup is called when a serving component looks up a model by name
+	ObserveCacheLookup(name string, hit bool)
+}
+
+var (
+	metricsMu sync.RWMutex
+	metrics   Metrics = noopMetrics{}
+)
+
+// SetMetrics installs m as the package-wide metrics hook. Passing nil disables instrumentation.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		m = noopMetrics{}
+	}
+	metricsMu.Lock()
+	metrics = m
+	metricsMu.Unlock()
+}
+
+// currentMetrics returns the installed metrics hook
+func currentMetrics() Metrics {
+	metricsMu.RLock()
+	defer metricsMu.RUnlock()
+	return metrics
+}
+
+// noopMetrics discards all events
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveFit(string, time.Duration, int, bool) {}
+func (noopMetrics) ObservePredict(int, time.Duration)           {}
+func (noopMetrics) ObserveCacheLookup(string, bool)             {}
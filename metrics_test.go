@@ -0,0 +1,84 @@
+package quantreg
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	fits     []string
+	rows     int
+	lookups  int
+	failures int
+}
+
+func (r *recordingMetrics) ObserveFit(method string, duration time.Duration, iterations int, converged bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fits = append(r.fits, method)
+	if !converged {
+		r.failures++
+	}
+}
+
+func (r *recordingMetrics) ObservePredict(rows int, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rows += rows
+}
+
+func (r *recordingMetrics) ObserveCacheLookup(name string, hit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+}
+
+func TestMetricsHooks(t *testing.T) {
+	rec := &recordingMetrics{}
+	SetMetrics(rec)
+	defer SetMetrics(nil)
+
+	x := [][]float64{
+		{1, 0.5},
+		{1, 1.0},
+		{1, 1.5},
+		{1, 2.0},
+	}
+	y := []float64{1.0, 2.0, 2.5, 3.0}
+
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	if _, err := fit.Predict(x); err != nil {
+		t.Fatalf("Failed to generate predictions: %v", err)
+	}
+
+	if len(rec.fits) != 1 || rec.fits[0] != "br" {
+		t.Errorf("Expected one observed br fit, got %v", rec.fits)
+	}
+
+	if fit.Iterations == 0 {
+		t.Error("Expected iteration count to be recorded on the fit")
+	}
+
+	if !fit.Converged && rec.failures != 1 {
+		t.Errorf("Expected convergence failure to be reported, got %d", rec.failures)
+	}
+
+	if rec.rows != len(x) {
+		t.Errorf("Expected %d predicted rows, got %d", len(x), rec.rows)
+	}
+
+	// Disabling metrics must not panic
+	SetMetrics(nil)
+	if _, err := fit.Predict(x); err != nil {
+		t.Fatalf("Failed to generate predictions: %v", err)
+	}
+	if rec.rows != len(x) {
+		t.Error("Expected no events after metrics were disabled")
+	}
+}
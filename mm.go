@@ -0,0 +1,125 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MMOptions controls RQMM
+type MMOptions struct {
+	Epsilon   float64 // Perturbation of the check function (default 1e-6 times the mean absolute deviation of y, at least 1e-10)
+	MaxIter   int     // Maximum iterations (default 1000)
+	Tolerance float64 // Relative decrease of the perturbed objective at convergence (default 1e-10)
+	Lean      bool    // Store no Fitted or Residuals; FittedValues and ResidualValues recompute them
+}
+
+// RQMM fits a linear quantile regression by the majorize-minimize algorithm of
+// Hunter and Lange (2000). The check function is perturbed to
+// rho_eps(r) = rho_tau(r) - eps/2 log(eps + |r|), which is majorized at the
+// current residuals r_k by the quadratic r^2/(4(eps+|r_k|)) + (2tau-1)r/2 plus a
+// constant. Each iteration minimizes the majorizer, a weighted least-squares
+// problem X'WX b = X'Wy + (2tau-1)X'1 with W = diag(1/(eps+|r_k|)), so the
+// perturbed objective never increases. Iteration starts from least squares.
+func RQMM(y []float64, x [][]float64, tau float64, opts MMOptions) (*RQFit, error) {
+	if len(y) == 0 || len(x) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if tau <= 0 || tau >= 1 {
+		return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+	}
+	if opts.Epsilon == 0 {
+		opts.Epsilon = deviationFloor(y, 1e-6)
+	}
+	if opts.MaxIter == 0 {
+		opts.MaxIter = 1000
+	}
+	if opts.Tolerance == 0 {
+		opts.Tolerance = 1e-10
+	}
+	if opts.Epsilon < 0 || opts.MaxIter < 0 || opts.Tolerance < 0 {
+		return nil, fmt.Errorf("MM options must be positive")
+	}
+
+	start := time.Now()
+	n, p := len(y), len(x[0])
+	fit := &RQFit{Tau: tau, N: n, P: p, Method: "mm", X: x, Y: y}
+	eps := opts.Epsilon
+	w := make([]float64, n)
+	r := make([]float64, n)
+	objective := func(beta []float64) float64 {
+		f := 0.0
+		for i := range y {
+			r[i] = y[i] - dot(x[i], beta)
+			f += rho(r[i], tau) - eps/2*math.Log(eps+math.Abs(r[i]))
+		}
+		return f
+	}
+
+	var beta []float64
+	var err error
+	d := withPhase(PhaseSolve, func() {
+		for i := range w {
+			w[i] = 1
+		}
+		beta, _, err = weightedSolve(x, w, matVec(transpose(x), y))
+		if err != nil {
+			return
+		}
+		f := objective(beta)
+		g := make([]float64, p)
+		for fit.Iterations < opts.MaxIter {
+			fit.Iterations++
+			for j := range g {
+				g[j] = 0
+			}
+			for i, row := range x {
+				w[i] = 1 / (eps + math.Abs(r[i]))
+				for j, v := range row {
+					g[j] += v * (w[i]*y[i] + 2*tau - 1)
+				}
+			}
+			var next []float64
+			if next, _, err = weightedSolve(x, w, g); err != nil {
+				return
+			}
+			fNext := objective(next)
+			beta = next
+			if f-fNext <= opts.Tolerance*math.Max(math.Abs(f), 1) {
+				fit.Converged = true
+				break
+			}
+			f = fNext
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("optimization failed: %w", err)
+	}
+	fit.recordPhase(PhaseSolve, d)
+
+	fit.Coefficients = beta
+	fit.setFitted(y, x, opts.Lean)
+	for i := range y {
+		r[i] = y[i] - dot(x[i], beta)
+	}
+	fit.BasicObs = basicObservations(r, p)
+	fit.Meta = newMeta(fit.Method, map[string]float64{"epsilon": eps, "max_iter": float64(opts.MaxIter), "tolerance": opts.Tolerance},
+		[]float64{tau}, n, p, start, y, x)
+	logFit(fit.Method, tau, n, p, fit.Iterations, fit.Converged, fit.Meta)
+	currentMetrics().ObserveFit(fit.Method, time.Since(start), fit.Iterations, fit.Converged)
+	return fit, nil
+}
+
+// deviationFloor returns frac times the mean absolute deviation of y, at least
+// 1e-10, the default size of the residual floors of RQMM and RQQuick
+func deviationFloor(y []float64, frac float64) float64 {
+	mean, _ := meanSD(y)
+	mad := 0.0
+	for _, v := range y {
+		mad += math.Abs(v-mean) / float64(len(y))
+	}
+	return math.Max(frac*mad, 1e-10)
+}
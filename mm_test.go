@@ -0,0 +1,39 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQMM(t *testing.T) {
+	y, x := inferenceData()
+
+	for _, tau := range []float64{0.25, 0.5, 0.9} {
+		exact, err := RQConstrained(y, x, tau, Constraints{})
+		if err != nil {
+			t.Fatalf("Failed to fit exact model: %v", err)
+		}
+		fit, err := RQMM(y, x, tau, MMOptions{})
+		if err != nil {
+			t.Fatalf("Failed to fit MM model: %v", err)
+		}
+		if !fit.Converged || fit.Method != "mm" {
+			t.Errorf("Expected a converged mm fit, got converged=%v method=%q", fit.Converged, fit.Method)
+		}
+		for j, b := range exact.Coefficients {
+			if math.Abs(fit.Coefficients[j]-b) > 1e-3 {
+				t.Errorf("Expected coefficient %d = %v at tau=%v, got %v", j, b, tau, fit.Coefficients[j])
+			}
+		}
+		if loss, best := sumRho(fit.Residuals, tau), sumRho(exact.Residuals, tau); loss > best+1e-6 {
+			t.Errorf("Expected check loss %v at tau=%v, got %v", best, tau, loss)
+		}
+	}
+
+	if _, err := RQMM(y, x, 1.5, MMOptions{}); err == nil {
+		t.Error("Expected error for invalid tau")
+	}
+	if _, err := RQMM(y, x[:5], 0.5, MMOptions{}); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
@@ -0,0 +1,209 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// MultiResponseFit holds quantile regressions of several responses on a shared
+// design. Coefficients are stacked by response and then coefficient, so entry
+// e*P+j of a stacked vector is coefficient j of equation e.
+type MultiResponseFit struct {
+	Fits  []*RQFit    // One fit per response, in response order
+	Tau   float64     // Quantile level
+	N     int         // Number of observations
+	P     int         // Number of coefficients per equation
+	Draws [][]float64 // Joint bootstrap draws of the stacked coefficients, set by Bootstrap
+	Meta  Meta        // Reproducibility metadata; its data fingerprint covers every response
+}
+
+// RQMultiResponse fits a quantile regression at tau for each response ys[e] on the
+// shared design x. The equations are estimated separately, as the check loss does
+// not couple them, but Bootstrap resamples observations jointly so that the
+// cross-equation covariance of the estimates is captured.
+func RQMultiResponse(ys [][]float64, x [][]float64, tau float64) (*MultiResponseFit, error) {
+	if len(ys) == 0 {
+		return nil, fmt.Errorf("no responses specified")
+	}
+	start := time.Now()
+	m := &MultiResponseFit{Tau: tau}
+	for e, y := range ys {
+		fit, err := RQ(y, x, tau)
+		if err != nil {
+			return nil, fmt.Errorf("fit failed for response %d: %w", e, err)
+		}
+		m.Fits = append(m.Fits, fit)
+	}
+	m.N, m.P = m.Fits[0].N, m.Fits[0].P
+
+	// Fingerprint the responses stacked against the repeated design
+	var stackedY []float64
+	var stackedX [][]float64
+	for _, y := range ys {
+		stackedY = append(stackedY, y...)
+		stackedX = append(stackedX, x...)
+	}
+	m.Meta = newMeta(m.Fits[0].Method, map[string]float64{"responses": float64(len(ys))}, []float64{tau}, m.N, m.P, start, stackedY, stackedX)
+	return m, nil
+}
+
+// Coefficients returns the stacked coefficients of all equations
+func (m *MultiResponseFit) Coefficients() []float64 {
+	b := make([]float64, 0, len(m.Fits)*m.P)
+	for _, fit := range m.Fits {
+		b = append(b, fit.Coefficients...)
+	}
+	return b
+}
+
+// Predict returns the predicted quantiles of each response at newX
+func (m *MultiResponseFit) Predict(newX [][]float64) ([][]float64, error) {
+	out := make([][]float64, len(m.Fits))
+	for e, fit := range m.Fits {
+		pred, err := fit.Predict(newX)
+		if err != nil {
+			return nil, err
+		}
+		out[e] = pred
+	}
+	return out, nil
+}
+
+// Bootstrap draws stacked coefficient vectors by the pairs bootstrap, refitting
+// every equation on the same resampled observations in each replication, and
+// stores them in m.Draws. Draws are replaced on each call.
+func (m *MultiResponseFit) Bootstrap(opts BootstrapOptions, rng *rand.Rand) error {
+	x := m.Fits[0].X
+	if len(x) == 0 {
+		return fmt.Errorf("fit does not carry its design matrix")
+	}
+	if opts.Replications == 0 {
+		opts.Replications = 200
+	}
+	if opts.Replications < 2 {
+		return fmt.Errorf("need at least 2 replications, got %d", opts.Replications)
+	}
+
+	rng = randOrDefault(rng)
+	w := make([]float64, m.N)
+	draws := make([][]float64, opts.Replications)
+	for r := range draws {
+		for i := range w {
+			w[i] = 0
+		}
+		for k := 0; k < m.N; k++ {
+			w[rng.Intn(m.N)]++
+		}
+		for e, fit := range m.Fits {
+			bf, err := RQWeighted(fit.Y, x, w, m.Tau)
+			if err != nil {
+				return fmt.Errorf("replication %d failed for response %d: %w", r, e, err)
+			}
+			draws[r] = append(draws[r], bf.Coefficients...)
+		}
+	}
+	m.Draws = draws
+	return nil
+}
+
+// Vcov returns the bootstrap covariance of the stacked coefficients, including
+// the cross-equation blocks
+func (m *MultiResponseFit) Vcov() ([][]float64, error) {
+	if len(m.Draws) < 2 {
+		return nil, fmt.Errorf("fit has no bootstrap draws, call Bootstrap first")
+	}
+	size := len(m.Draws[0])
+	mean := make([]float64, size)
+	for _, d := range m.Draws {
+		for a, v := range d {
+			mean[a] += v / float64(len(m.Draws))
+		}
+	}
+	cov := make([][]float64, size)
+	for a := range cov {
+		cov[a] = make([]float64, size)
+	}
+	for _, d := range m.Draws {
+		for a := range d {
+			for b := a; b < size; b++ {
+				cov[a][b] += (d[a] - mean[a]) * (d[b] - mean[b]) / float64(len(m.Draws)-1)
+			}
+		}
+	}
+	for a := range cov {
+		for b := 0; b < a; b++ {
+			cov[a][b] = cov[b][a]
+		}
+	}
+	return cov, nil
+}
+
+// LinearTest tests the null R b = q for the stacked coefficients b with a Wald
+// test using the bootstrap covariance
+func (m *MultiResponseFit) LinearTest(r [][]float64, q []float64) (*WaldTest, error) {
+	if len(r) == 0 {
+		return nil, fmt.Errorf("no restrictions to test")
+	}
+	if len(q) != len(r) {
+		return nil, fmt.Errorf("%w: %d restrictions, %d right-hand sides", ErrDimensionMismatch, len(r), len(q))
+	}
+	cov, err := m.Vcov()
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range r {
+		if len(row) != len(cov) {
+			return nil, fmt.Errorf("%w: restriction has %d entries, want %d", ErrDimensionMismatch, len(row), len(cov))
+		}
+	}
+
+	rb := matVec(r, m.Coefficients())
+	for a := range rb {
+		rb[a] -= q[a]
+	}
+	rv := matMul(r, cov)
+	rvr := make([][]float64, len(r))
+	for a := range r {
+		rvr[a] = make([]float64, len(r))
+		for c := range r {
+			rvr[a][c] = dot(rv[a], r[c])
+		}
+	}
+	inv, err := invert(rvr)
+	if err != nil {
+		return nil, fmt.Errorf("restriction covariance is singular: %w", err)
+	}
+
+	stat := math.Max(quadForm(inv, rb), 0)
+	return &WaldTest{
+		Tau:       m.Tau,
+		Statistic: stat,
+		DF:        len(r),
+		PValue:    chiSquareSF(stat, len(r)),
+	}, nil
+}
+
+// EqualityTest tests the null that coefficient term is the same in every equation
+func (m *MultiResponseFit) EqualityTest(term int) (*WaldTest, error) {
+	if len(m.Fits) < 2 {
+		return nil, fmt.Errorf("need at least 2 responses, got %d", len(m.Fits))
+	}
+	if term < 0 || term >= m.P {
+		return nil, fmt.Errorf("coefficient index %d out of range [0, %d)", term, m.P)
+	}
+	var r [][]float64
+	for e := 1; e < len(m.Fits); e++ {
+		row := make([]float64, len(m.Fits)*m.P)
+		row[e*m.P+term] = 1
+		row[term] = -1
+		r = append(r, row)
+	}
+	wt, err := m.LinearTest(r, make([]float64, len(r)))
+	if err != nil {
+		return nil, err
+	}
+	wt.Terms = []int{term}
+	return wt, nil
+}
@@ -0,0 +1,77 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRQMultiResponse(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 80
+	x := make([][]float64, n)
+	ys := [][]float64{make([]float64, n), make([]float64, n), make([]float64, n)}
+	for i := range x {
+		xi := float64(i) / 20
+		x[i] = []float64{1, xi}
+		// Shared noise makes the equations correlated
+		common := rng.NormFloat64()
+		ys[0][i] = 1 + xi + common + 0.3*rng.NormFloat64()
+		ys[1][i] = 2 + xi + common + 0.3*rng.NormFloat64()
+		ys[2][i] = 2 + 3*xi + common + 0.3*rng.NormFloat64()
+	}
+
+	m, err := RQMultiResponse(ys, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit multi-response model: %v", err)
+	}
+	if len(m.Fits) != 3 || m.P != 2 || len(m.Coefficients()) != 6 {
+		t.Fatalf("Unexpected fit dimensions: %d fits, P=%d", len(m.Fits), m.P)
+	}
+	if m.Meta.N != n || m.Meta.P != 2 || m.Meta.Options["responses"] != 3 || len(m.Meta.Taus) != 1 {
+		t.Errorf("Unexpected metadata %+v", m.Meta)
+	}
+	if _, err := m.Vcov(); err == nil {
+		t.Error("Expected error before bootstrapping")
+	}
+
+	if err := m.Bootstrap(BootstrapOptions{Replications: 100}, rand.New(rand.NewSource(2))); err != nil {
+		t.Fatalf("Failed to bootstrap: %v", err)
+	}
+	cov, err := m.Vcov()
+	if err != nil {
+		t.Fatalf("Failed to compute covariance: %v", err)
+	}
+	if len(cov) != 6 {
+		t.Fatalf("Expected 6x6 covariance, got %d", len(cov))
+	}
+	// The intercepts share the common noise
+	if corr := cov[0][2] / math.Sqrt(cov[0][0]*cov[2][2]); corr < 0.3 {
+		t.Errorf("Expected positively correlated intercepts, got correlation %v", corr)
+	}
+
+	wt, err := m.EqualityTest(1)
+	if err != nil {
+		t.Fatalf("Failed to test slope equality: %v", err)
+	}
+	if wt.DF != 2 || wt.PValue > 0.01 {
+		t.Errorf("Expected unequal slopes to be rejected on 2 df, got %+v", wt)
+	}
+
+	// Equal slopes in the first two equations
+	r := [][]float64{{0, -1, 0, 1, 0, 0}}
+	wt, err = m.LinearTest(r, []float64{0})
+	if err != nil {
+		t.Fatalf("Failed to run linear test: %v", err)
+	}
+	if wt.PValue < 0.01 {
+		t.Errorf("Expected equal slopes not to be rejected, got p=%v", wt.PValue)
+	}
+
+	if _, err := m.LinearTest(r, []float64{0, 1}); err == nil {
+		t.Error("Expected error for mismatched right-hand side")
+	}
+	if _, err := RQMultiResponse(nil, x, 0.5); err == nil {
+		t.Error("Expected error for no responses")
+	}
+}
@@ -4,8 +4,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
-
-	"github.com/andreasmuller/sparsem"
+	"time"
 )
 
 // MultiRQFit represents multiple quantile regression fits
@@ -16,6 +15,7 @@ type MultiRQFit struct {
 	P         int                // Number of parameters
 	Method    string            // Method used for fitting
 	Formula   string            // Model formula
+	Meta      Meta              // Reproducibility metadata
 }
 
 // MultiNLRQFit represents multiple non-linear quantile regression fits
@@ -26,10 +26,12 @@ type MultiNLRQFit struct {
 	P         int                  // Number of parameters
 	Model     NonLinearModel       // The non-linear model
 	Formula   string              // Model formula
+	Meta      Meta                // Reproducibility metadata
 }
 
 // RQProcess fits multiple quantile regression models
 func RQProcess(y []float64, x [][]float64, taus []float64) (*MultiRQFit, error) {
+	start := time.Now()
 	if len(taus) == 0 {
 		return nil, fmt.Errorf("no quantile levels specified")
 	}
@@ -42,7 +44,7 @@ func RQProcess(y []float64, x [][]float64, taus []float64) (*MultiRQFit, error)
 	// Check tau values
 	for _, tau := range sortedTaus {
 		if tau <= 0 || tau >= 1 {
-			return nil, fmt.Errorf("tau must be between 0 and 1, got %f", tau)
+			return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
 		}
 	}
 
@@ -53,7 +55,7 @@ func RQProcess(y []float64, x [][]float64, taus []float64) (*MultiRQFit, error)
 	for _, tau := range sortedTaus {
 		fit, err := RQ(y, x, tau)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fit model for tau=%f: %v", tau, err)
+			return nil, fmt.Errorf("failed to fit model for tau=%f: %w", tau, err)
 		}
 		fits[tau] = fit
 		if firstFit == nil {
@@ -61,6 +63,14 @@ func RQProcess(y []float64, x [][]float64, taus []float64) (*MultiRQFit, error)
 		}
 	}
 
+	fitted := make([][]float64, len(sortedTaus))
+	for k, tau := range sortedTaus {
+		fitted[k] = fits[tau].FittedValues()
+	}
+	logCrossings(sortedTaus, fitted)
+	currentLogger().Info("process complete", "method", "br", "taus", len(sortedTaus), "n", firstFit.N, "p", firstFit.P,
+		"duration", time.Since(start))
+
 	return &MultiRQFit{
 		Fits:    fits,
 		Taus:    sortedTaus,
@@ -68,11 +78,13 @@ func RQProcess(y []float64, x [][]float64, taus []float64) (*MultiRQFit, error)
 		P:       firstFit.P,
 		Method:  "br",
 		Formula: firstFit.Formula,
+		Meta:    newMeta("br", firstFit.Meta.Options, sortedTaus, firstFit.N, firstFit.P, start, y, x),
 	}, nil
 }
 
 // NLRQProcess fits multiple non-linear quantile regression models
 func NLRQProcess(y []float64, x [][]float64, model NonLinearModel, beta0 []float64, taus []float64) (*MultiNLRQFit, error) {
+	start := time.Now()
 	if len(taus) == 0 {
 		return nil, fmt.Errorf("no quantile levels specified")
 	}
@@ -85,7 +97,7 @@ func NLRQProcess(y []float64, x [][]float64, model NonLinearModel, beta0 []float
 	// Check tau values
 	for _, tau := range sortedTaus {
 		if tau <= 0 || tau >= 1 {
-			return nil, fmt.Errorf("tau must be between 0 and 1, got %f", tau)
+			return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
 		}
 	}
 
@@ -96,7 +108,7 @@ func NLRQProcess(y []float64, x [][]float64, model NonLinearModel, beta0 []float
 	for _, tau := range sortedTaus {
 		fit, err := NLRQ(y, x, model, beta0, tau)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fit model for tau=%f: %v", tau, err)
+			return nil, fmt.Errorf("failed to fit model for tau=%f: %w", tau, err)
 		}
 		fits[tau] = fit
 		if firstFit == nil {
@@ -111,6 +123,7 @@ func NLRQProcess(y []float64, x [][]float64, model NonLinearModel, beta0 []float
 		P:       firstFit.P,
 		Model:   model,
 		Formula: firstFit.Formula,
+		Meta:    newMeta("nlrq", firstFit.Meta.Options, sortedTaus, firstFit.N, firstFit.P, start, y, x),
 	}, nil
 }
 
@@ -121,7 +134,7 @@ func (m *MultiRQFit) Predict(newX [][]float64) (map[float64][]float64, error) {
 	for _, tau := range m.Taus {
 		pred, err := m.Fits[tau].Predict(newX)
 		if err != nil {
-			return nil, fmt.Errorf("prediction failed for tau=%f: %v", tau, err)
+			return nil, fmt.Errorf("prediction failed for tau=%f: %w", tau, err)
 		}
 		predictions[tau] = pred
 	}
@@ -136,7 +149,7 @@ func (m *MultiNLRQFit) Predict(newX [][]float64) (map[float64][]float64, error)
 	for _, tau := range m.Taus {
 		pred, err := m.Fits[tau].Predict(newX)
 		if err != nil {
-			return nil, fmt.Errorf("prediction failed for tau=%f: %v", tau, err)
+			return nil, fmt.Errorf("prediction failed for tau=%f: %w", tau, err)
 		}
 		predictions[tau] = pred
 	}
@@ -146,22 +159,7 @@ func (m *MultiNLRQFit) Predict(newX [][]float64) (map[float64][]float64, error)
 
 // Summary prints a summary of all fitted models
 func (m *MultiRQFit) Summary() string {
-	result := fmt.Sprintf("Multiple Quantile Regression\n")
-	result += fmt.Sprintf("Number of observations: %d\n", m.N)
-	result += fmt.Sprintf("Number of parameters: %d\n", m.P)
-	result += fmt.Sprintf("Quantile levels: %v\n\n", m.Taus)
-
-	for _, tau := range m.Taus {
-		fit := m.Fits[tau]
-		result += fmt.Sprintf("=== Quantile %f ===\n", tau)
-		result += "Coefficients:\n"
-		for i, coef := range fit.Coefficients {
-			result += fmt.Sprintf("  Beta[%d]: %.6f\n", i, coef)
-		}
-		result += "\n"
-	}
-
-	return result
+	return m.SummaryTable().String()
 }
 
 // Summary prints a summary of all fitted models
@@ -186,23 +184,28 @@ func (m *MultiNLRQFit) Summary() string {
 
 // Diagnostics computes various diagnostic measures
 type Diagnostics struct {
-	PseudoRSquared  float64            // Pseudo R-squared
-	ResidualStats   map[float64]Stats  // Residual statistics for each tau
-	CrossingMatrix  [][]int            // Matrix showing quantile crossing counts
+	Taus            []float64            // Quantile levels, indexing CrossingMatrix
+	PseudoRSquared  float64              // Koenker-Machado R1 at the median, zero when tau=0.5 was not fitted
+	R1              map[float64]float64  // Koenker-Machado goodness of fit for each tau
+	ResidualStats   map[float64]Stats    // Residual statistics for each tau
+	CrossingMatrix  [][]int              // Matrix showing quantile crossing counts
+	Flagged         []FlaggedObservation // Outlying or high-impact observations, set by ComputeDiagnosticsFlagged
 }
 
 // Stats holds basic statistical measures
 type Stats struct {
-	Min     float64
-	Max     float64
-	Median  float64
-	Mean    float64
-	StdDev  float64
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Median  float64 `json:"median"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"std_dev"`
 }
 
 // ComputeDiagnostics calculates diagnostic measures for the fits
 func (m *MultiRQFit) ComputeDiagnostics() *Diagnostics {
 	diag := &Diagnostics{
+		Taus:           m.Taus,
+		R1:             make(map[float64]float64),
 		ResidualStats:  make(map[float64]Stats),
 		CrossingMatrix: make([][]int, len(m.Taus)),
 	}
@@ -215,30 +218,45 @@ func (m *MultiRQFit) ComputeDiagnostics() *Diagnostics {
 	// Compute residual statistics for each tau
 	for i, tau1 := range m.Taus {
 		fit1 := m.Fits[tau1]
-		stats := computeStats(fit1.Residuals)
+		stats := computeStats(fit1.ResidualValues())
 		diag.ResidualStats[tau1] = stats
 
+		if r1, err := fit1.R1(); err == nil {
+			diag.R1[tau1] = r1
+		}
+
 		// Check for quantile crossings
 		for j, tau2 := range m.Taus {
 			if j <= i {
 				continue
 			}
 			fit2 := m.Fits[tau2]
-			crossings := countCrossings(fit1.Fitted, fit2.Fitted)
+			crossings := countCrossings(fit1.FittedValues(), fit2.FittedValues())
 			diag.CrossingMatrix[i][j] = crossings
 			diag.CrossingMatrix[j][i] = crossings
 		}
 	}
 
-	// Compute pseudo R-squared using the median fit
-	medianFit := m.Fits[0.5]
-	if medianFit != nil {
-		diag.PseudoRSquared = computePseudoRSquared(medianFit)
-	}
+	// Report the median goodness of fit as the overall pseudo R-squared
+	diag.PseudoRSquared = diag.R1[0.5]
 
 	return diag
 }
 
+// ComputeDiagnosticsFlagged calculates the diagnostics of ComputeDiagnostics and
+// also flags outlying and influential observations at thresholds th. Flagging
+// needs the influence of every observation at every tau, which costs O(n p^2)
+// per tau, so ComputeDiagnostics leaves it out.
+func (m *MultiRQFit) ComputeDiagnosticsFlagged(th FlagThresholds) (*Diagnostics, error) {
+	diag := m.ComputeDiagnostics()
+	flagged, err := m.FlagObservations(th)
+	if err != nil {
+		return nil, err
+	}
+	diag.Flagged = flagged
+	return diag, nil
+}
+
 // Helper function to compute basic statistics
 func computeStats(data []float64) Stats {
 	n := len(data)
@@ -282,30 +300,3 @@ func countCrossings(fit1, fit2 []float64) int {
 	}
 	return crossings
 }
-
-// Helper function to compute pseudo R-squared
-func computePseudoRSquared(fit *RQFit) float64 {
-	var sumRes, sumTot float64
-	median := median(fit.Residuals)
-
-	for i, res := range fit.Residuals {
-		sumRes += math.Abs(res)
-		sumTot += math.Abs(fit.Fitted[i] - median)
-	}
-
-	if sumTot == 0 {
-		return 0
-	}
-	return 1 - (sumRes / sumTot)
-}
-
-// Helper function to compute median
-func median(data []float64) float64 {
-	if len(data) == 0 {
-		return 0
-	}
-	sorted := make([]float64, len(data))
-	copy(sorted, data)
-	sort.Float64s(sorted)
-	return sorted[len(sorted)/2]
-}
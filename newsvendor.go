@@ -0,0 +1,106 @@
+package quantreg
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NewsvendorPlan is a cost-optimal quantile forecast for asymmetric linear costs
+type NewsvendorPlan struct {
+	Tau          float64   // Critical ratio under / (under + over)
+	UnderCost    float64   // Cost per unit of demand above the order
+	OverCost     float64   // Cost per unit of order above demand
+	Orders       []float64 // Cost-optimal order quantity for each row of newX
+	ExpectedCost float64   // Mean in-sample cost per observation at the optimal quantile
+}
+
+// CriticalRatio returns the cost-optimal quantile level for unit under- and
+// over-forecast costs
+func CriticalRatio(underCost, overCost float64) (float64, error) {
+	if underCost <= 0 || overCost <= 0 {
+		return 0, fmt.Errorf("unit costs must be positive, got under=%f over=%f", underCost, overCost)
+	}
+	return underCost / (underCost + overCost), nil
+}
+
+// Newsvendor fits the conditional quantile at the critical ratio and returns the
+// cost-optimal orders at newX. The expected cost is the in-sample mean of
+// under*(y-q)+ + over*(q-y)+, which equals (under+over) times the mean check loss.
+func Newsvendor(y []float64, x [][]float64, underCost, overCost float64, newX [][]float64) (*NewsvendorPlan, error) {
+	tau, err := CriticalRatio(underCost, overCost)
+	if err != nil {
+		return nil, err
+	}
+	fit, err := RQ(y, x, tau)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fit model for tau=%f: %w", tau, err)
+	}
+	orders, err := fit.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+	return &NewsvendorPlan{
+		Tau:          tau,
+		UnderCost:    underCost,
+		OverCost:     overCost,
+		Orders:       orders,
+		ExpectedCost: (underCost + overCost) * fit.Rho() / float64(fit.N),
+	}, nil
+}
+
+// Newsvendor returns cost-optimal orders by interpolating the fitted quantile
+// process at the critical ratio instead of refitting
+func (m *MultiRQFit) Newsvendor(underCost, overCost float64, newX [][]float64) (*NewsvendorPlan, error) {
+	tau, err := CriticalRatio(underCost, overCost)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := m.PredictTau(newX, tau)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &NewsvendorPlan{Tau: tau, UnderCost: underCost, OverCost: overCost, Orders: orders}
+	first := m.Fits[m.Taus[0]]
+	if len(first.X) > 0 && len(first.Y) > 0 {
+		fitted, err := m.PredictTau(first.X, tau)
+		if err != nil {
+			return nil, err
+		}
+		total := 0.0
+		for i, yi := range first.Y {
+			total += rho(yi-fitted[i], tau)
+		}
+		plan.ExpectedCost = (underCost + overCost) * total / float64(len(first.Y))
+	}
+	return plan, nil
+}
+
+// PredictTau predicts the conditional quantile at any tau in the fitted range by
+// linear interpolation of the rearranged quantile process
+func (m *MultiRQFit) PredictTau(newX [][]float64, tau float64) ([]float64, error) {
+	if len(m.Taus) == 0 {
+		return nil, fmt.Errorf("no fitted quantiles")
+	}
+	if fit, ok := m.Fits[tau]; ok {
+		return fit.Predict(newX)
+	}
+	if tau < m.Taus[0] || tau > m.Taus[len(m.Taus)-1] {
+		return nil, fmt.Errorf("tau=%f lies outside the fitted range [%f, %f]", tau, m.Taus[0], m.Taus[len(m.Taus)-1])
+	}
+
+	pred, err := m.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(newX))
+	q := make([]float64, len(m.Taus))
+	for i := range newX {
+		for k, t := range m.Taus {
+			q[k] = pred[t][i]
+		}
+		sort.Float64s(q)
+		out[i] = interpolateQuantile(m.Taus, q, tau)
+	}
+	return out, nil
+}
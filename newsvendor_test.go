@@ -0,0 +1,66 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCriticalRatio(t *testing.T) {
+	tau, err := CriticalRatio(3, 1)
+	if err != nil {
+		t.Fatalf("Failed to compute critical ratio: %v", err)
+	}
+	if tau != 0.75 {
+		t.Errorf("Expected critical ratio 0.75, got %f", tau)
+	}
+	if _, err := CriticalRatio(0, 1); err == nil {
+		t.Error("Expected error for zero cost")
+	}
+}
+
+func TestNewsvendor(t *testing.T) {
+	x := [][]float64{{1, 0.5}, {1, 1.0}, {1, 1.5}, {1, 2.0}, {1, 2.5}}
+	y := []float64{1.0, 2.0, 2.5, 3.0, 4.0}
+	newX := [][]float64{{1, 3.0}}
+
+	plan, err := Newsvendor(y, x, 3, 1, newX)
+	if err != nil {
+		t.Fatalf("Failed to compute newsvendor plan: %v", err)
+	}
+	if plan.Tau != 0.75 || len(plan.Orders) != 1 {
+		t.Errorf("Unexpected plan: %+v", plan)
+	}
+
+	// Expected cost matches the direct cost computation
+	fit, _ := RQ(y, x, 0.75)
+	cost := 0.0
+	for _, r := range fit.Residuals {
+		cost += 3*math.Max(r, 0) + 1*math.Max(-r, 0)
+	}
+	if math.Abs(plan.ExpectedCost-cost/5) > 1e-9 {
+		t.Errorf("Expected cost %f, got %f", cost/5, plan.ExpectedCost)
+	}
+
+	fits, err := RQProcess(y, x, []float64{0.5, 0.9})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+	plan, err = fits.Newsvendor(3, 1, newX)
+	if err != nil {
+		t.Fatalf("Failed to compute newsvendor plan: %v", err)
+	}
+	pred, _ := fits.Predict(newX)
+	lo := math.Min(pred[0.5][0], pred[0.9][0])
+	hi := math.Max(pred[0.5][0], pred[0.9][0])
+	if plan.Orders[0] < lo || plan.Orders[0] > hi {
+		t.Errorf("Expected interpolated order in [%f, %f], got %f", lo, hi, plan.Orders[0])
+	}
+	if plan.ExpectedCost <= 0 {
+		t.Errorf("Expected positive cost, got %f", plan.ExpectedCost)
+	}
+
+	// Critical ratio outside the fitted grid
+	if _, err := fits.Newsvendor(1, 3, newX); err == nil {
+		t.Error("Expected error for tau outside the fitted range")
+	}
+}
@@ -3,7 +3,9 @@ package quantreg
 import (
 	"fmt"
 	"math"
-	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/floats"
 )
 
 // NonLinearModel represents a non-linear model function and its gradient
@@ -26,10 +28,32 @@ type NLRQFit struct {
 	P            int            // Number of parameters
 	Model        NonLinearModel // The non-linear model
 	Formula      string         // Model formula
+	X            [][]float64    // Predictors used for fitting
+	Iterations   int            // Number of solver iterations
+	Converged    bool           // Whether the solver met its convergence tolerance
+	Ridge        float64        // L2 penalty weight, zero when unpenalized
+	RidgeWeights []float64      // Per-parameter penalty weights
+	Meta         Meta           // Reproducibility metadata
+}
+
+// NLRQOptions controls NLRQWithOptions
+type NLRQOptions struct {
+	Ridge        float64   // L2 penalty weight lambda (default 0, unpenalized)
+	RidgeWeights []float64 // Non-negative weight per parameter; zero leaves it unpenalized (default all 1)
+	Optimizer    Optimizer // Minimizer of the smoothed objective (default LBFGS())
+	MaxIter      int       // Maximum optimizer steps over all smoothing stages (default 1000)
+	Tolerance    float64   // Relative objective decrease ending a smoothing stage (default 1e-8)
 }
 
 // NLRQ fits a non-linear quantile regression model
 func NLRQ(y []float64, x [][]float64, model NonLinearModel, beta0 []float64, tau float64) (*NLRQFit, error) {
+	return NLRQWithOptions(y, x, model, beta0, tau, NLRQOptions{})
+}
+
+// NLRQWithOptions fits a non-linear quantile regression model. A positive Ridge
+// adds lambda * sum_j w_j beta_j^2 to the check loss, stabilizing parameters that
+// the data identify only weakly.
+func NLRQWithOptions(y []float64, x [][]float64, model NonLinearModel, beta0 []float64, tau float64, opts NLRQOptions) (*NLRQFit, error) {
 	if len(y) == 0 || len(x) == 0 {
 		return nil, fmt.Errorf("empty input data")
 	}
@@ -38,25 +62,56 @@ func NLRQ(y []float64, x [][]float64, model NonLinearModel, beta0 []float64, tau
 	p := len(beta0)
 
 	if n != len(x) {
-		return nil, fmt.Errorf("x and y dimensions do not match: len(y)=%d, len(x)=%d", len(y), len(x))
+		return nil, fmt.Errorf("x and y %w: len(y)=%d, len(x)=%d", ErrDimensionMismatch, len(y), len(x))
 	}
 
 	if tau <= 0 || tau >= 1 {
-		return nil, fmt.Errorf("tau must be between 0 and 1")
+		return nil, ErrInvalidTau
 	}
 
+	if opts.Optimizer == nil {
+		opts.Optimizer = LBFGS()
+	}
+	if opts.MaxIter == 0 {
+		opts.MaxIter = 1000
+	}
+	if opts.Tolerance == 0 {
+		opts.Tolerance = 1e-8
+	}
+	if opts.Ridge < 0 {
+		return nil, fmt.Errorf("ridge penalty must be non-negative, got %v", opts.Ridge)
+	}
+	if opts.RidgeWeights == nil {
+		opts.RidgeWeights = make([]float64, p)
+		for j := range opts.RidgeWeights {
+			opts.RidgeWeights[j] = 1
+		}
+	}
+	if len(opts.RidgeWeights) != p {
+		return nil, fmt.Errorf("%w: %d ridge weights for %d parameters", ErrDimensionMismatch, len(opts.RidgeWeights), p)
+	}
+	for j, w := range opts.RidgeWeights {
+		if w < 0 {
+			return nil, fmt.Errorf("ridge weight %d must be non-negative, got %v", j, w)
+		}
+	}
+
+	start := time.Now()
+
 	// Initialize the fit
 	fit := &NLRQFit{
-		Tau:   tau,
-		N:     n,
-		P:     p,
-		Model: model,
+		Tau:          tau,
+		N:            n,
+		P:            p,
+		Model:        model,
+		X:            x,
+		Ridge:        opts.Ridge,
+		RidgeWeights: opts.RidgeWeights,
 	}
 
-	// Optimize parameters using interior point method
-	coef, err := fit.solveInteriorPoint(y, x, beta0)
+	coef, err := fit.solve(y, x, beta0, opts.Optimizer, opts.MaxIter, opts.Tolerance)
 	if err != nil {
-		return nil, fmt.Errorf("optimization failed: %v", err)
+		return nil, fmt.Errorf("optimization failed: %w", err)
 	}
 
 	fit.Coefficients = coef
@@ -71,68 +126,118 @@ func NLRQ(y []float64, x [][]float64, model NonLinearModel, beta0 []float64, tau
 		fit.Residuals[i] = y[i] - fitted
 	}
 
+	options := map[string]float64{"max_iter": float64(opts.MaxIter), "tolerance": opts.Tolerance}
+	if opts.Ridge > 0 {
+		options["ridge"] = opts.Ridge
+	}
+	fit.Meta = newMeta("nlrq", options, []float64{tau}, n, p, start, y, x)
+	logFit("nlrq", tau, n, p, fit.Iterations, fit.Converged, fit.Meta)
+
+	currentMetrics().ObserveFit("nlrq", time.Since(start), fit.Iterations, fit.Converged)
+
 	return fit, nil
 }
 
-// solveInteriorPoint implements the interior point method for non-linear quantile regression
-func (fit *NLRQFit) solveInteriorPoint(y []float64, x [][]float64, beta0 []float64) ([]float64, error) {
-	n := len(y)
+// solve minimizes the check loss by continuation: the kink of rho_tau is replaced
+// by a quadratic on [-h, h], and h shrinks tenfold from the spread of y to a
+// millionth of it, each smoothed objective being minimized by the optimizer from
+// the previous solution
+func (fit *NLRQFit) solve(y []float64, x [][]float64, beta0 []float64, opt Optimizer, maxIter int, tolerance float64) ([]float64, error) {
 	p := len(beta0)
+	beta := append([]float64(nil), beta0...)
 
-	// Algorithm parameters
-	maxIter := 1000
-	tolerance := 1e-8
-	learningRate := 0.01
-	t := 1.0 // Barrier parameter
-	mu := 10.0 // Barrier update parameter
-
-	// Initialize solution with starting values
-	beta := make([]float64, p)
-	copy(beta, beta0)
-
-	for iter := 0; iter < maxIter; iter++ {
-		// Calculate residuals and their gradients
-		residuals := make([]float64, n)
-		gradients := make([][]float64, n)
-
-		for i := 0; i < n; i++ {
-			predicted := fit.Model.F(beta, x[i])
-			residuals[i] = y[i] - predicted
-			gradients[i] = fit.Model.Gradient(beta, x[i])
-		}
+	mean := 0.0
+	for _, v := range y {
+		mean += v / float64(len(y))
+	}
+	scale := 0.0
+	for _, v := range y {
+		scale += math.Abs(v-mean) / float64(len(y))
+	}
+	if scale == 0 {
+		scale = 1
+	}
 
-		// Calculate the objective gradient
-		objGrad := make([]float64, p)
-		for j := 0; j < p; j++ {
-			for i := 0; i < n; i++ {
-				if residuals[i] > 0 {
-					objGrad[j] += gradients[i][j] * (fit.Tau - 1)
-				} else {
-					objGrad[j] += gradients[i][j] * fit.Tau
-				}
+	grad := make([]float64, p)
+	for h := scale; ; h /= 10 {
+		obj := fit.objective(y, x, h)
+		opt.Init(p)
+		f := obj.Func(beta)
+		obj.Grad(grad, beta)
+		done := false
+		for fit.Iterations < maxIter {
+			if floats.Norm(grad, math.Inf(1)) <= tolerance {
+				done = true
+				break
+			}
+			next, err := opt.Step(obj, beta, f, grad)
+			if err != nil {
+				return nil, err
+			}
+			if len(next) != p {
+				return nil, fmt.Errorf("optimizer returned %d parameters, want %d", len(next), p)
+			}
+			fit.Iterations++
+			fNext := obj.Func(next)
+			if math.IsNaN(fNext) {
+				return nil, fmt.Errorf("objective is NaN at %v", next)
+			}
+			obj.Grad(grad, next)
+			done = f-fNext <= tolerance*(1+math.Abs(f))
+			beta, f = next, fNext
+			if done {
+				break
 			}
 		}
-
-		// Check convergence
-		maxGrad := 0.0
-		for j := 0; j < p; j++ {
-			maxGrad = math.Max(maxGrad, math.Abs(objGrad[j]))
-		}
-
-		if maxGrad < tolerance {
+		if !done {
 			break
 		}
-
-		// Update parameters
-		for j := 0; j < p; j++ {
-			beta[j] -= learningRate * objGrad[j]
+		if h <= 1e-6*scale {
+			fit.Converged = true
+			break
 		}
+	}
+	return beta, nil
+}
 
-		// Update barrier parameter
-		t *= mu
+// objective returns the check loss smoothed with bandwidth h plus the ridge penalty
+func (fit *NLRQFit) objective(y []float64, x [][]float64, h float64) Objective {
+	return Objective{
+		Func: func(beta []float64) float64 {
+			f := 0.0
+			for i, row := range x {
+				v, _ := smoothRho(y[i]-fit.Model.F(beta, row), fit.Tau, h)
+				f += v
+			}
+			for j, b := range beta {
+				f += fit.Ridge * fit.RidgeWeights[j] * b * b
+			}
+			return f
+		},
+		Grad: func(grad, beta []float64) {
+			for j, b := range beta {
+				grad[j] = 2 * fit.Ridge * fit.RidgeWeights[j] * b
+			}
+			for i, row := range x {
+				_, psi := smoothRho(y[i]-fit.Model.F(beta, row), fit.Tau, h)
+				for j, g := range fit.Model.Gradient(beta, row) {
+					grad[j] -= psi * g
+				}
+			}
+		},
 	}
+}
 
-	return beta, nil
+// smoothRho returns the check function with its kink replaced by a quadratic on
+// [-h, h], and its derivative; both are continuous and agree with rho_tau outside
+func smoothRho(r, tau, h float64) (float64, float64) {
+	switch {
+	case r > h:
+		return tau * r, tau
+	case r < -h:
+		return (tau - 1) * r, tau - 1
+	}
+	return r*r/(4*h) + (tau-0.5)*r + h/4, r/(2*h) + tau - 0.5
 }
 
 // Predict generates predictions from a fitted non-linear quantile regression model
@@ -141,6 +246,7 @@ func (fit *NLRQFit) Predict(newX [][]float64) ([]float64, error) {
 		return nil, fmt.Errorf("empty input data")
 	}
 
+	start := time.Now()
 	n := len(newX)
 	predictions := make([]float64, n)
 
@@ -148,34 +254,12 @@ func (fit *NLRQFit) Predict(newX [][]float64) ([]float64, error) {
 		predictions[i] = fit.Model.F(fit.Coefficients, newX[i])
 	}
 
+	currentMetrics().ObservePredict(n, time.Since(start))
+
 	return predictions, nil
 }
 
 // Summary prints a summary of the fitted non-linear model
 func (fit *NLRQFit) Summary() string {
-	result := fmt.Sprintf("Non-linear Quantile Regression (tau = %.2f)\n", fit.Tau)
-	result += fmt.Sprintf("Number of observations: %d\n", fit.N)
-	result += fmt.Sprintf("Number of parameters: %d\n\n", fit.P)
-
-	result += "Coefficients:\n"
-	for i, coef := range fit.Coefficients {
-		result += fmt.Sprintf("  Beta[%d]: %.6f\n", i, coef)
-	}
-
-	// Calculate residual statistics
-	sortedResiduals := make([]float64, len(fit.Residuals))
-	copy(sortedResiduals, fit.Residuals)
-	sort.Float64s(sortedResiduals)
-
-	var sumAbs float64
-	for _, r := range sortedResiduals {
-		sumAbs += math.Abs(r)
-	}
-
-	result += fmt.Sprintf("\nResidual summary:\n")
-	result += fmt.Sprintf("  Min: %.6f\n", sortedResiduals[0])
-	result += fmt.Sprintf("  Max: %.6f\n", sortedResiduals[len(sortedResiduals)-1])
-	result += fmt.Sprintf("  Median: %.6f\n", sortedResiduals[len(sortedResiduals)/2])
-
-	return result
+	return fit.SummaryTable().String()
 }
@@ -127,3 +127,57 @@ func TestNLRQFitSummary(t *testing.T) {
 		t.Error("Expected non-empty summary string")
 	}
 }
+
+func TestNLRQRidge(t *testing.T) {
+	// Only the sum of the two parameters is identified
+	model := NonLinearModel{
+		F: func(beta []float64, x []float64) float64 {
+			return (beta[0] + beta[1]) * x[0]
+		},
+		Gradient: func(beta []float64, x []float64) []float64 {
+			return []float64{x[0], x[0]}
+		},
+	}
+	x := make([][]float64, 20)
+	y := make([]float64, 20)
+	for i := range x {
+		x[i] = []float64{float64(i+1) / 10}
+		y[i] = 2 * x[i][0]
+	}
+	beta0 := []float64{3, -1}
+
+	fit, err := NLRQ(y, x, model, beta0, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if d := fit.Coefficients[0] - fit.Coefficients[1]; math.Abs(d-4) > 1e-8 {
+		t.Errorf("Expected the unpenalized fit to keep the starting difference 4, got %v", d)
+	}
+
+	ridge, err := NLRQWithOptions(y, x, model, beta0, 0.5, NLRQOptions{Ridge: 1})
+	if err != nil {
+		t.Fatalf("Failed to fit ridge model: %v", err)
+	}
+	if d := ridge.Coefficients[0] - ridge.Coefficients[1]; math.Abs(d) > 0.01 {
+		t.Errorf("Expected the ridge penalty to balance the parameters, got difference %v", d)
+	}
+	if ridge.Meta.Options["ridge"] != 1 {
+		t.Errorf("Expected ridge recorded in metadata, got %v", ridge.Meta.Options)
+	}
+
+	// A zero weight leaves the first parameter free
+	free, err := NLRQWithOptions(y, x, model, beta0, 0.5, NLRQOptions{Ridge: 1, RidgeWeights: []float64{0, 1}})
+	if err != nil {
+		t.Fatalf("Failed to fit weighted ridge model: %v", err)
+	}
+	if math.Abs(free.Coefficients[1]) > 0.1 {
+		t.Errorf("Expected the penalized parameter near 0, got %v", free.Coefficients[1])
+	}
+
+	if _, err := NLRQWithOptions(y, x, model, beta0, 0.5, NLRQOptions{Ridge: -1}); err == nil {
+		t.Error("Expected error for negative ridge penalty")
+	}
+	if _, err := NLRQWithOptions(y, x, model, beta0, 0.5, NLRQOptions{Ridge: 1, RidgeWeights: []float64{1}}); err == nil {
+		t.Error("Expected error for mismatched ridge weights")
+	}
+}
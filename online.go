@@ -0,0 +1,191 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// OnlineOptions controls NewOnlineRQ
+type OnlineOptions struct {
+	LearningRate float64 // Initial step size eta0; step t is eta0 / t^0.6 (default 1)
+	Replicas     int     // Poisson bootstrap replicas for streaming inference (default 0)
+}
+
+// OnlineRQ estimates a linear quantile regression from a stream by averaged
+// stochastic subgradient descent on the check loss. With replicas enabled it also
+// runs K perturbed copies, each applying every update Poisson(1) times, so
+// bootstrap standard errors are available at any point without storing history.
+// It is safe for concurrent use.
+type OnlineRQ struct {
+	Tau float64
+
+	mu       sync.Mutex
+	eta0     float64
+	n        int
+	beta     []float64
+	avg      []float64
+	repBeta  [][]float64
+	repAvg   [][]float64
+	repCount []int
+	rng      *rand.Rand
+}
+
+// NewOnlineRQ creates a streaming estimator for p coefficients
+func NewOnlineRQ(p int, tau float64, opts OnlineOptions, rng *rand.Rand) (*OnlineRQ, error) {
+	if p <= 0 {
+		return nil, fmt.Errorf("number of parameters must be positive, got %d", p)
+	}
+	if tau <= 0 || tau >= 1 {
+		return nil, ErrInvalidTau
+	}
+	if opts.LearningRate == 0 {
+		opts.LearningRate = 1
+	}
+	if opts.LearningRate < 0 || opts.Replicas < 0 {
+		return nil, fmt.Errorf("learning rate and replicas must be non-negative")
+	}
+
+	o := &OnlineRQ{
+		Tau:      tau,
+		eta0:     opts.LearningRate,
+		beta:     make([]float64, p),
+		avg:      make([]float64, p),
+		repCount: make([]int, opts.Replicas),
+		rng:      randOrDefault(rng),
+	}
+	for k := 0; k < opts.Replicas; k++ {
+		o.repBeta = append(o.repBeta, make([]float64, p))
+		o.repAvg = append(o.repAvg, make([]float64, p))
+	}
+	return o, nil
+}
+
+// Update processes one observation
+func (o *OnlineRQ) Update(x []float64, y float64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(x) != len(o.beta) {
+		return fmt.Errorf("%w: expected %d features, got %d", ErrDimensionMismatch, len(o.beta), len(x))
+	}
+
+	o.n++
+	sgdStep(o.beta, x, y, o.Tau, o.eta0/math.Pow(float64(o.n), 0.6))
+	polyak(o.avg, o.beta, o.n)
+
+	for k := range o.repBeta {
+		for w := poisson1(o.rng); w > 0; w-- {
+			o.repCount[k]++
+			sgdStep(o.repBeta[k], x, y, o.Tau, o.eta0/math.Pow(float64(o.repCount[k]), 0.6))
+			polyak(o.repAvg[k], o.repBeta[k], o.repCount[k])
+		}
+	}
+	return nil
+}
+
+// N returns the number of observations processed
+func (o *OnlineRQ) N() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.n
+}
+
+// Coefficients returns the averaged coefficient estimate
+func (o *OnlineRQ) Coefficients() []float64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]float64(nil), o.avg...)
+}
+
+// Predict returns predictions from the current averaged estimate
+func (o *OnlineRQ) Predict(newX [][]float64) ([]float64, error) {
+	beta := o.Coefficients()
+	out := make([]float64, len(newX))
+	for i, row := range newX {
+		if len(row) != len(beta) {
+			return nil, fmt.Errorf("%w: expected %d features, got %d", ErrDimensionMismatch, len(beta), len(row))
+		}
+		for j, v := range row {
+			out[i] += v * beta[j]
+		}
+	}
+	return out, nil
+}
+
+// StdErrors returns the standard deviation of the replica estimates
+func (o *OnlineRQ) StdErrors() ([]float64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.repAvg) < 2 {
+		return nil, fmt.Errorf("need at least 2 bootstrap replicas, got %d", len(o.repAvg))
+	}
+	se := make([]float64, len(o.avg))
+	col := make([]float64, len(o.repAvg))
+	for j := range se {
+		for k, r := range o.repAvg {
+			col[k] = r[j]
+		}
+		_, s := meanSE(col)
+		se[j] = s * math.Sqrt(float64(len(col)))
+	}
+	return se, nil
+}
+
+// ConfInt returns percentile intervals from the replica estimates
+func (o *OnlineRQ) ConfInt(level float64) ([]float64, []float64, error) {
+	if level <= 0 || level >= 1 {
+		return nil, nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.repAvg) < 2 {
+		return nil, nil, fmt.Errorf("need at least 2 bootstrap replicas, got %d", len(o.repAvg))
+	}
+	p := len(o.avg)
+	lower := make([]float64, p)
+	upper := make([]float64, p)
+	col := make([]float64, len(o.repAvg))
+	for j := 0; j < p; j++ {
+		for k, r := range o.repAvg {
+			col[k] = r[j]
+		}
+		sort.Float64s(col)
+		lower[j] = empiricalQuantile(col, (1-level)/2)
+		upper[j] = empiricalQuantile(col, 1-(1-level)/2)
+	}
+	return lower, upper, nil
+}
+
+// sgdStep moves beta along the negative check loss subgradient at (x, y)
+func sgdStep(beta, x []float64, y, tau, eta float64) {
+	fitted := 0.0
+	for j, v := range x {
+		fitted += v * beta[j]
+	}
+	g := tau
+	if y < fitted {
+		g = tau - 1
+	}
+	for j, v := range x {
+		beta[j] += eta * g * v
+	}
+}
+
+// polyak updates the running mean avg of the iterates with the n-th iterate beta
+func polyak(avg, beta []float64, n int) {
+	for j := range avg {
+		avg[j] += (beta[j] - avg[j]) / float64(n)
+	}
+}
+
+// poisson1 draws from a Poisson distribution with mean 1
+func poisson1(rng *rand.Rand) int {
+	l := math.Exp(-1)
+	k := 0
+	for p := rng.Float64(); p > l; p *= rng.Float64() {
+		k++
+	}
+	return k
+}
@@ -0,0 +1,71 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestOnlineRQ(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	o, err := NewOnlineRQ(2, 0.5, OnlineOptions{Replicas: 20}, rng)
+	if err != nil {
+		t.Fatalf("Failed to create online estimator: %v", err)
+	}
+
+	data := rand.New(rand.NewSource(2))
+	for i := 0; i < 20000; i++ {
+		xi := data.Float64() * 2
+		if err := o.Update([]float64{1, xi}, 1+2*xi+data.NormFloat64()*0.5); err != nil {
+			t.Fatalf("Failed to update: %v", err)
+		}
+	}
+	if o.N() != 20000 {
+		t.Errorf("Expected 20000 observations, got %d", o.N())
+	}
+
+	beta := o.Coefficients()
+	if math.Abs(beta[0]-1) > 0.15 || math.Abs(beta[1]-2) > 0.15 {
+		t.Errorf("Expected coefficients near (1, 2), got %v", beta)
+	}
+
+	se, err := o.StdErrors()
+	if err != nil {
+		t.Fatalf("Failed to compute standard errors: %v", err)
+	}
+	lower, upper, err := o.ConfInt(0.9)
+	if err != nil {
+		t.Fatalf("Failed to compute intervals: %v", err)
+	}
+	for j := range se {
+		if se[j] <= 0 || se[j] > 0.5 || lower[j] >= upper[j] {
+			t.Errorf("Unexpected inference for coefficient %d: se=%f [%f, %f]", j, se[j], lower[j], upper[j])
+		}
+	}
+
+	pred, err := o.Predict([][]float64{{1, 1}})
+	if err != nil || math.Abs(pred[0]-(beta[0]+beta[1])) > 1e-12 {
+		t.Errorf("Unexpected prediction: %v, %v", pred, err)
+	}
+
+	// Poisson(1) draws have mean 1
+	total := 0
+	for i := 0; i < 10000; i++ {
+		total += poisson1(rng)
+	}
+	if mean := float64(total) / 10000; math.Abs(mean-1) > 0.05 {
+		t.Errorf("Expected Poisson mean near 1, got %f", mean)
+	}
+
+	// Error cases
+	if err := o.Update([]float64{1}, 0); err == nil {
+		t.Error("Expected error for wrong feature count")
+	}
+	plain, _ := NewOnlineRQ(2, 0.5, OnlineOptions{}, nil)
+	if _, err := plain.StdErrors(); err == nil {
+		t.Error("Expected error without replicas")
+	}
+	if _, err := NewOnlineRQ(2, 1.5, OnlineOptions{}, nil); err == nil {
+		t.Error("Expected error for invalid tau")
+	}
+}
@@ -0,0 +1,112 @@
+package quantreg
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/optimize"
+)
+
+// Objective is a differentiable function handed to an Optimizer
+type Objective struct {
+	Func func(beta []float64) float64
+	Grad func(grad, beta []float64) // Stores the gradient at beta in grad
+}
+
+// Optimizer proposes parameter steps for NLRQ. NLRQ owns the smoothing of the
+// check loss and the convergence test; the optimizer only turns the current
+// objective value and gradient into the next parameter vector.
+type Optimizer interface {
+	// Init resets the optimizer for a new objective in p parameters
+	Init(p int)
+	// Step returns the next parameters from beta, given the objective value f and
+	// gradient grad at beta. It must not modify beta or grad.
+	Step(obj Objective, beta []float64, f float64, grad []float64) ([]float64, error)
+}
+
+// LineSearchOptimizer moves along the search direction of a gonum/optimize
+// NextDirectioner, such as *optimize.LBFGS or *optimize.CG, with a backtracking
+// line search enforcing the Armijo condition. A nil Direction is steepest descent.
+// gonum fills the defaults of its methods only inside optimize.Minimize, so a
+// Direction set here must be fully configured, as by LBFGS and ConjugateGradient.
+type LineSearchOptimizer struct {
+	Direction optimize.NextDirectioner
+
+	dir []float64
+}
+
+// LBFGS returns a limited-memory BFGS optimizer
+func LBFGS() *LineSearchOptimizer {
+	return &LineSearchOptimizer{Direction: &optimize.LBFGS{Store: 15}}
+}
+
+// ConjugateGradient returns a nonlinear conjugate gradient optimizer
+func ConjugateGradient() *LineSearchOptimizer {
+	return &LineSearchOptimizer{Direction: &optimize.CG{
+		Variant:                &optimize.HestenesStiefel{},
+		InitialStep:            &optimize.FirstOrderStepSize{},
+		IterationRestartFactor: 6,
+		AngleRestartThreshold:  -0.9,
+	}}
+}
+
+// GradientDescent returns a steepest descent optimizer
+func GradientDescent() *LineSearchOptimizer {
+	return &LineSearchOptimizer{}
+}
+
+// Init resets the search direction history
+func (o *LineSearchOptimizer) Init(p int) {
+	if o.Direction == nil {
+		o.Direction = steepestDescent{}
+	}
+	o.dir = nil
+}
+
+// Step takes one line search step along the current search direction, returning
+// beta unchanged when no decrease is found
+func (o *LineSearchOptimizer) Step(obj Objective, beta []float64, f float64, grad []float64) ([]float64, error) {
+	loc := &optimize.Location{X: beta, F: f, Gradient: grad}
+	var step float64
+	if o.dir == nil {
+		o.dir = make([]float64, len(beta))
+		step = o.Direction.InitDirection(loc, o.dir)
+	} else {
+		step = o.Direction.NextDirection(loc, o.dir)
+	}
+	slope := dot(grad, o.dir)
+	if slope >= 0 {
+		// Not a descent direction: restart from steepest descent
+		step = o.Direction.InitDirection(loc, o.dir)
+		slope = dot(grad, o.dir)
+	}
+	next := append([]float64(nil), beta...)
+	if slope >= 0 || math.IsNaN(step) || step <= 0 {
+		return next, nil
+	}
+	for k := 0; k < 60; k++ {
+		for j := range next {
+			next[j] = beta[j] + step*o.dir[j]
+		}
+		if obj.Func(next) <= f+1e-4*step*slope {
+			return next, nil
+		}
+		step /= 2
+	}
+	return append(next[:0], beta...), nil
+}
+
+// steepestDescent is the NextDirectioner of GradientDescent
+type steepestDescent struct{}
+
+// InitDirection sets dir to the negative gradient with a unit initial step
+func (steepestDescent) InitDirection(loc *optimize.Location, dir []float64) float64 {
+	for j, g := range loc.Gradient {
+		dir[j] = -g
+	}
+	return 1
+}
+
+// NextDirection sets dir to the negative gradient with a unit initial step
+func (s steepestDescent) NextDirection(loc *optimize.Location, dir []float64) float64 {
+	return s.InitDirection(loc, dir)
+}
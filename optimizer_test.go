@@ -0,0 +1,111 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// fixedStep is a user optimizer taking gradient steps of fixed length
+type fixedStep struct {
+	length float64
+	calls  int
+}
+
+func (s *fixedStep) Init(p int) {}
+
+func (s *fixedStep) Step(obj Objective, beta []float64, f float64, grad []float64) ([]float64, error) {
+	s.calls++
+	norm := 0.0
+	for _, g := range grad {
+		norm += g * g
+	}
+	next := append([]float64(nil), beta...)
+	for j, g := range grad {
+		next[j] -= s.length * g / math.Sqrt(norm)
+	}
+	if obj.Func(next) > f {
+		return append([]float64(nil), beta...), nil
+	}
+	return next, nil
+}
+
+type badOptimizer struct{}
+
+func (badOptimizer) Init(p int) {}
+
+func (badOptimizer) Step(obj Objective, beta []float64, f float64, grad []float64) ([]float64, error) {
+	return nil, fmt.Errorf("no step")
+}
+
+func TestNLRQOptimizers(t *testing.T) {
+	model := NonLinearModel{
+		F: func(beta []float64, x []float64) float64 {
+			return beta[0] * math.Exp(beta[1]*x[0])
+		},
+		Gradient: func(beta []float64, x []float64) []float64 {
+			exp := math.Exp(beta[1] * x[0])
+			return []float64{exp, beta[0] * x[0] * exp}
+		},
+	}
+	n := 100
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := range x {
+		x[i] = []float64{float64(i) / 50}
+		y[i] = math.Exp(0.5*x[i][0]) + 0.2*math.Sin(float64(7*i))
+	}
+	beta0 := []float64{0.5, 0.1}
+
+	for name, opt := range map[string]Optimizer{
+		"lbfgs": LBFGS(),
+		"cg":    ConjugateGradient(),
+		"gd":    GradientDescent(),
+		"user":  &fixedStep{length: 1e-3},
+	} {
+		fit, err := NLRQWithOptions(y, x, model, beta0, 0.9, NLRQOptions{Optimizer: opt, MaxIter: 5000})
+		if err != nil {
+			t.Fatalf("%s: failed to fit model: %v", name, err)
+		}
+		below := 0
+		for _, r := range fit.Residuals {
+			if r <= 1e-6 {
+				below++
+			}
+		}
+		if frac := float64(below) / float64(n); math.Abs(frac-0.9) > 0.06 {
+			t.Errorf("%s: expected about 90%% of observations below the fit, got %v", name, frac)
+		}
+		if math.Abs(fit.Coefficients[1]-0.5) > 0.1 {
+			t.Errorf("%s: expected growth rate near 0.5, got %v", name, fit.Coefficients[1])
+		}
+	}
+
+	fit, err := NLRQWithOptions(y, x, model, beta0, 0.5, NLRQOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if !fit.Converged || fit.Meta.Options["max_iter"] != 1000 {
+		t.Errorf("Expected a converged fit with default options, got %v iterations and %v", fit.Iterations, fit.Meta.Options)
+	}
+
+	if _, err := NLRQWithOptions(y, x, model, beta0, 0.5, NLRQOptions{Optimizer: badOptimizer{}}); err == nil {
+		t.Error("Expected error from a failing optimizer")
+	}
+}
+
+func TestSmoothRho(t *testing.T) {
+	tau, h := 0.3, 0.5
+	for _, r := range []float64{-2, -h, -0.1, 0, 0.2, h, 3} {
+		v, d := smoothRho(r, tau, h)
+		if math.Abs(r) >= h && math.Abs(v-rho(r, tau)) > 1e-12 {
+			t.Errorf("Expected rho(%v) = %v outside the band, got %v", r, rho(r, tau), v)
+		}
+		eps := 1e-6
+		lo, _ := smoothRho(r-eps, tau, h)
+		hi, _ := smoothRho(r+eps, tau, h)
+		if num := (hi - lo) / (2 * eps); math.Abs(num-d) > 1e-5 {
+			t.Errorf("Expected derivative %v at %v, got %v", num, r, d)
+		}
+	}
+}
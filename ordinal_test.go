@@ -0,0 +1,52 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRQOrdinal(t *testing.T) {
+	// Ratings 1, 3 and 5 increasing with x
+	n := 150
+	y := make([]int, n)
+	x := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		xi := float64(i) / float64(n)
+		x[i] = []float64{1, xi}
+		latent := 3*xi + 0.4*math.Sin(float64(9*i))
+		switch {
+		case latent < 1:
+			y[i] = 1
+		case latent < 2:
+			y[i] = 3
+		default:
+			y[i] = 5
+		}
+	}
+
+	fit, err := RQOrdinal(y, x, 0.5, DitherOptions{}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to fit ordinal model: %v", err)
+	}
+	if len(fit.Levels) != 3 || fit.Levels[0] != 1 || fit.Levels[2] != 5 {
+		t.Errorf("Expected levels [1 3 5], got %v", fit.Levels)
+	}
+	if fit.Coefficients[1] <= 0 {
+		t.Errorf("Expected positive latent slope, got %v", fit.Coefficients[1])
+	}
+
+	pred, err := fit.Predict([][]float64{{1, 0.05}, {1, 0.5}, {1, 0.95}, {1, 10}})
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	for i, want := range []int{1, 3, 5, 5} {
+		if pred[i] != want {
+			t.Errorf("Expected category %d at row %d, got %d", want, i, pred[i])
+		}
+	}
+
+	if _, err := RQOrdinal([]int{2, 2}, [][]float64{{1}, {1}}, 0.5, DitherOptions{}, nil); err == nil {
+		t.Error("Expected error for a single category")
+	}
+}
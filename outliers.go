@@ -0,0 +1,109 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Reasons reported for flagged observations
+const (
+	FlagResidual  = "extreme residual"
+	FlagLeverage  = "high leverage"
+	FlagInfluence = "high influence"
+)
+
+// FlagThresholds sets the cut-offs used by FlagObservations. Zero fields take the defaults.
+type FlagThresholds struct {
+	Residual float64 // Absolute robustly standardized residual (default 3)
+	Leverage float64 // Multiple of the average leverage p/n (default 2)
+	Distance float64 // Multiple of 1/n for the Cook-type distance (default 4)
+}
+
+// FlaggedObservation describes an observation singled out at one tau
+type FlaggedObservation struct {
+	Index       int      // Row of the observation in the design matrix
+	Tau         float64  // Quantile level
+	Reasons     []string // Why the observation was flagged
+	StdResidual float64  // Residual centred at the median and scaled by the normalized MAD
+	Leverage    float64  // Hat value, NaN when unavailable
+	Distance    float64  // Cook-type distance, NaN when unavailable
+}
+
+// withDefaults fills unset thresholds
+func (th FlagThresholds) withDefaults() FlagThresholds {
+	if th.Residual <= 0 {
+		th.Residual = 3
+	}
+	if th.Leverage <= 0 {
+		th.Leverage = 2
+	}
+	if th.Distance <= 0 {
+		th.Distance = 4
+	}
+	return th
+}
+
+// FlagObservations returns observations with extreme standardized residuals, high
+// leverage or high influence, ordered by index. When the influence measures cannot
+// be computed only residuals are screened.
+func (fit *RQFit) FlagObservations(th FlagThresholds) ([]FlaggedObservation, error) {
+	th = th.withDefaults()
+
+	resid := fit.ResidualValues()
+	z, err := robustStandardize(resid)
+	if err != nil {
+		return nil, err
+	}
+
+	inf, infErr := fit.Influence()
+	n := float64(len(resid))
+
+	var flagged []FlaggedObservation
+	for i := range resid {
+		obs := FlaggedObservation{
+			Index:       i,
+			Tau:         fit.Tau,
+			StdResidual: z[i],
+			Leverage:    math.NaN(),
+			Distance:    math.NaN(),
+		}
+		if math.Abs(z[i]) > th.Residual {
+			obs.Reasons = append(obs.Reasons, FlagResidual)
+		}
+		if infErr == nil {
+			obs.Leverage = inf.Leverage[i]
+			obs.Distance = inf.Distance[i]
+			if inf.Leverage[i] > th.Leverage*float64(fit.P)/n {
+				obs.Reasons = append(obs.Reasons, FlagLeverage)
+			}
+			if inf.Distance[i] > th.Distance/n {
+				obs.Reasons = append(obs.Reasons, FlagInfluence)
+			}
+		}
+		if len(obs.Reasons) > 0 {
+			flagged = append(flagged, obs)
+		}
+	}
+
+	return flagged, nil
+}
+
+// FlagObservations screens every tau and returns the flagged observations ordered by tau and index
+func (m *MultiRQFit) FlagObservations(th FlagThresholds) ([]FlaggedObservation, error) {
+	var flagged []FlaggedObservation
+	for _, tau := range m.Taus {
+		obs, err := m.Fits[tau].FlagObservations(th)
+		if err != nil {
+			return nil, fmt.Errorf("flagging failed for tau=%f: %w", tau, err)
+		}
+		flagged = append(flagged, obs...)
+	}
+	sort.SliceStable(flagged, func(a, b int) bool {
+		if flagged[a].Tau != flagged[b].Tau {
+			return flagged[a].Tau < flagged[b].Tau
+		}
+		return flagged[a].Index < flagged[b].Index
+	})
+	return flagged, nil
+}
@@ -0,0 +1,86 @@
+package quantreg
+
+import (
+	"testing"
+)
+
+func TestFlagObservations(t *testing.T) {
+	y, x := inferenceData()
+	y[3] += 25
+	x[len(x)-1] = []float64{1, 12}
+
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	flagged, err := fit.FlagObservations(FlagThresholds{})
+	if err != nil {
+		t.Fatalf("Failed to flag observations: %v", err)
+	}
+
+	reasons := make(map[int][]string)
+	for _, obs := range flagged {
+		reasons[obs.Index] = obs.Reasons
+		if obs.Tau != 0.5 {
+			t.Errorf("Expected tau 0.5, got %f", obs.Tau)
+		}
+	}
+
+	if !contains(reasons[3], FlagResidual) {
+		t.Errorf("Expected observation 3 to be flagged for its residual, got %v", reasons[3])
+	}
+	if !contains(reasons[len(x)-1], FlagLeverage) {
+		t.Errorf("Expected observation %d to be flagged for leverage, got %v", len(x)-1, reasons[len(x)-1])
+	}
+
+	// Very loose thresholds flag nothing
+	loose, err := fit.FlagObservations(FlagThresholds{Residual: 1e6, Leverage: 1e6, Distance: 1e6})
+	if err != nil {
+		t.Fatalf("Failed to flag observations: %v", err)
+	}
+	if len(loose) != 0 {
+		t.Errorf("Expected no flagged observations, got %d", len(loose))
+	}
+}
+
+func TestDiagnosticsFlagged(t *testing.T) {
+	y, x := inferenceData()
+	y[5] -= 30
+
+	fits, err := RQProcess(y, x, []float64{0.25, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	if plain := fits.ComputeDiagnostics(); plain.Flagged != nil {
+		t.Error("Expected ComputeDiagnostics to leave flagging out")
+	}
+	diag, err := fits.ComputeDiagnosticsFlagged(FlagThresholds{})
+	if err != nil {
+		t.Fatalf("Failed to compute flagged diagnostics: %v", err)
+	}
+	seen := make(map[float64]bool)
+	for i, obs := range diag.Flagged {
+		if obs.Index == 5 && contains(obs.Reasons, FlagResidual) {
+			seen[obs.Tau] = true
+		}
+		if i > 0 && diag.Flagged[i-1].Tau > obs.Tau {
+			t.Error("Expected flagged observations ordered by tau")
+		}
+	}
+	for _, tau := range fits.Taus {
+		if !seen[tau] {
+			t.Errorf("Expected observation 5 to be flagged at tau=%f", tau)
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
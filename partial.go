@@ -0,0 +1,149 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// PartiallyLinearOptions controls RQPartiallyLinear
+type PartiallyLinearOptions struct {
+	Knots    int       // Interior knots of the spline, placed at quantiles of z (default 4)
+	Interior []float64 // Explicit interior knots, e.g. from SelectKnots, overriding Knots
+	Degree   int       // Spline degree (default 3)
+	Grid     int       // Number of grid points for the reported smooth (default 50)
+	SE       string    // Standard error method (default SEKer)
+}
+
+// PartiallyLinearFit is a quantile regression x'b + g(z) with a B-spline smooth g
+type PartiallyLinearFit struct {
+	*RQFit
+	Linear    int       // Number of parametric coefficients, the leading entries of Coefficients
+	StdErrors []float64 // Standard errors of the parametric coefficients
+	Knots     []float64 // Clamped knot vector of the smooth
+	Degree    int
+	Grid      []float64 // Equally spaced points over the range of z
+	Smooth    []float64 // g on the grid
+	SmoothSE  []float64 // Pointwise standard errors of g on the grid
+	cov       [][]float64
+}
+
+// RQPartiallyLinear fits Q_tau(y | x, z) = x'b + g(z) jointly, expanding g in a
+// B-spline basis. The first basis function is dropped so g is identified
+// against the intercept, which x must contain; g is therefore zero at the
+// smallest z and the curve is reported relative to that point.
+func RQPartiallyLinear(y []float64, x [][]float64, z []float64, tau float64, opts PartiallyLinearOptions) (*PartiallyLinearFit, error) {
+	if opts.Knots == 0 {
+		opts.Knots = 4
+	}
+	if opts.Degree == 0 {
+		opts.Degree = 3
+	}
+	if opts.Grid == 0 {
+		opts.Grid = 50
+	}
+	if opts.SE == "" {
+		opts.SE = SEKer
+	}
+	if opts.Knots < 0 || opts.Degree < 0 || opts.Grid < 2 {
+		return nil, fmt.Errorf("invalid spline options %+v", opts)
+	}
+	if len(x) == 0 || len(x) != len(y) || len(z) != len(y) {
+		return nil, fmt.Errorf("x, z and y %w", ErrDimensionMismatch)
+	}
+	p := len(x[0])
+	if !hasIntercept(x) {
+		return nil, fmt.Errorf("x must contain an intercept column")
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range z {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if lo == hi {
+		return nil, fmt.Errorf("z is constant")
+	}
+	interior := opts.Interior
+	if interior == nil {
+		interior = quantileKnots(z, opts.Knots)
+	}
+	for _, k := range interior {
+		if k <= lo || k >= hi {
+			return nil, fmt.Errorf("interior knot %f outside (%f, %f)", k, lo, hi)
+		}
+	}
+	knots := clampedKnots(lo, hi, interior, opts.Degree)
+
+	design := make([][]float64, len(y))
+	for i := range y {
+		design[i] = append(append([]float64(nil), x[i]...), bsplineBasis(z[i], knots, opts.Degree)[1:]...)
+	}
+	fit, err := RQ(y, design, tau)
+	if err != nil {
+		return nil, err
+	}
+	cov, err := fit.Vcov(opts.SE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute covariance: %w", err)
+	}
+
+	pl := &PartiallyLinearFit{
+		RQFit:     fit,
+		Linear:    p,
+		StdErrors: make([]float64, p),
+		Knots:     knots,
+		Degree:    opts.Degree,
+		Grid:      make([]float64, opts.Grid),
+		Smooth:    make([]float64, opts.Grid),
+		SmoothSE:  make([]float64, opts.Grid),
+		cov:       cov,
+	}
+	for j := range pl.StdErrors {
+		pl.StdErrors[j] = math.Sqrt(cov[j][j])
+	}
+	for g := range pl.Grid {
+		pl.Grid[g] = lo + (hi-lo)*float64(g)/float64(opts.Grid-1)
+		pl.Smooth[g], pl.SmoothSE[g] = pl.smoothAt(pl.Grid[g])
+	}
+	return pl, nil
+}
+
+// smoothAt returns g(z) and its standard error
+func (f *PartiallyLinearFit) smoothAt(z float64) (float64, float64) {
+	b := bsplineBasis(z, f.Knots, f.Degree)[1:]
+	g, v := 0.0, 0.0
+	for k, bk := range b {
+		g += bk * f.Coefficients[f.Linear+k]
+		for l, bl := range b {
+			v += bk * f.cov[f.Linear+k][f.Linear+l] * bl
+		}
+	}
+	return g, math.Sqrt(math.Max(v, 0))
+}
+
+// SmoothAt evaluates g at each z; values outside the fitted range are held at the boundary
+func (f *PartiallyLinearFit) SmoothAt(z []float64) []float64 {
+	g := make([]float64, len(z))
+	for i, v := range z {
+		g[i], _ = f.smoothAt(v)
+	}
+	return g
+}
+
+// Predict returns the fitted quantiles x'b + g(z) at new observations
+func (f *PartiallyLinearFit) Predict(newX [][]float64, newZ []float64) ([]float64, error) {
+	if len(newX) != len(newZ) {
+		return nil, fmt.Errorf("x and z %w", ErrDimensionMismatch)
+	}
+	g := f.SmoothAt(newZ)
+	pred := make([]float64, len(newX))
+	for i, row := range newX {
+		if len(row) != f.Linear {
+			return nil, fmt.Errorf("expected %d columns, got %d", f.Linear, len(row))
+		}
+		pred[i] = g[i]
+		for j, v := range row {
+			pred[i] += v * f.Coefficients[j]
+		}
+	}
+	return pred, nil
+}
@@ -0,0 +1,55 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQPartiallyLinear(t *testing.T) {
+	n := 200
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	z := make([]float64, n)
+	for i := 0; i < n; i++ {
+		d := float64(i % 2)
+		z[i] = float64(i) / float64(n-1)
+		x[i] = []float64{1, d}
+		y[i] = 1 + 2*d + math.Sin(2*math.Pi*z[i]) + 0.1*math.Sin(float64(13*i))
+	}
+
+	fit, err := RQPartiallyLinear(y, x, z, 0.5, PartiallyLinearOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit partially linear model: %v", err)
+	}
+	if math.Abs(fit.Coefficients[1]-2) > 0.3 {
+		t.Errorf("Expected parametric effect near 2, got %v", fit.Coefficients[1])
+	}
+	if len(fit.StdErrors) != 2 || fit.StdErrors[1] <= 0 {
+		t.Errorf("Expected positive standard errors, got %v", fit.StdErrors)
+	}
+	if len(fit.Grid) != 50 || len(fit.Smooth) != 50 || len(fit.SmoothSE) != 50 {
+		t.Fatalf("Expected 50 grid points, got %d", len(fit.Grid))
+	}
+	if fit.Smooth[0] != 0 {
+		t.Errorf("Expected smooth anchored at zero, got %v", fit.Smooth[0])
+	}
+
+	// The smooth tracks sin(2 pi z) relative to its value at z=0
+	for g, v := range fit.Grid {
+		if want := math.Sin(2 * math.Pi * v); math.Abs(fit.Smooth[g]-want) > 0.5 {
+			t.Errorf("Expected smooth near %v at z=%v, got %v", want, v, fit.Smooth[g])
+		}
+	}
+
+	pred, err := fit.Predict([][]float64{{1, 1}}, []float64{0.25})
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	if math.Abs(pred[0]-4) > 0.6 {
+		t.Errorf("Expected prediction near 4, got %v", pred[0])
+	}
+
+	if _, err := RQPartiallyLinear(y, [][]float64{}, z, 0.5, PartiallyLinearOptions{}); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
@@ -0,0 +1,122 @@
+package quantreg
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PDPOptions controls PartialDependence
+type PDPOptions struct {
+	Grid   int         // Equally spaced points between the observed minimum and maximum of each column (default 20)
+	Values [][]float64 // Explicit grid values per column, overriding Grid
+	ICE    bool        // Keep the individual conditional expectation curves
+}
+
+// PDGrid holds partial dependence of predicted quantiles on one or two columns
+type PDGrid struct {
+	Columns []int
+	Taus    []float64
+	Points  [][]float64             // Values of Columns at each grid point; for two columns the second varies fastest
+	Average map[float64][]float64   // Mean prediction over the data at each grid point, per tau
+	ICE     map[float64][][]float64 // Prediction for each observation at each grid point, per tau, when requested
+}
+
+// PartialDependence computes the partial dependence of the predicted quantiles
+// of model on one or two columns of x: at every grid point those columns are set
+// to the grid values for all rows, and the predictions are averaged over the rows
+// (Friedman 2001). The individual curves before averaging are the ICE curves of
+// Goldstein et al. (2015), which reveal interactions that the average hides.
+func PartialDependence(model Model, x [][]float64, columns []int, opts PDPOptions) (*PDGrid, error) {
+	if len(x) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	if len(columns) != 1 && len(columns) != 2 {
+		return nil, fmt.Errorf("partial dependence needs one or two columns, got %d", len(columns))
+	}
+	p := len(x[0])
+	for _, j := range columns {
+		if j < 0 || j >= p {
+			return nil, fmt.Errorf("column index %d out of range [0, %d)", j, p)
+		}
+	}
+	if opts.Grid == 0 {
+		opts.Grid = 20
+	}
+	if opts.Grid < 2 {
+		return nil, fmt.Errorf("grid must have at least 2 points, got %d", opts.Grid)
+	}
+	if opts.Values != nil && len(opts.Values) != len(columns) {
+		return nil, fmt.Errorf("expected grid values for %d columns, got %d", len(columns), len(opts.Values))
+	}
+
+	axes := make([][]float64, len(columns))
+	for a, j := range columns {
+		if opts.Values != nil {
+			axes[a] = opts.Values[a]
+			continue
+		}
+		lo, hi := x[0][j], x[0][j]
+		for _, row := range x {
+			lo, hi = min(lo, row[j]), max(hi, row[j])
+		}
+		axes[a] = make([]float64, opts.Grid)
+		for g := range axes[a] {
+			axes[a][g] = lo + (hi-lo)*float64(g)/float64(opts.Grid-1)
+		}
+	}
+	res := &PDGrid{Columns: columns, Average: make(map[float64][]float64)}
+	for _, v := range axes[0] {
+		if len(axes) == 1 {
+			res.Points = append(res.Points, []float64{v})
+			continue
+		}
+		for _, w := range axes[1] {
+			res.Points = append(res.Points, []float64{v, w})
+		}
+	}
+	if len(res.Points) == 0 {
+		return nil, fmt.Errorf("empty grid")
+	}
+	if opts.ICE {
+		res.ICE = make(map[float64][][]float64)
+	}
+
+	n := len(x)
+	grid := make([][]float64, n)
+	for i, row := range x {
+		grid[i] = append([]float64(nil), row...)
+	}
+	for g, point := range res.Points {
+		for i := range grid {
+			for a, j := range columns {
+				grid[i][j] = point[a]
+			}
+		}
+		pred, err := model.Predict(grid)
+		if err != nil {
+			return nil, fmt.Errorf("prediction failed at grid point %v: %w", point, err)
+		}
+		for tau, q := range pred {
+			if _, ok := res.Average[tau]; !ok {
+				res.Average[tau] = make([]float64, len(res.Points))
+				if opts.ICE {
+					res.ICE[tau] = make([][]float64, n)
+					for i := range res.ICE[tau] {
+						res.ICE[tau][i] = make([]float64, len(res.Points))
+					}
+				}
+			}
+			for i, v := range q {
+				res.Average[tau][g] += v / float64(n)
+				if opts.ICE {
+					res.ICE[tau][i][g] = v
+				}
+			}
+		}
+	}
+	for tau := range res.Average {
+		res.Taus = append(res.Taus, tau)
+	}
+	sort.Float64s(res.Taus)
+	return res, nil
+}
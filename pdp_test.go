@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPartialDependence(t *testing.T) {
+	_, x := inferenceData()
+	for i := range x {
+		x[i] = append(x[i], float64(i%3))
+	}
+	m := &MultiRQFit{Fits: make(map[float64]*RQFit), Taus: []float64{0.25, 0.75}, P: 3}
+	for _, tau := range m.Taus {
+		m.Fits[tau] = &RQFit{Tau: tau, P: 3, Coefficients: []float64{tau, 2, -1}}
+	}
+
+	pd, err := PartialDependence(m, x, []int{1}, PDPOptions{Grid: 5, ICE: true})
+	if err != nil {
+		t.Fatalf("Failed to compute partial dependence: %v", err)
+	}
+	if len(pd.Points) != 5 || pd.Points[0][0] != 0 || pd.Points[4][0] != 3.8 || len(pd.Taus) != 2 {
+		t.Fatalf("Unexpected grid %+v", pd.Points)
+	}
+	// Linear model: the curve has slope 2 and ICE curves are parallel shifts
+	mean2 := 0.0
+	for _, row := range x {
+		mean2 += row[2] / float64(len(x))
+	}
+	for g, point := range pd.Points {
+		want := 0.25 + 2*point[0] - mean2
+		if math.Abs(pd.Average[0.25][g]-want) > 1e-12 {
+			t.Errorf("Expected average %f at %v, got %f", want, point, pd.Average[0.25][g])
+		}
+	}
+	if len(pd.ICE[0.75]) != len(x) || math.Abs(pd.ICE[0.75][4][1]-pd.ICE[0.75][4][0]-1.9) > 1e-12 {
+		t.Errorf("Unexpected ICE curves")
+	}
+
+	two, err := PartialDependence(m, x, []int{1, 2}, PDPOptions{Values: [][]float64{{0, 1}, {0, 1, 2}}})
+	if err != nil {
+		t.Fatalf("Failed to compute two-way partial dependence: %v", err)
+	}
+	if len(two.Points) != 6 || two.Points[1][1] != 1 || two.ICE != nil {
+		t.Errorf("Unexpected two-way grid %v", two.Points)
+	}
+	if math.Abs(two.Average[0.25][5]-(0.25+2-2)) > 1e-12 {
+		t.Errorf("Expected %f at (1, 2), got %f", 0.25, two.Average[0.25][5])
+	}
+
+	if _, err := PartialDependence(m, x, []int{0, 1, 2}, PDPOptions{}); err == nil {
+		t.Error("Expected error for three columns")
+	}
+}
@@ -0,0 +1,47 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPermutationTest(t *testing.T) {
+	y, x := inferenceData()
+	for i := range x {
+		x[i] = append(x[i], math.Cos(float64(11*i)))
+	}
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	for _, scheme := range []string{PermuteCovariate, PermuteResiduals} {
+		slope, err := fit.PermutationTest(1, PermutationOptions{Scheme: scheme, Replications: 99}, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatalf("Failed to run %s permutation test: %v", scheme, err)
+		}
+		if len(slope.Null) != 99 || slope.PValue < 0.01 || slope.PValue > 1 {
+			t.Errorf("%s: unexpected result %+v", scheme, slope)
+		}
+		if slope.PValue > 0.05 {
+			t.Errorf("%s: expected a significant slope, got p=%f", scheme, slope.PValue)
+		}
+
+		noise, err := fit.PermutationTest(2, PermutationOptions{Scheme: scheme, Replications: 99}, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatalf("Failed to run %s permutation test: %v", scheme, err)
+		}
+		if noise.PValue <= slope.PValue {
+			t.Errorf("%s: expected the noise column to be less significant than the slope, got %f <= %f",
+				scheme, noise.PValue, slope.PValue)
+		}
+	}
+
+	if _, err := fit.PermutationTest(0, PermutationOptions{}, nil); err == nil {
+		t.Error("Expected error when permuting the intercept")
+	}
+	if _, err := fit.PermutationTest(1, PermutationOptions{Scheme: "signs"}, nil); err == nil {
+		t.Error("Expected error for unknown scheme")
+	}
+}
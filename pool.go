@@ -0,0 +1,119 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Imputation is one completed dataset from a multiple imputation
+type Imputation struct {
+	Y []float64
+	X [][]float64
+}
+
+// PooledFit combines quantile regressions fitted to multiply imputed datasets by
+// Rubin's rules
+type PooledFit struct {
+	Tau          float64
+	M            int         // Number of imputations
+	Coefficients []float64   // Mean of the per-imputation coefficients
+	Within       [][]float64 // Mean within-imputation covariance
+	Between      [][]float64 // Between-imputation covariance of the coefficients
+	Total        [][]float64 // Within + (1 + 1/M) Between
+	StdErrors    []float64   // Square roots of the total variances
+	DF           []float64   // Rubin (1987) degrees of freedom per coefficient
+	FMI          []float64   // Fraction of missing information per coefficient
+	Fits         []*RQFit    // Per-imputation fits
+	Meta         Meta        // Reproducibility metadata
+}
+
+// RQPool fits the same quantile regression to each imputed dataset and pools the
+// estimates, using the given standard error method for the within-imputation
+// covariance
+func RQPool(data []Imputation, tau float64, se string) (*PooledFit, error) {
+	start := time.Now()
+	m := len(data)
+	if m < 2 {
+		return nil, fmt.Errorf("need at least 2 imputations, got %d", m)
+	}
+
+	pooled := &PooledFit{Tau: tau, M: m}
+	var covs [][][]float64
+	for k, d := range data {
+		fit, err := RQ(d.Y, d.X, tau)
+		if err != nil {
+			return nil, fmt.Errorf("imputation %d: %w", k, err)
+		}
+		if len(pooled.Fits) > 0 && fit.P != pooled.Fits[0].P {
+			return nil, fmt.Errorf("imputation %d has %d parameters, want %d", k, fit.P, pooled.Fits[0].P)
+		}
+		cov, err := fit.Vcov(se)
+		if err != nil {
+			return nil, fmt.Errorf("imputation %d: %w", k, err)
+		}
+		pooled.Fits = append(pooled.Fits, fit)
+		covs = append(covs, cov)
+	}
+
+	p := pooled.Fits[0].P
+	mf := float64(m)
+	pooled.Coefficients = make([]float64, p)
+	for _, fit := range pooled.Fits {
+		for j, b := range fit.Coefficients {
+			pooled.Coefficients[j] += b / mf
+		}
+	}
+
+	pooled.Within = make([][]float64, p)
+	pooled.Between = make([][]float64, p)
+	pooled.Total = make([][]float64, p)
+	for a := 0; a < p; a++ {
+		pooled.Within[a] = make([]float64, p)
+		pooled.Between[a] = make([]float64, p)
+		pooled.Total[a] = make([]float64, p)
+		for b := 0; b < p; b++ {
+			for k, fit := range pooled.Fits {
+				pooled.Within[a][b] += covs[k][a][b] / mf
+				da := fit.Coefficients[a] - pooled.Coefficients[a]
+				db := fit.Coefficients[b] - pooled.Coefficients[b]
+				pooled.Between[a][b] += da * db / (mf - 1)
+			}
+			pooled.Total[a][b] = pooled.Within[a][b] + (1+1/mf)*pooled.Between[a][b]
+		}
+	}
+
+	pooled.StdErrors = make([]float64, p)
+	pooled.DF = make([]float64, p)
+	pooled.FMI = make([]float64, p)
+	for j := 0; j < p; j++ {
+		u, b, t := pooled.Within[j][j], pooled.Between[j][j], pooled.Total[j][j]
+		pooled.StdErrors[j] = math.Sqrt(math.Max(t, 0))
+		if b <= 0 {
+			pooled.DF[j] = math.Inf(1)
+			continue
+		}
+		r := (1 + 1/mf) * b / u
+		pooled.DF[j] = (mf - 1) * (1 + 1/r) * (1 + 1/r)
+		pooled.FMI[j] = (r + 2/(pooled.DF[j]+3)) / (r + 1)
+	}
+
+	first := pooled.Fits[0]
+	pooled.Meta = newMeta(first.Method, map[string]float64{"imputations": float64(m)}, []float64{tau}, first.N, first.P, start, nil, nil)
+	return pooled, nil
+}
+
+// Predict returns predictions from the pooled coefficients
+func (pf *PooledFit) Predict(newX [][]float64) ([]float64, error) {
+	p := len(pf.Coefficients)
+	out := make([]float64, len(newX))
+	for i, row := range newX {
+		if len(row) != p {
+			return nil, fmt.Errorf("%w: expected %d features, got %d", ErrDimensionMismatch, p, len(row))
+		}
+		for j, v := range row {
+			out[i] += v * pf.Coefficients[j]
+		}
+	}
+	return out, nil
+}
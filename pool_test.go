@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQPool(t *testing.T) {
+	y, x := inferenceData()
+
+	// Two imputations differing in a few response values
+	y2 := append([]float64(nil), y...)
+	y2[3] += 1
+	y2[11] -= 0.5
+	data := []Imputation{{Y: y, X: x}, {Y: y2, X: x}, {Y: y, X: x}}
+
+	pooled, err := RQPool(data, 0.5, SEKer)
+	if err != nil {
+		t.Fatalf("Failed to pool imputations: %v", err)
+	}
+	if pooled.M != 3 || len(pooled.Fits) != 3 {
+		t.Errorf("Expected 3 imputations, got %d", pooled.M)
+	}
+
+	for j := range pooled.Coefficients {
+		mean := 0.0
+		for _, fit := range pooled.Fits {
+			mean += fit.Coefficients[j] / 3
+		}
+		if math.Abs(pooled.Coefficients[j]-mean) > 1e-12 {
+			t.Errorf("Coefficient %d: got %f, want %f", j, pooled.Coefficients[j], mean)
+		}
+		want := pooled.Within[j][j] + 4.0/3*pooled.Between[j][j]
+		if math.Abs(pooled.Total[j][j]-want) > 1e-12 {
+			t.Errorf("Total variance %d: got %f, want %f", j, pooled.Total[j][j], want)
+		}
+		if pooled.StdErrors[j] <= 0 || pooled.DF[j] <= 0 {
+			t.Errorf("Unexpected standard error or df for coefficient %d: %f, %f", j, pooled.StdErrors[j], pooled.DF[j])
+		}
+		if pooled.FMI[j] < 0 || pooled.FMI[j] > 1 {
+			t.Errorf("Fraction of missing information out of range: %f", pooled.FMI[j])
+		}
+	}
+
+	pred, err := pooled.Predict(x[:2])
+	if err != nil || len(pred) != 2 {
+		t.Errorf("Failed to predict from pooled fit: %v", err)
+	}
+
+	// Error cases
+	if _, err := RQPool(data[:1], 0.5, SEKer); err == nil {
+		t.Error("Expected error for a single imputation")
+	}
+}
@@ -0,0 +1,162 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// DGP draws a sample of n observations in which coefficient Term of the design
+// has the given effect at the quantile of interest
+type DGP func(n int, effect float64, rng *rand.Rand) (y []float64, x [][]float64)
+
+// PowerOptions controls PowerAnalysis
+type PowerOptions struct {
+	Term   int     // Coefficient tested against zero
+	Effect float64 // Effect size passed to the DGP
+	Sizes  []int   // Sample sizes to simulate, increasing
+	Alpha  float64 // Two-sided significance level (default 0.05)
+	Target float64 // Power the study should reach (default 0.8)
+	Reps   int     // Simulated samples per size (default 200)
+	SE     string  // Standard error method of the Wald test (default SEKer)
+}
+
+// PowerCurve is the simulated power of the Wald test at each sample size
+type PowerCurve struct {
+	Tau       float64
+	Sizes     []int
+	Power     []float64 // Rejection rate over the successful replications
+	PowerSE   []float64 // Monte Carlo standard error of Power
+	Failed    []int     // Replications whose fit or standard errors failed
+	Target    float64
+	RequiredN int // Smallest n reaching Target, interpolated between sizes; 0 when no size reaches it
+}
+
+// PowerAnalysis estimates by simulation the power of the two-sided Wald test of
+// coefficient Term at tau: for every sample size Reps samples are drawn from dgp,
+// refitted with RQ, and tested at level Alpha. The sample size needed for the
+// target power is interpolated linearly in n between the simulated sizes.
+func PowerAnalysis(dgp DGP, tau float64, opts PowerOptions, rng *rand.Rand) (*PowerCurve, error) {
+	if tau <= 0 || tau >= 1 {
+		return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+	}
+	if len(opts.Sizes) == 0 {
+		return nil, fmt.Errorf("no sample sizes specified")
+	}
+	for k, n := range opts.Sizes {
+		if n <= 0 || (k > 0 && n <= opts.Sizes[k-1]) {
+			return nil, fmt.Errorf("sample sizes must be positive and increasing, got %v", opts.Sizes)
+		}
+	}
+	if opts.Alpha == 0 {
+		opts.Alpha = 0.05
+	}
+	if opts.Target == 0 {
+		opts.Target = 0.8
+	}
+	if opts.Reps == 0 {
+		opts.Reps = 200
+	}
+	if opts.SE == "" {
+		opts.SE = SEKer
+	}
+	if opts.Alpha <= 0 || opts.Alpha >= 1 || opts.Target <= 0 || opts.Target >= 1 {
+		return nil, fmt.Errorf("alpha and target power must be between 0 and 1")
+	}
+	if opts.Reps < 1 {
+		return nil, fmt.Errorf("number of replications must be positive, got %d", opts.Reps)
+	}
+
+	rng = randOrDefault(rng)
+	z := normQuantile(1 - opts.Alpha/2)
+	pc := &PowerCurve{
+		Tau:     tau,
+		Sizes:   opts.Sizes,
+		Power:   make([]float64, len(opts.Sizes)),
+		PowerSE: make([]float64, len(opts.Sizes)),
+		Failed:  make([]int, len(opts.Sizes)),
+		Target:  opts.Target,
+	}
+	for k, n := range opts.Sizes {
+		rejected, ok := 0, 0
+		for r := 0; r < opts.Reps; r++ {
+			y, x := dgp(n, opts.Effect, rng)
+			if len(x) == 0 || opts.Term < 0 || opts.Term >= len(x[0]) {
+				return nil, fmt.Errorf("term %d out of range for the simulated design", opts.Term)
+			}
+			fit, err := RQ(y, x, tau)
+			if err != nil {
+				pc.Failed[k]++
+				continue
+			}
+			se, err := fit.StdErrors(opts.SE)
+			if err != nil || !(se[opts.Term] > 0) {
+				pc.Failed[k]++
+				continue
+			}
+			ok++
+			if math.Abs(fit.Coefficients[opts.Term]/se[opts.Term]) > z {
+				rejected++
+			}
+		}
+		if ok == 0 {
+			return nil, fmt.Errorf("every replication failed at n=%d", n)
+		}
+		p := float64(rejected) / float64(ok)
+		pc.Power[k] = p
+		pc.PowerSE[k] = math.Sqrt(p * (1 - p) / float64(ok))
+	}
+
+	for k, p := range pc.Power {
+		if p < opts.Target {
+			continue
+		}
+		pc.RequiredN = opts.Sizes[k]
+		if k > 0 {
+			lo, hi := float64(opts.Sizes[k-1]), float64(opts.Sizes[k])
+			w := (opts.Target - pc.Power[k-1]) / (p - pc.Power[k-1])
+			pc.RequiredN = int(math.Ceil(lo + w*(hi-lo)))
+		}
+		break
+	}
+	return pc, nil
+}
+
+// PilotDGP returns a DGP built from a pilot fit: design rows are resampled from
+// the pilot design, coefficient term is set to the effect, and the pilot
+// residuals are resampled as errors, which assumes they are iid
+func PilotDGP(pilot *RQFit, term int) (DGP, error) {
+	if len(pilot.X) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	if term < 0 || term >= pilot.P {
+		return nil, fmt.Errorf("term %d out of range [0, %d)", term, pilot.P)
+	}
+	resid := pilot.ResidualValues()
+	return func(n int, effect float64, rng *rand.Rand) ([]float64, [][]float64) {
+		beta := append([]float64(nil), pilot.Coefficients...)
+		beta[term] = effect
+		y := make([]float64, n)
+		x := make([][]float64, n)
+		for i := range x {
+			x[i] = pilot.X[rng.Intn(len(pilot.X))]
+			y[i] = dot(x[i], beta) + resid[rng.Intn(len(resid))]
+		}
+		return y, x
+	}, nil
+}
+
+// Table returns the power curve as a formatted text table
+func (pc *PowerCurve) Table() string {
+	result := fmt.Sprintf("Simulated power at tau = %.3f\n\n", pc.Tau)
+	result += fmt.Sprintf("%8s %10s %10s %8s\n", "n", "Power", "MC SE", "Failed")
+	for k, n := range pc.Sizes {
+		result += fmt.Sprintf("%8d %10.4f %10.4f %8d\n", n, pc.Power[k], pc.PowerSE[k], pc.Failed[k])
+	}
+	if pc.RequiredN > 0 {
+		result += fmt.Sprintf("\nn for power %.2f: %d\n", pc.Target, pc.RequiredN)
+	} else {
+		result += fmt.Sprintf("\nPower %.2f not reached at the simulated sizes\n", pc.Target)
+	}
+	return result
+}
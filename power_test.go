@@ -0,0 +1,70 @@
+package quantreg
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestPowerAnalysis(t *testing.T) {
+	dgp := func(n int, effect float64, rng *rand.Rand) ([]float64, [][]float64) {
+		y := make([]float64, n)
+		x := make([][]float64, n)
+		for i := range x {
+			d := float64(i % 2)
+			x[i] = []float64{1, d}
+			y[i] = 1 + effect*d + rng.NormFloat64()
+		}
+		return y, x
+	}
+	opts := PowerOptions{Term: 1, Effect: 1, Sizes: []int{10, 40, 160}, Reps: 60}
+	pc, err := PowerAnalysis(dgp, 0.5, opts, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to run power analysis: %v", err)
+	}
+	if pc.Power[2] < 0.9 {
+		t.Errorf("Expected high power at n=160, got %v", pc.Power[2])
+	}
+	if pc.Power[0] >= pc.Power[2] {
+		t.Errorf("Expected power to grow with n, got %v", pc.Power)
+	}
+	if pc.RequiredN <= 10 || pc.RequiredN > 160 {
+		t.Errorf("Expected required n between 10 and 160, got %d", pc.RequiredN)
+	}
+	if !strings.Contains(pc.Table(), "n for power 0.80") {
+		t.Errorf("Expected required n in the table, got %q", pc.Table())
+	}
+
+	// Under the null the rejection rate is near alpha
+	opts.Effect, opts.Sizes = 0, []int{100}
+	null, err := PowerAnalysis(dgp, 0.5, opts, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Failed to run null power analysis: %v", err)
+	}
+	if null.Power[0] > 0.2 || null.RequiredN != 0 {
+		t.Errorf("Expected a rejection rate near 0.05 under the null, got %v", null.Power[0])
+	}
+
+	if _, err := PowerAnalysis(dgp, 0.5, PowerOptions{Term: 1, Sizes: []int{40, 20}}, nil); err == nil {
+		t.Error("Expected error for decreasing sample sizes")
+	}
+}
+
+func TestPilotDGP(t *testing.T) {
+	y, x := inferenceData()
+	pilot, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit pilot: %v", err)
+	}
+	dgp, err := PilotDGP(pilot, 1)
+	if err != nil {
+		t.Fatalf("Failed to build DGP: %v", err)
+	}
+	sy, sx := dgp(50, 2, rand.New(rand.NewSource(3)))
+	if len(sy) != 50 || len(sx) != 50 || len(sx[0]) != 2 {
+		t.Fatalf("Unexpected simulated sample sizes %d and %d", len(sy), len(sx))
+	}
+	if _, err := PilotDGP(pilot, 2); err == nil {
+		t.Error("Expected error for out-of-range term")
+	}
+}
@@ -0,0 +1,57 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// JointVcov returns the joint asymptotic covariance of the coefficients across all
+// taus as a (K*P) x (K*P) matrix ordered by tau and then coefficient. The blocks are
+// (min(tau_k, tau_l) - tau_k tau_l) H_k^-1 X'X H_l^-1 with H_k = X' diag(f_k) X and
+// Powell kernel density estimates f_k at each tau.
+func (m *MultiRQFit) JointVcov() ([][]float64, error) {
+	if len(m.Taus) == 0 {
+		return nil, fmt.Errorf("no fitted quantiles")
+	}
+	first := m.Fits[m.Taus[0]]
+	if len(first.X) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+
+	xtx := crossprod(first.X, nil)
+	hinv := make([][][]float64, len(m.Taus))
+	for k, tau := range m.Taus {
+		fit := m.Fits[tau]
+		f, err := fit.kernelDensities()
+		if err != nil {
+			return nil, fmt.Errorf("density estimate failed for tau=%f: %w", tau, err)
+		}
+		hinv[k], err = invert(crossprod(fit.X, f))
+		if err != nil {
+			return nil, fmt.Errorf("density-weighted design is singular for tau=%f: %w", tau, err)
+		}
+	}
+
+	p := m.P
+	size := len(m.Taus) * p
+	cov := make([][]float64, size)
+	for i := range cov {
+		cov[i] = make([]float64, size)
+	}
+	for k, tk := range m.Taus {
+		left := matMul(hinv[k], xtx)
+		for l := k; l < len(m.Taus); l++ {
+			tl := m.Taus[l]
+			block := matMul(left, hinv[l])
+			w := math.Min(tk, tl) - tk*tl
+			for a := 0; a < p; a++ {
+				for b := 0; b < p; b++ {
+					cov[k*p+a][l*p+b] = w * block[a][b]
+					cov[l*p+b][k*p+a] = w * block[a][b]
+				}
+			}
+		}
+	}
+
+	return cov, nil
+}
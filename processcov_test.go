@@ -0,0 +1,48 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJointVcov(t *testing.T) {
+	y, x := inferenceData()
+
+	fits, err := RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	cov, err := fits.JointVcov()
+	if err != nil {
+		t.Fatalf("Failed to compute joint covariance: %v", err)
+	}
+
+	size := len(fits.Taus) * fits.P
+	if len(cov) != size {
+		t.Fatalf("Expected %d x %d matrix, got %d rows", size, size, len(cov))
+	}
+
+	// Symmetric with the single-tau kernel covariance on the diagonal blocks
+	for i := range cov {
+		for j := range cov {
+			if math.Abs(cov[i][j]-cov[j][i]) > 1e-12 {
+				t.Fatalf("Joint covariance is not symmetric at (%d, %d)", i, j)
+			}
+		}
+	}
+	for k, tau := range fits.Taus {
+		single, err := fits.Fits[tau].Vcov(SEKer)
+		if err != nil {
+			t.Fatalf("Failed to compute kernel covariance: %v", err)
+		}
+		for a := 0; a < fits.P; a++ {
+			for b := 0; b < fits.P; b++ {
+				got := cov[k*fits.P+a][k*fits.P+b]
+				if math.Abs(got-single[a][b]) > 1e-9*math.Max(1, math.Abs(single[a][b])) {
+					t.Errorf("tau=%f block (%d, %d): got %f, want %f", tau, a, b, got, single[a][b])
+				}
+			}
+		}
+	}
+}
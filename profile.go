@@ -0,0 +1,47 @@
+package quantreg
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+)
+
+// Solver phases reported in RQFit.Timings and as the quantreg_phase pprof label.
+// PhaseCovariance is only a label: Vcov and the methods built on it leave the
+// fit unchanged.
+const (
+	PhasePrep       = "prep"       // Input validation and design conversion
+	PhaseSolve      = "solve"      // Solver iterations
+	PhaseCovariance = "covariance" // Covariance estimation
+	PhaseBootstrap  = "bootstrap"  // Bootstrap replications
+)
+
+var profilingEnabled atomic.Bool
+
+// EnableProfiling turns pprof labelling of solver phases on or off. When enabled,
+// CPU profiles can be filtered by the quantreg_phase label, e.g.
+// go tool pprof -tagfocus=quantreg_phase=solve.
+func EnableProfiling(on bool) {
+	profilingEnabled.Store(on)
+}
+
+// withPhase runs f under the pprof label of phase when profiling is enabled and
+// returns its wall time
+func withPhase(phase string, f func()) time.Duration {
+	start := time.Now()
+	if profilingEnabled.Load() {
+		pprof.Do(context.Background(), pprof.Labels("quantreg_phase", phase), func(context.Context) { f() })
+	} else {
+		f()
+	}
+	return time.Since(start)
+}
+
+// recordPhase adds d to the accumulated time of phase
+func (fit *RQFit) recordPhase(phase string, d time.Duration) {
+	if fit.Timings == nil {
+		fit.Timings = make(map[string]time.Duration)
+	}
+	fit.Timings[phase] += d
+}
@@ -0,0 +1,41 @@
+package quantreg
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTimings(t *testing.T) {
+	EnableProfiling(true)
+	defer EnableProfiling(false)
+
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	for _, phase := range []string{PhasePrep, PhaseSolve} {
+		if _, ok := fit.Timings[phase]; !ok {
+			t.Errorf("Missing timing for phase %s", phase)
+		}
+	}
+	if fit.Timings[PhaseSolve] <= 0 {
+		t.Errorf("Expected positive solve time, got %v", fit.Timings[PhaseSolve])
+	}
+
+	// Inference reads the fit only, so it is safe from several goroutines
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := fit.StdErrors(SEKer); err != nil {
+				t.Errorf("Failed to compute standard errors: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, ok := fit.Timings[PhaseCovariance]; ok {
+		t.Error("Expected Vcov to leave the timings unchanged")
+	}
+}
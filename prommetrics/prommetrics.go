@@ -0,0 +1,99 @@
+// Package prommetrics adapts quantreg instrumentation hooks to Prometheus
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/andreasmuller/quantreg"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector implements quantreg.Metrics on top of Prometheus collectors
+type Collector struct {
+	fitDuration         *prometheus.HistogramVec
+	fitIterations       *prometheus.HistogramVec
+	convergenceFailures *prometheus.CounterVec
+	predictDuration     prometheus.Histogram
+	predictRows         prometheus.Counter
+	cacheLookups        *prometheus.CounterVec
+}
+
+var _ quantreg.Metrics = (*Collector)(nil)
+
+// New creates a Collector and registers its metrics with reg under the given namespace
+func New(reg prometheus.Registerer, namespace string) (*Collector, error) {
+	c := &Collector{
+		fitDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "fit_duration_seconds",
+			Help:      "Wall time spent fitting quantile regression models.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"method"}),
+		fitIterations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "fit_iterations",
+			Help:      "Solver iterations used per fit.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"method"}),
+		convergenceFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "convergence_failures_total",
+			Help:      "Fits that stopped before meeting the convergence tolerance.",
+		}, []string{"method"}),
+		predictDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "predict_duration_seconds",
+			Help:      "Latency of Predict calls.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}),
+		predictRows: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "predict_rows_total",
+			Help:      "Rows scored by Predict calls.",
+		}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "model_cache_lookups_total",
+			Help:      "Model lookups by serving components, partitioned by result.",
+		}, []string{"result"}),
+	}
+
+	for _, col := range []prometheus.Collector{
+		c.fitDuration,
+		c.fitIterations,
+		c.convergenceFailures,
+		c.predictDuration,
+		c.predictRows,
+		c.cacheLookups,
+	} {
+		if err := reg.Register(col); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// ObserveFit records fit duration, iterations and convergence failures
+func (c *Collector) ObserveFit(method string, duration time.Duration, iterations int, converged bool) {
+	c.fitDuration.WithLabelValues(method).Observe(duration.Seconds())
+	c.fitIterations.WithLabelValues(method).Observe(float64(iterations))
+	if !converged {
+		c.convergenceFailures.WithLabelValues(method).Inc()
+	}
+}
+
+// ObservePredict records prediction latency and volume
+func (c *Collector) ObservePredict(rows int, duration time.Duration) {
+	c.predictDuration.Observe(duration.Seconds())
+	c.predictRows.Add(float64(rows))
+}
+
+// ObserveCacheLookup records a model cache hit or miss
+func (c *Collector) ObserveCacheLookup(name string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	c.cacheLookups.WithLabelValues(result).Inc()
+}
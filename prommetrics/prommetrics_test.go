@@ -0,0 +1,41 @@
+package prommetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c, err := New(reg, "quantreg")
+	if err != nil {
+		t.Fatalf("Failed to create collector: %v", err)
+	}
+
+	c.ObserveFit("br", 10*time.Millisecond, 1000, false)
+	c.ObserveFit("br", 5*time.Millisecond, 12, true)
+	c.ObservePredict(3, time.Microsecond)
+	c.ObserveCacheLookup("demand", true)
+	c.ObserveCacheLookup("demand", false)
+	c.ObserveCacheLookup("demand", true)
+
+	if got := testutil.ToFloat64(c.convergenceFailures.WithLabelValues("br")); got != 1 {
+		t.Errorf("Expected 1 convergence failure, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(c.predictRows); got != 3 {
+		t.Errorf("Expected 3 predicted rows, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(c.cacheLookups.WithLabelValues("hit")); got != 2 {
+		t.Errorf("Expected 2 cache hits, got %v", got)
+	}
+
+	// Registering twice on the same registry must fail
+	if _, err := New(reg, "quantreg"); err == nil {
+		t.Error("Expected error for duplicate registration")
+	}
+}
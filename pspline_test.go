@@ -0,0 +1,66 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDifferenceMatrix(t *testing.T) {
+	d := differenceMatrix(4, 2)
+	want := [][]float64{{1, -2, 1, 0}, {0, 1, -2, 1}}
+	for i := range want {
+		for j := range want[i] {
+			if d[i][j] != want[i][j] {
+				t.Fatalf("Expected second differences %v, got %v", want, d)
+			}
+		}
+	}
+}
+
+func TestRQPSpline(t *testing.T) {
+	n := 80
+	y := make([]float64, n)
+	z := make([]float64, n)
+	for i := 0; i < n; i++ {
+		z[i] = float64(i) / float64(n-1)
+		y[i] = math.Sin(2*math.Pi*z[i]) + 0.3*math.Sin(float64(13*i))
+	}
+	lambdas := []float64{0.01, 0.3, 100}
+
+	fit, err := RQPSpline(y, z, 0.5, PSplineOptions{Lambdas: lambdas})
+	if err != nil {
+		t.Fatalf("Failed to fit P-spline: %v", err)
+	}
+	if len(fit.Scores) != 3 {
+		t.Fatalf("Expected 3 criterion values, got %d", len(fit.Scores))
+	}
+	if fit.Lambda == 100 {
+		t.Errorf("Expected a penalty below the linear limit, got %v", fit.Lambda)
+	}
+	if fit.EDF < 3 || fit.EDF > 14 {
+		t.Errorf("Expected effective degrees of freedom between 3 and 14, got %d", fit.EDF)
+	}
+	for _, v := range []float64{0.25, 0.75} {
+		if got := fit.SmoothAt([]float64{v})[0]; math.Abs(got-math.Sin(2*math.Pi*v)) > 0.35 {
+			t.Errorf("Expected curve near %v at z=%v, got %v", math.Sin(2*math.Pi*v), v, got)
+		}
+	}
+
+	// A huge penalty on second differences forces a straight line
+	line, err := RQPSpline(y, z, 0.5, PSplineOptions{Lambdas: []float64{1e4}})
+	if err != nil {
+		t.Fatalf("Failed to fit heavily penalized P-spline: %v", err)
+	}
+	g := line.SmoothAt([]float64{0, 0.5, 1})
+	if math.Abs(g[1]-(g[0]+g[2])/2) > 1e-6 {
+		t.Errorf("Expected a linear fit, got %v", g)
+	}
+
+	cv, err := RQPSpline(y, z, 0.5, PSplineOptions{Lambdas: lambdas, Criterion: CriterionCV, Folds: 4})
+	if err != nil {
+		t.Fatalf("Failed to fit cross-validated P-spline: %v", err)
+	}
+	if cv.Lambda == 100 {
+		t.Errorf("Expected cross-validation to reject the linear limit, got %v", cv.Lambda)
+	}
+}
@@ -0,0 +1,65 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestQGComputation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 200
+	y := make([]float64, n)
+	exposures := make([][]float64, n)
+	covariates := make([][]float64, n)
+	for i := range y {
+		common := rng.NormFloat64()
+		e := []float64{common + rng.NormFloat64(), common + rng.NormFloat64(), common + rng.NormFloat64()}
+		exposures[i] = e
+		covariates[i] = []float64{float64(i % 2)}
+		y[i] = 1 + 0.6*e[0] + 0.3*e[1] - 0.3*e[2] + 0.5*covariates[i][0] + 0.2*rng.NormFloat64()
+	}
+
+	q, err := QGComputation(y, exposures, covariates, 0.5, QGCompOptions{Replications: 50}, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Failed to run quantile g-computation: %v", err)
+	}
+	if len(q.Breaks) != 3 || len(q.Breaks[0]) != 3 || q.Fit.P != 5 {
+		t.Fatalf("Unexpected bins %v or design width %d", q.Breaks, q.Fit.P)
+	}
+	sum := 0.0
+	for _, b := range q.Coefficients {
+		sum += b
+	}
+	if math.Abs(q.Psi-sum) > 1e-12 || q.PsiLower > q.PsiUpper || !(q.PsiSE > 0) {
+		t.Errorf("Expected Psi to be the sum of the bin effects with an interval, got %v [%v, %v]", q.Psi, q.PsiLower, q.PsiUpper)
+	}
+	pos, neg := 0.0, 0.0
+	for j, w := range q.Weights {
+		if w > 0 {
+			pos += w
+		} else {
+			neg += w
+		}
+		if (w > 0) != (q.Coefficients[j] > 0) {
+			t.Errorf("Expected weight %d to share the sign of its coefficient %v, got %v", j, q.Coefficients[j], w)
+		}
+		if q.WeightLower[j] > q.WeightUpper[j] {
+			t.Errorf("Expected ordered weight bounds for exposure %d", j)
+		}
+	}
+	if (pos != 0 && math.Abs(pos-1) > 1e-12) || (neg != 0 && math.Abs(neg+1) > 1e-12) {
+		t.Errorf("Expected positive weights summing to 1 and negative to -1, got %v", q.Weights)
+	}
+	if s := q.Scores([]float64{-10, 0, 10}); s[0] != 0 || s[2] != 3 {
+		t.Errorf("Expected extreme bin scores 0 and 3, got %v", s)
+	}
+	if !strings.Contains(q.Table(), "Psi =") {
+		t.Error("Expected the mixture effect in the table")
+	}
+
+	if _, err := QGComputation(y, exposures[:10], nil, 0.5, QGCompOptions{}, nil); err == nil {
+		t.Error("Expected error for mismatched exposures")
+	}
+}
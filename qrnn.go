@@ -0,0 +1,336 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// QRNNOptions controls FitQRNN
+type QRNNOptions struct {
+	Hidden          int     // Hidden units (default 8)
+	Epochs          int     // Maximum training epochs (default 2000)
+	LearningRate    float64 // Adam step size (default 0.01)
+	CrossingPenalty float64 // Weight of the penalty on crossing heads, zero to disable
+	Validation      float64 // Fraction of observations held out for early stopping (default 0.2)
+	Patience        int     // Epochs without validation improvement before stopping (default 100)
+}
+
+// QRNN is a one-hidden-layer neural network with one output head per quantile
+// level, trained on the pinball loss
+type QRNN struct {
+	Taus           []float64
+	Hidden         int
+	Epochs         int     // Epochs run before stopping
+	ValidationLoss float64 // Best mean validation pinball loss, or training loss without a validation set
+	w1             [][]float64
+	b1             []float64
+	w2             [][]float64
+	b2             []float64
+	xMean, xScale  []float64
+	yMean, yScale  float64
+	Meta           Meta // Reproducibility metadata
+}
+
+// FitQRNN trains a quantile regression neural network (Taylor 2000; Cannon 2018)
+// with tanh hidden units by full-batch Adam on the mean pinball loss over all
+// heads. Inputs and response are standardized internally. With a crossing
+// penalty c, c * max(0, q_k - q_k+1) is added for every adjacent pair of heads.
+// Training stops when the loss on a random validation split has not improved for
+// Patience epochs, and the best weights are kept.
+func FitQRNN(y []float64, x [][]float64, taus []float64, opts QRNNOptions, rng *rand.Rand) (*QRNN, error) {
+	start := time.Now()
+	if opts.Hidden == 0 {
+		opts.Hidden = 8
+	}
+	if opts.Epochs == 0 {
+		opts.Epochs = 2000
+	}
+	if opts.LearningRate == 0 {
+		opts.LearningRate = 0.01
+	}
+	if opts.Validation == 0 {
+		opts.Validation = 0.2
+	}
+	if opts.Patience == 0 {
+		opts.Patience = 100
+	}
+	if opts.Hidden < 1 || opts.Epochs < 1 || opts.LearningRate < 0 || opts.CrossingPenalty < 0 {
+		return nil, fmt.Errorf("invalid network options %+v", opts)
+	}
+	if opts.Validation < 0 || opts.Validation >= 1 {
+		return nil, fmt.Errorf("validation fraction must be in [0, 1), got %f", opts.Validation)
+	}
+	if len(taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels specified")
+	}
+	sortedTaus := append([]float64(nil), taus...)
+	sort.Float64s(sortedTaus)
+	for _, tau := range sortedTaus {
+		if tau <= 0 || tau >= 1 {
+			return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+		}
+	}
+	n := len(y)
+	if len(x) != n || n == 0 {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+
+	rng = randOrDefault(rng)
+	net := newQRNN(x, y, sortedTaus, opts.Hidden, rng)
+
+	// Standardized data and the train/validation split
+	xs := make([][]float64, n)
+	ys := make([]float64, n)
+	for i := range x {
+		xs[i] = net.scaleX(x[i])
+		ys[i] = (y[i] - net.yMean) / net.yScale
+	}
+	perm := rng.Perm(n)
+	nVal := int(opts.Validation * float64(n))
+	val, train := perm[:nVal], perm[nVal:]
+
+	best := math.Inf(1)
+	var bestNet *QRNN
+	adam := newAdamState(net)
+	since := 0
+	for epoch := 1; epoch <= opts.Epochs; epoch++ {
+		net.Epochs = epoch
+		grads := net.gradients(xs, ys, train, opts.CrossingPenalty)
+		adam.step(net, grads, opts.LearningRate)
+
+		monitor := val
+		if len(monitor) == 0 {
+			monitor = train
+		}
+		loss := net.loss(xs, ys, monitor)
+		if loss < best-1e-9 {
+			best, bestNet, since = loss, net.clone(), 0
+		} else if since++; since >= opts.Patience {
+			break
+		}
+	}
+	bestNet.Epochs = net.Epochs
+	bestNet.ValidationLoss = best * net.yScale
+	bestNet.Meta = newMeta("adam", map[string]float64{
+		"hidden": float64(opts.Hidden), "epochs": float64(opts.Epochs), "learning_rate": opts.LearningRate,
+		"crossing_penalty": opts.CrossingPenalty, "validation": opts.Validation, "patience": float64(opts.Patience),
+	}, taus, len(y), len(x[0]), start, y, x)
+	return bestNet, nil
+}
+
+func newQRNN(x [][]float64, y []float64, taus []float64, hidden int, rng *rand.Rand) *QRNN {
+	p := len(x[0])
+	net := &QRNN{Taus: taus, Hidden: hidden, xMean: make([]float64, p), xScale: make([]float64, p)}
+	for j := 0; j < p; j++ {
+		col := make([]float64, len(x))
+		for i := range x {
+			col[i] = x[i][j]
+		}
+		st := computeStats(col)
+		net.xMean[j], net.xScale[j] = st.Mean, st.StdDev
+		if net.xScale[j] == 0 {
+			net.xScale[j] = 1
+		}
+	}
+	st := computeStats(y)
+	net.yMean, net.yScale = st.Mean, st.StdDev
+	if net.yScale == 0 {
+		net.yScale = 1
+	}
+
+	// Glorot-scaled initial weights
+	s1 := math.Sqrt(2 / float64(p+hidden))
+	net.w1 = make([][]float64, hidden)
+	net.b1 = make([]float64, hidden)
+	for h := range net.w1 {
+		net.w1[h] = make([]float64, p)
+		for j := range net.w1[h] {
+			net.w1[h][j] = s1 * rng.NormFloat64()
+		}
+	}
+	s2 := math.Sqrt(2 / float64(hidden+1))
+	net.w2 = make([][]float64, len(taus))
+	net.b2 = make([]float64, len(taus))
+	for k := range net.w2 {
+		net.w2[k] = make([]float64, hidden)
+		for h := range net.w2[k] {
+			net.w2[k][h] = s2 * rng.NormFloat64()
+		}
+	}
+	return net
+}
+
+func (net *QRNN) scaleX(row []float64) []float64 {
+	out := make([]float64, len(row))
+	for j, v := range row {
+		out[j] = (v - net.xMean[j]) / net.xScale[j]
+	}
+	return out
+}
+
+// forward returns the hidden activations and standardized outputs for a scaled input
+func (net *QRNN) forward(xs []float64) ([]float64, []float64) {
+	hid := make([]float64, net.Hidden)
+	for h, w := range net.w1 {
+		z := net.b1[h]
+		for j, v := range xs {
+			z += w[j] * v
+		}
+		hid[h] = math.Tanh(z)
+	}
+	out := make([]float64, len(net.Taus))
+	for k, w := range net.w2 {
+		out[k] = net.b2[k]
+		for h, v := range hid {
+			out[k] += w[h] * v
+		}
+	}
+	return hid, out
+}
+
+// loss returns the mean pinball loss over heads on the standardized scale
+func (net *QRNN) loss(xs [][]float64, ys []float64, idx []int) float64 {
+	total := 0.0
+	for _, i := range idx {
+		_, out := net.forward(xs[i])
+		for k, tau := range net.Taus {
+			total += rho(ys[i]-out[k], tau)
+		}
+	}
+	return total / float64(len(idx)*len(net.Taus))
+}
+
+// gradients returns the subgradient of the penalized training loss, laid out as a
+// network with the same shape
+func (net *QRNN) gradients(xs [][]float64, ys []float64, idx []int, penalty float64) *QRNN {
+	g := net.zeros()
+	scale := 1 / float64(len(idx)*len(net.Taus))
+	dout := make([]float64, len(net.Taus))
+	for _, i := range idx {
+		hid, out := net.forward(xs[i])
+		for k, tau := range net.Taus {
+			dout[k] = -(tau - step(out[k] > ys[i])) * scale
+		}
+		if penalty > 0 {
+			for k := 0; k+1 < len(out); k++ {
+				if out[k] > out[k+1] {
+					dout[k] += penalty * scale
+					dout[k+1] -= penalty * scale
+				}
+			}
+		}
+		for k, d := range dout {
+			g.b2[k] += d
+			for h, v := range hid {
+				g.w2[k][h] += d * v
+			}
+		}
+		for h, v := range hid {
+			dz := 0.0
+			for k, d := range dout {
+				dz += d * net.w2[k][h]
+			}
+			dz *= 1 - v*v
+			g.b1[h] += dz
+			for j, xv := range xs[i] {
+				g.w1[h][j] += dz * xv
+			}
+		}
+	}
+	return g
+}
+
+func step(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// params lists the parameter slices of the network in a fixed order
+func (net *QRNN) params() [][]float64 {
+	ps := append([][]float64{}, net.w1...)
+	ps = append(ps, net.b1)
+	ps = append(ps, net.w2...)
+	return append(ps, net.b2)
+}
+
+func (net *QRNN) zeros() *QRNN {
+	z := &QRNN{Taus: net.Taus, Hidden: net.Hidden}
+	z.w1 = make([][]float64, len(net.w1))
+	for h := range z.w1 {
+		z.w1[h] = make([]float64, len(net.w1[h]))
+	}
+	z.b1 = make([]float64, len(net.b1))
+	z.w2 = make([][]float64, len(net.w2))
+	for k := range z.w2 {
+		z.w2[k] = make([]float64, len(net.w2[k]))
+	}
+	z.b2 = make([]float64, len(net.b2))
+	return z
+}
+
+func (net *QRNN) clone() *QRNN {
+	c := net.zeros()
+	dst, src := c.params(), net.params()
+	for k := range dst {
+		copy(dst[k], src[k])
+	}
+	c.xMean, c.xScale, c.yMean, c.yScale = net.xMean, net.xScale, net.yMean, net.yScale
+	return c
+}
+
+// adamState holds the Adam moment estimates for every parameter
+type adamState struct {
+	m, v [][]float64
+	t    int
+}
+
+func newAdamState(net *QRNN) *adamState {
+	return &adamState{m: net.zeros().params(), v: net.zeros().params()}
+}
+
+func (a *adamState) step(net, grads *QRNN, lr float64) {
+	const beta1, beta2, eps = 0.9, 0.999, 1e-8
+	a.t++
+	c1 := 1 - math.Pow(beta1, float64(a.t))
+	c2 := 1 - math.Pow(beta2, float64(a.t))
+	ps, gs := net.params(), grads.params()
+	for k := range ps {
+		for j, g := range gs[k] {
+			a.m[k][j] = beta1*a.m[k][j] + (1-beta1)*g
+			a.v[k][j] = beta2*a.v[k][j] + (1-beta2)*g*g
+			ps[k][j] -= lr * (a.m[k][j] / c1) / (math.Sqrt(a.v[k][j]/c2) + eps)
+		}
+	}
+}
+
+// Predict returns the predicted quantiles at newX for every tau
+func (net *QRNN) Predict(newX [][]float64) (map[float64][]float64, error) {
+	pred := make(map[float64][]float64, len(net.Taus))
+	for _, tau := range net.Taus {
+		pred[tau] = make([]float64, len(newX))
+	}
+	for i, row := range newX {
+		if len(row) != len(net.xMean) {
+			return nil, fmt.Errorf("expected %d columns, got %d", len(net.xMean), len(row))
+		}
+		_, out := net.forward(net.scaleX(row))
+		for k, tau := range net.Taus {
+			pred[tau][i] = net.yMean + net.yScale*out[k]
+		}
+	}
+	return pred, nil
+}
+
+// Score predicts at newX and scores the predictions against the outcomes y
+func (net *QRNN) Score(newX [][]float64, y []float64) (*ForecastScores, error) {
+	pred, err := net.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+	return ScoreQuantiles(pred, y)
+}
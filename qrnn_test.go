@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestQRNN(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 300
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		xi := 6 * rng.Float64()
+		x[i] = []float64{xi}
+		y[i] = math.Sin(xi) + 0.2*rng.NormFloat64()
+	}
+	taus := []float64{0.1, 0.5, 0.9}
+
+	net, err := FitQRNN(y, x, taus, QRNNOptions{CrossingPenalty: 1}, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Failed to train network: %v", err)
+	}
+	if net.Epochs < 1 || net.ValidationLoss <= 0 {
+		t.Errorf("Expected training progress, got %d epochs and loss %v", net.Epochs, net.ValidationLoss)
+	}
+
+	grid := [][]float64{{1}, {2}, {4.5}}
+	pred, err := net.Predict(grid)
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	for i, row := range grid {
+		if want := math.Sin(row[0]); math.Abs(pred[0.5][i]-want) > 0.3 {
+			t.Errorf("Expected median near %v at x=%v, got %v", want, row[0], pred[0.5][i])
+		}
+		if pred[0.1][i] > pred[0.5][i] || pred[0.5][i] > pred[0.9][i] {
+			t.Errorf("Crossing quantiles at x=%v: %v %v %v", row[0], pred[0.1][i], pred[0.5][i], pred[0.9][i])
+		}
+	}
+
+	scores, err := net.Score(x, y)
+	if err != nil {
+		t.Fatalf("Failed to score: %v", err)
+	}
+	if c := scores.Coverage[0.9]; math.Abs(c-0.9) > 0.1 {
+		t.Errorf("Expected coverage near 0.9, got %v", c)
+	}
+
+	if _, err := net.Predict([][]float64{{1, 2}}); err == nil {
+		t.Error("Expected error for wrong number of columns")
+	}
+}
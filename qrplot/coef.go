@@ -0,0 +1,118 @@
+package qrplot
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/andreasmuller/quantreg"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// CoefOptions controls coefficient process rendering
+type CoefOptions struct {
+	SE      string   // Standard error method for the bands (default quantreg.SEIID)
+	Level   float64  // Confidence level of the bands (default 0.9)
+	Names   []string // Coefficient names used as panel titles
+	HideOLS bool     // Omit the least squares reference line
+}
+
+// CoefficientProcess returns one panel per coefficient showing the estimates of m
+// across tau with shaded confidence bands and the OLS estimate as a reference line
+func CoefficientProcess(m *quantreg.MultiRQFit, opts CoefOptions) ([]*plot.Plot, error) {
+	x, y, err := design(m)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Names != nil && len(opts.Names) != m.P {
+		return nil, fmt.Errorf("expected %d coefficient names, got %d", m.P, len(opts.Names))
+	}
+
+	se := opts.SE
+	if se == "" {
+		se = quantreg.SEIID
+	}
+	level := opts.Level
+	if level == 0 {
+		level = 0.9
+	}
+
+	// Collect estimates and interval bounds for every tau
+	estimates := make([]plotter.XYs, m.P)
+	bands := make([]plotter.XYs, m.P)
+	for j := range estimates {
+		estimates[j] = make(plotter.XYs, len(m.Taus))
+		bands[j] = make(plotter.XYs, 2*len(m.Taus))
+	}
+	for k, tau := range m.Taus {
+		fit := m.Fits[tau]
+		lower, upper, err := fit.ConfInt(se, level)
+		if err != nil {
+			return nil, fmt.Errorf("confidence band failed for tau=%f: %w", tau, err)
+		}
+		for j := 0; j < m.P; j++ {
+			estimates[j][k] = plotter.XY{X: tau, Y: fit.Coefficients[j]}
+			bands[j][k] = plotter.XY{X: tau, Y: lower[j]}
+			bands[j][2*len(m.Taus)-1-k] = plotter.XY{X: tau, Y: upper[j]}
+		}
+	}
+
+	var ols []float64
+	if !opts.HideOLS {
+		ols, err = leastSquares(x, y)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plots := make([]*plot.Plot, m.P)
+	for j := range plots {
+		p := plot.New()
+		p.Title.Text = fmt.Sprintf("Beta[%d]", j)
+		if opts.Names != nil {
+			p.Title.Text = opts.Names[j]
+		}
+		p.X.Label.Text = "tau"
+		p.X.Min, p.X.Max = 0, 1
+
+		band, err := plotter.NewPolygon(bands[j])
+		if err != nil {
+			return nil, err
+		}
+		band.Color = color.Gray{Y: 200}
+		band.LineStyle.Width = 0
+		p.Add(band)
+
+		line, points, err := plotter.NewLinePoints(estimates[j])
+		if err != nil {
+			return nil, err
+		}
+		line.LineStyle.Width = vg.Points(1)
+		points.GlyphStyle.Radius = vg.Points(2)
+		p.Add(line, points)
+
+		if ols != nil {
+			ref, err := plotter.NewLine(plotter.XYs{{X: 0, Y: ols[j]}, {X: 1, Y: ols[j]}})
+			if err != nil {
+				return nil, err
+			}
+			ref.LineStyle.Color = color.NRGBA{R: 200, A: 255}
+			ref.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+			p.Add(ref)
+		}
+
+		plots[j] = p
+	}
+
+	return plots, nil
+}
+
+// leastSquares returns the OLS coefficients of y on x
+func leastSquares(x [][]float64, y []float64) ([]float64, error) {
+	fit, err := quantreg.LS(y, x)
+	if err != nil {
+		return nil, err
+	}
+	return fit.Coefficients, nil
+}
@@ -0,0 +1,67 @@
+package qrplot
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/andreasmuller/quantreg"
+)
+
+func TestCoefficientProcess(t *testing.T) {
+	m := testProcess(t)
+
+	plots, err := CoefficientProcess(m, CoefOptions{Names: []string{"(Intercept)", "x"}})
+	if err != nil {
+		t.Fatalf("Failed to build coefficient plots: %v", err)
+	}
+
+	if len(plots) != m.P {
+		t.Fatalf("Expected %d panels, got %d", m.P, len(plots))
+	}
+
+	if plots[1].Title.Text != "x" {
+		t.Errorf("Expected panel title x, got %q", plots[1].Title.Text)
+	}
+
+	if err := SavePanels(plots, 2, filepath.Join(t.TempDir(), "coef.png")); err != nil {
+		t.Errorf("Failed to save coefficient plots: %v", err)
+	}
+
+	// Kernel bands without the OLS reference
+	plots, err = CoefficientProcess(m, CoefOptions{SE: quantreg.SEKer, Level: 0.8, HideOLS: true})
+	if err != nil {
+		t.Fatalf("Failed to build coefficient plots: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePanels(plots, 1, &buf, "svg"); err != nil {
+		t.Errorf("Failed to render coefficient plots: %v", err)
+	}
+
+	// Error cases
+	if _, err := CoefficientProcess(m, CoefOptions{Names: []string{"x"}}); err == nil {
+		t.Error("Expected error for wrong number of names")
+	}
+
+	if _, err := CoefficientProcess(m, CoefOptions{SE: "bogus"}); err == nil {
+		t.Error("Expected error for unknown standard error method")
+	}
+
+	if err := WritePanels(nil, 1, &buf, "svg"); err == nil {
+		t.Error("Expected error for empty panel list")
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	x := [][]float64{{1, 0}, {1, 1}, {1, 2}}
+	y := []float64{1, 3, 5}
+
+	beta, err := leastSquares(x, y)
+	if err != nil {
+		t.Fatalf("Failed to fit least squares: %v", err)
+	}
+	if len(beta) != 2 || beta[0] < 0.999 || beta[0] > 1.001 || beta[1] < 1.999 || beta[1] > 2.001 {
+		t.Errorf("Expected coefficients [1 2], got %v", beta)
+	}
+}
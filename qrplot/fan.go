@@ -0,0 +1,137 @@
+package qrplot
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/andreasmuller/quantreg"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// FanOptions controls fan chart rendering
+type FanOptions struct {
+	GridPoints int       // Number of covariate values at which curves are evaluated (default 50)
+	At         []float64 // Values of the remaining covariates; defaults to the column means
+	ShowData   bool      // Overlay the observed responses as a scatter
+	Title      string    // Plot title
+	XLabel     string    // Horizontal axis label
+	YLabel     string    // Vertical axis label
+}
+
+// FanChart plots the fitted conditional quantile curves of m against covariate
+// column col, shading the region between symmetric pairs of quantiles
+func FanChart(m *quantreg.MultiRQFit, col int, opts FanOptions) (*plot.Plot, error) {
+	x, y, err := design(m)
+	if err != nil {
+		return nil, err
+	}
+	if col < 0 || col >= m.P {
+		return nil, fmt.Errorf("covariate column %d out of range [0, %d)", col, m.P)
+	}
+
+	at := opts.At
+	if at == nil {
+		at = columnMeans(x)
+	}
+	if len(at) != m.P {
+		return nil, fmt.Errorf("expected %d covariate values, got %d", m.P, len(at))
+	}
+
+	gridPoints := opts.GridPoints
+	if gridPoints <= 0 {
+		gridPoints = 50
+	}
+
+	// Evaluate every quantile curve on a grid over the observed covariate range
+	lo, hi := columnRange(x, col)
+	grid := make([]float64, gridPoints)
+	rows := make([][]float64, gridPoints)
+	for i := range grid {
+		grid[i] = lo
+		if gridPoints > 1 {
+			grid[i] += (hi - lo) * float64(i) / float64(gridPoints-1)
+		}
+		rows[i] = make([]float64, m.P)
+		copy(rows[i], at)
+		rows[i][col] = grid[i]
+	}
+
+	predictions, err := m.Predict(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	p := plot.New()
+	p.Title.Text = opts.Title
+	p.X.Label.Text = opts.XLabel
+	if p.X.Label.Text == "" {
+		p.X.Label.Text = fmt.Sprintf("x[%d]", col)
+	}
+	p.Y.Label.Text = opts.YLabel
+	if p.Y.Label.Text == "" {
+		p.Y.Label.Text = "y"
+	}
+
+	if opts.ShowData {
+		points := make(plotter.XYs, len(x))
+		for i := range x {
+			points[i].X = x[i][col]
+			points[i].Y = y[i]
+		}
+		scatter, err := plotter.NewScatter(points)
+		if err != nil {
+			return nil, err
+		}
+		scatter.GlyphStyle.Color = color.Gray{Y: 120}
+		scatter.GlyphStyle.Radius = vg.Points(1.5)
+		p.Add(scatter)
+	}
+
+	// Shade bands between symmetric quantile pairs, darker towards the centre
+	taus := m.Taus
+	pairs := len(taus) / 2
+	for i := 0; i < pairs; i++ {
+		lower := predictions[taus[i]]
+		upper := predictions[taus[len(taus)-1-i]]
+		band := make(plotter.XYs, 0, 2*gridPoints)
+		for k := range grid {
+			band = append(band, plotter.XY{X: grid[k], Y: lower[k]})
+		}
+		for k := len(grid) - 1; k >= 0; k-- {
+			band = append(band, plotter.XY{X: grid[k], Y: upper[k]})
+		}
+		poly, err := plotter.NewPolygon(band)
+		if err != nil {
+			return nil, err
+		}
+		alpha := uint8(40 + 120*(i+1)/(pairs+1))
+		poly.Color = color.NRGBA{R: 31, G: 119, B: 180, A: alpha}
+		poly.LineStyle.Width = 0
+		p.Add(poly)
+		p.Legend.Add(fmt.Sprintf("%.2f-%.2f", taus[i], taus[len(taus)-1-i]), poly)
+	}
+
+	// Draw each quantile curve on top of the bands
+	for i, tau := range taus {
+		curve := make(plotter.XYs, gridPoints)
+		for k := range grid {
+			curve[k].X = grid[k]
+			curve[k].Y = predictions[tau][k]
+		}
+		line, err := plotter.NewLine(curve)
+		if err != nil {
+			return nil, err
+		}
+		line.LineStyle.Color = color.NRGBA{R: 8, G: 48, B: 107, A: 255}
+		line.LineStyle.Width = vg.Points(0.5)
+		if len(taus)%2 == 1 && i == pairs {
+			line.LineStyle.Width = vg.Points(1.5)
+			p.Legend.Add(fmt.Sprintf("tau=%.2f", tau), line)
+		}
+		p.Add(line)
+	}
+
+	return p, nil
+}
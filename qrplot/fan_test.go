@@ -0,0 +1,32 @@
+package qrplot
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFanChart(t *testing.T) {
+	m := testProcess(t)
+
+	p, err := FanChart(m, 1, FanOptions{ShowData: true, Title: "Fan chart"})
+	if err != nil {
+		t.Fatalf("Failed to build fan chart: %v", err)
+	}
+
+	if err := Save(p, filepath.Join(t.TempDir(), "fan.svg")); err != nil {
+		t.Errorf("Failed to save fan chart: %v", err)
+	}
+
+	// Error cases
+	if _, err := FanChart(m, 2, FanOptions{}); err == nil {
+		t.Error("Expected error for out-of-range covariate")
+	}
+
+	if _, err := FanChart(m, 1, FanOptions{At: []float64{1}}); err == nil {
+		t.Error("Expected error for wrong number of covariate values")
+	}
+
+	if _, err := FanChart(nil, 1, FanOptions{}); err == nil {
+		t.Error("Expected error for missing fit")
+	}
+}
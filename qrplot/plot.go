@@ -0,0 +1,163 @@
+// Package qrplot renders quantile regression fits as charts using gonum/plot
+package qrplot
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreasmuller/quantreg"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// Default image dimensions used by Save and Write
+var (
+	DefaultWidth  = 6 * vg.Inch
+	DefaultHeight = 4 * vg.Inch
+)
+
+// Dimensions of a single panel used by SavePanels and WritePanels
+var (
+	PanelWidth  = 3 * vg.Inch
+	PanelHeight = 2.5 * vg.Inch
+)
+
+// Save writes p to path using the default dimensions; the format is chosen
+// from the file extension, which must be .svg or .png
+func Save(p *plot.Plot, path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".svg" && ext != ".png" {
+		return fmt.Errorf("unsupported image format %q, want .svg or .png", ext)
+	}
+	return p.Save(DefaultWidth, DefaultHeight, path)
+}
+
+// Write renders p in the given format ("svg" or "png") to w using the default dimensions
+func Write(p *plot.Plot, w io.Writer, format string) error {
+	format = strings.ToLower(format)
+	if format != "svg" && format != "png" {
+		return fmt.Errorf("unsupported image format %q, want svg or png", format)
+	}
+	wt, err := p.WriterTo(DefaultWidth, DefaultHeight, format)
+	if err != nil {
+		return err
+	}
+	_, err = wt.WriteTo(w)
+	return err
+}
+
+// SavePanels tiles plots into a grid with the given number of columns and
+// writes it to path; the format is chosen from the extension (.svg or .png)
+func SavePanels(plots []*plot.Plot, cols int, path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".svg" && ext != ".png" {
+		return fmt.Errorf("unsupported image format %q, want .svg or .png", ext)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WritePanels(plots, cols, f, ext[1:]); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WritePanels tiles plots into a grid with the given number of columns and
+// renders it in the given format ("svg" or "png") to w
+func WritePanels(plots []*plot.Plot, cols int, w io.Writer, format string) error {
+	if len(plots) == 0 {
+		return fmt.Errorf("no plots to render")
+	}
+	if cols <= 0 || cols > len(plots) {
+		cols = len(plots)
+	}
+	rows := (len(plots) + cols - 1) / cols
+
+	width := vg.Length(cols) * PanelWidth
+	height := vg.Length(rows) * PanelHeight
+
+	var c vg.CanvasWriterTo
+	switch strings.ToLower(format) {
+	case "svg":
+		c = vgsvg.New(width, height)
+	case "png":
+		c = vgimg.PngCanvas{Canvas: vgimg.New(width, height)}
+	default:
+		return fmt.Errorf("unsupported image format %q, want svg or png", format)
+	}
+
+	grid := make([][]*plot.Plot, rows)
+	for i := range grid {
+		grid[i] = make([]*plot.Plot, cols)
+	}
+	for k, p := range plots {
+		grid[k/cols][k%cols] = p
+	}
+
+	tiles := draw.Tiles{
+		Rows: rows,
+		Cols: cols,
+		PadX: vg.Millimeter,
+		PadY: vg.Millimeter,
+	}
+	canvases := plot.Align(grid, tiles, draw.New(c))
+	for i := range grid {
+		for j, p := range grid[i] {
+			if p != nil {
+				p.Draw(canvases[i][j])
+			}
+		}
+	}
+
+	_, err := c.WriteTo(w)
+	return err
+}
+
+// design returns the design matrix and response stored on a multi-quantile fit
+func design(m *quantreg.MultiRQFit) ([][]float64, []float64, error) {
+	if m == nil || len(m.Taus) == 0 {
+		return nil, nil, fmt.Errorf("no fitted quantiles")
+	}
+	fit := m.Fits[m.Taus[0]]
+	if fit == nil || len(fit.X) == 0 {
+		return nil, nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	return fit.X, fit.Y, nil
+}
+
+// columnRange returns the minimum and maximum of column j
+func columnRange(x [][]float64, j int) (float64, float64) {
+	lo, hi := x[0][j], x[0][j]
+	for _, row := range x[1:] {
+		if row[j] < lo {
+			lo = row[j]
+		}
+		if row[j] > hi {
+			hi = row[j]
+		}
+	}
+	return lo, hi
+}
+
+// columnMeans returns the mean of every column of x
+func columnMeans(x [][]float64) []float64 {
+	means := make([]float64, len(x[0]))
+	for _, row := range x {
+		for j, v := range row {
+			means[j] += v
+		}
+	}
+	for j := range means {
+		means[j] /= float64(len(x))
+	}
+	return means
+}
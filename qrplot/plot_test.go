@@ -0,0 +1,56 @@
+package qrplot
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreasmuller/quantreg"
+	"gonum.org/v1/plot"
+)
+
+// testProcess fits a small quantile process used by the plotting tests
+func testProcess(t *testing.T) *quantreg.MultiRQFit {
+	t.Helper()
+	x := make([][]float64, 12)
+	y := make([]float64, 12)
+	for i := range x {
+		xi := float64(i) / 4
+		x[i] = []float64{1, xi}
+		y[i] = 1 + 0.8*xi + 0.3*float64(i%3-1)*(1+xi)
+	}
+
+	m, err := quantreg.RQProcess(y, x, []float64{0.1, 0.25, 0.5, 0.75, 0.9})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+	return m
+}
+
+func TestSaveAndWrite(t *testing.T) {
+	p := plot.New()
+	dir := t.TempDir()
+
+	for _, name := range []string{"chart.svg", "chart.png"} {
+		if err := Save(p, filepath.Join(dir, name)); err != nil {
+			t.Errorf("Failed to save %s: %v", name, err)
+		}
+	}
+
+	if err := Save(p, filepath.Join(dir, "chart.gif")); err == nil {
+		t.Error("Expected error for unsupported extension")
+	}
+
+	var buf bytes.Buffer
+	if err := Write(p, &buf, "svg"); err != nil {
+		t.Fatalf("Failed to write svg: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<svg") {
+		t.Error("Expected svg output")
+	}
+
+	if err := Write(p, &buf, "pdf"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -0,0 +1,174 @@
+package qrplot
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+
+	"github.com/andreasmuller/quantreg"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Colours used for positive, negative and zero residuals
+var (
+	positiveColor = color.NRGBA{R: 31, G: 119, B: 180, A: 255}
+	negativeColor = color.NRGBA{R: 214, G: 39, B: 40, A: 255}
+	zeroColor     = color.Gray{Y: 90}
+)
+
+// ResidualsVsFitted returns one panel per tau plotting residuals against fitted values
+func ResidualsVsFitted(m *quantreg.MultiRQFit) ([]*plot.Plot, error) {
+	if _, _, err := design(m); err != nil {
+		return nil, err
+	}
+
+	plots := make([]*plot.Plot, len(m.Taus))
+	for k, tau := range m.Taus {
+		fit := m.Fits[tau]
+		fitted, resid := fit.FittedValues(), fit.ResidualValues()
+		points := make(plotter.XYs, len(fitted))
+		for i := range points {
+			points[i].X = fitted[i]
+			points[i].Y = resid[i]
+		}
+
+		p := plot.New()
+		p.Title.Text = fmt.Sprintf("tau = %.2f", tau)
+		p.X.Label.Text = "Fitted"
+		p.Y.Label.Text = "Residual"
+
+		scatter, err := plotter.NewScatter(points)
+		if err != nil {
+			return nil, err
+		}
+		scatter.GlyphStyle.Radius = vg.Points(1.5)
+		p.Add(scatter)
+
+		zero := plotter.NewFunction(func(float64) float64 { return 0 })
+		zero.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+		p.Add(zero)
+
+		plots[k] = p
+	}
+
+	return plots, nil
+}
+
+// WormPlot returns one detrended normal QQ panel per tau with a pointwise 95%
+// envelope simulated from nsim normal samples; see quantreg.RQFit.WormData
+func WormPlot(m *quantreg.MultiRQFit, nsim int, rng *rand.Rand) ([]*plot.Plot, error) {
+	if _, _, err := design(m); err != nil {
+		return nil, err
+	}
+	worms, err := m.WormData(nsim, 0.95, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	plots := make([]*plot.Plot, len(m.Taus))
+	for k, tau := range m.Taus {
+		w := worms[tau]
+		points := make(plotter.XYs, len(w.Deviation))
+		lower := make(plotter.XYs, len(w.Deviation))
+		upper := make(plotter.XYs, len(w.Deviation))
+		for i, z := range w.Theoretical {
+			points[i] = plotter.XY{X: z, Y: w.Deviation[i]}
+			lower[i] = plotter.XY{X: z, Y: w.Lower[i]}
+			upper[i] = plotter.XY{X: z, Y: w.Upper[i]}
+		}
+
+		p := plot.New()
+		p.Title.Text = fmt.Sprintf("tau = %.2f", tau)
+		p.X.Label.Text = "Unit normal quantile"
+		p.Y.Label.Text = "Deviation"
+
+		scatter, err := plotter.NewScatter(points)
+		if err != nil {
+			return nil, err
+		}
+		scatter.GlyphStyle.Radius = vg.Points(1.5)
+		p.Add(scatter)
+
+		for _, bound := range []plotter.XYs{lower, upper} {
+			line, err := plotter.NewLine(bound)
+			if err != nil {
+				return nil, err
+			}
+			line.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+			line.LineStyle.Color = color.Gray{Y: 120}
+			p.Add(line)
+		}
+
+		plots[k] = p
+	}
+
+	return plots, nil
+}
+
+// ResidualSigns returns a map of residual signs with one row per tau. Observations
+// are placed along covariate column col, or by index when col is negative.
+func ResidualSigns(m *quantreg.MultiRQFit, col int) (*plot.Plot, error) {
+	x, _, err := design(m)
+	if err != nil {
+		return nil, err
+	}
+	if col >= m.P {
+		return nil, fmt.Errorf("covariate column %d out of range [0, %d)", col, m.P)
+	}
+
+	var positive, negative, zero plotter.XYs
+	for _, tau := range m.Taus {
+		for i, r := range m.Fits[tau].ResidualValues() {
+			pt := plotter.XY{X: float64(i), Y: tau}
+			if col >= 0 {
+				pt.X = x[i][col]
+			}
+			switch {
+			case r > 0:
+				positive = append(positive, pt)
+			case r < 0:
+				negative = append(negative, pt)
+			default:
+				zero = append(zero, pt)
+			}
+		}
+	}
+
+	p := plot.New()
+	p.Title.Text = "Residual signs"
+	p.X.Label.Text = "Observation"
+	if col >= 0 {
+		p.X.Label.Text = fmt.Sprintf("x[%d]", col)
+	}
+	p.Y.Label.Text = "tau"
+
+	groups := []struct {
+		name   string
+		points plotter.XYs
+		color  color.Color
+		shape  draw.GlyphDrawer
+	}{
+		{"positive", positive, positiveColor, draw.PlusGlyph{}},
+		{"negative", negative, negativeColor, draw.CircleGlyph{}},
+		{"zero", zero, zeroColor, draw.SquareGlyph{}},
+	}
+	for _, g := range groups {
+		if len(g.points) == 0 {
+			continue
+		}
+		scatter, err := plotter.NewScatter(g.points)
+		if err != nil {
+			return nil, err
+		}
+		scatter.GlyphStyle.Color = g.color
+		scatter.GlyphStyle.Shape = g.shape
+		scatter.GlyphStyle.Radius = vg.Points(2)
+		p.Add(scatter)
+		p.Legend.Add(g.name, scatter)
+	}
+
+	return p, nil
+}
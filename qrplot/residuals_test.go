@@ -0,0 +1,45 @@
+package qrplot
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+func TestResidualPlots(t *testing.T) {
+	m := testProcess(t)
+	dir := t.TempDir()
+
+	rvf, err := ResidualsVsFitted(m)
+	if err != nil {
+		t.Fatalf("Failed to build residual plots: %v", err)
+	}
+	if len(rvf) != len(m.Taus) {
+		t.Errorf("Expected %d panels, got %d", len(m.Taus), len(rvf))
+	}
+	if err := SavePanels(rvf, 3, filepath.Join(dir, "rvf.svg")); err != nil {
+		t.Errorf("Failed to save residual plots: %v", err)
+	}
+
+	worm, err := WormPlot(m, 50, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to build worm plots: %v", err)
+	}
+	if err := SavePanels(worm, 3, filepath.Join(dir, "worm.png")); err != nil {
+		t.Errorf("Failed to save worm plots: %v", err)
+	}
+
+	for _, col := range []int{-1, 1} {
+		signs, err := ResidualSigns(m, col)
+		if err != nil {
+			t.Fatalf("Failed to build sign map: %v", err)
+		}
+		if err := Save(signs, filepath.Join(dir, "signs.svg")); err != nil {
+			t.Errorf("Failed to save sign map: %v", err)
+		}
+	}
+
+	if _, err := ResidualSigns(m, 5); err == nil {
+		t.Error("Expected error for out-of-range covariate")
+	}
+}
@@ -0,0 +1,107 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// QuickOptions controls RQQuick
+type QuickOptions struct {
+	Passes int     // Reweighting passes after the least-squares start (default 5)
+	Delta  float64 // Floor on |r_i| in the weights (default 1e-4 times the mean absolute deviation of y, at least 1e-10)
+	Lean   bool    // Store no Fitted or Residuals; FittedValues and ResidualValues recompute them
+}
+
+// RQQuick approximates a linear quantile regression by a few passes of
+// iteratively reweighted least squares. Each pass solves the weighted problem
+// with w_i = tau/|r_i| for positive and (1-tau)/|r_i| for negative residuals of
+// the previous pass, so that w_i r_i^2 equals the check loss at those residuals.
+// The fit is meant for screening many candidate models and for starting exact
+// solvers through RQOptions.Start; it is not the exact minimizer, and Meta.Note
+// says so. Converged reports whether the coefficients settled to a relative
+// change below 1e-6.
+func RQQuick(y []float64, x [][]float64, tau float64, opts QuickOptions) (*RQFit, error) {
+	if len(y) == 0 || len(x) == 0 {
+		return nil, fmt.Errorf("empty input data")
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if tau <= 0 || tau >= 1 {
+		return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+	}
+	if opts.Passes == 0 {
+		opts.Passes = 5
+	}
+	if opts.Delta == 0 {
+		opts.Delta = deviationFloor(y, 1e-4)
+	}
+	if opts.Passes < 0 || opts.Delta < 0 {
+		return nil, fmt.Errorf("quick-fit options must be positive")
+	}
+
+	start := time.Now()
+	n, p := len(y), len(x[0])
+	fit := &RQFit{Tau: tau, N: n, P: p, Method: "irls", X: x, Y: y}
+	w := make([]float64, n)
+	g := make([]float64, p)
+	var beta []float64
+	var err error
+	d := withPhase(PhaseSolve, func() {
+		for i := range w {
+			w[i] = 1
+		}
+		if beta, _, err = weightedSolve(x, w, matVec(transpose(x), y)); err != nil {
+			return
+		}
+		for fit.Iterations < opts.Passes {
+			fit.Iterations++
+			for j := range g {
+				g[j] = 0
+			}
+			for i, row := range x {
+				r := y[i] - dot(row, beta)
+				w[i] = tau / math.Max(math.Abs(r), opts.Delta)
+				if r < 0 {
+					w[i] = (1 - tau) / math.Max(math.Abs(r), opts.Delta)
+				}
+				for j, v := range row {
+					g[j] += v * w[i] * y[i]
+				}
+			}
+			var next []float64
+			if next, _, err = weightedSolve(x, w, g); err != nil {
+				return
+			}
+			change, size := 0.0, 0.0
+			for j := range next {
+				change = math.Max(change, math.Abs(next[j]-beta[j]))
+				size = math.Max(size, math.Abs(next[j]))
+			}
+			beta = next
+			if change <= 1e-6*math.Max(size, 1) {
+				fit.Converged = true
+				break
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("optimization failed: %w", err)
+	}
+	fit.recordPhase(PhaseSolve, d)
+
+	fit.Coefficients = beta
+	fit.setFitted(y, x, opts.Lean)
+	r := make([]float64, n)
+	for i := range y {
+		r[i] = y[i] - dot(x[i], beta)
+	}
+	fit.BasicObs = basicObservations(r, p)
+	fit.Meta = newMeta(fit.Method, map[string]float64{"passes": float64(opts.Passes), "delta": opts.Delta},
+		[]float64{tau}, n, p, start, y, x)
+	fit.Meta.Note = fmt.Sprintf("approximate fit from %d IRLS passes; coefficients are not the exact quantile regression solution and standard errors assume they are", fit.Iterations)
+	logFit(fit.Method, tau, n, p, fit.Iterations, fit.Converged, fit.Meta)
+	currentMetrics().ObserveFit(fit.Method, time.Since(start), fit.Iterations, fit.Converged)
+	return fit, nil
+}
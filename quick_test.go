@@ -0,0 +1,56 @@
+package quantreg
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRQQuick(t *testing.T) {
+	y, x := inferenceData()
+
+	exact, err := RQConstrained(y, x, 0.75, Constraints{})
+	if err != nil {
+		t.Fatalf("Failed to fit exact model: %v", err)
+	}
+	fit, err := RQQuick(y, x, 0.75, QuickOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit quick model: %v", err)
+	}
+	if fit.Method != "irls" || fit.Iterations == 0 || fit.Iterations > 5 {
+		t.Errorf("Expected at most 5 irls passes, got method=%q iterations=%d", fit.Method, fit.Iterations)
+	}
+	if !strings.Contains(fit.Meta.Note, "approximate") {
+		t.Errorf("Expected an accuracy disclaimer, got %q", fit.Meta.Note)
+	}
+	// The approximation should land near the optimum of the check loss
+	if loss, best := sumRho(fit.Residuals, 0.75), sumRho(exact.Residuals, 0.75); loss > 1.1*best {
+		t.Errorf("Expected check loss within 10%% of %v, got %v", best, loss)
+	}
+	if math.Abs(fit.Coefficients[1]-exact.Coefficients[1]) > 0.3 {
+		t.Errorf("Expected slope near %v, got %v", exact.Coefficients[1], fit.Coefficients[1])
+	}
+
+	// The quick fit starts the simplex near the optimum
+	cold, err := RQ(y, x, 0.75)
+	if err != nil {
+		t.Fatalf("Failed to fit exact model: %v", err)
+	}
+	warm, err := RQWithOptions(y, x, 0.75, RQOptions{Start: fit.Coefficients})
+	if err != nil {
+		t.Fatalf("Failed to fit warm-started model: %v", err)
+	}
+	if loss, best := warm.Rho(), exact.Rho(); !warm.Converged || math.Abs(loss-best) > 1e-9*best {
+		t.Errorf("Expected optimal check loss %v from the warm start, got %v", best, loss)
+	}
+	if warm.Iterations > cold.Iterations {
+		t.Errorf("Expected at most %d iterations from the warm start, got %d", cold.Iterations, warm.Iterations)
+	}
+	if _, err := RQWithOptions(y, x, 0.75, RQOptions{Start: []float64{1}}); err == nil {
+		t.Error("Expected error for a start of the wrong length")
+	}
+
+	if _, err := RQQuick(y, x, 0, QuickOptions{}); err == nil {
+		t.Error("Expected error for invalid tau")
+	}
+}
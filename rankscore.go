@@ -0,0 +1,146 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// RankScoreProcess is the regression rank-score process a(tau) of Gutenbrunner
+// and Jureckova (1992), the solution of the dual program
+// max y'a subject to X'a = (1-tau)X'1 and 0 <= a <= 1. Each a_i(tau) falls from 1
+// at tau = 0 to 0 at tau = 1 and is piecewise linear in tau; for tests it plays
+// the role that the ranks play in the location model.
+type RankScoreProcess struct {
+	Taus   []float64   // Sorted grid of quantile levels, excluding the endpoints 0 and 1
+	Scores [][]float64 // Rank scores of every observation, one row per tau
+	N      int
+}
+
+// RankScoreCurve is the data of a rank-score plot of one observation, a_i(tau)
+// against tau including the endpoints
+type RankScoreCurve struct {
+	Taus   []float64
+	Scores []float64
+}
+
+// RankScores computes the regression rank-score process on the tau grid. At each
+// tau the primal problem is solved exactly; observations above the fit score 1,
+// those below score 0, and the scores of the p basic observations solve the dual
+// equality constraints.
+func RankScores(y []float64, x [][]float64, taus []float64) (*RankScoreProcess, error) {
+	if len(taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels specified")
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	sorted := append([]float64(nil), taus...)
+	sort.Float64s(sorted)
+	for _, tau := range sorted {
+		if tau <= 0 || tau >= 1 {
+			return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+		}
+	}
+
+	rs := &RankScoreProcess{Taus: sorted, Scores: make([][]float64, len(sorted)), N: len(y)}
+	for k, tau := range sorted {
+		fit, err := rqSimplex(y, x, nil, tau)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fit model for tau=%f: %w", tau, err)
+		}
+		a, err := dualScores(x, fit.ResidualValues(), fit.BasicObs, tau)
+		if err != nil {
+			return nil, fmt.Errorf("rank scores failed for tau=%f: %w", tau, err)
+		}
+		rs.Scores[k] = a
+	}
+	return rs, nil
+}
+
+// dualScores returns the dual solution for an exact fit with the given residuals
+// and basic observations h: a_i = 1 above the fit, 0 below, and
+// X_h'a_h = (1-tau)X'1 - sum of x_i over the observations above
+func dualScores(x [][]float64, resid []float64, basic []int, tau float64) ([]float64, error) {
+	p := len(x[0])
+	a := make([]float64, len(x))
+	isBasic := make([]bool, len(x))
+	for _, i := range basic {
+		isBasic[i] = true
+	}
+	rhs := make([]float64, p)
+	for i, row := range x {
+		if !isBasic[i] && resid[i] > 0 {
+			a[i] = 1
+		}
+		for j, v := range row {
+			rhs[j] += (1-tau)*v - a[i]*v
+		}
+	}
+	xh := make([][]float64, p)
+	for j := range xh {
+		xh[j] = make([]float64, len(basic))
+		for k, i := range basic {
+			xh[j][k] = x[i][j]
+		}
+	}
+	inv, err := invert(xh)
+	if err != nil {
+		return nil, err
+	}
+	// Degenerate bases can leave the scores marginally outside [0, 1]
+	for k, v := range matVec(inv, rhs) {
+		a[basic[k]] = math.Min(math.Max(v, 0), 1)
+	}
+	return a, nil
+}
+
+// At returns the rank scores at tau in [0, 1], interpolating linearly between the
+// grid taus and the endpoint values a(0) = 1 and a(1) = 0
+func (rs *RankScoreProcess) At(tau float64) ([]float64, error) {
+	if tau < 0 || tau > 1 {
+		return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+	}
+	a := make([]float64, rs.N)
+	for i := range a {
+		taus, scores := rs.path(i)
+		a[i] = interpolateQuantile(taus, scores, tau)
+	}
+	return a, nil
+}
+
+// Integrate returns the rank scores b_i = -integral of phi(t) da_i(t) for a score
+// function phi on (0, 1), by the midpoint rule over the segments of the path. Since
+// phi is evaluated only at midpoints it may be unbounded at the endpoints:
+// phi(t) = t - 1/2 gives the Wilcoxon scores, normQuantile the normal scores and
+// sign(t - 1/2)/2 the sign scores.
+func (rs *RankScoreProcess) Integrate(phi func(t float64) float64) []float64 {
+	b := make([]float64, rs.N)
+	for i := range b {
+		taus, scores := rs.path(i)
+		for k := 1; k < len(taus); k++ {
+			b[i] += phi((taus[k-1]+taus[k])/2) * (scores[k-1] - scores[k])
+		}
+	}
+	return b
+}
+
+// Curve returns the plot data of the rank-score path of observation i
+func (rs *RankScoreProcess) Curve(i int) (*RankScoreCurve, error) {
+	if i < 0 || i >= rs.N {
+		return nil, fmt.Errorf("observation index %d out of range [0, %d)", i, rs.N)
+	}
+	taus, scores := rs.path(i)
+	return &RankScoreCurve{Taus: taus, Scores: scores}, nil
+}
+
+// path returns the taus and rank scores of observation i with the endpoints added
+func (rs *RankScoreProcess) path(i int) ([]float64, []float64) {
+	taus := append(append([]float64{0}, rs.Taus...), 1)
+	scores := make([]float64, len(taus))
+	scores[0] = 1
+	for k := range rs.Taus {
+		scores[k+1] = rs.Scores[k][i]
+	}
+	return taus, scores
+}
@@ -0,0 +1,75 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRankScores(t *testing.T) {
+	y, x := inferenceData()
+	taus := []float64{0.9, 0.1, 0.25, 0.5, 0.75}
+
+	rs, err := RankScores(y, x, taus)
+	if err != nil {
+		t.Fatalf("Failed to compute rank scores: %v", err)
+	}
+	if rs.Taus[0] != 0.1 || len(rs.Scores) != 5 || rs.N != len(y) {
+		t.Fatalf("Expected 5 sorted taus over %d observations, got %v", len(y), rs.Taus)
+	}
+	// Dual feasibility: X'a = (1-tau)X'1 with 0 <= a <= 1
+	for k, tau := range rs.Taus {
+		for j := 0; j < 2; j++ {
+			lhs, rhs := 0.0, 0.0
+			for i, row := range x {
+				a := rs.Scores[k][i]
+				if a < 0 || a > 1 {
+					t.Fatalf("Expected scores in [0, 1], got %v", a)
+				}
+				lhs += row[j] * a
+				rhs += (1 - tau) * row[j]
+			}
+			if math.Abs(lhs-rhs) > 1e-8 {
+				t.Errorf("Expected X'a = %v for column %d at tau=%v, got %v", rhs, j, tau, lhs)
+			}
+		}
+	}
+
+	a, err := rs.At(0)
+	if err != nil {
+		t.Fatalf("Failed to evaluate rank scores: %v", err)
+	}
+	if a[3] != 1 {
+		t.Errorf("Expected a(0) = 1, got %v", a[3])
+	}
+	mid, _ := rs.At(0.625)
+	for i := range mid {
+		want := (rs.Scores[2][i] + rs.Scores[3][i]) / 2
+		if math.Abs(mid[i]-want) > 1e-12 {
+			t.Errorf("Expected interpolated score %v, got %v", want, mid[i])
+		}
+	}
+
+	// With an intercept the Wilcoxon scores sum to zero
+	b := rs.Integrate(func(t float64) float64 { return t - 0.5 })
+	sum := 0.0
+	for _, v := range b {
+		sum += v
+	}
+	if math.Abs(sum) > 1e-8 {
+		t.Errorf("Expected Wilcoxon scores to sum to zero, got %v", sum)
+	}
+
+	c, err := rs.Curve(2)
+	if err != nil {
+		t.Fatalf("Failed to get rank-score curve: %v", err)
+	}
+	if len(c.Taus) != 7 || c.Scores[0] != 1 || c.Scores[6] != 0 {
+		t.Errorf("Expected a curve from 1 to 0 over 7 points, got %v", c.Scores)
+	}
+	if _, err := rs.Curve(len(y)); err == nil {
+		t.Error("Expected error for out-of-range observation")
+	}
+	if _, err := RankScores(y, x, []float64{1}); err == nil {
+		t.Error("Expected error for invalid tau")
+	}
+}
@@ -0,0 +1,102 @@
+package quantreg
+
+import "fmt"
+
+// RecalibratedFit is a quantile process with a monotone map from nominal levels to
+// the fitted levels that attain them on a calibration set
+type RecalibratedFit struct {
+	*MultiRQFit
+	RawCoverage  []float64 // Empirical coverage of each fitted tau on the calibration set
+	Coverage     []float64 // Isotonic, non-decreasing fit of RawCoverage in tau
+	CalibrationN int       // Size of the calibration set
+}
+
+// Recalibrate learns how the fitted quantiles of m actually cover on the
+// calibration data (xCal, yCal), which should not have been used for fitting.
+// The coverage of the fitted taus is smoothed into a non-decreasing curve by the
+// pool-adjacent-violators algorithm, and predictions at a nominal level tau are
+// then made at the fitted level whose calibrated coverage is tau.
+func (m *MultiRQFit) Recalibrate(xCal [][]float64, yCal []float64) (*RecalibratedFit, error) {
+	if len(m.Taus) < 2 {
+		return nil, fmt.Errorf("need at least 2 fitted quantiles, got %d", len(m.Taus))
+	}
+	report, err := m.CheckCalibration(xCal, yCal, 0)
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]float64, len(report.Levels))
+	for k, l := range report.Levels {
+		raw[k] = l.Coverage
+	}
+	return &RecalibratedFit{
+		MultiRQFit:   m,
+		RawCoverage:  raw,
+		Coverage:     isotonicIncreasing(raw),
+		CalibrationN: len(yCal),
+	}, nil
+}
+
+// Level returns the fitted level whose calibrated coverage is tau, interpolating
+// linearly between fitted taus and clamping to the fitted range
+func (f *RecalibratedFit) Level(tau float64) float64 {
+	taus, c := f.Taus, f.Coverage
+	if tau <= c[0] {
+		return taus[0]
+	}
+	last := len(c) - 1
+	if tau >= c[last] {
+		return taus[last]
+	}
+	k := 0
+	for c[k+1] <= tau {
+		k++
+	}
+	return taus[k] + (tau-c[k])/(c[k+1]-c[k])*(taus[k+1]-taus[k])
+}
+
+// PredictTau predicts the recalibrated quantile at the nominal level tau
+func (f *RecalibratedFit) PredictTau(newX [][]float64, tau float64) ([]float64, error) {
+	if tau <= 0 || tau >= 1 {
+		return nil, ErrInvalidTau
+	}
+	return f.MultiRQFit.PredictTau(newX, f.Level(tau))
+}
+
+// Predict predicts the recalibrated quantiles at every fitted tau, keyed by the
+// nominal level
+func (f *RecalibratedFit) Predict(newX [][]float64) (map[float64][]float64, error) {
+	out := make(map[float64][]float64, len(f.Taus))
+	for _, tau := range f.Taus {
+		pred, err := f.PredictTau(newX, tau)
+		if err != nil {
+			return nil, fmt.Errorf("prediction failed for tau=%f: %w", tau, err)
+		}
+		out[tau] = pred
+	}
+	return out, nil
+}
+
+// isotonicIncreasing returns the least-squares non-decreasing fit to v by pool
+// adjacent violators
+func isotonicIncreasing(v []float64) []float64 {
+	type block struct {
+		mean float64
+		size int
+	}
+	var blocks []block
+	for _, x := range v {
+		blocks = append(blocks, block{x, 1})
+		for len(blocks) > 1 && blocks[len(blocks)-2].mean > blocks[len(blocks)-1].mean {
+			a, b := blocks[len(blocks)-2], blocks[len(blocks)-1]
+			size := a.size + b.size
+			blocks = append(blocks[:len(blocks)-2], block{(a.mean*float64(a.size) + b.mean*float64(b.size)) / float64(size), size})
+		}
+	}
+	out := make([]float64, 0, len(v))
+	for _, b := range blocks {
+		for i := 0; i < b.size; i++ {
+			out = append(out, b.mean)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIsotonicIncreasing(t *testing.T) {
+	got := isotonicIncreasing([]float64{1, 3, 2, 4, 0})
+	want := []float64{1, 2.25, 2.25, 2.25, 2.25}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestRecalibrate(t *testing.T) {
+	// Quantiles of y = 1 + 0.5x + u with u uniform on [-0.5, 0.5), evaluated on
+	// calibration data shifted up by 0.2 so that every level undercovers
+	n := 60
+	x := make([][]float64, n)
+	yCal := make([]float64, n)
+	for i := range x {
+		xi := float64(i) / 20
+		x[i] = []float64{1, xi}
+		yCal[i] = 1.2 + 0.5*xi + float64((7*i)%n)/float64(n) - 0.5
+	}
+	m := &MultiRQFit{Fits: make(map[float64]*RQFit), Taus: []float64{0.1, 0.3, 0.5, 0.7, 0.9}, P: 2}
+	for _, tau := range m.Taus {
+		m.Fits[tau] = &RQFit{Tau: tau, P: 2, Coefficients: []float64{0.5 + tau, 0.5}}
+	}
+
+	f, err := m.Recalibrate(x, yCal)
+	if err != nil {
+		t.Fatalf("Failed to recalibrate: %v", err)
+	}
+	for k := 1; k < len(f.Coverage); k++ {
+		if f.Coverage[k] < f.Coverage[k-1] {
+			t.Errorf("Expected non-decreasing coverage, got %v", f.Coverage)
+		}
+	}
+	if level := f.Level(0.5); level <= 0.5 {
+		t.Errorf("Expected a level above 0.5 to compensate undercoverage, got %f", level)
+	}
+
+	coverage := func(q []float64) float64 {
+		c := 0.0
+		for i := range yCal {
+			if yCal[i] <= q[i] {
+				c++
+			}
+		}
+		return c / float64(n)
+	}
+	raw, err := m.PredictTau(x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	pred, err := f.Predict(x)
+	if err != nil {
+		t.Fatalf("Failed to predict recalibrated quantiles: %v", err)
+	}
+	if math.Abs(coverage(pred[0.5])-0.5) >= math.Abs(coverage(raw)-0.5) {
+		t.Errorf("Expected recalibration to improve median coverage, got %f vs raw %f", coverage(pred[0.5]), coverage(raw))
+	}
+
+	if _, err := f.PredictTau(x, 1); err == nil {
+		t.Error("Expected error for tau outside (0, 1)")
+	}
+}
@@ -0,0 +1,201 @@
+package quantreg
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ReconciledForecast holds quantile forecasts that are coherent across a hierarchy
+type ReconciledForecast struct {
+	Taus      []float64
+	Quantiles map[float64][]float64 // Forecast quantiles of every series, per tau
+	Samples   [][]float64           // Coherent joint samples, one row per draw over all series; bottom-up only
+}
+
+// BottomUpOptions controls ReconcileBottomUp
+type BottomUpOptions struct {
+	Samples     int         // Joint draws of the bottom series (default 1000)
+	Correlation [][]float64 // Gaussian copula correlation of the bottom series (default independence)
+}
+
+// MinTOptions controls ReconcileMinT
+type MinTOptions struct {
+	Variances []float64 // Forecast error variance of each series (default the squared spread between the extreme taus)
+}
+
+// ReconcileBottomUp reconciles the quantile forecasts of the bottom series of a
+// hierarchy with summing matrix s, whose row k gives the weights of the bottom
+// series in series k. Joint samples of the bottom series are drawn by inverting
+// their quantile forecasts, linearly interpolated between taus, at uniforms from a
+// Gaussian copula; aggregating every sample through s makes each draw coherent,
+// and the quantiles of all series are read from the aggregated samples.
+func ReconcileBottomUp(s [][]float64, bottom map[float64][]float64, opts BottomUpOptions, rng *rand.Rand) (*ReconciledForecast, error) {
+	taus, err := hierarchyTaus(s, bottom, len(s[0]))
+	if err != nil {
+		return nil, err
+	}
+	if opts.Samples == 0 {
+		opts.Samples = 1000
+	}
+	if opts.Samples < 2 {
+		return nil, fmt.Errorf("need at least 2 samples, got %d", opts.Samples)
+	}
+	b := len(s[0])
+	var chol *mat.TriDense
+	if opts.Correlation != nil {
+		if len(opts.Correlation) != b {
+			return nil, fmt.Errorf("%w: correlation has %d rows for %d bottom series", ErrDimensionMismatch, len(opts.Correlation), b)
+		}
+		sym := mat.NewSymDense(b, nil)
+		for i, row := range opts.Correlation {
+			if len(row) != b {
+				return nil, fmt.Errorf("%w: correlation row %d has %d entries", ErrDimensionMismatch, i, len(row))
+			}
+			for j := i; j < b; j++ {
+				sym.SetSym(i, j, row[j])
+			}
+		}
+		var c mat.Cholesky
+		if !c.Factorize(sym) {
+			return nil, fmt.Errorf("correlation matrix is not positive definite")
+		}
+		chol = mat.NewTriDense(b, mat.Lower, nil)
+		c.LTo(chol)
+	}
+
+	// Quantile curves of each bottom series, rearranged to be non-decreasing
+	curves := make([][]float64, b)
+	for j := range curves {
+		curves[j] = make([]float64, len(taus))
+		for k, tau := range taus {
+			curves[j][k] = bottom[tau][j]
+		}
+		sort.Float64s(curves[j])
+	}
+
+	rng = randOrDefault(rng)
+	z := make([]float64, b)
+	draw := make([]float64, b)
+	rf := &ReconciledForecast{Taus: taus, Quantiles: make(map[float64][]float64, len(taus))}
+	rf.Samples = make([][]float64, opts.Samples)
+	for r := range rf.Samples {
+		for j := range z {
+			z[j] = rng.NormFloat64()
+		}
+		for j := range draw {
+			v := z[j]
+			if chol != nil {
+				v = 0
+				for k := 0; k <= j; k++ {
+					v += chol.At(j, k) * z[k]
+				}
+			}
+			draw[j] = interpolateQuantile(taus, curves[j], normCDF(v))
+		}
+		rf.Samples[r] = matVec(s, draw)
+	}
+
+	col := make([]float64, opts.Samples)
+	for _, tau := range taus {
+		rf.Quantiles[tau] = make([]float64, len(s))
+	}
+	for k := range s {
+		for r, sample := range rf.Samples {
+			col[r] = sample[k]
+		}
+		sort.Float64s(col)
+		for _, tau := range taus {
+			rf.Quantiles[tau][k] = empiricalQuantile(col, tau)
+		}
+	}
+	return rf, nil
+}
+
+// ReconcileMinT reconciles base quantile forecasts of all series of a hierarchy
+// with summing matrix s by projecting the forecast vector at each tau onto the
+// coherent subspace, b = (S'W^-1 S)^-1 S'W^-1 q and q~ = S b, the trace
+// minimization (MinT) combination with a diagonal W of forecast error variances.
+// The bottom-level quantiles are rearranged across taus before aggregation, so the
+// reconciled quantiles do not cross and sum exactly up the hierarchy.
+func ReconcileMinT(s [][]float64, forecasts map[float64][]float64, opts MinTOptions) (*ReconciledForecast, error) {
+	taus, err := hierarchyTaus(s, forecasts, len(s))
+	if err != nil {
+		return nil, err
+	}
+	m, b := len(s), len(s[0])
+	if opts.Variances == nil {
+		lo, hi := forecasts[taus[0]], forecasts[taus[len(taus)-1]]
+		opts.Variances = make([]float64, m)
+		for k := range opts.Variances {
+			opts.Variances[k] = (hi[k] - lo[k]) * (hi[k] - lo[k])
+		}
+	}
+	if len(opts.Variances) != m {
+		return nil, fmt.Errorf("%w: %d variances for %d series", ErrDimensionMismatch, len(opts.Variances), m)
+	}
+	w := make([]float64, m)
+	for k, v := range opts.Variances {
+		if !(v > 0) {
+			return nil, fmt.Errorf("variance of series %d must be positive, got %v", k, v)
+		}
+		w[k] = 1 / v
+	}
+
+	sinv, err := invert(crossprod(s, w))
+	if err != nil {
+		return nil, fmt.Errorf("summing matrix is rank deficient: %w", err)
+	}
+	// g maps all series to the bottom series: (S'W^-1 S)^-1 S'W^-1
+	g := make([][]float64, b)
+	for j := range g {
+		g[j] = make([]float64, m)
+		for k := range g[j] {
+			for l := 0; l < b; l++ {
+				g[j][k] += sinv[j][l] * s[k][l] * w[k]
+			}
+		}
+	}
+
+	bottom := make(map[float64][]float64, len(taus))
+	for _, tau := range taus {
+		bottom[tau] = matVec(g, forecasts[tau])
+	}
+	rearrangeRows(bottom, taus, b)
+
+	rf := &ReconciledForecast{Taus: taus, Quantiles: make(map[float64][]float64, len(taus))}
+	for _, tau := range taus {
+		rf.Quantiles[tau] = matVec(s, bottom[tau])
+	}
+	return rf, nil
+}
+
+// hierarchyTaus validates the summing matrix and forecasts, each of width series,
+// and returns the sorted taus
+func hierarchyTaus(s [][]float64, forecasts map[float64][]float64, width int) ([]float64, error) {
+	if len(s) == 0 || len(s[0]) == 0 {
+		return nil, fmt.Errorf("empty summing matrix")
+	}
+	for k, row := range s {
+		if len(row) != len(s[0]) {
+			return nil, fmt.Errorf("%w: summing matrix row %d has %d entries", ErrDimensionMismatch, k, len(row))
+		}
+	}
+	if len(forecasts) < 2 {
+		return nil, fmt.Errorf("need at least 2 quantile levels, got %d", len(forecasts))
+	}
+	taus := make([]float64, 0, len(forecasts))
+	for tau, q := range forecasts {
+		if tau <= 0 || tau >= 1 {
+			return nil, fmt.Errorf("%w, got %f", ErrInvalidTau, tau)
+		}
+		if len(q) != width {
+			return nil, fmt.Errorf("%w: forecast at tau=%f has %d series, want %d", ErrDimensionMismatch, tau, len(q), width)
+		}
+		taus = append(taus, tau)
+	}
+	sort.Float64s(taus)
+	return taus, nil
+}
@@ -0,0 +1,84 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// twoRegions is the summing matrix of total = north + south
+var twoRegions = [][]float64{{1, 1}, {1, 0}, {0, 1}}
+
+func TestReconcileBottomUp(t *testing.T) {
+	// Bottom series with standard normal quantiles around 10 and 20
+	bottom := make(map[float64][]float64)
+	for _, tau := range []float64{0.05, 0.25, 0.5, 0.75, 0.95} {
+		z := normQuantile(tau)
+		bottom[tau] = []float64{10 + z, 20 + z}
+	}
+
+	rf, err := ReconcileBottomUp(twoRegions, bottom, BottomUpOptions{Samples: 4000}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+	for _, sample := range rf.Samples {
+		if math.Abs(sample[0]-sample[1]-sample[2]) > 1e-12 {
+			t.Fatalf("Expected coherent samples, got %v", sample)
+		}
+	}
+	if med := rf.Quantiles[0.5][0]; math.Abs(med-30) > 0.15 {
+		t.Errorf("Expected total median near 30, got %v", med)
+	}
+	// Independent errors add in quadrature
+	spread := rf.Quantiles[0.75][0] - rf.Quantiles[0.25][0]
+	if want := math.Sqrt2 * (normQuantile(0.75) - normQuantile(0.25)); math.Abs(spread-want) > 0.15 {
+		t.Errorf("Expected total interquartile range near %v, got %v", want, spread)
+	}
+
+	// Perfectly correlated errors add linearly
+	corr := [][]float64{{1, 0.999999}, {0.999999, 1}}
+	rf, err = ReconcileBottomUp(twoRegions, bottom, BottomUpOptions{Samples: 4000, Correlation: corr}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to reconcile with correlation: %v", err)
+	}
+	spread = rf.Quantiles[0.75][0] - rf.Quantiles[0.25][0]
+	if want := 2 * (normQuantile(0.75) - normQuantile(0.25)); math.Abs(spread-want) > 0.15 {
+		t.Errorf("Expected total interquartile range near %v, got %v", want, spread)
+	}
+
+	if _, err := ReconcileBottomUp(twoRegions, map[float64][]float64{0.5: {1, 2}}, BottomUpOptions{}, nil); err == nil {
+		t.Error("Expected error for a single quantile level")
+	}
+}
+
+func TestReconcileMinT(t *testing.T) {
+	// The total forecast disagrees with the sum of the regions
+	forecasts := map[float64][]float64{
+		0.1: {26, 8, 17},
+		0.5: {32, 10, 20},
+		0.9: {38, 12, 23},
+	}
+	rf, err := ReconcileMinT(twoRegions, forecasts, MinTOptions{})
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+	for _, tau := range rf.Taus {
+		q := rf.Quantiles[tau]
+		if math.Abs(q[0]-q[1]-q[2]) > 1e-10 {
+			t.Errorf("Expected coherent quantiles at tau=%f, got %v", tau, q)
+		}
+	}
+	// The precise regional forecasts absorb little of the discrepancy
+	if med := rf.Quantiles[0.5]; med[0] < 30 || med[0] > 31 || med[1] < 10 || med[2] < 20 {
+		t.Errorf("Expected the median total pulled close to 30, got %v", med)
+	}
+	for k := range twoRegions {
+		if rf.Quantiles[0.1][k] > rf.Quantiles[0.5][k] || rf.Quantiles[0.5][k] > rf.Quantiles[0.9][k] {
+			t.Errorf("Expected non-crossing quantiles for series %d", k)
+		}
+	}
+
+	if _, err := ReconcileMinT(twoRegions, forecasts, MinTOptions{Variances: []float64{1, 1}}); err == nil {
+		t.Error("Expected error for mismatched variances")
+	}
+}
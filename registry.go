@@ -0,0 +1,209 @@
+package quantreg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Model is a fitted multi-quantile model that can be served from a Registry
+type Model interface {
+	Predict(newX [][]float64) (map[float64][]float64, error)
+}
+
+// ModelVersion describes one loaded revision of a named model
+type ModelVersion struct {
+	Name     string    // Registry name
+	Version  int       // Revision number, starting at 1 for each name
+	Source   string    // File the model was loaded from, empty for pushed models
+	ModTime  time.Time // Modification time of Source when it was loaded
+	LoadedAt time.Time // Time the revision became active
+	Model    Model     // The served model
+}
+
+// Decoder reads a model from its serialized form
+type Decoder func(r io.Reader) (Model, error)
+
+// DecodeMultiRQFit decodes a JSON-encoded MultiRQFit
+func DecodeMultiRQFit(r io.Reader) (Model, error) {
+	m := &MultiRQFit{}
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Registry holds named models and swaps them atomically when new versions arrive.
+// Lookups never block on reloads: readers see either the old or the new version.
+type Registry struct {
+	mu       sync.Mutex     // serializes writers
+	current  sync.Map       // name -> *ModelVersion
+	versions map[string]int // last version number handed out per name
+	decode   Decoder
+}
+
+// NewRegistry creates an empty registry; a nil decoder defaults to DecodeMultiRQFit
+func NewRegistry(decode Decoder) *Registry {
+	if decode == nil {
+		decode = DecodeMultiRQFit
+	}
+	return &Registry{decode: decode, versions: make(map[string]int)}
+}
+
+// Set publishes model under name as a new version and returns that version
+func (r *Registry) Set(name string, model Model) (*ModelVersion, error) {
+	if name == "" {
+		return nil, fmt.Errorf("model name must not be empty")
+	}
+	if model == nil {
+		return nil, fmt.Errorf("model must not be nil")
+	}
+	return r.publish(&ModelVersion{Name: name, Model: model}), nil
+}
+
+// Remove drops the named model
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current.Delete(name)
+}
+
+// Get returns the active version of the named model
+func (r *Registry) Get(name string) (*ModelVersion, bool) {
+	v, ok := r.current.Load(name)
+	currentMetrics().ObserveCacheLookup(name, ok)
+	if !ok {
+		return nil, false
+	}
+	return v.(*ModelVersion), true
+}
+
+// Names returns the names of all registered models
+func (r *Registry) Names() []string {
+	var names []string
+	r.current.Range(func(k, _ interface{}) bool {
+		names = append(names, k.(string))
+		return true
+	})
+	return names
+}
+
+// Predict scores newX with the active version of the named model
+func (r *Registry) Predict(name string, newX [][]float64) (map[float64][]float64, int, error) {
+	v, ok := r.Get(name)
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown model %q", name)
+	}
+	pred, err := v.Model.Predict(newX)
+	return pred, v.Version, err
+}
+
+// Reload scans dir for *.json model files and publishes every file that is new
+// or has changed since it was last loaded. Models whose files disappeared are removed.
+// The model name is the file name without extension.
+func (r *Registry) Reload(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var errs []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		path := filepath.Join(dir, entry.Name())
+		seen[name] = true
+
+		info, err := entry.Info()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		if v, ok := r.current.Load(name); ok {
+			old := v.(*ModelVersion)
+			if old.Source == path && old.ModTime.Equal(info.ModTime()) {
+				continue
+			}
+		}
+
+		model, err := r.load(path)
+		if err != nil {
+			// Keep serving the previous version when a new file is broken
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		r.publish(&ModelVersion{Name: name, Source: path, ModTime: info.ModTime(), Model: model})
+	}
+
+	r.mu.Lock()
+	r.current.Range(func(k, v interface{}) bool {
+		mv := v.(*ModelVersion)
+		if mv.Source != "" && filepath.Dir(mv.Source) == filepath.Clean(dir) && !seen[mv.Name] {
+			r.current.Delete(k)
+		}
+		return true
+	})
+	r.mu.Unlock()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to load models: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Watch reloads dir every interval until ctx is cancelled. Load errors are
+// passed to onError when it is non-nil; the previous model versions stay active.
+// The interval must be positive.
+func (r *Registry) Watch(ctx context.Context, dir string, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %v", interval)
+	}
+	if err := r.Reload(dir); err != nil && onError != nil {
+		onError(err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := r.Reload(dir); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// load decodes the model stored at path
+func (r *Registry) load(path string) (Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return r.decode(f)
+}
+
+// publish assigns the next version number for v.Name and makes v active.
+// Version numbers keep increasing when a model is removed and published again.
+func (r *Registry) publish(v *ModelVersion) *ModelVersion {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.versions[v.Name]++
+	v.Version = r.versions[v.Name]
+	v.LoadedAt = time.Now()
+	r.current.Store(v.Name, v)
+	return v
+}
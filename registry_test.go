@@ -0,0 +1,187 @@
+package quantreg
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeModel fits a small process with the given slope and stores it as JSON in path
+func writeModel(t *testing.T, path string, slope float64) *MultiRQFit {
+	t.Helper()
+	x := [][]float64{
+		{1, 0.0},
+		{1, 1.0},
+		{1, 2.0},
+		{1, 3.0},
+	}
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = 1 + slope*x[i][1]
+	}
+
+	m, err := RQProcess(y, x, []float64{0.5})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Failed to encode fit: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("Failed to write model: %v", err)
+	}
+	return m
+}
+
+func TestRegistrySet(t *testing.T) {
+	rec := &recordingMetrics{}
+	SetMetrics(rec)
+	defer SetMetrics(nil)
+
+	reg := NewRegistry(nil)
+	m := writeModel(t, filepath.Join(t.TempDir(), "m.json"), 1)
+
+	v, err := reg.Set("demand", m)
+	if err != nil {
+		t.Fatalf("Failed to publish model: %v", err)
+	}
+	if v.Version != 1 {
+		t.Errorf("Expected version 1, got %d", v.Version)
+	}
+
+	v, _ = reg.Set("demand", m)
+	if v.Version != 2 {
+		t.Errorf("Expected version 2, got %d", v.Version)
+	}
+
+	pred, version, err := reg.Predict("demand", [][]float64{{1, 4}})
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	if version != 2 || len(pred[0.5]) != 1 {
+		t.Errorf("Unexpected prediction %v from version %d", pred, version)
+	}
+
+	if _, _, err := reg.Predict("missing", [][]float64{{1, 4}}); err == nil {
+		t.Error("Expected error for unknown model")
+	}
+	if rec.lookups != 2 {
+		t.Errorf("Expected 2 cache lookups, got %d", rec.lookups)
+	}
+
+	// Versions keep increasing across removal
+	reg.Remove("demand")
+	if _, ok := reg.Get("demand"); ok {
+		t.Error("Expected removed model to be gone")
+	}
+	v, _ = reg.Set("demand", m)
+	if v.Version != 3 {
+		t.Errorf("Expected version 3 after re-publishing, got %d", v.Version)
+	}
+
+	if _, err := reg.Set("", m); err == nil {
+		t.Error("Expected error for empty name")
+	}
+}
+
+func TestRegistryReload(t *testing.T) {
+	dir := t.TempDir()
+	reg := NewRegistry(nil)
+
+	writeModel(t, filepath.Join(dir, "a.json"), 1)
+	writeModel(t, filepath.Join(dir, "b.json"), 2)
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reg.Reload(dir); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if len(reg.Names()) != 2 {
+		t.Fatalf("Expected 2 models, got %v", reg.Names())
+	}
+
+	// Unchanged files are not reloaded
+	if err := reg.Reload(dir); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if v, _ := reg.Get("a"); v.Version != 1 {
+		t.Errorf("Expected version 1 for unchanged model, got %d", v.Version)
+	}
+
+	// Updated files produce a new version
+	path := filepath.Join(dir, "a.json")
+	writeModel(t, path, 3)
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Reload(dir); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if v, _ := reg.Get("a"); v.Version != 2 || v.Source != path {
+		t.Errorf("Expected version 2 loaded from %s, got %d from %s", path, v.Version, v.Source)
+	}
+
+	// A broken file keeps the previous version active
+	if err := os.WriteFile(filepath.Join(dir, "b.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later = later.Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, "b.json"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Reload(dir); err == nil {
+		t.Error("Expected error for broken model file")
+	}
+	if v, ok := reg.Get("b"); !ok || v.Version != 1 {
+		t.Error("Expected previous version of b to stay active")
+	}
+
+	// Deleted files are removed
+	if err := os.Remove(filepath.Join(dir, "b.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Reload(dir); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if _, ok := reg.Get("b"); ok {
+		t.Error("Expected b to be removed with its file")
+	}
+}
+
+func TestRegistryWatch(t *testing.T) {
+	dir := t.TempDir()
+	writeModel(t, filepath.Join(dir, "a.json"), 1)
+
+	reg := NewRegistry(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- reg.Watch(ctx, dir, 10*time.Millisecond, func(err error) { t.Errorf("Unexpected error: %v", err) })
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := reg.Get("a"); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Model was not loaded by Watch")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if err := reg.Watch(context.Background(), dir, 0, nil); err == nil {
+		t.Error("Expected error for a zero interval")
+	}
+}
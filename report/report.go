@@ -0,0 +1,361 @@
+// Package report renders self-contained HTML or Markdown reports of quantile regression fits
+package report
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/andreasmuller/quantreg"
+	"github.com/andreasmuller/quantreg/qrplot"
+)
+
+// Output formats accepted by Options.Format
+const (
+	HTML     = "html"
+	Markdown = "markdown"
+)
+
+// Options controls report contents
+type Options struct {
+	Format    string   // HTML (default) or Markdown
+	Title     string   // Report title
+	Names     []string // Coefficient names; defaults to Beta[j]
+	SE        string   // Standard error method (default quantreg.SEIID)
+	Level     float64  // Confidence level (default 0.95)
+	Covariate *int     // Covariate column for the fan chart; nil selects 1, or 0 for single-column designs
+	NoPlots   bool     // Omit the embedded plots
+}
+
+// coefRow is one line of the coefficient table
+type coefRow struct {
+	Tau      float64
+	Term     string
+	Estimate string
+	StdErr   string
+	Lower    string
+	Upper    string
+}
+
+// residualRow summarizes the residuals at one tau
+type residualRow struct {
+	Tau                            float64
+	Min, Median, Max, Mean, StdDev string
+}
+
+// crossing records quantile crossings between a pair of taus
+type crossing struct {
+	Lower, Upper float64
+	Count        int
+}
+
+// figure is an embedded image
+type figure struct {
+	Caption string
+	DataURI string
+}
+
+// document is the data passed to the report templates
+type document struct {
+	Title          string
+	N, P           int
+	Taus           []float64
+	Method         string
+	SE             string
+	Level          float64
+	Coefficients   []coefRow
+	Residuals      []residualRow
+	PseudoRSquared string
+	Crossings      []crossing
+	Figures        []figure
+	Notes          []string
+}
+
+// Report renders a report of m in the requested format
+func Report(m *quantreg.MultiRQFit, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Write(&buf, m, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write renders a report of m in the requested format to w
+func Write(w io.Writer, m *quantreg.MultiRQFit, opts Options) error {
+	if m == nil || len(m.Taus) == 0 {
+		return fmt.Errorf("no fitted quantiles")
+	}
+	if opts.Names != nil && len(opts.Names) != m.P {
+		return fmt.Errorf("expected %d coefficient names, got %d", m.P, len(opts.Names))
+	}
+
+	format := strings.ToLower(opts.Format)
+	if format == "" {
+		format = HTML
+	}
+	if format != HTML && format != Markdown {
+		return fmt.Errorf("unsupported report format %q", opts.Format)
+	}
+
+	doc := build(m, opts)
+
+	if format == Markdown {
+		return markdownTemplate.Execute(w, doc)
+	}
+	return htmlTemplate.Execute(w, doc)
+}
+
+// build assembles the report contents
+func build(m *quantreg.MultiRQFit, opts Options) *document {
+	se := opts.SE
+	if se == "" {
+		se = quantreg.SEIID
+	}
+	level := opts.Level
+	if level == 0 {
+		level = 0.95
+	}
+	title := opts.Title
+	if title == "" {
+		title = "Quantile Regression Report"
+	}
+
+	doc := &document{
+		Title:  title,
+		N:      m.N,
+		P:      m.P,
+		Taus:   m.Taus,
+		Method: m.Method,
+		SE:     se,
+		Level:  level,
+	}
+
+	terms := make([]string, m.P)
+	for j := range terms {
+		terms[j] = fmt.Sprintf("Beta[%d]", j)
+		if opts.Names != nil {
+			terms[j] = opts.Names[j]
+		}
+	}
+
+	for _, tau := range m.Taus {
+		fit := m.Fits[tau]
+		stdErr, errSE := fit.StdErrors(se)
+		lower, upper, errCI := fit.ConfInt(se, level)
+		if errSE != nil || errCI != nil {
+			doc.Notes = append(doc.Notes, fmt.Sprintf("Standard errors unavailable for tau=%.2f: %v", tau, firstError(errSE, errCI)))
+		}
+		for j, coef := range fit.Coefficients {
+			row := coefRow{Tau: tau, Term: terms[j], Estimate: number(coef), StdErr: "NA", Lower: "NA", Upper: "NA"}
+			if errSE == nil {
+				row.StdErr = number(stdErr[j])
+			}
+			if errCI == nil {
+				row.Lower = number(lower[j])
+				row.Upper = number(upper[j])
+			}
+			doc.Coefficients = append(doc.Coefficients, row)
+		}
+	}
+
+	diag := m.ComputeDiagnostics()
+	for _, tau := range m.Taus {
+		s := diag.ResidualStats[tau]
+		doc.Residuals = append(doc.Residuals, residualRow{
+			Tau:    tau,
+			Min:    number(s.Min),
+			Median: number(s.Median),
+			Max:    number(s.Max),
+			Mean:   number(s.Mean),
+			StdDev: number(s.StdDev),
+		})
+	}
+	doc.PseudoRSquared = number(diag.PseudoRSquared)
+	for i := range m.Taus {
+		for j := i + 1; j < len(m.Taus); j++ {
+			if c := diag.CrossingMatrix[i][j]; c > 0 {
+				doc.Crossings = append(doc.Crossings, crossing{Lower: m.Taus[i], Upper: m.Taus[j], Count: c})
+			}
+		}
+	}
+
+	if !opts.NoPlots {
+		doc.Figures, doc.Notes = figures(m, opts, se, level, doc.Notes)
+	}
+
+	return doc
+}
+
+// figures renders the embedded plots, recording a note for every plot that fails
+func figures(m *quantreg.MultiRQFit, opts Options, se string, level float64, notes []string) ([]figure, []string) {
+	var figs []figure
+
+	add := func(caption string, render func(w io.Writer) error) {
+		var buf bytes.Buffer
+		if err := render(&buf); err != nil {
+			notes = append(notes, fmt.Sprintf("%s unavailable: %v", caption, err))
+			return
+		}
+		figs = append(figs, figure{
+			Caption: caption,
+			DataURI: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+		})
+	}
+
+	add("Coefficient process", func(w io.Writer) error {
+		plots, err := qrplot.CoefficientProcess(m, qrplot.CoefOptions{SE: se, Level: level, Names: opts.Names})
+		if err != nil {
+			return err
+		}
+		return qrplot.WritePanels(plots, 2, w, "png")
+	})
+
+	col := 0
+	if opts.Covariate != nil {
+		col = *opts.Covariate
+	} else if m.P > 1 {
+		col = 1
+	}
+	add("Fan chart", func(w io.Writer) error {
+		fanOpts := qrplot.FanOptions{ShowData: true}
+		if opts.Names != nil && col < len(opts.Names) {
+			fanOpts.XLabel = opts.Names[col]
+		}
+		p, err := qrplot.FanChart(m, col, fanOpts)
+		if err != nil {
+			return err
+		}
+		return qrplot.Write(p, w, "png")
+	})
+
+	add("Residuals versus fitted values", func(w io.Writer) error {
+		plots, err := qrplot.ResidualsVsFitted(m)
+		if err != nil {
+			return err
+		}
+		return qrplot.WritePanels(plots, 3, w, "png")
+	})
+
+	add("Residual signs", func(w io.Writer) error {
+		p, err := qrplot.ResidualSigns(m, -1)
+		if err != nil {
+			return err
+		}
+		return qrplot.Write(p, w, "png")
+	})
+
+	return figs, notes
+}
+
+// number formats a value for the report tables
+func number(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "NA"
+	}
+	return fmt.Sprintf("%.6f", v)
+}
+
+// firstError returns the first non-nil error
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("report").Funcs(htmltemplate.FuncMap{
+	"mulPct": mulPct,
+	"uri":    func(s string) htmltemplate.URL { return htmltemplate.URL(s) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: right; }
+th { background: #f0f0f0; }
+td.term { text-align: left; }
+figure { margin: 1em 0; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Observations: {{.N}}, parameters: {{.P}}, method: {{.Method}}, quantile levels: {{.Taus}}</p>
+
+<h2>Coefficients</h2>
+<p>Standard errors: {{.SE}}, {{printf "%.0f" (mulPct .Level)}}% confidence intervals</p>
+<table>
+<tr><th>tau</th><th>Term</th><th>Estimate</th><th>Std. Error</th><th>Lower</th><th>Upper</th></tr>
+{{range .Coefficients}}<tr><td>{{printf "%.2f" .Tau}}</td><td class="term">{{.Term}}</td><td>{{.Estimate}}</td><td>{{.StdErr}}</td><td>{{.Lower}}</td><td>{{.Upper}}</td></tr>
+{{end}}</table>
+
+<h2>Diagnostics</h2>
+<p>Pseudo R-squared: {{.PseudoRSquared}}</p>
+<table>
+<tr><th>tau</th><th>Min</th><th>Median</th><th>Max</th><th>Mean</th><th>Std. Dev.</th></tr>
+{{range .Residuals}}<tr><td>{{printf "%.2f" .Tau}}</td><td>{{.Min}}</td><td>{{.Median}}</td><td>{{.Max}}</td><td>{{.Mean}}</td><td>{{.StdDev}}</td></tr>
+{{end}}</table>
+
+<h2>Quantile crossings</h2>
+{{if .Crossings}}<table>
+<tr><th>Lower tau</th><th>Upper tau</th><th>Crossings</th></tr>
+{{range .Crossings}}<tr><td>{{printf "%.2f" .Lower}}</td><td>{{printf "%.2f" .Upper}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>{{else}}<p>No crossings between fitted quantiles.</p>{{end}}
+{{range .Figures}}
+<figure><img src="{{uri .DataURI}}" alt="{{.Caption}}"><figcaption>{{.Caption}}</figcaption></figure>
+{{end}}{{if .Notes}}
+<h2>Notes</h2>
+<ul>{{range .Notes}}<li>{{.}}</li>{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+var markdownTemplate = texttemplate.Must(texttemplate.New("report").Funcs(texttemplate.FuncMap{
+	"mulPct": mulPct,
+}).Parse(`# {{.Title}}
+
+Observations: {{.N}}, parameters: {{.P}}, method: {{.Method}}, quantile levels: {{.Taus}}
+
+## Coefficients
+
+Standard errors: {{.SE}}, {{printf "%.0f" (mulPct .Level)}}% confidence intervals
+
+| tau | Term | Estimate | Std. Error | Lower | Upper |
+|----:|:-----|---------:|-----------:|------:|------:|
+{{range .Coefficients}}| {{printf "%.2f" .Tau}} | {{.Term}} | {{.Estimate}} | {{.StdErr}} | {{.Lower}} | {{.Upper}} |
+{{end}}
+## Diagnostics
+
+Pseudo R-squared: {{.PseudoRSquared}}
+
+| tau | Min | Median | Max | Mean | Std. Dev. |
+|----:|----:|-------:|----:|-----:|----------:|
+{{range .Residuals}}| {{printf "%.2f" .Tau}} | {{.Min}} | {{.Median}} | {{.Max}} | {{.Mean}} | {{.StdDev}} |
+{{end}}
+## Quantile crossings
+
+{{if .Crossings}}| Lower tau | Upper tau | Crossings |
+|----------:|----------:|----------:|
+{{range .Crossings}}| {{printf "%.2f" .Lower}} | {{printf "%.2f" .Upper}} | {{.Count}} |
+{{end}}{{else}}No crossings between fitted quantiles.
+{{end}}{{range .Figures}}
+![{{.Caption}}]({{.DataURI}})
+{{end}}{{if .Notes}}
+## Notes
+
+{{range .Notes}}- {{.}}
+{{end}}{{end}}`))
+
+// mulPct converts a level in (0, 1) to a percentage
+func mulPct(level float64) float64 {
+	return 100 * level
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreasmuller/quantreg"
+)
+
+func testProcess(t *testing.T) *quantreg.MultiRQFit {
+	t.Helper()
+	x := make([][]float64, 12)
+	y := make([]float64, 12)
+	for i := range x {
+		xi := float64(i) / 4
+		x[i] = []float64{1, xi}
+		y[i] = 1 + 0.8*xi + 0.3*float64(i%3-1)*(1+xi)
+	}
+
+	m, err := quantreg.RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+	return m
+}
+
+func TestReportHTML(t *testing.T) {
+	m := testProcess(t)
+
+	out, err := Report(m, Options{Title: "Demand <model>", Names: []string{"(Intercept)", "price"}})
+	if err != nil {
+		t.Fatalf("Failed to render report: %v", err)
+	}
+
+	html := string(out)
+	for _, want := range []string{"<h1>Demand &lt;model&gt;</h1>", "price", "Quantile crossings", "data:image/png;base64,"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected report to contain %q", want)
+		}
+	}
+}
+
+func TestReportMarkdown(t *testing.T) {
+	m := testProcess(t)
+
+	out, err := Report(m, Options{Format: Markdown, NoPlots: true})
+	if err != nil {
+		t.Fatalf("Failed to render report: %v", err)
+	}
+
+	md := string(out)
+	if !strings.HasPrefix(md, "# Quantile Regression Report") {
+		t.Errorf("Unexpected report header: %q", strings.SplitN(md, "\n", 2)[0])
+	}
+	if !strings.Contains(md, "| 0.50 | Beta[1] |") {
+		t.Error("Expected coefficient row for Beta[1] at tau=0.50")
+	}
+	if strings.Contains(md, "data:image") {
+		t.Error("Expected no embedded plots")
+	}
+
+	// An explicit covariate column is used as given, including column 0
+	col := 5
+	out, err = Report(m, Options{Format: Markdown, Covariate: &col})
+	if err != nil {
+		t.Fatalf("Failed to render report: %v", err)
+	}
+	if !strings.Contains(string(out), "Fan chart unavailable: covariate column 5 out of range") {
+		t.Error("Expected a note for the out-of-range fan chart covariate")
+	}
+
+	// Error cases
+	if _, err := Report(m, Options{Format: "pdf"}); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if _, err := Report(m, Options{Names: []string{"x"}}); err == nil {
+		t.Error("Expected error for wrong number of names")
+	}
+	if _, err := Report(nil, Options{}); err == nil {
+		t.Error("Expected error for missing fit")
+	}
+}
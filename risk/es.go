@@ -0,0 +1,143 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/andreasmuller/quantreg"
+)
+
+// Forecast is a joint (VaR, ES) forecast for one row with delta-method uncertainty
+type Forecast struct {
+	Alpha float64 // Tail probability
+	VaR   float64 // Value-at-Risk as a positive loss
+	ES    float64 // Expected Shortfall as a positive loss
+	VaRSE float64 // Standard error of VaR
+	ESSE  float64 // Standard error of ES
+	Cov   float64 // Covariance of the VaR and ES estimates
+}
+
+// ExpectedShortfall estimates VaR and ES at level alpha for each row of newX by
+// integrating the fitted conditional quantile function over (0, alpha]. Between
+// grid points the quantile function is linear in tau; below the smallest tau it is
+// extrapolated linearly in log(tau) from the two lowest levels. Both estimates are
+// linear in the coefficients, so their joint covariance follows from
+// MultiRQFit.JointVcov.
+func ExpectedShortfall(m *quantreg.MultiRQFit, newX [][]float64, alpha float64) ([]Forecast, error) {
+	weights, varWeights, err := esWeights(m.Taus, alpha)
+	if err != nil {
+		return nil, err
+	}
+	cov, err := m.JointVcov()
+	if err != nil {
+		return nil, fmt.Errorf("joint covariance failed: %w", err)
+	}
+	pred, err := m.Predict(newX)
+	if err != nil {
+		return nil, fmt.Errorf("prediction failed: %w", err)
+	}
+
+	p := m.P
+	out := make([]Forecast, len(newX))
+	for i, x := range newX {
+		f := Forecast{Alpha: alpha}
+		for k, tau := range m.Taus {
+			f.VaR -= varWeights[k] * pred[tau][i]
+			f.ES -= weights[k] * pred[tau][i]
+		}
+
+		// x' V_kl x for every pair of taus
+		var vv, ee, ve float64
+		for k := range m.Taus {
+			for l := range m.Taus {
+				s := 0.0
+				for a := 0; a < p; a++ {
+					for b := 0; b < p; b++ {
+						s += x[a] * cov[k*p+a][l*p+b] * x[b]
+					}
+				}
+				vv += varWeights[k] * varWeights[l] * s
+				ee += weights[k] * weights[l] * s
+				ve += varWeights[k] * weights[l] * s
+			}
+		}
+		f.VaRSE = math.Sqrt(math.Max(vv, 0))
+		f.ESSE = math.Sqrt(math.Max(ee, 0))
+		f.Cov = ve
+		out[i] = f
+	}
+
+	return out, nil
+}
+
+// esWeights returns weights over the tau grid such that ES = -sum w_k Q(tau_k)
+// and VaR = -sum v_k Q(tau_k)
+func esWeights(taus []float64, alpha float64) ([]float64, []float64, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return nil, nil, fmt.Errorf("alpha must be between 0 and 1")
+	}
+	if len(taus) == 0 || taus[0] > alpha {
+		return nil, nil, fmt.Errorf("tau grid must contain a level at or below alpha=%f", alpha)
+	}
+	K := len(taus)
+
+	// Quantile at alpha, interpolated on the grid
+	v := make([]float64, K)
+	j := 0
+	for j+1 < K && taus[j+1] <= alpha {
+		j++
+	}
+	if taus[j] == alpha || j+1 == K {
+		if taus[j] != alpha {
+			return nil, nil, fmt.Errorf("alpha=%f lies above the largest tau %f", alpha, taus[j])
+		}
+		v[j] = 1
+	} else {
+		t := (alpha - taus[j]) / (taus[j+1] - taus[j])
+		v[j] = 1 - t
+		v[j+1] = t
+	}
+
+	// Nodes on (0, alpha]: grid levels up to alpha, then alpha itself
+	type node struct {
+		u float64
+		w []float64
+	}
+	var nodes []node
+	for k := 0; k <= j; k++ {
+		w := make([]float64, K)
+		w[k] = 1
+		nodes = append(nodes, node{taus[k], w})
+	}
+	if taus[j] != alpha {
+		nodes = append(nodes, node{alpha, v})
+	}
+
+	integral := make([]float64, K)
+	add := func(w []float64, c float64) {
+		for k := range w {
+			integral[k] += c * w[k]
+		}
+	}
+
+	// Tail: Q(u) = Q(u0) + b log(u/u0) gives the integral u0 (Q(u0) - b)
+	u0 := nodes[0].u
+	add(nodes[0].w, u0)
+	if len(nodes) > 1 {
+		d := math.Log(nodes[1].u / u0)
+		add(nodes[1].w, -u0/d)
+		add(nodes[0].w, u0/d)
+	}
+
+	// Trapezoids between nodes
+	for k := 1; k < len(nodes); k++ {
+		h := nodes[k].u - nodes[k-1].u
+		add(nodes[k-1].w, h/2)
+		add(nodes[k].w, h/2)
+	}
+
+	for k := range integral {
+		integral[k] /= alpha
+	}
+	return integral, v, nil
+}
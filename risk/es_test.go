@@ -0,0 +1,82 @@
+package risk
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andreasmuller/quantreg"
+)
+
+func TestESWeights(t *testing.T) {
+	// A quantile function linear in log(u) is integrated exactly
+	taus := []float64{0.01, 0.025, 0.05, 0.1}
+	q := func(u float64) float64 { return 2 + 3*math.Log(u) }
+	alpha := 0.04
+
+	w, v, err := esWeights(taus, alpha)
+	if err != nil {
+		t.Fatalf("Failed to compute weights: %v", err)
+	}
+
+	varEst, esEst := 0.0, 0.0
+	for k, tau := range taus {
+		varEst += v[k] * q(tau)
+		esEst += w[k] * q(tau)
+	}
+	wantVaR := q(0.025) + (alpha-0.025)/(0.05-0.025)*(q(0.05)-q(0.025))
+	if math.Abs(varEst-wantVaR) > 1e-12 {
+		t.Errorf("Expected interpolated quantile %f, got %f", wantVaR, varEst)
+	}
+
+	// Exact tail integral plus trapezoids of the true function
+	want := 0.01 * (q(0.01) - 3)
+	want += (0.025 - 0.01) * (q(0.01) + q(0.025)) / 2
+	want += (alpha - 0.025) * (q(0.025) + wantVaR) / 2
+	want /= alpha
+	if math.Abs(esEst-want) > 1e-12 {
+		t.Errorf("Expected integrated quantile %f, got %f", want, esEst)
+	}
+
+	// Error cases
+	if _, _, err := esWeights(taus, 0.005); err == nil {
+		t.Error("Expected error when alpha lies below the grid")
+	}
+	if _, _, err := esWeights(taus, 0.2); err == nil {
+		t.Error("Expected error when alpha lies above the grid")
+	}
+}
+
+func TestExpectedShortfall(t *testing.T) {
+	n := 20
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xi := float64(i%5) / 2
+		x[i] = []float64{1, xi}
+		y[i] = 0.5*xi + (1+xi)*math.Sin(float64(3*i))
+	}
+
+	m, err := quantreg.RQProcess(y, x, []float64{0.1, 0.2, 0.3})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	newX := [][]float64{{1, 0.5}, {1, 1.5}}
+	fc, err := ExpectedShortfall(m, newX, 0.2)
+	if err != nil {
+		t.Fatalf("Failed to compute expected shortfall: %v", err)
+	}
+
+	pred, _ := m.Predict(newX)
+	for i, f := range fc {
+		if math.Abs(f.VaR+pred[0.2][i]) > 1e-12 {
+			t.Errorf("Expected VaR %f, got %f", -pred[0.2][i], f.VaR)
+		}
+		if f.VaRSE <= 0 || f.ESSE <= 0 || math.IsNaN(f.Cov) {
+			t.Errorf("Expected positive standard errors, got %+v", f)
+		}
+		if math.Abs(f.Cov) > f.VaRSE*f.ESSE+1e-12 {
+			t.Errorf("Covariance %f violates Cauchy-Schwarz", f.Cov)
+		}
+	}
+}
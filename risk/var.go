@@ -0,0 +1,166 @@
+// Package risk turns conditional quantile forecasts into Value-at-Risk and
+// Expected Shortfall estimates and backtests them
+package risk
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/andreasmuller/quantreg"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// QuantileForecaster predicts a conditional quantile of returns for each row of
+// newX. *quantreg.RQFit satisfies it; CAViaR-style models can implement it too.
+type QuantileForecaster interface {
+	Predict(newX [][]float64) ([]float64, error)
+}
+
+var _ QuantileForecaster = (*quantreg.RQFit)(nil)
+
+// VaR returns Value-at-Risk forecasts as positive losses, the negated conditional
+// return quantile. Fit the forecaster at tau = alpha (e.g. 0.01 or 0.05).
+func VaR(f QuantileForecaster, newX [][]float64) ([]float64, error) {
+	q, err := f.Predict(newX)
+	if err != nil {
+		return nil, fmt.Errorf("quantile forecast failed: %w", err)
+	}
+	v := make([]float64, len(q))
+	for i := range q {
+		v[i] = -q[i]
+	}
+	return v, nil
+}
+
+// Hits returns the violation sequence: true where the realized return fell below -VaR
+func Hits(returns, vaR []float64) ([]bool, error) {
+	if len(returns) != len(vaR) {
+		return nil, fmt.Errorf("dimensions mismatch: %d returns, %d VaR forecasts", len(returns), len(vaR))
+	}
+	hits := make([]bool, len(returns))
+	for i := range returns {
+		hits[i] = returns[i] < -vaR[i]
+	}
+	return hits, nil
+}
+
+// Backtest holds coverage tests of a VaR hit sequence
+type Backtest struct {
+	Alpha      float64 // Nominal violation probability
+	N          int     // Number of forecasts
+	Violations int     // Number of hits
+	HitRate    float64 // Observed violation frequency
+
+	LRUC     float64 // Kupiec unconditional coverage statistic, chi-square(1)
+	PValueUC float64
+
+	LRInd     float64 // Christoffersen independence statistic, chi-square(1)
+	PValueInd float64
+
+	LRCC     float64 // Christoffersen conditional coverage statistic, chi-square(2)
+	PValueCC float64
+}
+
+// Kupiec returns the unconditional coverage likelihood ratio of hits at level alpha and its p-value
+func Kupiec(hits []bool, alpha float64) (float64, float64, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return 0, 0, fmt.Errorf("alpha must be between 0 and 1")
+	}
+	if len(hits) == 0 {
+		return 0, 0, fmt.Errorf("empty hit sequence")
+	}
+	n := float64(len(hits))
+	x := float64(countHits(hits))
+	pi := x / n
+	lr := -2 * (bernoulliLogLik(n-x, x, alpha) - bernoulliLogLik(n-x, x, pi))
+	lr = math.Max(lr, 0)
+	return lr, chiSquareSF(lr, 1), nil
+}
+
+// Christoffersen runs the Kupiec, independence and conditional coverage tests on hits
+func Christoffersen(hits []bool, alpha float64) (*Backtest, error) {
+	if len(hits) < 2 {
+		return nil, fmt.Errorf("need at least 2 observations, got %d", len(hits))
+	}
+	lruc, puc, err := Kupiec(hits, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transition counts n_ij from state i to state j
+	var n00, n01, n10, n11 float64
+	for t := 1; t < len(hits); t++ {
+		switch {
+		case !hits[t-1] && !hits[t]:
+			n00++
+		case !hits[t-1] && hits[t]:
+			n01++
+		case hits[t-1] && !hits[t]:
+			n10++
+		default:
+			n11++
+		}
+	}
+	pi01 := ratio(n01, n00+n01)
+	pi11 := ratio(n11, n10+n11)
+	pi := ratio(n01+n11, n00+n01+n10+n11)
+	lrind := -2 * (bernoulliLogLik(n00+n10, n01+n11, pi) -
+		bernoulliLogLik(n00, n01, pi01) - bernoulliLogLik(n10, n11, pi11))
+	lrind = math.Max(lrind, 0)
+
+	v := countHits(hits)
+	return &Backtest{
+		Alpha:      alpha,
+		N:          len(hits),
+		Violations: v,
+		HitRate:    float64(v) / float64(len(hits)),
+		LRUC:       lruc,
+		PValueUC:   puc,
+		LRInd:      lrind,
+		PValueInd:  chiSquareSF(lrind, 1),
+		LRCC:       lruc + lrind,
+		PValueCC:   chiSquareSF(lruc+lrind, 2),
+	}, nil
+}
+
+// BacktestVaR computes hits of returns against VaR forecasts and runs all coverage tests
+func BacktestVaR(returns, vaR []float64, alpha float64) (*Backtest, error) {
+	hits, err := Hits(returns, vaR)
+	if err != nil {
+		return nil, err
+	}
+	return Christoffersen(hits, alpha)
+}
+
+func countHits(hits []bool) int {
+	c := 0
+	for _, h := range hits {
+		if h {
+			c++
+		}
+	}
+	return c
+}
+
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
+
+// bernoulliLogLik returns n0 log(1-p) + n1 log(p) with 0 log 0 = 0
+func bernoulliLogLik(n0, n1, p float64) float64 {
+	ll := 0.0
+	if n0 > 0 {
+		ll += n0 * math.Log(1-p)
+	}
+	if n1 > 0 {
+		ll += n1 * math.Log(p)
+	}
+	return ll
+}
+
+func chiSquareSF(x float64, df int) float64 {
+	return distuv.ChiSquared{K: float64(df)}.Survival(x)
+}
@@ -0,0 +1,124 @@
+package risk
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/andreasmuller/quantreg"
+)
+
+// failingForecaster returns err from every forecast
+type failingForecaster struct{ err error }
+
+func (f failingForecaster) Predict([][]float64) ([]float64, error) {
+	return nil, f.err
+}
+
+func TestVaR(t *testing.T) {
+	x := [][]float64{{1, 0.5}, {1, 1.0}, {1, 1.5}, {1, 2.0}, {1, 2.5}}
+	y := []float64{-1.0, -2.0, -2.5, -3.0, -4.0}
+
+	fit, err := quantreg.RQ(y, x, 0.05)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	v, err := VaR(fit, x)
+	if err != nil {
+		t.Fatalf("Failed to compute VaR: %v", err)
+	}
+	q, _ := fit.Predict(x)
+	for i := range v {
+		if v[i] != -q[i] {
+			t.Errorf("Expected VaR %f to be the negated quantile %f", v[i], q[i])
+		}
+	}
+
+	// Forecast errors stay matchable with errors.Is
+	if _, err := VaR(failingForecaster{quantreg.ErrSingularDesign}, x); !errors.Is(err, quantreg.ErrSingularDesign) {
+		t.Errorf("Expected a wrapped ErrSingularDesign, got %v", err)
+	}
+
+	hits, err := Hits([]float64{-2, 0, -0.5}, []float64{1, 1, 1})
+	if err != nil {
+		t.Fatalf("Failed to compute hits: %v", err)
+	}
+	if !hits[0] || hits[1] || hits[2] {
+		t.Errorf("Unexpected hit sequence: %v", hits)
+	}
+	if _, err := Hits([]float64{1}, nil); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+}
+
+func TestKupiec(t *testing.T) {
+	// 5 hits in 100 at alpha = 0.05 is exact coverage
+	hits := make([]bool, 100)
+	for i := 0; i < 5; i++ {
+		hits[i*20] = true
+	}
+	lr, p, err := Kupiec(hits, 0.05)
+	if err != nil {
+		t.Fatalf("Failed to run Kupiec test: %v", err)
+	}
+	if math.Abs(lr) > 1e-9 || math.Abs(p-1) > 1e-9 {
+		t.Errorf("Expected LR 0 and p-value 1, got %f and %f", lr, p)
+	}
+
+	// 15 hits in 100 rejects
+	for i := 0; i < 10; i++ {
+		hits[i*9+1] = true
+	}
+	lr, p, _ = Kupiec(hits, 0.05)
+	want := -2 * (85*math.Log(0.95) + 15*math.Log(0.05) - 85*math.Log(0.85) - 15*math.Log(0.15))
+	if math.Abs(lr-want) > 1e-9 {
+		t.Errorf("Expected LR %f, got %f", want, lr)
+	}
+	if p > 0.01 {
+		t.Errorf("Expected rejection, got p-value %f", p)
+	}
+
+	if _, _, err := Kupiec(hits, 0); err == nil {
+		t.Error("Expected error for invalid alpha")
+	}
+}
+
+func TestChristoffersen(t *testing.T) {
+	// Same number of hits, spread out versus clustered
+	spread := make([]bool, 100)
+	clustered := make([]bool, 100)
+	for i := 0; i < 5; i++ {
+		spread[i*20] = true
+		clustered[40+i] = true
+	}
+
+	a, err := Christoffersen(spread, 0.05)
+	if err != nil {
+		t.Fatalf("Failed to run Christoffersen test: %v", err)
+	}
+	b, err := Christoffersen(clustered, 0.05)
+	if err != nil {
+		t.Fatalf("Failed to run Christoffersen test: %v", err)
+	}
+
+	if a.Violations != 5 || a.HitRate != 0.05 || a.N != 100 {
+		t.Errorf("Unexpected counts: %+v", a)
+	}
+	if a.LRUC != b.LRUC {
+		t.Errorf("Expected equal unconditional coverage statistics, got %f and %f", a.LRUC, b.LRUC)
+	}
+	if b.LRInd <= a.LRInd || b.PValueInd > 0.01 {
+		t.Errorf("Expected clustered hits to reject independence: spread %f, clustered %f (p=%f)", a.LRInd, b.LRInd, b.PValueInd)
+	}
+	if math.Abs(b.LRCC-(b.LRUC+b.LRInd)) > 1e-12 {
+		t.Errorf("Expected LRCC = LRUC + LRInd")
+	}
+
+	bt, err := BacktestVaR([]float64{-2, 0, -0.5, -3}, []float64{1, 1, 1, 1}, 0.05)
+	if err != nil {
+		t.Fatalf("Failed to backtest VaR: %v", err)
+	}
+	if bt.Violations != 2 {
+		t.Errorf("Expected 2 violations, got %d", bt.Violations)
+	}
+}
@@ -2,9 +2,13 @@
 package quantreg
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"math"
+	"math/rand"
 	"sort"
+	"time"
 
 	"github.com/andreasmuller/sparsem"
 )
@@ -19,23 +23,106 @@ type RQFit struct {
 	P            int          // Number of parameters
 	Method       string       // Method used for fitting
 	Formula      string       // Model formula
+	X            [][]float64  // Design matrix used for fitting
+	Y            []float64    // Response used for fitting
+	Weights      []float64    // Observation weights, nil for an unweighted fit
+	Iterations   int          // Number of solver iterations
+	Converged    bool         // Whether the solver met its convergence tolerance
+	BasicObs     []int        // Observations in the solution basis, in index order
+	Timings      map[string]time.Duration // Wall time spent in each solver phase
+	Draws        [][]float64  // Bootstrap coefficient draws, one row per replication, set by Bootstrap
+	Meta         Meta         // Reproducibility metadata
+}
+
+// Solvers for linear quantile regression
+const (
+	MethodBR   = "br"   // Barrodale-Roberts simplex, exact and fast up to a few thousand observations
+	MethodFN   = "fn"   // Frisch-Newton interior point, for tens of thousands of observations and more
+	MethodAuto = "auto" // MethodBR up to autoFNRows observations, MethodFN beyond
+)
+
+// autoFNRows is the number of observations beyond which MethodAuto uses MethodFN
+const autoFNRows = 5000
+
+// brAttempts is the number of perturbed responses MethodBR pivots on before it
+// reports a fit its dual does not certify as unconverged
+const brAttempts = 3
+
+// RQOptions controls RQWithOptions
+type RQOptions struct {
+	Method string    // Solver (default MethodBR)
+	Start  []float64 // Optional coefficients, e.g. from RQQuick, near which MethodBR picks its starting basis; MethodFN ignores them
+	Lean   bool      // Store no Fitted or Residuals; FittedValues and ResidualValues recompute them
 }
 
 // RQ fits a linear quantile regression model
 func RQ(y []float64, x [][]float64, tau float64) (*RQFit, error) {
+	return rqFrom(y, x, tau, RQOptions{Method: MethodBR})
+}
+
+// RQWithOptions fits a linear quantile regression model with the solver chosen by
+// opts.Method; the fit records the solver actually used in Method
+func RQWithOptions(y []float64, x [][]float64, tau float64, opts RQOptions) (*RQFit, error) {
+	if opts.Method == "" {
+		opts.Method = MethodBR
+	}
+	return rqFrom(y, x, tau, opts)
+}
+
+// rqFrom fits like RQ with the method, starting values and storage of opts
+func rqFrom(y []float64, x [][]float64, tau float64, opts RQOptions) (*RQFit, error) {
+	start := time.Now()
+	fit, options, err := rqSolve(y, x, tau, opts.Method, opts.Start)
+	if err != nil {
+		return nil, err
+	}
+	n, p := fit.N, fit.P
+
+	fit.setFitted(y, x, opts.Lean)
+	if fit.Method == MethodFN {
+		fit.BasicObs = basicObservations(fit.ResidualValues(), p)
+	}
+	fit.Meta = newMeta(fit.Method, options, []float64{tau}, n, p, start, y, x)
+	logFit(fit.Method, tau, n, p, fit.Iterations, fit.Converged, fit.Meta)
+
+	currentMetrics().ObserveFit(fit.Method, time.Since(start), fit.Iterations, fit.Converged)
+
+	return fit, nil
+}
+
+// rqSolve validates the data and runs the solver, returning the fit with its
+// coefficients, iterations, phase timings and, for MethodBR, its basis, along
+// with the solver options for Meta. Fitted values, residuals and Meta are left
+// to the caller, and nothing is logged or recorded in the metrics.
+func rqSolve(y []float64, x [][]float64, tau float64, method string, beta0 []float64) (*RQFit, map[string]float64, error) {
 	if len(y) == 0 || len(x) == 0 {
-		return nil, fmt.Errorf("empty input data")
+		return nil, nil, fmt.Errorf("empty input data")
 	}
 	
 	n := len(y)
 	p := len(x[0])
 	
 	if n != len(x) {
-		return nil, fmt.Errorf("x and y dimensions do not match")
+		return nil, nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
 	}
 	
 	if tau <= 0 || tau >= 1 {
-		return nil, fmt.Errorf("tau must be between 0 and 1")
+		return nil, nil, ErrInvalidTau
+	}
+
+	if beta0 != nil && len(beta0) != p {
+		return nil, nil, fmt.Errorf("%w: %d starting values for %d parameters", ErrDimensionMismatch, len(beta0), p)
+	}
+
+	switch method {
+	case MethodAuto:
+		method = MethodBR
+		if n > autoFNRows {
+			method = MethodFN
+		}
+	case MethodBR, MethodFN:
+	default:
+		return nil, nil, fmt.Errorf("unknown method %q", method)
 	}
 
 	// Initialize the fit
@@ -43,87 +130,449 @@ func RQ(y []float64, x [][]float64, tau float64) (*RQFit, error) {
 		Tau:    tau,
 		N:      n,
 		P:      p,
-		Method: "br",
+		Method: method,
+		X:      x,
+		Y:      y,
 	}
 
 	// Convert x to sparse matrix format
-	xMat := sparsem.NewCSRMatrix(x)
+	var xMat *sparsem.CSRMatrix
+	fit.recordPhase(PhasePrep, withPhase(PhasePrep, func() {
+		checkDesignHealth(fit.Method, tau, x)
+		if method == MethodBR {
+			xMat = sparsem.NewCSRMatrix(x)
+		}
+	}))
 
-	// Solve the optimization problem using the Barrodale and Roberts algorithm
-	coef, err := fit.solveBarrodaleRoberts(y, xMat)
+	var coef []float64
+	var err error
+	var options map[string]float64
+	fit.recordPhase(PhaseSolve, withPhase(PhaseSolve, func() {
+		if method == MethodFN {
+			coef, err = fit.solveFrischNewton(y, x)
+			options = map[string]float64{"max_iter": fnMaxIter, "tolerance": fnTolerance, "step": fnStep}
+			return
+		}
+		coef, err = fit.solveBarrodaleRoberts(y, xMat, beta0)
+		options = map[string]float64{"max_iter": 1000, "tolerance": 1e-10}
+	}))
 	if err != nil {
-		return nil, fmt.Errorf("optimization failed: %v", err)
+		return nil, nil, fmt.Errorf("optimization failed: %w", err)
 	}
 
 	fit.Coefficients = coef
-	
-	// Calculate fitted values and residuals
-	fit.Fitted = make([]float64, n)
-	fit.Residuals = make([]float64, n)
-	
-	for i := 0; i < n; i++ {
-		fitted := 0.0
-		for j := 0; j < p; j++ {
-			fitted += x[i][j] * coef[j]
-		}
-		fit.Fitted[i] = fitted
-		fit.Residuals[i] = y[i] - fitted
-	}
+	return fit, options, nil
+}
 
-	return fit, nil
+// solveBarrodaleRoberts solves the quantile regression linear program exactly by
+// the Barrodale and Roberts (1974) exterior-point simplex method as adapted to
+// quantile regression by Koenker and d'Orey (1987). A vertex is a basis h of p
+// observations that the fit interpolates. Each iteration moves along the edge
+// that frees one basic observation to the side giving the steepest descent of the
+// check loss, and the line search along that edge passes through every vertex at
+// which the loss still decreases, so one iteration can replace many ordinary
+// simplex pivots; the step is a weighted median of the residual-to-direction
+// ratios. The method stops at the vertex where no edge descends, and the final
+// basis is recorded in fit.BasicObs. beta0, when given, chooses the starting basis
+// among the observations it fits best.
+// The pivoting runs on a response perturbed far below the scale of the data, and
+// the fit counts as converged only when the dual of the final basis certifies it
+// optimal for y itself.
+func (fit *RQFit) solveBarrodaleRoberts(y []float64, x *sparsem.CSRMatrix, beta0 []float64) ([]float64, error) {
+	coef, _, err := fit.simplex(y, x.ToDense(), nil, nil, beta0)
+	return coef, err
 }
 
-// solveBarrodaleRoberts implements the Barrodale and Roberts algorithm for quantile regression
-func (fit *RQFit) solveBarrodaleRoberts(y []float64, x *sparsem.CSRMatrix) ([]float64, error) {
+// simplex runs solveBarrodaleRoberts on the loss above[i] r_i for r_i > 0 and
+// below[i] |r_i| for r_i < 0, or the check loss when the weights are nil, and
+// also returns the dual of the final basis: for each observation its share of the
+// subgradient, in [-below[i], above[i]].
+func (fit *RQFit) simplex(y []float64, full [][]float64, above, below []float64, beta0 []float64) ([]float64, []float64, error) {
 	n := len(y)
-	p := x.Cols
-	
-	// Initialize arrays
-	solution := make([]float64, p)
-	
-	// Maximum iterations
+	tau := fit.Tau
+	if above == nil {
+		above, below = make([]float64, n), make([]float64, n)
+		for i := range above {
+			above[i], below[i] = tau, 1-tau
+		}
+	}
+	cols, dense, err := independentDesign(full)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := len(cols)
+	flat, _ := flatten(dense)
+
+	// Tolerance for zero residuals, relative to y
+	scale := 1.0
+	for _, v := range y {
+		scale = math.Max(scale, math.Abs(v))
+	}
+	tol := 1e-10 * scale
 	maxIter := 1000
-	tolerance := 1e-8
-	learningRate := 0.01
-	
-	for iter := 0; iter < maxIter; iter++ {
-		// Calculate residuals
-		residuals := make([]float64, n)
-		for i := 0; i < n; i++ {
-			pred := 0.0
-			for j := 0; j < p; j++ {
-				pred += x.ToDense()[i][j] * solution[j]
+
+	ptol := 1e-13 * scale // for residuals of the perturbed response
+	log := currentLogger()
+	debug := log.Enabled(context.Background(), slog.LevelDebug)
+
+	var basis []int
+	if beta0 != nil {
+		start := make([]float64, n)
+		fullFlat, _ := flatten(full)
+		residualsInto(start, y, fullFlat, len(full[0]), beta0)
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool { return math.Abs(start[order[a]]) < math.Abs(start[order[b]]) })
+		basis, err = initialBasis(dense, order, p, 1e-3)
+	}
+	if basis == nil || err != nil {
+		if basis, err = pivotedBasis(dense, p); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	isBasic := make([]bool, n)
+	residuals := make([]float64, n)
+	u := make([]float64, p)
+	var solution []float64
+	type breakpoint struct {
+		t, w float64
+		i    int
+	}
+	var breaks []breakpoint
+
+	// vertex sets solution to the fit interpolating y at the basis, b = X_h^-1 y_h,
+	// and returns X_h^-1
+	vertex := func(y []float64) ([][]float64, error) {
+		xh := make([][]float64, p)
+		yh := make([]float64, p)
+		for k, i := range basis {
+			xh[k] = dense[i]
+			yh[k] = y[i]
+		}
+		inv, err := invert(xh)
+		if err != nil {
+			return nil, fmt.Errorf("simplex basis became singular: %w", err)
+		}
+		solution = matVec(inv, yh)
+		return inv, nil
+	}
+
+	// pivot runs the simplex on the response yp from the current basis. It
+	// reports whether it stopped at a vertex where no edge descends, and leaves
+	// the residuals of yp at the final vertex.
+	pivot := func(yp []float64) (bool, error) {
+		for fit.Iterations < maxIter {
+			fit.Iterations++
+
+			inv, err := vertex(yp)
+			if err != nil {
+				return false, err
+			}
+			residualsInto(residuals, yp, flat, p, solution)
+			for i := range isBasic {
+				isBasic[i] = false
+			}
+			for _, i := range basis {
+				isBasic[i] = true
+				residuals[i] = 0
+			}
+
+			// Directional derivatives along the edges d = sigma X_h^-1 e_k, for
+			// which the residual of basic observation k moves to -sigma t and the
+			// other basic observations stay interpolated
+			bestK, bestSigma, bestSlope := -1, 0.0, 0.0
+			for k := range basis {
+				for j := range u {
+					u[j] = inv[j][k]
+				}
+				// sigma = +1 pushes observation k below the fit and sigma = -1 above it
+				k0 := basis[k]
+				up, down, size := below[k0], above[k0], above[k0]+below[k0]
+				for i := 0; i < n; i++ {
+					if isBasic[i] {
+						continue
+					}
+					zi := dot(flat[i*p:i*p+p], u)
+					size += (above[i] + below[i]) * math.Abs(zi)
+					switch r := residuals[i]; {
+					case r > ptol:
+						up -= above[i] * zi
+						down += above[i] * zi
+					case r < -ptol:
+						up += below[i] * zi
+						down -= below[i] * zi
+					default:
+						// A residual at zero leaves it on the side the direction pushes it
+						up += math.Max(-above[i]*zi, below[i]*zi)
+						down += math.Max(above[i]*zi, -below[i]*zi)
+					}
+				}
+				if up < bestSlope-1e-12*size {
+					bestK, bestSigma, bestSlope = k, 1, up
+				}
+				if down < bestSlope-1e-12*size {
+					bestK, bestSigma, bestSlope = k, -1, down
+				}
+			}
+			if debug && fit.Iterations%100 == 1 {
+				log.Debug("solver iteration", "method", fit.Method, "tau", tau, "iteration", fit.Iterations-1, "slope", bestSlope)
 			}
-			residuals[i] = y[i] - pred
-		}
-		
-		// Calculate gradients
-		gradients := make([]float64, p)
-		maxGrad := 0.0
-		for i := 0; i < p; i++ {
-			grad := 0.0
-			for j := 0; j < n; j++ {
-				if residuals[j] > 0 {
-					grad += x.ToDense()[j][i] * (fit.Tau - 1)
-				} else {
-					grad += x.ToDense()[j][i] * fit.Tau
+			if bestK < 0 {
+				return true, nil
+			}
+
+			// Line search along the chosen edge: the loss is convex and piecewise
+			// linear in t with a kink where each nonbasic residual r_i - t z_i
+			// crosses zero, and its slope rises by (above_i + below_i)|z_i| there.
+			// Step to the kink at which the slope turns non-negative, the weighted
+			// median of the ratios r_i / z_i.
+			for j := range u {
+				u[j] = bestSigma * inv[j][bestK]
+			}
+			breaks = breaks[:0]
+			unorm := math.Sqrt(dot(u, u))
+			for i := 0; i < n; i++ {
+				if isBasic[i] {
+					continue
 				}
+				row := flat[i*p : i*p+p]
+				zi := dot(row, u)
+				r := residuals[i]
+				// Residuals at zero were counted on the side the edge pushes them
+				// to, and rows nearly parallel to the edge would make a singular basis
+				if math.Abs(zi) <= 1e-11*unorm*math.Sqrt(dot(row, row)) || math.Abs(r) <= ptol || (r > 0) != (zi > 0) {
+					continue
+				}
+				breaks = append(breaks, breakpoint{t: r / zi, w: (above[i] + below[i]) * math.Abs(zi), i: i})
+			}
+			if len(breaks) == 0 {
+				return false, fmt.Errorf("%w: check loss is unbounded along a simplex edge", ErrSingularDesign)
 			}
-			gradients[i] = grad
-			maxGrad = math.Max(maxGrad, math.Abs(grad))
+			sort.Slice(breaks, func(a, b int) bool { return breaks[a].t < breaks[b].t })
+			slope := bestSlope
+			enter := breaks[len(breaks)-1].i
+			for _, b := range breaks {
+				slope += b.w
+				if slope >= 0 {
+					enter = b.i
+					break
+				}
+			}
+			basis[bestK] = enter
 		}
-		
-		if maxGrad < tolerance {
-			break
+		// Report the vertex of the final basis rather than the one it replaced
+		if _, err := vertex(yp); err != nil {
+			return false, err
 		}
-		
-		// Update solution using gradient descent with momentum
-		for i := 0; i < p; i++ {
-			solution[i] -= learningRate * gradients[i]
+		residualsInto(residuals, yp, flat, p, solution)
+		return false, nil
+	}
+
+	// Tied or discrete responses make degenerate vertices, at which no edge of
+	// the basis may descend although the vertex is not optimal. Pivoting on a
+	// randomly perturbed response avoids them, and the dual of the final basis
+	// decides whether it is optimal for y; a vertex it rejects is the start of
+	// another attempt with a fresh perturbation.
+	yp := make([]float64, n)
+	var dual []float64
+	rng := rand.New(rand.NewSource(1))
+	for attempt := 0; attempt < brAttempts && !fit.Converged && fit.Iterations < maxIter; attempt++ {
+		for i, v := range y {
+			yp[i] = v + 1e-7*scale*(rng.Float64()-0.5)
 		}
+		stopped, err := pivot(yp)
+		if err != nil {
+			return nil, nil, err
+		}
+		inv, err := vertex(y)
+		if err != nil {
+			return nil, nil, err
+		}
+		var certified bool
+		dual, certified = basisDual(dense, y, basis, inv, solution, residuals, above, below, tol)
+		fit.Converged = stopped && certified
 	}
-	
-	return solution, nil
+
+	sorted := append([]int(nil), basis...)
+	sort.Ints(sorted)
+	fit.BasicObs = sorted
+	return expandCoefficients(solution, cols, len(full[0])), dual, nil
+}
+
+// basisDual returns the dual of the fit b interpolating y at basis, with inv the
+// inverse of the basis rows, and reports whether it certifies b as optimal. Each
+// nonbasic observation contributes above[i] or -below[i] to the subgradient by
+// the sign of its residual, and one fitted exactly takes the sign of side, its
+// residual at the perturbed vertex. The duals of the basic observations balance
+// the subgradient, and the vertex is optimal when each lies in [-below, above].
+func basisDual(x [][]float64, y []float64, basis []int, inv [][]float64, b, side, above, below []float64, tol float64) ([]float64, bool) {
+	p := len(basis)
+	dual := make([]float64, len(y))
+	isBasic := make(map[int]bool, p)
+	for _, i := range basis {
+		isBasic[i] = true
+	}
+	g := make([]float64, p)
+	for i, row := range x {
+		if isBasic[i] {
+			continue
+		}
+		r := y[i] - dot(row, b)
+		if math.Abs(r) <= tol {
+			r = side[i]
+		}
+		dual[i] = above[i]
+		if r < 0 {
+			dual[i] = -below[i]
+		}
+		for j, v := range row {
+			g[j] += dual[i] * v
+		}
+	}
+	// X_h' a_h = -g, so a_h = -(X_h^-1)' g
+	optimal := true
+	for k, i := range basis {
+		size := above[i] + below[i]
+		for j := range g {
+			dual[i] -= inv[j][k] * g[j]
+			size += math.Abs(inv[j][k] * g[j])
+		}
+		if dual[i] < -below[i]-1e-9*size || dual[i] > above[i]+1e-9*size {
+			optimal = false
+		}
+	}
+	return dual, optimal
+}
+
+// independentDesign returns the columns kept by independentColumns and the design
+// restricted to them. Aliased columns of a rank-deficient design get zero
+// coefficients, and the problem is solved on the independent columns.
+func independentDesign(x [][]float64) ([]int, [][]float64, error) {
+	cols, err := independentColumns(x)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(cols) == len(x[0]) {
+		return cols, x, nil
+	}
+	dense := make([][]float64, len(x))
+	for i, row := range x {
+		dense[i] = make([]float64, len(cols))
+		for k, j := range cols {
+			dense[i][k] = row[j]
+		}
+	}
+	return cols, dense, nil
+}
+
+// expandCoefficients places the coefficients of the kept columns in a vector of
+// length p with zeros for the aliased columns
+func expandCoefficients(beta []float64, cols []int, p int) []float64 {
+	coef := make([]float64, p)
+	for k, j := range cols {
+		coef[j] = beta[k]
+	}
+	return coef
+}
+
+// independentColumns returns the indices of a maximal set of linearly independent
+// columns of x, keeping earlier columns in preference to later ones
+func independentColumns(x [][]float64) ([]int, error) {
+	order := make([]int, len(x[0]))
+	for j := range order {
+		order[j] = j
+	}
+	cols, err := initialBasis(transpose(x), order, len(order), 1e-10)
+	if err == nil {
+		return cols, nil
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("%w: design has no non-zero column", ErrSingularDesign)
+	}
+	sort.Ints(cols)
+	return cols, nil
+}
+
+// initialBasis picks p observations with linearly independent rows, trying them in
+// the given order and accepting a row when the part of it orthogonal to the rows
+// already picked keeps at least the fraction ratio of its norm. When fewer than p
+// rows qualify it returns those it found along with the error.
+func initialBasis(x [][]float64, order []int, p int, ratio float64) ([]int, error) {
+	basis := make([]int, 0, p)
+	var q [][]float64
+	for _, i := range order {
+		v := append([]float64(nil), x[i]...)
+		norm0 := math.Sqrt(dot(v, v))
+		if norm0 == 0 {
+			continue
+		}
+		for _, e := range q {
+			c := dot(v, e)
+			for j := range v {
+				v[j] -= c * e[j]
+			}
+		}
+		norm := math.Sqrt(dot(v, v))
+		if norm <= ratio*norm0 {
+			continue
+		}
+		for j := range v {
+			v[j] /= norm
+		}
+		q = append(q, v)
+		basis = append(basis, i)
+		if len(basis) == p {
+			return basis, nil
+		}
+	}
+	return basis, fmt.Errorf("%w: design has rank %d, need %d", ErrSingularDesign, len(basis), p)
+}
+
+// pivotedBasis picks p observations whose rows are well conditioned together by
+// Gram-Schmidt with pivoting, each time taking the row with the largest part
+// orthogonal to the rows already picked
+func pivotedBasis(x [][]float64, p int) ([]int, error) {
+	n := len(x)
+	v := make([][]float64, n)
+	norms := make([]float64, n)
+	for i, row := range x {
+		v[i] = append([]float64(nil), row...)
+		norms[i] = dot(row, row)
+	}
+	basis := make([]int, 0, p)
+	picked := make([]bool, n)
+	for len(basis) < p {
+		best := -1
+		for i := range v {
+			if !picked[i] && (best < 0 || norms[i] > norms[best]) {
+				best = i
+			}
+		}
+		if best < 0 || norms[best] <= 1e-20*dot(x[best], x[best]) || norms[best] == 0 {
+			return nil, fmt.Errorf("%w: design has rank %d, need %d", ErrSingularDesign, len(basis), p)
+		}
+		picked[best] = true
+		basis = append(basis, best)
+		e := v[best]
+		scale := 1 / math.Sqrt(norms[best])
+		for j := range e {
+			e[j] *= scale
+		}
+		for i := range v {
+			if picked[i] {
+				continue
+			}
+			c := dot(v[i], e)
+			for j := range e {
+				v[i][j] -= c * e[j]
+			}
+			norms[i] = dot(v[i], v[i])
+		}
+	}
+	return basis, nil
 }
 
 // Predict generates predictions from a fitted quantile regression model
@@ -136,6 +585,7 @@ func (fit *RQFit) Predict(newX [][]float64) ([]float64, error) {
 		return nil, fmt.Errorf("number of variables in new data does not match model")
 	}
 	
+	start := time.Now()
 	n := len(newX)
 	predictions := make([]float64, n)
 	
@@ -147,34 +597,12 @@ func (fit *RQFit) Predict(newX [][]float64) ([]float64, error) {
 		predictions[i] = pred
 	}
 	
+	currentMetrics().ObservePredict(n, time.Since(start))
+
 	return predictions, nil
 }
 
 // Summary prints a summary of the fitted model
 func (fit *RQFit) Summary() string {
-	result := fmt.Sprintf("Quantile Regression (tau = %.2f)\n", fit.Tau)
-	result += fmt.Sprintf("Number of observations: %d\n", fit.N)
-	result += fmt.Sprintf("Number of parameters: %d\n\n", fit.P)
-	
-	result += "Coefficients:\n"
-	for i, coef := range fit.Coefficients {
-		result += fmt.Sprintf("  Beta[%d]: %.6f\n", i, coef)
-	}
-	
-	// Calculate pseudo R-squared
-	sortedResiduals := make([]float64, len(fit.Residuals))
-	copy(sortedResiduals, fit.Residuals)
-	sort.Float64s(sortedResiduals)
-	
-	var sumAbs float64
-	for _, r := range sortedResiduals {
-		sumAbs += math.Abs(r)
-	}
-	
-	result += fmt.Sprintf("\nResidual summary:\n")
-	result += fmt.Sprintf("  Min: %.6f\n", sortedResiduals[0])
-	result += fmt.Sprintf("  Max: %.6f\n", sortedResiduals[len(sortedResiduals)-1])
-	result += fmt.Sprintf("  Median: %.6f\n", sortedResiduals[len(sortedResiduals)/2])
-	
-	return result
+	return fit.SummaryTable().String()
 }
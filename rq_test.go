@@ -2,6 +2,7 @@ package quantreg
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -78,3 +79,75 @@ func TestRQFitSummary(t *testing.T) {
 		t.Error("Expected non-empty summary string")
 	}
 }
+
+func TestRQSimplex(t *testing.T) {
+	y, x := inferenceData()
+
+	for _, tau := range []float64{0.1, 0.5, 0.8} {
+		fit, err := RQ(y, x, tau)
+		if err != nil {
+			t.Fatalf("Failed to fit model: %v", err)
+		}
+		exact, err := RQConstrained(y, x, tau, Constraints{})
+		if err != nil {
+			t.Fatalf("Failed to fit exact model: %v", err)
+		}
+		if !fit.Converged {
+			t.Errorf("Expected the simplex to converge at tau=%v", tau)
+		}
+		if loss, best := sumRho(fit.Residuals, tau), sumRho(exact.Residuals, tau); math.Abs(loss-best) > 1e-9*best {
+			t.Errorf("Expected optimal check loss %v at tau=%v, got %v", best, tau, loss)
+		}
+		// The optimal basis interpolates its observations
+		if len(fit.BasicObs) != 2 {
+			t.Fatalf("Expected 2 basic observations, got %v", fit.BasicObs)
+		}
+		for _, i := range fit.BasicObs {
+			if math.Abs(fit.Residuals[i]) > 1e-10 {
+				t.Errorf("Expected zero residual at basic observation %d, got %v", i, fit.Residuals[i])
+			}
+		}
+	}
+
+	// An aliased column gets a zero coefficient
+	xs := make([][]float64, len(x))
+	for i, row := range x {
+		xs[i] = []float64{row[0], row[1], 2 * row[1]}
+	}
+	fit, err := RQ(y, xs, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit rank-deficient model: %v", err)
+	}
+	if fit.Coefficients[2] != 0 {
+		t.Errorf("Expected zero coefficient for the aliased column, got %v", fit.Coefficients[2])
+	}
+}
+
+func TestRQSimplexTies(t *testing.T) {
+	// Counts on discrete covariates fit many observations exactly at each vertex
+	rng := rand.New(rand.NewSource(6))
+	n := 200
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	for i := range y {
+		x[i] = []float64{1, float64(rng.Intn(4)), float64(rng.Intn(3))}
+		y[i] = float64(rng.Intn(6)) + x[i][1]
+	}
+
+	for _, tau := range []float64{0.1, 0.25, 0.5, 0.75} {
+		fit, err := RQ(y, x, tau)
+		if err != nil {
+			t.Fatalf("Failed to fit model: %v", err)
+		}
+		ref, err := RQWithOptions(y, x, tau, RQOptions{Method: MethodFN})
+		if err != nil {
+			t.Fatalf("Failed to fit reference model: %v", err)
+		}
+		if !fit.Converged {
+			t.Errorf("Expected the simplex to converge at tau=%v", tau)
+		}
+		if loss, best := sumRho(fit.Residuals, tau), sumRho(ref.Residuals, tau); loss > best+1e-6*best {
+			t.Errorf("Expected check loss at most %v at tau=%v, got %v", best, tau, loss)
+		}
+	}
+}
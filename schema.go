@@ -0,0 +1,218 @@
+package quantreg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Frame holds named raw covariates: numeric columns and factor columns of level labels
+type Frame struct {
+	Numeric map[string][]float64
+	Factors map[string][]string
+}
+
+// Factor is a factor column and its levels; the first level is the reference
+type Factor struct {
+	Name   string
+	Levels []string
+}
+
+// Schema records the columns and factor levels seen when fitting, so new data are
+// encoded into the same design columns: an intercept, the numeric columns in
+// name order, then one treatment dummy per non-reference level of each factor
+type Schema struct {
+	Numeric []string
+	Factors []Factor
+}
+
+// UnseenLevelsError reports factor levels in new data that were not seen when fitting
+type UnseenLevelsError struct {
+	Column string
+	Levels []string
+}
+
+func (e *UnseenLevelsError) Error() string {
+	return fmt.Sprintf("factor %q has unseen levels: %s", e.Column, strings.Join(e.Levels, ", "))
+}
+
+// MissingColumnError reports a column of the schema that is absent from new data
+type MissingColumnError struct {
+	Column string
+}
+
+func (e *MissingColumnError) Error() string {
+	return fmt.Sprintf("column %q is missing", e.Column)
+}
+
+// NewSchema learns the schema of a frame; factor levels are sorted
+func NewSchema(frame Frame) (*Schema, error) {
+	s := &Schema{}
+	for name := range frame.Numeric {
+		s.Numeric = append(s.Numeric, name)
+	}
+	sort.Strings(s.Numeric)
+	var names []string
+	for name := range frame.Factors {
+		if _, ok := frame.Numeric[name]; ok {
+			return nil, fmt.Errorf("column %q is both numeric and a factor", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		seen := make(map[string]bool)
+		var levels []string
+		for _, v := range frame.Factors[name] {
+			if !seen[v] {
+				seen[v] = true
+				levels = append(levels, v)
+			}
+		}
+		sort.Strings(levels)
+		s.Factors = append(s.Factors, Factor{Name: name, Levels: levels})
+	}
+	if _, err := frame.rows(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// rows returns the common length of the frame's columns
+func (f Frame) rows() (int, error) {
+	n := -1
+	check := func(name string, m int) error {
+		if n >= 0 && m != n {
+			return fmt.Errorf("column %q has %d rows, expected %d", name, m, n)
+		}
+		n = m
+		return nil
+	}
+	for name, col := range f.Numeric {
+		if err := check(name, len(col)); err != nil {
+			return 0, err
+		}
+	}
+	for name, col := range f.Factors {
+		if err := check(name, len(col)); err != nil {
+			return 0, err
+		}
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("empty input data")
+	}
+	return n, nil
+}
+
+// Columns returns the names of the design columns
+func (s *Schema) Columns() []string {
+	cols := []string{"(Intercept)"}
+	cols = append(cols, s.Numeric...)
+	for _, f := range s.Factors {
+		for _, lv := range f.Levels[1:] {
+			cols = append(cols, f.Name+lv)
+		}
+	}
+	return cols
+}
+
+// Formula returns the model formula for a response name
+func (s *Schema) Formula(response string) string {
+	terms := append([]string(nil), s.Numeric...)
+	for _, f := range s.Factors {
+		terms = append(terms, f.Name)
+	}
+	if len(terms) == 0 {
+		return response + " ~ 1"
+	}
+	return response + " ~ " + strings.Join(terms, " + ")
+}
+
+// Design encodes a frame into design rows, returning a *MissingColumnError for
+// absent columns and an *UnseenLevelsError for factor levels outside the schema.
+// Extra columns are ignored.
+func (s *Schema) Design(frame Frame) ([][]float64, error) {
+	n, err := frame.rows()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range s.Numeric {
+		if _, ok := frame.Numeric[name]; !ok {
+			return nil, &MissingColumnError{Column: name}
+		}
+	}
+	codes := make([]map[string]int, len(s.Factors))
+	for k, f := range s.Factors {
+		col, ok := frame.Factors[f.Name]
+		if !ok {
+			return nil, &MissingColumnError{Column: f.Name}
+		}
+		codes[k] = make(map[string]int, len(f.Levels))
+		for c, lv := range f.Levels {
+			codes[k][lv] = c
+		}
+		var unseen []string
+		for _, v := range col {
+			if _, ok := codes[k][v]; !ok {
+				unseen = append(unseen, v)
+				codes[k][v] = -1
+			}
+		}
+		if len(unseen) > 0 {
+			sort.Strings(unseen)
+			return nil, &UnseenLevelsError{Column: f.Name, Levels: unseen}
+		}
+	}
+
+	p := len(s.Columns())
+	x := make([][]float64, n)
+	for i := range x {
+		x[i] = make([]float64, p)
+		x[i][0] = 1
+		j := 1
+		for _, name := range s.Numeric {
+			x[i][j] = frame.Numeric[name][i]
+			j++
+		}
+		for k, f := range s.Factors {
+			if c := codes[k][frame.Factors[f.Name][i]]; c > 0 {
+				x[i][j+c-1] = 1
+			}
+			j += len(f.Levels) - 1
+		}
+	}
+	return x, nil
+}
+
+// SchemaFit is a quantile regression fitted on a frame
+type SchemaFit struct {
+	*RQFit
+	Schema *Schema
+}
+
+// RQFrame fits a linear quantile regression on the encoded frame
+func RQFrame(y []float64, frame Frame, tau float64) (*SchemaFit, error) {
+	schema, err := NewSchema(frame)
+	if err != nil {
+		return nil, err
+	}
+	x, err := schema.Design(frame)
+	if err != nil {
+		return nil, err
+	}
+	fit, err := RQ(y, x, tau)
+	if err != nil {
+		return nil, err
+	}
+	fit.Formula = schema.Formula("y")
+	return &SchemaFit{RQFit: fit, Schema: schema}, nil
+}
+
+// Predict validates newData against the schema and predicts the conditional quantiles
+func (f *SchemaFit) Predict(newData Frame) ([]float64, error) {
+	x, err := f.Schema.Design(newData)
+	if err != nil {
+		return nil, err
+	}
+	return f.RQFit.Predict(x)
+}
@@ -0,0 +1,69 @@
+package quantreg
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaPredict(t *testing.T) {
+	n := 30
+	frame := Frame{
+		Numeric: map[string][]float64{"x": make([]float64, n)},
+		Factors: map[string][]string{"region": make([]string, n)},
+	}
+	y := make([]float64, n)
+	regions := []string{"north", "east", "south"}
+	for i := 0; i < n; i++ {
+		frame.Numeric["x"][i] = float64(i) / 10
+		frame.Factors["region"][i] = regions[i%3]
+		y[i] = 1 + frame.Numeric["x"][i] + float64(i%3) + 0.1*math.Sin(float64(5*i))
+	}
+
+	fit, err := RQFrame(y, frame, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit frame: %v", err)
+	}
+	wantCols := []string{"(Intercept)", "x", "regionnorth", "regionsouth"}
+	if cols := fit.Schema.Columns(); !reflect.DeepEqual(cols, wantCols) {
+		t.Errorf("Expected columns %v, got %v", wantCols, cols)
+	}
+	if fit.Formula != "y ~ x + region" {
+		t.Errorf("Expected formula y ~ x + region, got %q", fit.Formula)
+	}
+
+	// Levels map to the training encoding regardless of their order in new data
+	newData := Frame{
+		Numeric: map[string][]float64{"x": {1, 1}},
+		Factors: map[string][]string{"region": {"south", "east"}},
+	}
+	pred, err := fit.Predict(newData)
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	b := fit.Coefficients
+	if want := b[0] + b[1] + b[3]; math.Abs(pred[0]-want) > 1e-12 {
+		t.Errorf("Expected prediction %f, got %f", want, pred[0])
+	}
+	if want := b[0] + b[1]; math.Abs(pred[1]-want) > 1e-12 {
+		t.Errorf("Expected prediction %f, got %f", want, pred[1])
+	}
+
+	newData.Factors["region"] = []string{"west", "south", "up", "west"}
+	newData.Numeric["x"] = []float64{1, 2, 3, 4}
+	_, err = fit.Predict(newData)
+	var unseen *UnseenLevelsError
+	if !errors.As(err, &unseen) {
+		t.Fatalf("Expected an unseen levels error, got %v", err)
+	}
+	if unseen.Column != "region" || !reflect.DeepEqual(unseen.Levels, []string{"up", "west"}) {
+		t.Errorf("Expected unseen levels [up west] of region, got %v of %s", unseen.Levels, unseen.Column)
+	}
+
+	_, err = fit.Predict(Frame{Numeric: map[string][]float64{"x": {1}}})
+	var missing *MissingColumnError
+	if !errors.As(err, &missing) || missing.Column != "region" {
+		t.Errorf("Expected a missing column error for region, got %v", err)
+	}
+}
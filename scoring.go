@@ -0,0 +1,123 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ForecastScores holds probabilistic forecast scores of a quantile grid against
+// realized outcomes. Lower CRPS and WIS are better.
+type ForecastScores struct {
+	Taus             []float64           // Quantile levels scored
+	CRPS             float64             // Mean approximate continuous ranked probability score
+	WIS              float64             // Mean weighted interval score (NaN if the grid is not symmetric around the median)
+	Coverage         map[float64]float64 // Fraction of outcomes at or below each predicted quantile
+	IntervalCoverage map[float64]float64 // Fraction inside each central interval, keyed by nominal level
+	PerObsCRPS       []float64
+	PerObsWIS        []float64
+}
+
+// Score predicts at newX and scores the predictions against the outcomes y
+func (m *MultiRQFit) Score(newX [][]float64, y []float64) (*ForecastScores, error) {
+	pred, err := m.Predict(newX)
+	if err != nil {
+		return nil, err
+	}
+	return ScoreQuantiles(pred, y)
+}
+
+// ScoreQuantiles scores predicted quantiles, keyed by tau as returned by
+// MultiRQFit.Predict, against outcomes y. CRPS is approximated by twice the mean
+// pinball loss over the grid. WIS follows Bracher et al. (2021) and is computed
+// when the grid contains the median and pairs every tau < 0.5 with 1 - tau.
+func ScoreQuantiles(pred map[float64][]float64, y []float64) (*ForecastScores, error) {
+	if len(pred) == 0 {
+		return nil, fmt.Errorf("no quantile predictions")
+	}
+	taus := make([]float64, 0, len(pred))
+	for tau, q := range pred {
+		if len(q) != len(y) {
+			return nil, fmt.Errorf("%w: %d predictions for tau=%f, %d outcomes", ErrDimensionMismatch, len(q), tau, len(y))
+		}
+		taus = append(taus, tau)
+	}
+	if len(y) == 0 {
+		return nil, fmt.Errorf("no outcomes to score")
+	}
+	sort.Float64s(taus)
+
+	n := len(y)
+	s := &ForecastScores{
+		Taus:             taus,
+		Coverage:         make(map[float64]float64),
+		IntervalCoverage: make(map[float64]float64),
+		PerObsCRPS:       make([]float64, n),
+	}
+
+	for _, tau := range taus {
+		below := 0
+		for i, yi := range y {
+			s.PerObsCRPS[i] += 2 * rho(yi-pred[tau][i], tau) / float64(len(taus))
+			if yi <= pred[tau][i] {
+				below++
+			}
+		}
+		s.Coverage[tau] = float64(below) / float64(n)
+	}
+	for _, v := range s.PerObsCRPS {
+		s.CRPS += v / float64(n)
+	}
+
+	// Central intervals from symmetric pairs
+	pairs := 0
+	hasMedian := false
+	for _, tau := range taus {
+		if math.Abs(tau-0.5) < 1e-9 {
+			hasMedian = true
+			continue
+		}
+		if tau > 0.5 {
+			continue
+		}
+		upper, ok := matchTau(pred, 1-tau)
+		if !ok {
+			continue
+		}
+		pairs++
+		inside := 0
+		for i, yi := range y {
+			if yi >= pred[tau][i] && yi <= upper[i] {
+				inside++
+			}
+		}
+		s.IntervalCoverage[1-2*tau] = float64(inside) / float64(n)
+	}
+
+	if hasMedian && 2*pairs+1 == len(taus) {
+		// Interval scores weighted by alpha/2 reduce to pinball losses
+		s.PerObsWIS = make([]float64, n)
+		for _, tau := range taus {
+			for i, yi := range y {
+				s.PerObsWIS[i] += rho(yi-pred[tau][i], tau) / (float64(pairs) + 0.5)
+			}
+		}
+		for _, v := range s.PerObsWIS {
+			s.WIS += v / float64(n)
+		}
+	} else {
+		s.WIS = math.NaN()
+	}
+
+	return s, nil
+}
+
+// matchTau looks up predictions for tau allowing for floating point error in 1 - tau
+func matchTau(pred map[float64][]float64, tau float64) ([]float64, bool) {
+	for t, q := range pred {
+		if math.Abs(t-tau) < 1e-9 {
+			return q, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,77 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreQuantiles(t *testing.T) {
+	pred := map[float64][]float64{
+		0.1: {0, 1},
+		0.5: {1, 2},
+		0.9: {2, 3},
+	}
+	y := []float64{1.5, 4}
+
+	s, err := ScoreQuantiles(pred, y)
+	if err != nil {
+		t.Fatalf("Failed to score forecasts: %v", err)
+	}
+
+	// Observation 1: losses 0.15, 0.25, 0.05; observation 2: 0.3, 1.0, 0.9
+	wantCRPS := (2*(0.15+0.25+0.05)/3 + 2*(0.3+1.0+0.9)/3) / 2
+	if math.Abs(s.CRPS-wantCRPS) > 1e-12 {
+		t.Errorf("Expected CRPS %f, got %f", wantCRPS, s.CRPS)
+	}
+
+	// WIS by definition: (|y-m|/2 + alpha/2 * IS_alpha) / (K + 1/2) with alpha = 0.2
+	is := func(l, u, y float64) float64 {
+		return (u - l) + 2/0.2*math.Max(l-y, 0) + 2/0.2*math.Max(y-u, 0)
+	}
+	wis1 := (0.5*0.5 + 0.1*is(0, 2, 1.5)) / 1.5
+	wis2 := (0.5*2 + 0.1*is(1, 3, 4)) / 1.5
+	if math.Abs(s.WIS-(wis1+wis2)/2) > 1e-12 {
+		t.Errorf("Expected WIS %f, got %f", (wis1+wis2)/2, s.WIS)
+	}
+
+	if s.Coverage[0.9] != 0.5 || s.Coverage[0.1] != 0 {
+		t.Errorf("Unexpected coverage: %v", s.Coverage)
+	}
+	if c, ok := s.IntervalCoverage[0.8]; !ok || c != 0.5 {
+		t.Errorf("Expected 80%% interval coverage 0.5, got %v", s.IntervalCoverage)
+	}
+
+	// Asymmetric grid has no WIS
+	s, err = ScoreQuantiles(map[float64][]float64{0.25: {0}, 0.5: {1}}, []float64{1})
+	if err != nil {
+		t.Fatalf("Failed to score forecasts: %v", err)
+	}
+	if !math.IsNaN(s.WIS) {
+		t.Errorf("Expected NaN WIS for asymmetric grid, got %f", s.WIS)
+	}
+
+	// Error cases
+	if _, err := ScoreQuantiles(pred, []float64{1}); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+	if _, err := ScoreQuantiles(nil, y); err == nil {
+		t.Error("Expected error for empty predictions")
+	}
+}
+
+func TestMultiRQFitScore(t *testing.T) {
+	x := [][]float64{{1, 0.5}, {1, 1.0}, {1, 1.5}, {1, 2.0}, {1, 2.5}}
+	y := []float64{1.0, 2.0, 2.5, 3.0, 4.0}
+
+	fits, err := RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+	s, err := fits.Score(x, y)
+	if err != nil {
+		t.Fatalf("Failed to score fit: %v", err)
+	}
+	if s.CRPS < 0 || math.IsNaN(s.WIS) || len(s.PerObsCRPS) != 5 {
+		t.Errorf("Unexpected scores: %+v", s)
+	}
+}
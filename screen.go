@@ -0,0 +1,113 @@
+package quantreg
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ScreenResult ranks predictors by their marginal quantile utility
+type ScreenResult struct {
+	Selected  []int     // Indices of retained predictors, in order of selection
+	Utilities []float64 // Utility of every predictor at its last evaluation
+}
+
+// Screen performs quantile-adapted sure independence screening. Each column of the
+// predictor matrix z (without intercept) is scored by the reduction in check loss
+// of the marginal fit y ~ 1 + z_j over the intercept-only fit, and the keep
+// columns with the largest reduction are retained.
+func Screen(y []float64, z [][]float64, tau float64, keep int) (*ScreenResult, error) {
+	return IterativeScreen(y, z, tau, keep, 1)
+}
+
+// IterativeScreen runs iterative screening: after an initial marginal screen of
+// keep/rounds columns, each further round scores the remaining columns by the
+// check loss reduction from adding them to the model with the columns selected so
+// far, and retains the best, until keep columns are selected
+func IterativeScreen(y []float64, z [][]float64, tau float64, keep, rounds int) (*ScreenResult, error) {
+	n := len(y)
+	if len(z) != n || n == 0 {
+		return nil, fmt.Errorf("%w: y has %d rows, z has %d rows", ErrDimensionMismatch, n, len(z))
+	}
+	p := len(z[0])
+	if keep <= 0 || keep > p {
+		return nil, fmt.Errorf("number of retained predictors must be between 1 and %d, got %d", p, keep)
+	}
+	if rounds <= 0 {
+		return nil, fmt.Errorf("number of rounds must be positive, got %d", rounds)
+	}
+	if keep >= n-1 {
+		return nil, fmt.Errorf("retaining %d predictors leaves too few observations (%d)", keep, n)
+	}
+
+	res := &ScreenResult{Utilities: make([]float64, p)}
+	chosen := make(map[int]bool)
+	base := restrictedRho(y, tau)
+	for round := 0; round < rounds && len(res.Selected) < keep; round++ {
+		if len(res.Selected) > 0 {
+			fit, err := RQ(y, screenDesign(z, res.Selected, -1), tau)
+			if err != nil {
+				return nil, fmt.Errorf("conditional fit failed: %w", err)
+			}
+			base = fit.Rho()
+		}
+
+		var candidates []int
+		for j := 0; j < p; j++ {
+			if chosen[j] {
+				continue
+			}
+			fit, err := RQ(y, screenDesign(z, res.Selected, j), tau)
+			if err != nil {
+				return nil, fmt.Errorf("marginal fit for predictor %d failed: %w", j, err)
+			}
+			res.Utilities[j] = base - fit.Rho()
+			candidates = append(candidates, j)
+		}
+		sort.SliceStable(candidates, func(a, b int) bool {
+			return res.Utilities[candidates[a]] > res.Utilities[candidates[b]]
+		})
+
+		take := (keep - len(res.Selected) + rounds - round - 1) / (rounds - round)
+		for _, j := range candidates[:take] {
+			chosen[j] = true
+			res.Selected = append(res.Selected, j)
+		}
+	}
+
+	return res, nil
+}
+
+// ScreenedPath is a lasso path fitted on screened predictors
+type ScreenedPath struct {
+	Screen *ScreenResult
+	Path   []*LassoFit // Coefficients are intercept followed by Screen.Selected
+}
+
+// RQLassoScreened screens the predictors in z down to keep columns and fits the
+// lasso path on an intercept plus the retained columns
+func RQLassoScreened(y []float64, z [][]float64, tau float64, keep, rounds int, lambdas []float64) (*ScreenedPath, error) {
+	sr, err := IterativeScreen(y, z, tau, keep, rounds)
+	if err != nil {
+		return nil, err
+	}
+	path, err := RQLassoPath(y, screenDesign(z, sr.Selected, -1), tau, lambdas)
+	if err != nil {
+		return nil, err
+	}
+	return &ScreenedPath{Screen: sr, Path: path}, nil
+}
+
+// screenDesign builds an intercept plus the selected columns of z and, if extra >= 0, column extra
+func screenDesign(z [][]float64, selected []int, extra int) [][]float64 {
+	x := make([][]float64, len(z))
+	for i, row := range z {
+		x[i] = append(x[i], 1)
+		for _, j := range selected {
+			x[i] = append(x[i], row[j])
+		}
+		if extra >= 0 {
+			x[i] = append(x[i], row[extra])
+		}
+	}
+	return x
+}
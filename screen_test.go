@@ -0,0 +1,71 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func screenData() ([]float64, [][]float64) {
+	n := 20
+	y := make([]float64, n)
+	z := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		z[i] = []float64{
+			math.Sin(float64(3 * i)),
+			float64(i) / 4,
+			math.Cos(float64(5 * i)),
+			float64(i%3) - 1,
+		}
+		y[i] = 2*z[i][1] + 0.1*z[i][2]
+	}
+	return y, z
+}
+
+func TestScreen(t *testing.T) {
+	y, z := screenData()
+
+	res, err := Screen(y, z, 0.5, 2)
+	if err != nil {
+		t.Fatalf("Failed to screen predictors: %v", err)
+	}
+	if len(res.Selected) != 2 || len(res.Utilities) != 4 {
+		t.Fatalf("Unexpected screening result: %+v", res)
+	}
+	if res.Selected[0] != 1 {
+		t.Errorf("Expected the strong predictor first, got %v (utilities %v)", res.Selected, res.Utilities)
+	}
+
+	iter, err := IterativeScreen(y, z, 0.5, 3, 2)
+	if err != nil {
+		t.Fatalf("Failed to screen predictors: %v", err)
+	}
+	if len(iter.Selected) != 3 {
+		t.Errorf("Expected 3 selected predictors, got %v", iter.Selected)
+	}
+	seen := make(map[int]bool)
+	for _, j := range iter.Selected {
+		if seen[j] {
+			t.Errorf("Predictor %d selected twice", j)
+		}
+		seen[j] = true
+	}
+
+	// Error cases
+	if _, err := Screen(y, z, 0.5, 5); err == nil {
+		t.Error("Expected error for keeping more predictors than available")
+	}
+	if _, err := IterativeScreen(y, z, 0.5, 2, 0); err == nil {
+		t.Error("Expected error for zero rounds")
+	}
+}
+
+func TestRQLassoScreened(t *testing.T) {
+	y, z := screenData()
+	sp, err := RQLassoScreened(y, z, 0.5, 2, 1, []float64{1, 0.1})
+	if err != nil {
+		t.Fatalf("Failed to fit screened lasso path: %v", err)
+	}
+	if len(sp.Path) != 2 || sp.Path[0].P != 3 {
+		t.Errorf("Expected 2 fits with 3 coefficients, got %d fits", len(sp.Path))
+	}
+}
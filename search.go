@@ -0,0 +1,294 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Params is one hyperparameter setting, keyed by parameter name
+type Params map[string]float64
+
+// Predictor is a fitted single-quantile model
+type Predictor interface {
+	Predict(newX [][]float64) ([]float64, error)
+}
+
+// Learner fits a model at tau with the given hyperparameters
+type Learner func(y []float64, x [][]float64, tau float64, params Params) (Predictor, error)
+
+// ParamRange is a continuous range sampled by random search
+type ParamRange struct {
+	Min, Max float64
+	Log      bool // Sample uniformly on the log scale
+}
+
+// SearchOptions controls HyperparameterSearch
+type SearchOptions struct {
+	Grid    map[string][]float64  // Candidate values per parameter
+	Ranges  map[string]ParamRange // Continuous ranges per parameter, random search only
+	Trials  int                   // Random settings to draw; 0 searches the full Grid
+	Folds   int                   // Cross-validation folds (default 5)
+	Workers int                   // Settings evaluated in parallel (default runtime.NumCPU())
+}
+
+// SearchResult is the cross-validated loss of one setting
+type SearchResult struct {
+	Params  Params
+	Rank    int                 // 1 for the lowest mean loss
+	Loss    float64             // Mean out-of-fold pinball loss averaged over taus
+	SE      float64             // Standard error of Loss over observations
+	TauLoss map[float64]float64 // Mean out-of-fold pinball loss at each tau
+}
+
+// Search is a ranked table of hyperparameter settings
+type Search struct {
+	Taus    []float64
+	Folds   int
+	Results []SearchResult // Sorted by increasing mean loss
+}
+
+// HyperparameterSearch scores hyperparameter settings of learner by k-fold
+// cross-validated pinball loss at the given taus. With Trials zero every
+// combination of the Grid values is evaluated; otherwise Trials settings are drawn
+// at random, each parameter from its Grid values or its Range. Settings are
+// evaluated concurrently on a shared fold assignment, so differences between them
+// are paired by observation.
+func HyperparameterSearch(y []float64, x [][]float64, learner Learner, taus []float64, opts SearchOptions, rng *rand.Rand) (*Search, error) {
+	n := len(y)
+	if len(x) != n {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if len(taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels specified")
+	}
+	if opts.Folds == 0 {
+		opts.Folds = 5
+	}
+	if opts.Folds < 2 || opts.Folds > n {
+		return nil, fmt.Errorf("number of folds must be between 2 and %d, got %d", n, opts.Folds)
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Trials < 0 {
+		return nil, fmt.Errorf("number of trials must be non-negative, got %d", opts.Trials)
+	}
+	if opts.Trials == 0 && len(opts.Ranges) > 0 {
+		return nil, fmt.Errorf("parameter ranges need random search, set Trials")
+	}
+
+	rng = randOrDefault(rng)
+	settings, err := opts.settings(rng)
+	if err != nil {
+		return nil, err
+	}
+	sorted := append([]float64(nil), taus...)
+	sort.Float64s(sorted)
+	fold := foldAssignment(n, opts.Folds, rng)
+
+	results := make([]SearchResult, len(settings))
+	errs := make([]error, len(settings))
+	sem := make(chan struct{}, opts.Workers)
+	var wg sync.WaitGroup
+	for s := range settings {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(s int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[s], errs[s] = cvSetting(y, x, learner, sorted, fold, opts.Folds, settings[s])
+		}(s)
+	}
+	wg.Wait()
+	for s, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("setting %v: %w", settings[s], err)
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool { return results[a].Loss < results[b].Loss })
+	for r := range results {
+		results[r].Rank = r + 1
+	}
+	return &Search{Taus: sorted, Folds: opts.Folds, Results: results}, nil
+}
+
+// settings enumerates the grid or draws the random settings
+func (o *SearchOptions) settings(rng *rand.Rand) ([]Params, error) {
+	var names []string
+	for name, values := range o.Grid {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("no values for parameter %q", name)
+		}
+		names = append(names, name)
+	}
+	for name, r := range o.Ranges {
+		if _, ok := o.Grid[name]; ok {
+			return nil, fmt.Errorf("parameter %q has both values and a range", name)
+		}
+		if r.Max < r.Min || (r.Log && r.Min <= 0) {
+			return nil, fmt.Errorf("invalid range for parameter %q", name)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no hyperparameters to search")
+	}
+	sort.Strings(names)
+
+	if o.Trials > 0 {
+		out := make([]Params, o.Trials)
+		for t := range out {
+			out[t] = make(Params, len(names))
+			for _, name := range names {
+				if values, ok := o.Grid[name]; ok {
+					out[t][name] = values[rng.Intn(len(values))]
+					continue
+				}
+				r := o.Ranges[name]
+				if r.Log {
+					out[t][name] = math.Exp(math.Log(r.Min) + rng.Float64()*(math.Log(r.Max)-math.Log(r.Min)))
+				} else {
+					out[t][name] = r.Min + rng.Float64()*(r.Max-r.Min)
+				}
+			}
+		}
+		return out, nil
+	}
+
+	out := []Params{{}}
+	for _, name := range names {
+		var next []Params
+		for _, p := range out {
+			for _, v := range o.Grid[name] {
+				q := make(Params, len(p)+1)
+				for k, pv := range p {
+					q[k] = pv
+				}
+				q[name] = v
+				next = append(next, q)
+			}
+		}
+		out = next
+	}
+	return out, nil
+}
+
+// cvSetting cross-validates one hyperparameter setting
+func cvSetting(y []float64, x [][]float64, learner Learner, taus []float64, fold []int, folds int, params Params) (SearchResult, error) {
+	n := len(y)
+	res := SearchResult{Params: params, TauLoss: make(map[float64]float64, len(taus))}
+	pred, err := outOfFold(y, x, fold, folds, func(trainY []float64, trainX, testX [][]float64) (map[float64][]float64, error) {
+		out := make(map[float64][]float64, len(taus))
+		for _, tau := range taus {
+			model, err := learner(trainY, trainX, tau, params)
+			if err != nil {
+				return nil, fmt.Errorf("fit failed at tau=%f: %w", tau, err)
+			}
+			if out[tau], err = model.Predict(testX); err != nil {
+				return nil, fmt.Errorf("prediction failed at tau=%f: %w", tau, err)
+			}
+		}
+		return out, nil
+	})
+	if err != nil {
+		return res, err
+	}
+	perPoint := make([]float64, n)
+	for _, tau := range taus {
+		for i, q := range pred[tau] {
+			l := rho(y[i]-q, tau)
+			res.TauLoss[tau] += l / float64(n)
+			perPoint[i] += l / float64(len(taus))
+		}
+	}
+	res.Loss, res.SE = meanSE(perPoint)
+	return res, nil
+}
+
+// Best returns the setting with the lowest cross-validated loss
+func (s *Search) Best() Params {
+	return s.Results[0].Params
+}
+
+// Table returns the search results as a formatted text table
+func (s *Search) Table() string {
+	result := fmt.Sprintf("%d-fold cross-validated pinball loss (taus = %v)\n\n", s.Folds, s.Taus)
+	result += fmt.Sprintf("%-4s %-40s %12s %12s\n", "Rank", "Parameters", "Loss", "SE")
+	for _, r := range s.Results {
+		result += fmt.Sprintf("%-4d %-40s %12.6f %12.6f\n", r.Rank, r.Params, r.Loss, r.SE)
+	}
+	return result
+}
+
+// String formats the setting as name=value pairs in name order
+func (p Params) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + strconv.FormatFloat(p[name], 'g', 6, 64)
+	}
+	return strings.Join(parts, " ")
+}
+
+// LassoLearner fits RQLasso with the "lambda" parameter
+func LassoLearner(y []float64, x [][]float64, tau float64, params Params) (Predictor, error) {
+	fit, err := RQLasso(y, x, tau, params["lambda"])
+	if err != nil {
+		return nil, err
+	}
+	return fit, nil
+}
+
+// KernelQRLearner fits a Gaussian KernelQR with the "lambda" and "sigma"
+// parameters, using the KernelQR defaults for parameters that are not set
+func KernelQRLearner(y []float64, x [][]float64, tau float64, params Params) (Predictor, error) {
+	fit, err := KernelQR(y, x, tau, KernelQROptions{Lambda: params["lambda"], Sigma: params["sigma"]})
+	if err != nil {
+		return nil, err
+	}
+	return fit, nil
+}
+
+// PSplineLearner fits RQPSpline on the first column of x with the "segments" and
+// "lambda" parameters, using the defaults for parameters that are not set
+func PSplineLearner(y []float64, x [][]float64, tau float64, params Params) (Predictor, error) {
+	opts := PSplineOptions{Segments: int(params["segments"])}
+	if lambda, ok := params["lambda"]; ok {
+		opts.Lambdas = []float64{lambda}
+	}
+	fit, err := RQPSpline(y, column(x, 0), tau, opts)
+	if err != nil {
+		return nil, err
+	}
+	return splinePredictor{fit}, nil
+}
+
+// splinePredictor predicts a P-spline fit from the first column of newX
+type splinePredictor struct {
+	fit *PSplineFit
+}
+
+// Predict evaluates the spline at the first column of newX
+func (s splinePredictor) Predict(newX [][]float64) ([]float64, error) {
+	return s.fit.SmoothAt(column(newX, 0)), nil
+}
+
+// column returns column j of x
+func column(x [][]float64, j int) []float64 {
+	out := make([]float64, len(x))
+	for i, row := range x {
+		out[i] = row[j]
+	}
+	return out
+}
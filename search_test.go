@@ -0,0 +1,69 @@
+package quantreg
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestHyperparameterSearch(t *testing.T) {
+	y, x := inferenceData()
+	opts := SearchOptions{Grid: map[string][]float64{"lambda": {0, 0.5, 50}}, Folds: 4, Workers: 2}
+	s, err := HyperparameterSearch(y, x, LassoLearner, []float64{0.5}, opts, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+	if len(s.Results) != 3 || s.Results[0].Rank != 1 {
+		t.Fatalf("Unexpected results %+v", s.Results)
+	}
+	if s.Best()["lambda"] == 50 {
+		t.Errorf("Expected the heavily penalized fit to lose, got best %v", s.Best())
+	}
+	for k := 1; k < len(s.Results); k++ {
+		if s.Results[k].Loss < s.Results[k-1].Loss {
+			t.Errorf("Expected results sorted by loss")
+		}
+	}
+	if table := s.Table(); !strings.Contains(table, "lambda=50") {
+		t.Errorf("Expected the table to list every setting:\n%s", table)
+	}
+}
+
+func TestHyperparameterSearchRandom(t *testing.T) {
+	y, x := inferenceData()
+	z := make([][]float64, len(x))
+	for i, row := range x {
+		z[i] = row[1:]
+	}
+	opts := SearchOptions{
+		Grid:   map[string][]float64{"segments": {3, 5}},
+		Ranges: map[string]ParamRange{"lambda": {Min: 0.01, Max: 10, Log: true}},
+		Trials: 4,
+		Folds:  3,
+	}
+	s, err := HyperparameterSearch(y, z, PSplineLearner, []float64{0.25, 0.75}, opts, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+	if len(s.Results) != 4 {
+		t.Fatalf("Expected 4 trials, got %d", len(s.Results))
+	}
+	for _, r := range s.Results {
+		if l := r.Params["lambda"]; l < 0.01 || l > 10 {
+			t.Errorf("Expected lambda in range, got %f", l)
+		}
+		if seg := r.Params["segments"]; seg != 3 && seg != 5 {
+			t.Errorf("Expected segments from the grid, got %f", seg)
+		}
+		if len(r.TauLoss) != 2 {
+			t.Errorf("Expected a loss per tau, got %v", r.TauLoss)
+		}
+	}
+
+	if _, err := HyperparameterSearch(y, z, PSplineLearner, []float64{0.5}, SearchOptions{Ranges: opts.Ranges}, nil); err == nil {
+		t.Error("Expected error for ranges without trials")
+	}
+	if _, err := HyperparameterSearch(y, x, LassoLearner, []float64{0.5}, SearchOptions{}, nil); err == nil {
+		t.Error("Expected error for empty search space")
+	}
+}
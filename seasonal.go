@@ -0,0 +1,99 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// FourierTerms returns sin and cos harmonics of t for the given seasonal period,
+// one row per time point with columns sin(2 pi k t / period), cos(2 pi k t / period)
+// for k = 1..harmonics
+func FourierTerms(t []float64, period float64, harmonics int) ([][]float64, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("period must be positive, got %f", period)
+	}
+	if harmonics <= 0 || float64(2*harmonics) > period {
+		return nil, fmt.Errorf("harmonics must be between 1 and period/2, got %d", harmonics)
+	}
+	out := make([][]float64, len(t))
+	for i, ti := range t {
+		row := make([]float64, 0, 2*harmonics)
+		for k := 1; k <= harmonics; k++ {
+			a := 2 * math.Pi * float64(k) * ti / period
+			row = append(row, math.Sin(a), math.Cos(a))
+		}
+		out[i] = row
+	}
+	return out, nil
+}
+
+// DayOfWeekDummies returns six indicator columns for the days of the week other
+// than baseline, in Sunday-to-Saturday order
+func DayOfWeekDummies(dates []time.Time, baseline time.Weekday) [][]float64 {
+	out := make([][]float64, len(dates))
+	for i, d := range dates {
+		row := make([]float64, 0, 6)
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if day == baseline {
+				continue
+			}
+			v := 0.0
+			if d.Weekday() == day {
+				v = 1
+			}
+			row = append(row, v)
+		}
+		out[i] = row
+	}
+	return out
+}
+
+// HolidayDummies returns a single indicator column that is 1 when a date falls on
+// the same calendar day as one of holidays
+func HolidayDummies(dates []time.Time, holidays []time.Time) [][]float64 {
+	set := make(map[[3]int]bool, len(holidays))
+	for _, h := range holidays {
+		y, m, d := h.Date()
+		set[[3]int{y, int(m), d}] = true
+	}
+	out := make([][]float64, len(dates))
+	for i, d := range dates {
+		y, m, day := d.Date()
+		v := 0.0
+		if set[[3]int{y, int(m), day}] {
+			v = 1
+		}
+		out[i] = []float64{v}
+	}
+	return out
+}
+
+// BindColumns concatenates design blocks column-wise; all blocks must have the same number of rows
+func BindColumns(blocks ...[][]float64) ([][]float64, error) {
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no blocks to bind")
+	}
+	n := len(blocks[0])
+	for b, block := range blocks {
+		if len(block) != n {
+			return nil, fmt.Errorf("block %d has %d rows, want %d", b, len(block), n)
+		}
+	}
+	out := make([][]float64, n)
+	for i := range out {
+		for _, block := range blocks {
+			out[i] = append(out[i], block[i]...)
+		}
+	}
+	return out, nil
+}
+
+// WithIntercept prepends a column of ones to x
+func WithIntercept(x [][]float64) [][]float64 {
+	out := make([][]float64, len(x))
+	for i, row := range x {
+		out[i] = append([]float64{1}, row...)
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFourierTerms(t *testing.T) {
+	f, err := FourierTerms([]float64{0, 1.75, 7}, 7, 2)
+	if err != nil {
+		t.Fatalf("Failed to build Fourier terms: %v", err)
+	}
+	if len(f) != 3 || len(f[0]) != 4 {
+		t.Fatalf("Expected 3 x 4 design, got %d x %d", len(f), len(f[0]))
+	}
+	// A full period returns to the start
+	for j := range f[0] {
+		if math.Abs(f[0][j]-f[2][j]) > 1e-12 {
+			t.Errorf("Column %d is not periodic: %f vs %f", j, f[0][j], f[2][j])
+		}
+	}
+	if math.Abs(f[1][0]-1) > 1e-12 || math.Abs(f[1][1]) > 1e-12 {
+		t.Errorf("Expected sin=1, cos=0 at a quarter period, got %v", f[1][:2])
+	}
+
+	if _, err := FourierTerms([]float64{0}, 4, 3); err == nil {
+		t.Error("Expected error for too many harmonics")
+	}
+	if _, err := FourierTerms([]float64{0}, 0, 1); err == nil {
+		t.Error("Expected error for non-positive period")
+	}
+}
+
+func TestCalendarDummies(t *testing.T) {
+	monday := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	dates := []time.Time{monday, monday.AddDate(0, 0, 1), monday.AddDate(0, 0, 6)}
+
+	dow := DayOfWeekDummies(dates, time.Monday)
+	if len(dow[0]) != 6 {
+		t.Fatalf("Expected 6 columns, got %d", len(dow[0]))
+	}
+	for _, v := range dow[0] {
+		if v != 0 {
+			t.Errorf("Expected baseline day to have all-zero dummies, got %v", dow[0])
+		}
+	}
+	// Columns are Sunday, Tuesday, ..., Saturday
+	if dow[1][1] != 1 || dow[2][0] != 1 {
+		t.Errorf("Unexpected dummies: Tuesday %v, Sunday %v", dow[1], dow[2])
+	}
+
+	hol := HolidayDummies(dates, []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if hol[0][0] != 1 || hol[1][0] != 0 {
+		t.Errorf("Unexpected holiday dummies: %v", hol)
+	}
+
+	x, err := BindColumns(WithIntercept(hol), dow)
+	if err != nil {
+		t.Fatalf("Failed to bind columns: %v", err)
+	}
+	if len(x[0]) != 8 || x[0][0] != 1 || x[0][1] != 1 {
+		t.Errorf("Unexpected bound row: %v", x[0])
+	}
+	if _, err := BindColumns(hol, dow[:1]); err == nil {
+		t.Error("Expected error for mismatched rows")
+	}
+}
@@ -0,0 +1,71 @@
+package quantreg
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// SimulatedResiduals returns DHARMa-style scaled residuals in (0, 1). For each
+// observation nsim responses are drawn from the fitted conditional distribution,
+// obtained by inverting the fitted quantile process at x_i: a uniform tau is drawn
+// and mapped through the monotonically rearranged quantile curve, interpolated
+// linearly between fitted taus and extrapolated linearly beyond them. The residual
+// is the randomized empirical distribution function of the draws at y_i, which is
+// uniformly distributed when the model is correct.
+func (m *MultiRQFit) SimulatedResiduals(nsim int, rng *rand.Rand) ([]float64, error) {
+	if len(m.Taus) < 2 {
+		return nil, fmt.Errorf("need at least 2 quantile levels, got %d", len(m.Taus))
+	}
+	if nsim <= 0 {
+		return nil, fmt.Errorf("number of simulations must be positive")
+	}
+	first := m.Fits[m.Taus[0]]
+	if len(first.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its response")
+	}
+
+	rng = randOrDefault(rng)
+	q := make([]float64, len(m.Taus))
+	fitted := make([][]float64, len(m.Taus))
+	for k, tau := range m.Taus {
+		fitted[k] = m.Fits[tau].FittedValues()
+	}
+	resid := make([]float64, m.N)
+	for i := 0; i < m.N; i++ {
+		for k := range m.Taus {
+			q[k] = fitted[k][i]
+		}
+		// Rearrange so the conditional quantile function is monotone
+		sort.Float64s(q)
+
+		yi := first.Y[i]
+		below, ties := 0, 0
+		for s := 0; s < nsim; s++ {
+			draw := interpolateQuantile(m.Taus, q, rng.Float64())
+			switch {
+			case draw < yi:
+				below++
+			case draw == yi:
+				ties++
+			}
+		}
+		resid[i] = (float64(below) + rng.Float64()*float64(ties+1)) / float64(nsim+1)
+	}
+
+	return resid, nil
+}
+
+// interpolateQuantile evaluates the piecewise linear quantile function through
+// (taus[k], q[k]) at u, extrapolating the end segments into the tails
+func interpolateQuantile(taus, q []float64, u float64) float64 {
+	k := sort.SearchFloat64s(taus, u)
+	switch {
+	case k == 0:
+		k = 1
+	case k >= len(taus):
+		k = len(taus) - 1
+	}
+	t0, t1 := taus[k-1], taus[k]
+	return q[k-1] + (u-t0)*(q[k]-q[k-1])/(t1-t0)
+}
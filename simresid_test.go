@@ -0,0 +1,62 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSimulatedResiduals(t *testing.T) {
+	y, x := inferenceData()
+
+	fits, err := RQProcess(y, x, []float64{0.1, 0.3, 0.5, 0.7, 0.9})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	resid, err := fits.SimulatedResiduals(250, rand.New(rand.NewSource(11)))
+	if err != nil {
+		t.Fatalf("Failed to simulate residuals: %v", err)
+	}
+	if len(resid) != fits.N {
+		t.Fatalf("Expected %d residuals, got %d", fits.N, len(resid))
+	}
+	for i, u := range resid {
+		if u <= 0 || u >= 1 || math.IsNaN(u) {
+			t.Errorf("Residual %d outside (0, 1): %f", i, u)
+		}
+	}
+
+	again, _ := fits.SimulatedResiduals(250, rand.New(rand.NewSource(11)))
+	for i := range resid {
+		if again[i] != resid[i] {
+			t.Fatal("Expected reproducible residuals for identical seeds")
+		}
+	}
+
+	// Error cases
+	single, _ := RQProcess(y, x, []float64{0.5})
+	if _, err := single.SimulatedResiduals(10, nil); err == nil {
+		t.Error("Expected error for a single quantile level")
+	}
+	if _, err := fits.SimulatedResiduals(0, nil); err == nil {
+		t.Error("Expected error for non-positive number of simulations")
+	}
+}
+
+func TestInterpolateQuantile(t *testing.T) {
+	taus := []float64{0.25, 0.5, 0.75}
+	q := []float64{1, 2, 4}
+
+	cases := map[float64]float64{
+		0.5:   2,
+		0.625: 3,
+		0.0:   0, // extrapolated from the first segment
+		1.0:   6, // extrapolated from the last segment
+	}
+	for u, want := range cases {
+		if got := interpolateQuantile(taus, q, u); math.Abs(got-want) > 1e-12 {
+			t.Errorf("interpolateQuantile(%v) = %v, want %v", u, got, want)
+		}
+	}
+}
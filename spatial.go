@@ -0,0 +1,238 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// SpatialOptions controls RQSpatialLag
+type SpatialOptions struct {
+	Rhos          []float64 // Candidate spatial lag parameters (default -0.9 to 0.9 in steps of 0.02)
+	Level         float64   // Confidence level of the rho interval (default 0.95)
+	SE            string    // Covariance estimator of the Wald statistics (default SEKer)
+	SpatialErrors bool      // Use VcovSpatial instead of SE, for spatially correlated errors
+}
+
+// SpatialFit is a quantile regression with a spatially lagged response
+type SpatialFit struct {
+	*RQFit           // Fit of y - rho Wy on x at the estimated rho
+	Rho      float64 // Estimated spatial lag parameter
+	RhoLower float64 // Lower confidence bound of rho
+	RhoUpper float64 // Upper confidence bound of rho
+	Level    float64
+	Rhos     []float64 // Candidate rhos
+	Wald     []float64 // Wald statistic of the excluded instrument at each candidate
+}
+
+// RQSpatialLag estimates Q_tau(y | x) = rho Wy + x'b for a spatial weights matrix w
+// by inverse quantile regression (Chernozhukov and Hansen 2006). The endogenous
+// lag Wy is instrumented by its least squares projection on x, Wx and W^2x
+// (Kelejian and Prucha 1998); for each candidate rho, y - rho Wy is regressed on
+// x and the instrument, and rho is estimated where the instrument's coefficient
+// is least significant. The confidence interval collects the candidates whose
+// Wald statistic is below the chi-squared(1) critical value, which remains valid
+// with weak instruments. The returned fit and its standard errors are
+// conditional on the estimated rho. All fits are solved exactly by the simplex
+// method since the profile hinges on small coefficients.
+func RQSpatialLag(y []float64, x [][]float64, w [][]float64, tau float64, opts SpatialOptions) (*SpatialFit, error) {
+	if len(opts.Rhos) == 0 {
+		for k := -45; k <= 45; k++ {
+			opts.Rhos = append(opts.Rhos, float64(k)/50)
+		}
+	}
+	if opts.Level == 0 {
+		opts.Level = 0.95
+	}
+	if opts.SE == "" {
+		opts.SE = SEKer
+	}
+	if opts.Level <= 0 || opts.Level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	n := len(y)
+	if len(x) != n || n == 0 {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	if err := checkWeights(w, n); err != nil {
+		return nil, err
+	}
+
+	wy := matVec(w, y)
+	z, err := spatialInstrument(wy, x, w)
+	if err != nil {
+		return nil, err
+	}
+	p := len(x[0])
+	design := make([][]float64, n)
+	for i, row := range x {
+		design[i] = append(append([]float64(nil), row...), z[i])
+	}
+
+	res := &SpatialFit{Level: opts.Level, Rhos: opts.Rhos, Wald: make([]float64, len(opts.Rhos))}
+	ry := make([]float64, n)
+	best := math.Inf(1)
+	for k, rho := range opts.Rhos {
+		for i := range y {
+			ry[i] = y[i] - rho*wy[i]
+		}
+		fit, err := rqSimplex(append([]float64(nil), ry...), design, nil, tau)
+		if err != nil {
+			return nil, fmt.Errorf("fit failed at rho=%f: %w", rho, err)
+		}
+		var cov [][]float64
+		if opts.SpatialErrors {
+			cov, err = fit.VcovSpatial(w)
+		} else {
+			cov, err = fit.Vcov(opts.SE)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("covariance failed at rho=%f: %w", rho, err)
+		}
+		g := fit.Coefficients[p]
+		res.Wald[k] = math.Inf(1)
+		if cov[p][p] > 0 {
+			res.Wald[k] = g * g / cov[p][p]
+		}
+		if res.Wald[k] < best {
+			best = res.Wald[k]
+			res.Rho = rho
+		}
+	}
+
+	crit := math.Pow(normQuantile((1+opts.Level)/2), 2)
+	res.RhoLower, res.RhoUpper = res.Rho, res.Rho
+	for k, rho := range opts.Rhos {
+		if res.Wald[k] <= crit {
+			res.RhoLower = math.Min(res.RhoLower, rho)
+			res.RhoUpper = math.Max(res.RhoUpper, rho)
+		}
+	}
+
+	for i := range y {
+		ry[i] = y[i] - res.Rho*wy[i]
+	}
+	fit, err := rqSimplex(ry, x, nil, tau)
+	if err != nil {
+		return nil, err
+	}
+	res.RQFit = fit
+	return res, nil
+}
+
+// spatialInstrument projects wy on x, Wx and W^2x, dropping constant columns of
+// the spatial lags, which duplicate the intercept for row-standardized weights
+func spatialInstrument(wy []float64, x, w [][]float64) ([]float64, error) {
+	wx := matMul(w, x)
+	w2x := matMul(w, wx)
+	n, p := len(x), len(x[0])
+	inst := make([][]float64, n)
+	for i := range inst {
+		inst[i] = append([]float64(nil), x[i]...)
+	}
+	for _, lag := range [][][]float64{wx, w2x} {
+		for j := 0; j < p; j++ {
+			constant := true
+			for i := 1; i < n && constant; i++ {
+				constant = math.Abs(lag[i][j]-lag[0][j]) < 1e-12
+			}
+			if constant {
+				continue
+			}
+			for i := range inst {
+				inst[i] = append(inst[i], lag[i][j])
+			}
+		}
+	}
+
+	xtxinv, err := invert(crossprod(inst, nil))
+	if err != nil {
+		return nil, fmt.Errorf("instrument matrix is singular: %w", err)
+	}
+	xty := make([]float64, len(inst[0]))
+	for i, row := range inst {
+		for j, v := range row {
+			xty[j] += v * wy[i]
+		}
+	}
+	return matVec(inst, matVec(xtxinv, xty)), nil
+}
+
+// checkWeights validates an n x n spatial weights matrix with a zero diagonal
+func checkWeights(w [][]float64, n int) error {
+	if len(w) != n {
+		return fmt.Errorf("weights matrix has %d rows, expected %d", len(w), n)
+	}
+	for i, row := range w {
+		if len(row) != n {
+			return fmt.Errorf("weights row %d has %d columns, expected %d", i, len(row), n)
+		}
+		if row[i] != 0 {
+			return fmt.Errorf("weights matrix must have a zero diagonal")
+		}
+	}
+	return nil
+}
+
+// VcovSpatial returns a covariance H^-1 Omega H^-1 robust to correlation between
+// neighbouring observations, where i and j are neighbours when w_ij or w_ji is
+// nonzero. H uses Powell kernel densities. Omega averages the outer products of
+// the score sums g_i over each observation's neighbourhood B_i including itself,
+// Omega = sum_i g_i g_i' / mean |B_i|, with scores psi_i = x_i (tau - I(r_i < 0)).
+// Like the Bartlett estimator in time, which arises from overlapping blocks in
+// the same way, it is positive semi-definite by construction.
+func (fit *RQFit) VcovSpatial(w [][]float64) ([][]float64, error) {
+	if len(fit.X) == 0 || len(fit.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	if err := checkWeights(w, fit.N); err != nil {
+		return nil, err
+	}
+	f, err := fit.kernelDensities()
+	if err != nil {
+		return nil, err
+	}
+	hinv, err := invert(crossprod(fit.X, f))
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+
+	p := fit.P
+	psi := make([][]float64, fit.N)
+	resid := fit.ResidualValues()
+	for i, row := range fit.X {
+		s := fit.Tau
+		if resid[i] < 0 {
+			s = fit.Tau - 1
+		}
+		psi[i] = make([]float64, p)
+		for j, v := range row {
+			psi[i][j] = v * s
+		}
+	}
+	omega := make([][]float64, p)
+	for a := range omega {
+		omega[a] = make([]float64, p)
+	}
+	g := make([]float64, p)
+	size := 0
+	for i := range psi {
+		for a := range g {
+			g[a] = 0
+		}
+		for j := range psi {
+			if i != j && w[i][j] == 0 && w[j][i] == 0 {
+				continue
+			}
+			size++
+			for a, v := range psi[j] {
+				g[a] += v
+			}
+		}
+		for a := 0; a < p; a++ {
+			for b := 0; b < p; b++ {
+				omega[a][b] += g[a] * g[b]
+			}
+		}
+	}
+	return sandwich(hinv, scaleMatrix(omega, float64(fit.N)/float64(size))), nil
+}
@@ -0,0 +1,80 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+// ringWeights returns row-standardized weights linking each of n sites on a ring
+// to its k nearest neighbours on either side
+func ringWeights(n, k int) [][]float64 {
+	w := make([][]float64, n)
+	for i := range w {
+		w[i] = make([]float64, n)
+		for d := 1; d <= k; d++ {
+			w[i][(i+d)%n] = 1 / float64(2*k)
+			w[i][(i-d+n)%n] = 1 / float64(2*k)
+		}
+	}
+	return w
+}
+
+func TestRQSpatialLag(t *testing.T) {
+	n := 60
+	w := ringWeights(n, 2)
+	x := make([][]float64, n)
+	e := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xi := math.Cos(float64(5*i)) + float64(i%7)/7
+		x[i] = []float64{1, xi}
+		e[i] = 1 + 2*xi + 0.2*math.Sin(float64(11*i))
+	}
+
+	// y = (I - rho W)^-1 (x'b + e)
+	rho := 0.4
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+		for j := range a[i] {
+			a[i][j] = -rho * w[i][j]
+		}
+		a[i][i] = 1
+	}
+	ainv, err := invert(a)
+	if err != nil {
+		t.Fatalf("Failed to invert spatial filter: %v", err)
+	}
+	y := matVec(ainv, e)
+
+	var rhos []float64
+	for k := 0; k <= 8; k++ {
+		rhos = append(rhos, float64(k)/10)
+	}
+	fit, err := RQSpatialLag(y, x, w, 0.5, SpatialOptions{Rhos: rhos})
+	if err != nil {
+		t.Fatalf("Failed to fit spatial lag model: %v", err)
+	}
+	if math.Abs(fit.Rho-rho) > 0.25 {
+		t.Errorf("Expected rho near %v, got %v", rho, fit.Rho)
+	}
+	if fit.RhoLower > fit.Rho || fit.RhoUpper < fit.Rho {
+		t.Errorf("Rho %v outside interval [%v, %v]", fit.Rho, fit.RhoLower, fit.RhoUpper)
+	}
+	if math.Abs(fit.Coefficients[1]-2) > 0.5 {
+		t.Errorf("Expected slope near 2, got %v", fit.Coefficients[1])
+	}
+
+	cov, err := fit.VcovSpatial(w)
+	if err != nil {
+		t.Fatalf("Failed to compute spatial covariance: %v", err)
+	}
+	for j := range cov {
+		if cov[j][j] <= 0 {
+			t.Errorf("Expected positive variance for coefficient %d, got %v", j, cov[j][j])
+		}
+	}
+
+	if _, err := RQSpatialLag(y, x, w[:10], 0.5, SpatialOptions{Rhos: rhos}); err == nil {
+		t.Error("Expected error for mismatched weights")
+	}
+}
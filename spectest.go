@@ -0,0 +1,142 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SpecificationTest reports a test of correct linear specification at one tau
+type SpecificationTest struct {
+	Tau       float64 // Quantile level
+	KS        float64 // Kolmogorov-Smirnov type statistic max_j sup |R_j(x)|
+	CvM       float64 // Cramer-von Mises type statistic sum_j mean R_j(x)^2
+	PValueKS  float64 // Simulated p-value of KS
+	PValueCvM float64 // Simulated p-value of CvM
+	NSim      int     // Number of simulated null processes
+}
+
+// SpecificationTest tests the null that the linear conditional quantile model is
+// correctly specified at fit.Tau. It is based on the marked empirical processes
+// R_j(x) = n^-1/2 sum_i e_i I(x_ij <= x) of the generalized residuals
+// psi(r_i) = tau - I(r_i < 0), where e are the generalized residuals with their
+// projection on the design removed to account for estimating the coefficients.
+// There is one process for every non-constant column j, evaluated at that
+// column's observed values. Under the null the signs I(r_i < 0) are
+// Bernoulli(tau), which is used to simulate p-values from nsim draws.
+func (fit *RQFit) SpecificationTest(nsim int, rng *rand.Rand) (*SpecificationTest, error) {
+	if len(fit.X) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	if nsim <= 0 {
+		return nil, fmt.Errorf("number of simulations must be positive")
+	}
+
+	xxinv, err := invert(crossprod(fit.X, nil))
+	if err != nil {
+		return nil, fmt.Errorf("design matrix is singular: %w", err)
+	}
+
+	// Columns that vary define the indicator ordering
+	var cols []int
+	for j := 0; j < fit.P; j++ {
+		for i := 1; i < fit.N; i++ {
+			if fit.X[i][j] != fit.X[0][j] {
+				cols = append(cols, j)
+				break
+			}
+		}
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("design has no varying covariates")
+	}
+
+	// orders[c] lists the observations by increasing value of column cols[c]
+	orders := make([][]int, len(cols))
+	for c, j := range cols {
+		idx := make([]int, fit.N)
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(a, b int) bool { return fit.X[idx[a]][j] < fit.X[idx[b]][j] })
+		orders[c] = idx
+	}
+
+	psi := make([]float64, fit.N)
+	for i, r := range fit.ResidualValues() {
+		psi[i] = fit.Tau
+		if r < 0 {
+			psi[i] = fit.Tau - 1
+		}
+	}
+	ks, cvm := fit.markedProcess(psi, xxinv, cols, orders)
+
+	rng = randOrDefault(rng)
+	exceedKS, exceedCvM := 0, 0
+	for s := 0; s < nsim; s++ {
+		for i := range psi {
+			psi[i] = fit.Tau
+			if rng.Float64() < fit.Tau {
+				psi[i] = fit.Tau - 1
+			}
+		}
+		simKS, simCvM := fit.markedProcess(psi, xxinv, cols, orders)
+		if simKS >= ks {
+			exceedKS++
+		}
+		if simCvM >= cvm {
+			exceedCvM++
+		}
+	}
+
+	return &SpecificationTest{
+		Tau:       fit.Tau,
+		KS:        ks,
+		CvM:       cvm,
+		PValueKS:  float64(exceedKS+1) / float64(nsim+1),
+		PValueCvM: float64(exceedCvM+1) / float64(nsim+1),
+		NSim:      nsim,
+	}, nil
+}
+
+// markedProcess projects psi off the design and returns the KS and CvM statistics
+// of the resulting marked empirical processes, accumulating each along its
+// column's sort order
+func (fit *RQFit) markedProcess(psi []float64, xxinv [][]float64, cols []int, orders [][]int) (float64, float64) {
+	// e = psi - X (X'X)^-1 X' psi
+	xtpsi := make([]float64, fit.P)
+	for i, row := range fit.X {
+		for j, v := range row {
+			xtpsi[j] += v * psi[i]
+		}
+	}
+	coef := matVec(xxinv, xtpsi)
+
+	e := make([]float64, fit.N)
+	for i, row := range fit.X {
+		e[i] = psi[i]
+		for j, v := range row {
+			e[i] -= v * coef[j]
+		}
+	}
+
+	scale := 1 / math.Sqrt(float64(fit.N))
+	ks, cvm := 0.0, 0.0
+	for c, j := range cols {
+		order := orders[c]
+		r := 0.0
+		for a := 0; a < len(order); {
+			// Tied values share the process value after the whole tie group
+			b := a
+			for b < len(order) && fit.X[order[b]][j] == fit.X[order[a]][j] {
+				r += e[order[b]] * scale
+				b++
+			}
+			ks = math.Max(ks, math.Abs(r))
+			cvm += float64(b-a) * r * r
+			a = b
+		}
+	}
+	return ks, cvm / float64(fit.N)
+}
@@ -0,0 +1,94 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSpecificationTest(t *testing.T) {
+	// A linear fit to a strongly quadratic relationship is misspecified
+	n := 24
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xi := -2 + 4*float64(i)/float64(n-1)
+		x[i] = []float64{1, xi}
+		y[i] = 3*xi*xi + 0.1*float64(i%2)
+	}
+
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	res, err := fit.SpecificationTest(199, rand.New(rand.NewSource(3)))
+	if err != nil {
+		t.Fatalf("Failed to run specification test: %v", err)
+	}
+
+	if res.KS <= 0 || res.CvM <= 0 {
+		t.Errorf("Expected positive statistics, got KS=%f CvM=%f", res.KS, res.CvM)
+	}
+	if res.PValueKS > 0.05 || res.PValueCvM > 0.05 {
+		t.Errorf("Expected rejection of the linear model, got p-values %f and %f", res.PValueKS, res.PValueCvM)
+	}
+
+	// Identical seeds give identical p-values
+	again, _ := fit.SpecificationTest(199, rand.New(rand.NewSource(3)))
+	if again.PValueKS != res.PValueKS || again.PValueCvM != res.PValueCvM {
+		t.Error("Expected reproducible p-values for identical seeds")
+	}
+
+	// The one-pass processes match direct evaluation at every design point,
+	// including tied covariate values
+	tied := &RQFit{N: 8, P: 3}
+	rng := rand.New(rand.NewSource(4))
+	psi := make([]float64, tied.N)
+	for i := 0; i < tied.N; i++ {
+		tied.X = append(tied.X, []float64{1, float64(i % 3), float64(i*i%5) / 2})
+		psi[i] = rng.NormFloat64()
+	}
+	xxinv, err := invert(crossprod(tied.X, nil))
+	if err != nil {
+		t.Fatalf("Failed to invert design: %v", err)
+	}
+	coef := matVec(xxinv, matVec(transpose(tied.X), psi))
+	e := make([]float64, tied.N)
+	for i, row := range tied.X {
+		e[i] = psi[i] - dot(row, coef)
+	}
+	cols := []int{1, 2}
+	orders := make([][]int, len(cols))
+	wantKS, wantCvM := 0.0, 0.0
+	for c, j := range cols {
+		for i := range e {
+			orders[c] = append(orders[c], i)
+		}
+		sort.Slice(orders[c], func(a, b int) bool { return tied.X[orders[c][a]][j] < tied.X[orders[c][b]][j] })
+		for _, row := range tied.X {
+			r := 0.0
+			for i, other := range tied.X {
+				if other[j] <= row[j] {
+					r += e[i] / math.Sqrt(float64(tied.N))
+				}
+			}
+			wantKS = math.Max(wantKS, math.Abs(r))
+			wantCvM += r * r / float64(tied.N)
+		}
+	}
+	gotKS, gotCvM := tied.markedProcess(psi, xxinv, cols, orders)
+	if math.Abs(gotKS-wantKS) > 1e-12 || math.Abs(gotCvM-wantCvM) > 1e-12 {
+		t.Errorf("Expected KS=%f CvM=%f, got KS=%f CvM=%f", wantKS, wantCvM, gotKS, gotCvM)
+	}
+
+	// Error cases
+	if _, err := fit.SpecificationTest(0, nil); err == nil {
+		t.Error("Expected error for non-positive number of simulations")
+	}
+	constant := &RQFit{X: [][]float64{{1}, {1}}, N: 2, P: 1, Residuals: []float64{1, -1}}
+	if _, err := constant.SpecificationTest(10, nil); err == nil {
+		t.Error("Expected error for design without varying covariates")
+	}
+}
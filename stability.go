@@ -0,0 +1,166 @@
+package quantreg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// TermStability tracks the conclusions about one coefficient across taus
+type TermStability struct {
+	Term                int       `json:"term"`
+	Estimates           []float64 `json:"estimates"`
+	PValues             []float64 `json:"p_values"`
+	Signs               []int     `json:"signs"`       // Sign of the estimate at each tau
+	Significant         []bool    `json:"significant"` // Whether the estimate is significant at each tau
+	Ranks               []int     `json:"ranks"`       // Rank of the absolute estimate among the terms at each tau, 1 for the largest
+	SignChanges         int       `json:"sign_changes"`
+	SignificanceChanges int       `json:"significance_changes"`
+	RankChanges         int       `json:"rank_changes"`
+}
+
+// StabilityReport summarizes how signs, significance and the magnitude ordering of
+// coefficients change between adjacent taus. Changes count adjacent pairs of taus
+// that disagree.
+type StabilityReport struct {
+	Taus            []float64       `json:"taus"`
+	Alpha           float64         `json:"alpha"`
+	SE              string          `json:"se"`
+	Terms           []TermStability `json:"terms"`
+	OrderingChanges int             `json:"ordering_changes"` // Adjacent taus at which the magnitude ordering of the terms differs
+}
+
+// TauStability fits the quantile process over taus, by default the dense grid
+// 0.05, 0.10, ..., 0.95, and reports its stability for every varying column of x
+func TauStability(y []float64, x [][]float64, taus []float64, alpha float64, se string) (*StabilityReport, error) {
+	if taus == nil {
+		for k := 1; k < 20; k++ {
+			taus = append(taus, float64(k)/20)
+		}
+	}
+	m, err := RQProcess(y, x, taus)
+	if err != nil {
+		return nil, err
+	}
+	return m.Stability(alpha, se, nil)
+}
+
+// Stability reports how the conclusions about terms, by default every varying
+// column of the design, change over the fitted taus, testing each coefficient
+// against zero at level alpha with the covariance estimator se
+func (m *MultiRQFit) Stability(alpha float64, se string, terms []int) (*StabilityReport, error) {
+	if len(m.Taus) < 2 {
+		return nil, fmt.Errorf("need at least 2 quantile levels, got %d", len(m.Taus))
+	}
+	if alpha <= 0 || alpha >= 1 {
+		return nil, fmt.Errorf("significance level must be between 0 and 1")
+	}
+	if terms == nil {
+		x := m.Fits[m.Taus[0]].X
+		if len(x) == 0 {
+			return nil, fmt.Errorf("fit does not carry its design matrix")
+		}
+		for j, varies := range penalizedColumns(x) {
+			if varies {
+				terms = append(terms, j)
+			}
+		}
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("no terms to report")
+	}
+	for _, j := range terms {
+		if j < 0 || j >= m.P {
+			return nil, fmt.Errorf("coefficient index %d out of range [0, %d)", j, m.P)
+		}
+	}
+
+	r := &StabilityReport{Taus: m.Taus, Alpha: alpha, SE: se, Terms: make([]TermStability, len(terms))}
+	for a, j := range terms {
+		r.Terms[a].Term = j
+	}
+	z := normQuantile(1 - alpha/2)
+	order := make([]int, len(terms))
+	var prevOrder []int
+	for _, tau := range m.Taus {
+		fit := m.Fits[tau]
+		stdErr, err := fit.StdErrors(se)
+		if err != nil {
+			return nil, fmt.Errorf("standard errors failed for tau=%f: %w", tau, err)
+		}
+		for a, j := range terms {
+			t := &r.Terms[a]
+			b := fit.Coefficients[j]
+			stat := math.Abs(b / stdErr[j])
+			if math.IsNaN(stat) {
+				stat = 0
+			}
+			t.Estimates = append(t.Estimates, b)
+			t.PValues = append(t.PValues, 2*(1-normCDF(stat)))
+			t.Signs = append(t.Signs, sign(b))
+			t.Significant = append(t.Significant, stat > z)
+			order[a] = a
+		}
+		sort.SliceStable(order, func(u, v int) bool {
+			return math.Abs(fit.Coefficients[terms[order[u]]]) > math.Abs(fit.Coefficients[terms[order[v]]])
+		})
+		for rank, a := range order {
+			r.Terms[a].Ranks = append(r.Terms[a].Ranks, rank+1)
+		}
+		if prevOrder != nil {
+			for k := range order {
+				if order[k] != prevOrder[k] {
+					r.OrderingChanges++
+					break
+				}
+			}
+		}
+		prevOrder = append(prevOrder[:0], order...)
+	}
+
+	for a := range r.Terms {
+		t := &r.Terms[a]
+		for k := 1; k < len(m.Taus); k++ {
+			if t.Signs[k] != t.Signs[k-1] {
+				t.SignChanges++
+			}
+			if t.Significant[k] != t.Significant[k-1] {
+				t.SignificanceChanges++
+			}
+			if t.Ranks[k] != t.Ranks[k-1] {
+				t.RankChanges++
+			}
+		}
+	}
+	return r, nil
+}
+
+// Stable reports whether no term changes sign, significance or rank over the taus
+func (r *StabilityReport) Stable() bool {
+	for _, t := range r.Terms {
+		if t.SignChanges > 0 || t.SignificanceChanges > 0 || t.RankChanges > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteJSON writes the report to w as indented JSON
+func (r *StabilityReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// sign returns -1, 0 or 1 according to the sign of v
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+package quantreg
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestStability(t *testing.T) {
+	y, x := inferenceData()
+	x3 := make([][]float64, len(x))
+	for i, row := range x {
+		x3[i] = []float64{row[0], row[1], float64(i % 3)}
+	}
+	// The slope of column 1 flips sign and overtakes column 2 between the taus
+	coefs := map[float64][]float64{
+		0.25: {1, -0.2, 0.5},
+		0.5:  {1, 0.3, 0.5},
+		0.75: {1, 0.9, 0.5},
+	}
+	m := &MultiRQFit{Fits: make(map[float64]*RQFit), Taus: []float64{0.25, 0.5, 0.75}, N: len(y), P: 3}
+	for tau, c := range coefs {
+		m.Fits[tau] = &RQFit{Coefficients: c, Tau: tau, N: len(y), P: 3, X: x3, Y: y}
+	}
+
+	r, err := m.Stability(0.05, SEKer, nil)
+	if err != nil {
+		t.Fatalf("Failed to compute stability: %v", err)
+	}
+	if len(r.Terms) != 2 || r.Terms[0].Term != 1 || r.Terms[1].Term != 2 {
+		t.Fatalf("Expected the two varying columns, got %+v", r.Terms)
+	}
+	slope := r.Terms[0]
+	if slope.SignChanges != 1 || slope.Signs[0] != -1 || slope.Signs[2] != 1 {
+		t.Errorf("Expected one sign change, got %+v", slope)
+	}
+	if slope.Ranks[0] != 2 || slope.Ranks[2] != 1 || slope.RankChanges != 1 || r.OrderingChanges != 1 {
+		t.Errorf("Expected the slope to overtake column 2 once, got ranks %v", slope.Ranks)
+	}
+	if r.Stable() {
+		t.Error("Expected an unstable report")
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+	var back StabilityReport
+	if err := json.Unmarshal(buf.Bytes(), &back); err != nil {
+		t.Fatalf("Failed to read JSON: %v", err)
+	}
+	if back.Terms[0].SignChanges != 1 || len(back.Taus) != 3 {
+		t.Errorf("Expected the report to round-trip, got %+v", back)
+	}
+
+	dense, err := TauStability(y, x, nil, 0.05, SEKer)
+	if err != nil {
+		t.Fatalf("Failed to run tau stability: %v", err)
+	}
+	if len(dense.Taus) != 19 || len(dense.Terms[0].Estimates) != 19 {
+		t.Errorf("Expected the default grid of 19 taus, got %d", len(dense.Taus))
+	}
+
+	if _, err := m.Stability(0.05, SEKer, []int{3}); err == nil {
+		t.Error("Expected error for out-of-range term")
+	}
+}
@@ -0,0 +1,148 @@
+package quantreg
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// StackedFit combines candidate quantile regressions with per-tau convex weights
+// learned from out-of-fold predictions
+type StackedFit struct {
+	Candidates []Candidate
+	Bases      []*MultiRQFit         // Candidates refitted on all data
+	Taus       []float64             // Sorted quantile levels
+	Weights    map[float64][]float64 // Candidate weights per tau, non-negative and summing to one
+	OOFLoss    map[float64]float64   // Mean out-of-fold pinball loss of the stacked prediction per tau
+	Meta       Meta                  // Reproducibility metadata; P counts the candidates
+}
+
+// Stack fits a stacked quantile regression. Every candidate is cross-validated
+// with folds folds to obtain out-of-fold predictions, and for each tau the weights
+// w minimize sum_i rho_tau(y_i - sum_j w_j q_ij) over the simplex, a quantile
+// regression without intercept under the constraints w >= 0 and 1'w = 1 solved
+// exactly as a linear program. The candidates are then refitted on all data.
+func Stack(y []float64, data [][]float64, candidates []Candidate, taus []float64, folds int, rng *rand.Rand) (*StackedFit, error) {
+	start := time.Now()
+	n := len(y)
+	if len(data) != n {
+		return nil, fmt.Errorf("%w: y has %d rows, data has %d rows", ErrDimensionMismatch, n, len(data))
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no candidate models")
+	}
+	if len(taus) == 0 {
+		return nil, fmt.Errorf("no quantile levels specified")
+	}
+	if folds < 2 || folds > n {
+		return nil, fmt.Errorf("number of folds must be between 2 and %d, got %d", n, folds)
+	}
+	sorted := append([]float64(nil), taus...)
+	sort.Float64s(sorted)
+
+	fold := foldAssignment(n, folds, rng)
+	m := len(candidates)
+	// oof[tau][i][j] is the out-of-fold prediction of candidate j for observation i
+	oof := make(map[float64][][]float64, len(sorted))
+	for _, tau := range sorted {
+		oof[tau] = make([][]float64, n)
+		for i := range oof[tau] {
+			oof[tau][i] = make([]float64, m)
+		}
+	}
+
+	s := &StackedFit{
+		Candidates: candidates,
+		Taus:       sorted,
+		Weights:    make(map[float64][]float64, len(sorted)),
+		OOFLoss:    make(map[float64]float64, len(sorted)),
+	}
+	for j, cand := range candidates {
+		x, err := cand.Build(data)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: failed to build design: %w", cand.Name, err)
+		}
+		if len(x) != n {
+			return nil, fmt.Errorf("candidate %q: design has %d rows, want %d", cand.Name, len(x), n)
+		}
+		pred, err := outOfFold(y, x, fold, folds, func(trainY []float64, trainX, testX [][]float64) (map[float64][]float64, error) {
+			fits, err := RQProcess(trainY, trainX, sorted)
+			if err != nil {
+				return nil, fmt.Errorf("fit failed: %w", err)
+			}
+			return fits.Predict(testX)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: %w", cand.Name, err)
+		}
+		for _, tau := range sorted {
+			for i, q := range pred[tau] {
+				oof[tau][i][j] = q
+			}
+		}
+		base, err := RQProcess(y, x, sorted)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: fit failed: %w", cand.Name, err)
+		}
+		s.Bases = append(s.Bases, base)
+	}
+
+	for _, tau := range sorted {
+		w, err := simplexWeights(y, oof[tau], tau)
+		if err != nil {
+			return nil, fmt.Errorf("stacking weights failed for tau=%f: %w", tau, err)
+		}
+		s.Weights[tau] = w
+		for i, row := range oof[tau] {
+			s.OOFLoss[tau] += rho(y[i]-dot(row, w), tau) / float64(n)
+		}
+	}
+	s.Meta = newMeta("stack", map[string]float64{"folds": float64(folds)}, sorted, n, m, start, y, data)
+	return s, nil
+}
+
+// Predict builds each candidate design from the raw rows and returns the
+// weighted quantile predictions, rearranged so that they do not cross
+func (s *StackedFit) Predict(data [][]float64) (map[float64][]float64, error) {
+	out := make(map[float64][]float64, len(s.Taus))
+	for _, tau := range s.Taus {
+		out[tau] = make([]float64, len(data))
+	}
+	for j, cand := range s.Candidates {
+		x, err := cand.Build(data)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: failed to build design: %w", cand.Name, err)
+		}
+		pred, err := s.Bases[j].Predict(x)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %q: %w", cand.Name, err)
+		}
+		for _, tau := range s.Taus {
+			w := s.Weights[tau][j]
+			for i, v := range pred[tau] {
+				out[tau][i] += w * v
+			}
+		}
+	}
+	rearrangeRows(out, s.Taus, len(data))
+	return out, nil
+}
+
+// simplexWeights returns the weights w >= 0 with 1'w = 1 that minimize
+// sum_i rho_tau(y_i - q_i'w) for the model predictions q_i, a quantile
+// regression without intercept solved exactly under those constraints
+func simplexWeights(y []float64, q [][]float64, tau float64) ([]float64, error) {
+	m := len(q[0])
+	cons := Constraints{A: [][]float64{make([]float64, m)}, B: []float64{1}, C: make([][]float64, m), D: make([]float64, m)}
+	for j := 0; j < m; j++ {
+		cons.A[0][j] = 1
+		cons.C[j] = make([]float64, m)
+		cons.C[j][j] = -1
+	}
+	fit, err := RQConstrained(y, q, tau, cons)
+	if err != nil {
+		return nil, err
+	}
+	return fit.Coefficients, nil
+}
@@ -0,0 +1,71 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	n := 40
+	data := make([][]float64, n)
+	y := make([]float64, n)
+	for i := range data {
+		d := float64(i) / 4
+		data[i] = []float64{d}
+		y[i] = 2 + d + 0.5*math.Sin(float64(5*i))
+	}
+	candidates := []Candidate{
+		{Name: "intercept", Build: func(d [][]float64) ([][]float64, error) {
+			x := make([][]float64, len(d))
+			for i := range d {
+				x[i] = []float64{1}
+			}
+			return x, nil
+		}},
+		{Name: "linear", Build: func(d [][]float64) ([][]float64, error) {
+			x := make([][]float64, len(d))
+			for i := range d {
+				x[i] = []float64{1, d[i][0]}
+			}
+			return x, nil
+		}},
+	}
+
+	s, err := Stack(y, data, candidates, []float64{0.75, 0.25}, 4, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to stack: %v", err)
+	}
+	if len(s.Bases) != 2 || s.Taus[0] != 0.25 {
+		t.Fatalf("Unexpected stacked fit %+v", s)
+	}
+	if s.Meta.Solver != "stack" || s.Meta.N != n || s.Meta.P != 2 || len(s.Meta.Taus) != 2 {
+		t.Errorf("Unexpected metadata %+v", s.Meta)
+	}
+	for _, tau := range s.Taus {
+		w := s.Weights[tau]
+		if math.Abs(w[0]+w[1]-1) > 1e-8 || w[0] < -1e-10 || w[1] < -1e-10 {
+			t.Errorf("Expected convex weights at tau=%f, got %v", tau, w)
+		}
+		if w[1] < 0.5 {
+			t.Errorf("Expected the linear candidate to dominate at tau=%f, got %v", tau, w)
+		}
+		if s.OOFLoss[tau] <= 0 {
+			t.Errorf("Expected positive out-of-fold loss at tau=%f", tau)
+		}
+	}
+
+	pred, err := s.Predict(data[:5])
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	for i := range pred[0.25] {
+		if pred[0.25][i] > pred[0.75][i] {
+			t.Errorf("Expected non-crossing predictions at row %d", i)
+		}
+	}
+
+	if _, err := Stack(y, data, nil, []float64{0.5}, 4, nil); err == nil {
+		t.Error("Expected error for no candidates")
+	}
+}
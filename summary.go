@@ -0,0 +1,185 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CoefRow describes one coefficient at one quantile level. Inference fields are
+// NaN when the covariance cannot be estimated.
+type CoefRow struct {
+	Term      string
+	Tau       float64
+	Estimate  float64
+	StdErr    float64
+	Statistic float64 // Estimate / StdErr
+	PValue    float64 // Two-sided normal p-value of Statistic
+	ConfLow   float64 // Lower 95% Wald bound
+	ConfHigh  float64 // Upper 95% Wald bound
+}
+
+// ResidualSummary holds the range and median of the residuals
+type ResidualSummary struct {
+	Min    float64
+	Median float64
+	Max    float64
+}
+
+// SummaryTable is the structured content of Summary. Goodness-of-fit fields are
+// NaN when unavailable, e.g. for compacted fits.
+type SummaryTable struct {
+	Title        string
+	Tau          float64
+	N            int
+	P            int
+	Method       string
+	Formula      string
+	Coefficients []CoefRow
+	Residuals    *ResidualSummary // Nil when the fit carries no residuals
+	R1           float64
+	AIC          float64
+	BIC          float64
+}
+
+// MultiSummaryTable is the structured content of MultiRQFit.Summary
+type MultiSummaryTable struct {
+	Title  string
+	N      int
+	P      int
+	Taus   []float64
+	Tables []*SummaryTable // One per tau, in tau order
+}
+
+// coefRows builds the coefficient rows from the estimates and their standard
+// errors, which may be nil
+func coefRows(tau float64, coef, se []float64) []CoefRow {
+	z := normQuantile(0.975)
+	rows := make([]CoefRow, len(coef))
+	for j, b := range coef {
+		row := CoefRow{Term: fmt.Sprintf("Beta[%d]", j), Tau: tau, Estimate: b, StdErr: math.NaN()}
+		if se != nil {
+			row.StdErr = se[j]
+		}
+		row.Statistic = b / row.StdErr
+		row.PValue = 2 * (1 - normCDF(math.Abs(row.Statistic)))
+		row.ConfLow = b - z*row.StdErr
+		row.ConfHigh = b + z*row.StdErr
+		rows[j] = row
+	}
+	return rows
+}
+
+// summarizeResiduals returns the residual summary, or nil for no residuals
+func summarizeResiduals(resid []float64) *ResidualSummary {
+	if len(resid) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), resid...)
+	sort.Float64s(sorted)
+	return &ResidualSummary{Min: sorted[0], Median: sorted[len(sorted)/2], Max: sorted[len(sorted)-1]}
+}
+
+// SummaryTable returns the summary of the fit with Powell kernel standard errors
+func (fit *RQFit) SummaryTable() *SummaryTable {
+	se, _ := fit.StdErrors(SEKer)
+	return fit.summaryTable("Quantile Regression", se)
+}
+
+// summaryTable returns the summary of the fit with the given standard errors,
+// which may be nil
+func (fit *RQFit) summaryTable(title string, se []float64) *SummaryTable {
+	t := &SummaryTable{
+		Title:        title,
+		Tau:          fit.Tau,
+		N:            fit.N,
+		P:            fit.P,
+		Method:       fit.Method,
+		Formula:      fit.Formula,
+		Coefficients: coefRows(fit.Tau, fit.Coefficients, se),
+		Residuals:    summarizeResiduals(fit.ResidualValues()),
+		R1:           math.NaN(),
+		AIC:          math.NaN(),
+		BIC:          math.NaN(),
+	}
+	if t.Residuals != nil {
+		if r1, err := fit.R1(); err == nil {
+			t.R1 = r1
+		}
+		t.AIC, t.BIC = fit.AIC(), fit.BIC()
+	}
+	return t
+}
+
+// SummaryTable returns the summary of the fit with sandwich standard errors
+func (fit *NLRQFit) SummaryTable() *SummaryTable {
+	se, _ := fit.StdErrors()
+	return &SummaryTable{
+		Title:        "Non-linear Quantile Regression",
+		Tau:          fit.Tau,
+		N:            fit.N,
+		P:            fit.P,
+		Method:       "nlrq",
+		Formula:      fit.Formula,
+		Coefficients: coefRows(fit.Tau, fit.Coefficients, se),
+		Residuals:    summarizeResiduals(fit.Residuals),
+		R1:           math.NaN(),
+		AIC:          math.NaN(),
+		BIC:          math.NaN(),
+	}
+}
+
+// SummaryTable returns the summaries of the fits at every tau
+func (m *MultiRQFit) SummaryTable() *MultiSummaryTable {
+	t := &MultiSummaryTable{Title: "Multiple Quantile Regression", N: m.N, P: m.P, Taus: m.Taus}
+	for _, tau := range m.Taus {
+		t.Tables = append(t.Tables, m.Fits[tau].SummaryTable())
+	}
+	return t
+}
+
+// String renders the table in the format of Summary
+func (t *SummaryTable) String() string {
+	result := fmt.Sprintf("%s (tau = %.2f)\n", t.Title, t.Tau)
+	result += fmt.Sprintf("Number of observations: %d\n", t.N)
+	result += fmt.Sprintf("Number of parameters: %d\n\n", t.P)
+
+	result += "Coefficients:\n"
+	for _, row := range t.Coefficients {
+		result += fmt.Sprintf("  %s: %.6f\n", row.Term, row.Estimate)
+	}
+	if t.Residuals == nil {
+		return result
+	}
+
+	result += "\nResidual summary:\n"
+	result += fmt.Sprintf("  Min: %.6f\n", t.Residuals.Min)
+	result += fmt.Sprintf("  Max: %.6f\n", t.Residuals.Max)
+	result += fmt.Sprintf("  Median: %.6f\n", t.Residuals.Median)
+
+	if !math.IsNaN(t.R1) {
+		result += fmt.Sprintf("\nGoodness of fit R1: %.6f\n", t.R1)
+	}
+	if !math.IsNaN(t.AIC) {
+		result += fmt.Sprintf("AIC: %.4f, BIC: %.4f\n", t.AIC, t.BIC)
+	}
+	return result
+}
+
+// String renders the tables in the format of MultiRQFit.Summary
+func (t *MultiSummaryTable) String() string {
+	result := fmt.Sprintf("%s\n", t.Title)
+	result += fmt.Sprintf("Number of observations: %d\n", t.N)
+	result += fmt.Sprintf("Number of parameters: %d\n", t.P)
+	result += fmt.Sprintf("Quantile levels: %v\n\n", t.Taus)
+
+	for _, table := range t.Tables {
+		result += fmt.Sprintf("=== Quantile %f ===\n", table.Tau)
+		result += "Coefficients:\n"
+		for _, row := range table.Coefficients {
+			result += fmt.Sprintf("  %s: %.6f\n", row.Term, row.Estimate)
+		}
+		result += "\n"
+	}
+	return result
+}
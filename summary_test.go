@@ -0,0 +1,61 @@
+package quantreg
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSummaryTable(t *testing.T) {
+	y, x := inferenceData()
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	table := fit.SummaryTable()
+	if table.Tau != 0.5 || table.N != 20 || table.P != 2 || len(table.Coefficients) != 2 {
+		t.Fatalf("Unexpected table header: %+v", table)
+	}
+	se, err := fit.StdErrors(SEKer)
+	if err != nil {
+		t.Fatalf("Failed to compute standard errors: %v", err)
+	}
+	for j, row := range table.Coefficients {
+		if row.Estimate != fit.Coefficients[j] || math.Abs(row.StdErr-se[j]) > 1e-12 {
+			t.Errorf("Unexpected row %d: %+v", j, row)
+		}
+		if row.ConfLow >= row.Estimate || row.ConfHigh <= row.Estimate || row.PValue < 0 || row.PValue > 1 {
+			t.Errorf("Unexpected inference in row %d: %+v", j, row)
+		}
+	}
+	if table.Residuals == nil || table.Residuals.Min > table.Residuals.Median || table.Residuals.Median > table.Residuals.Max {
+		t.Errorf("Unexpected residual summary: %+v", table.Residuals)
+	}
+	if table.AIC != fit.AIC() {
+		t.Errorf("Expected AIC %f, got %f", fit.AIC(), table.AIC)
+	}
+	if fit.Summary() != table.String() {
+		t.Error("Expected Summary to render the table")
+	}
+
+	fit.Compact()
+	compact := fit.SummaryTable()
+	if compact.Residuals != nil || !math.IsNaN(compact.AIC) || !math.IsNaN(compact.Coefficients[0].StdErr) {
+		t.Errorf("Expected no residuals or inference for a compact fit, got %+v", compact)
+	}
+	if strings.Contains(fit.Summary(), "Residual summary") {
+		t.Error("Expected no residual summary for a compact fit")
+	}
+
+	multi, err := RQProcess(y, x, []float64{0.25, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	mt := multi.SummaryTable()
+	if len(mt.Tables) != 2 || mt.Tables[1].Tau != 0.75 {
+		t.Errorf("Expected tables for 2 taus, got %d", len(mt.Tables))
+	}
+	if !strings.Contains(multi.Summary(), "=== Quantile 0.750000 ===") {
+		t.Errorf("Expected a section per tau, got:\n%s", multi.Summary())
+	}
+}
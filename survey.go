@@ -0,0 +1,179 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// SurveyDesign describes a complex sample for design-based inference
+type SurveyDesign struct {
+	Weights        []float64   // Sampling weights
+	Strata         []int       // Stratum of each observation; nil for a single stratum
+	PSU            []int       // Primary sampling unit within stratum; nil treats observations as PSUs
+	Replicates     [][]float64 // Optional replicate weights, one slice of length n per replicate
+	ReplicateScale float64     // Multiplier of the replicate variance, e.g. (R-1)/R for JK1 (default 1/R)
+}
+
+// SurveyFit is a weighted quantile regression with its survey design
+type SurveyFit struct {
+	*RQFit
+	Design SurveyDesign
+}
+
+// RQSurvey fits a sampling-weighted quantile regression
+func RQSurvey(y []float64, x [][]float64, design SurveyDesign, tau float64) (*SurveyFit, error) {
+	n := len(y)
+	if design.Strata != nil && len(design.Strata) != n {
+		return nil, fmt.Errorf("%w: %d strata for %d observations", ErrDimensionMismatch, len(design.Strata), n)
+	}
+	if design.PSU != nil && len(design.PSU) != n {
+		return nil, fmt.Errorf("%w: %d PSU ids for %d observations", ErrDimensionMismatch, len(design.PSU), n)
+	}
+	for r, w := range design.Replicates {
+		if len(w) != n {
+			return nil, fmt.Errorf("replicate %d has %d weights, want %d", r, len(w), n)
+		}
+	}
+	fit, err := RQWeighted(y, x, design.Weights, tau)
+	if err != nil {
+		return nil, err
+	}
+	return &SurveyFit{RQFit: fit, Design: design}, nil
+}
+
+// Vcov returns the design-based covariance of the coefficients. With replicate
+// weights it refits the model for each replicate and returns
+// scale * sum_r (b_r - b)(b_r - b)'. Otherwise it returns the Taylor-linearized
+// sandwich H^-1 Omega H^-1 with H = X' diag(w f) X and Omega the between-PSU
+// variance of the weighted score totals within strata, with the n_h/(n_h-1)
+// correction for n_h PSUs in stratum h.
+func (s *SurveyFit) Vcov() ([][]float64, error) {
+	if len(s.Design.Replicates) > 0 {
+		return s.vcovReplicate()
+	}
+	return s.vcovLinearized()
+}
+
+// StdErrors returns design-based coefficient standard errors
+func (s *SurveyFit) StdErrors() ([]float64, error) {
+	cov, err := s.Vcov()
+	if err != nil {
+		return nil, err
+	}
+	se := make([]float64, s.P)
+	for j := range se {
+		se[j] = math.Sqrt(math.Max(cov[j][j], 0))
+	}
+	return se, nil
+}
+
+// SummaryTable returns the summary of the fit with design-based standard errors
+func (s *SurveyFit) SummaryTable() *SummaryTable {
+	se, _ := s.StdErrors()
+	return s.summaryTable("Survey-weighted Quantile Regression", se)
+}
+
+// Summary prints a summary of the fit with design-based standard errors
+func (s *SurveyFit) Summary() string {
+	return s.SummaryTable().String()
+}
+
+func (s *SurveyFit) vcovLinearized() ([][]float64, error) {
+	f, err := s.kernelDensities()
+	if err != nil {
+		return nil, err
+	}
+	wf := make([]float64, s.N)
+	for i := range wf {
+		wf[i] = s.Design.Weights[i] * f[i]
+	}
+	hinv, err := invert(crossprod(s.X, wf))
+	if err != nil {
+		return nil, fmt.Errorf("density-weighted design is singular: %w", err)
+	}
+
+	// Weighted score totals per PSU, grouped by stratum
+	p := s.P
+	type psuKey struct{ stratum, psu int }
+	totals := make(map[psuKey][]float64)
+	resid := s.ResidualValues()
+	for i, row := range s.X {
+		key := psuKey{0, i}
+		if s.Design.Strata != nil {
+			key.stratum = s.Design.Strata[i]
+		}
+		if s.Design.PSU != nil {
+			key.psu = s.Design.PSU[i]
+		}
+		sc := s.Tau
+		if resid[i] < 0 {
+			sc = s.Tau - 1
+		}
+		tot, ok := totals[key]
+		if !ok {
+			tot = make([]float64, p)
+			totals[key] = tot
+		}
+		for j, v := range row {
+			tot[j] += s.Design.Weights[i] * v * sc
+		}
+	}
+	strata := make(map[int][][]float64)
+	for key, tot := range totals {
+		strata[key.stratum] = append(strata[key.stratum], tot)
+	}
+
+	omega := make([][]float64, p)
+	for a := range omega {
+		omega[a] = make([]float64, p)
+	}
+	for h, psus := range strata {
+		nh := len(psus)
+		if nh < 2 {
+			return nil, fmt.Errorf("stratum %d has a single PSU", h)
+		}
+		mean := make([]float64, p)
+		for _, tot := range psus {
+			for j := range tot {
+				mean[j] += tot[j] / float64(nh)
+			}
+		}
+		c := float64(nh) / float64(nh-1)
+		for _, tot := range psus {
+			for a := 0; a < p; a++ {
+				for b := 0; b < p; b++ {
+					omega[a][b] += c * (tot[a] - mean[a]) * (tot[b] - mean[b])
+				}
+			}
+		}
+	}
+
+	return sandwich(hinv, omega), nil
+}
+
+func (s *SurveyFit) vcovReplicate() ([][]float64, error) {
+	r := len(s.Design.Replicates)
+	scale := s.Design.ReplicateScale
+	if scale == 0 {
+		scale = 1 / float64(r)
+	}
+	p := s.P
+	cov := make([][]float64, p)
+	for a := range cov {
+		cov[a] = make([]float64, p)
+	}
+	for k, w := range s.Design.Replicates {
+		rep, err := RQWeighted(s.Y, s.X, w, s.Tau)
+		if err != nil {
+			return nil, fmt.Errorf("replicate %d failed: %w", k, err)
+		}
+		for a := 0; a < p; a++ {
+			for b := 0; b < p; b++ {
+				da := rep.Coefficients[a] - s.Coefficients[a]
+				db := rep.Coefficients[b] - s.Coefficients[b]
+				cov[a][b] += scale * da * db
+			}
+		}
+	}
+	return cov, nil
+}
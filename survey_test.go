@@ -0,0 +1,69 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQSurvey(t *testing.T) {
+	y, x := inferenceData()
+	n := len(y)
+	w := make([]float64, n)
+	strata := make([]int, n)
+	psu := make([]int, n)
+	for i := range w {
+		w[i] = 1 + float64(i%3)
+		strata[i] = i % 2
+		psu[i] = i / 4
+	}
+
+	fit, err := RQSurvey(y, x, SurveyDesign{Weights: w, Strata: strata, PSU: psu}, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit survey model: %v", err)
+	}
+	se, err := fit.StdErrors()
+	if err != nil {
+		t.Fatalf("Failed to compute linearized standard errors: %v", err)
+	}
+	for j, v := range se {
+		if v <= 0 || math.IsNaN(v) {
+			t.Errorf("Expected positive standard error for coefficient %d, got %f", j, v)
+		}
+		if got := fit.SummaryTable().Coefficients[j].StdErr; math.Abs(got-v) > 1e-12 {
+			t.Errorf("Expected design-based standard error %f in the summary, got %f", v, got)
+		}
+	}
+
+	// Replicate weights: delete-one-PSU jackknife
+	var reps [][]float64
+	for g := 0; g < n/4; g++ {
+		rw := make([]float64, n)
+		for i := range rw {
+			if psu[i] != g {
+				rw[i] = w[i] * float64(n/4) / float64(n/4-1)
+			}
+		}
+		reps = append(reps, rw)
+	}
+	r := float64(len(reps))
+	fit, err = RQSurvey(y, x, SurveyDesign{Weights: w, Replicates: reps, ReplicateScale: (r - 1) / r}, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit survey model: %v", err)
+	}
+	cov, err := fit.Vcov()
+	if err != nil {
+		t.Fatalf("Failed to compute replicate covariance: %v", err)
+	}
+	if cov[0][0] < 0 || math.Abs(cov[0][1]-cov[1][0]) > 1e-12 {
+		t.Errorf("Unexpected replicate covariance: %v", cov)
+	}
+
+	// Error cases
+	if _, err := RQSurvey(y, x, SurveyDesign{Weights: w, Strata: strata[:2]}, 0.5); err == nil {
+		t.Error("Expected error for mismatched strata")
+	}
+	single, _ := RQSurvey(y, x, SurveyDesign{Weights: w, PSU: make([]int, n)}, 0.5)
+	if _, err := single.Vcov(); err == nil {
+		t.Error("Expected error for a stratum with a single PSU")
+	}
+}
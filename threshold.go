@@ -0,0 +1,160 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Threshold model types
+const (
+	KinkModel      = "kink"      // Continuous, with the slope on q changing at the threshold
+	ThresholdModel = "threshold" // All coefficients switch at the threshold
+)
+
+// ThresholdOptions controls RQThreshold
+type ThresholdOptions struct {
+	Type  string  // KinkModel or ThresholdModel (default KinkModel)
+	Grid  int     // Number of candidate thresholds (default 50)
+	Trim  float64 // Fraction of q excluded at each end of the candidate range (default 0.15)
+	Level float64 // Confidence level of the threshold interval (default 0.95)
+}
+
+// ThresholdFit is a quantile regression with a slope change at an estimated threshold
+type ThresholdFit struct {
+	*RQFit     // Fit at the estimated threshold
+	Type       string
+	Threshold  float64 // Estimated threshold
+	Lower      float64 // Lower confidence bound of the threshold
+	Upper      float64 // Upper confidence bound of the threshold
+	Level      float64
+	Candidates []float64 // Candidate thresholds
+	Loss       []float64 // Profiled check loss at each candidate
+	Below      []float64 // Coefficients in the regime q <= threshold
+	Above      []float64 // Coefficients in the regime q > threshold
+}
+
+// RQThreshold estimates a quantile regression whose coefficients change when q
+// crosses an unknown threshold g, chosen by profiling the check loss over
+// candidates between the Trim and 1-Trim quantiles of q.
+//
+// The kink model is Q_tau(y) = x'b + b1 (q-g)_- + b2 (q-g)_+, continuous in q with
+// segment slopes b1 and b2; Below and Above hold (b, b1) and (b, b2). The
+// threshold model fits x'b1 for q <= g and x'b2 for q > g.
+//
+// The confidence set collects the candidates whose quasi-likelihood ratio
+// 2 (V(g) - V(g_hat)) / (tau (1-tau) s), with s the Siddiqui sparsity at g_hat, is
+// below the critical value: the chi-squared(1) quantile for kinks and Hansen's
+// (2000) -2 log(1 - sqrt(level)) for thresholds.
+func RQThreshold(y []float64, x [][]float64, q []float64, tau float64, opts ThresholdOptions) (*ThresholdFit, error) {
+	if opts.Type == "" {
+		opts.Type = KinkModel
+	}
+	if opts.Grid == 0 {
+		opts.Grid = 50
+	}
+	if opts.Trim == 0 {
+		opts.Trim = 0.15
+	}
+	if opts.Level == 0 {
+		opts.Level = 0.95
+	}
+	if opts.Type != KinkModel && opts.Type != ThresholdModel {
+		return nil, fmt.Errorf("unknown threshold model %q", opts.Type)
+	}
+	if opts.Grid < 2 || opts.Trim <= 0 || opts.Trim >= 0.5 {
+		return nil, fmt.Errorf("invalid candidate grid %+v", opts)
+	}
+	if opts.Level <= 0 || opts.Level >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1")
+	}
+	if len(x) == 0 || len(x) != len(y) || len(q) != len(y) {
+		return nil, fmt.Errorf("x, q and y %w", ErrDimensionMismatch)
+	}
+
+	sorted := append([]float64(nil), q...)
+	sort.Float64s(sorted)
+	lo, hi := empiricalQuantile(sorted, opts.Trim), empiricalQuantile(sorted, 1-opts.Trim)
+	if lo == hi {
+		return nil, fmt.Errorf("no variation in q between the trimmed quantiles")
+	}
+
+	res := &ThresholdFit{
+		Type:       opts.Type,
+		Level:      opts.Level,
+		Candidates: make([]float64, opts.Grid),
+		Loss:       make([]float64, opts.Grid),
+	}
+	best := math.Inf(1)
+	for k := range res.Candidates {
+		g := lo + (hi-lo)*float64(k)/float64(opts.Grid-1)
+		fit, err := RQ(y, thresholdDesign(x, q, g, opts.Type), tau)
+		if err != nil {
+			return nil, fmt.Errorf("fit failed at threshold %f: %w", g, err)
+		}
+		res.Candidates[k] = g
+		res.Loss[k] = fit.Rho()
+		if res.Loss[k] < best {
+			best = res.Loss[k]
+			res.RQFit = fit
+			res.Threshold = g
+		}
+	}
+
+	sparsity, err := siddiquiSparsity(res.ResidualValues(), tau)
+	if err != nil {
+		return nil, err
+	}
+	crit := math.Pow(normQuantile((1+opts.Level)/2), 2)
+	if opts.Type == ThresholdModel {
+		crit = -2 * math.Log(1-math.Sqrt(opts.Level))
+	}
+	res.Lower, res.Upper = res.Threshold, res.Threshold
+	for k, g := range res.Candidates {
+		if 2*(res.Loss[k]-best)/(tau*(1-tau)*sparsity) <= crit {
+			res.Lower = math.Min(res.Lower, g)
+			res.Upper = math.Max(res.Upper, g)
+		}
+	}
+
+	p := len(x[0])
+	c := res.Coefficients
+	if opts.Type == KinkModel {
+		res.Below = append(append([]float64(nil), c[:p]...), c[p])
+		res.Above = append(append([]float64(nil), c[:p]...), c[p+1])
+	} else {
+		res.Below = append([]float64(nil), c[:p]...)
+		res.Above = append([]float64(nil), c[p:]...)
+	}
+	return res, nil
+}
+
+// thresholdDesign expands x for the threshold g
+func thresholdDesign(x [][]float64, q []float64, g float64, model string) [][]float64 {
+	design := make([][]float64, len(x))
+	p := len(x[0])
+	for i, row := range x {
+		d := make([]float64, 2*p)
+		switch {
+		case model == KinkModel:
+			d = append(append(d[:0], row...), math.Min(q[i]-g, 0), math.Max(q[i]-g, 0))
+		case q[i] <= g:
+			copy(d, row)
+		default:
+			copy(d[p:], row)
+		}
+		design[i] = d
+	}
+	return design
+}
+
+// PredictThreshold returns the fitted quantiles at new covariates and threshold variable
+func (f *ThresholdFit) PredictThreshold(newX [][]float64, newQ []float64) ([]float64, error) {
+	if len(newX) != len(newQ) {
+		return nil, fmt.Errorf("x and q %w", ErrDimensionMismatch)
+	}
+	if len(newX) == 0 {
+		return nil, nil
+	}
+	return f.RQFit.Predict(thresholdDesign(newX, newQ, f.Threshold, f.Type))
+}
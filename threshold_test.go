@@ -0,0 +1,66 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQThreshold(t *testing.T) {
+	// Slope on q jumps from 0.5 to 2.5 at q = 6
+	n := 200
+	y := make([]float64, n)
+	x := make([][]float64, n)
+	q := make([]float64, n)
+	for i := 0; i < n; i++ {
+		q[i] = 10 * float64(i) / float64(n)
+		x[i] = []float64{1}
+		y[i] = 1 + 0.5*q[i] + 2*math.Max(q[i]-6, 0) + 0.2*math.Sin(float64(11*i))
+	}
+
+	fit, err := RQThreshold(y, x, q, 0.5, ThresholdOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fit kink model: %v", err)
+	}
+	if math.Abs(fit.Threshold-6) > 1 {
+		t.Errorf("Expected threshold near 6, got %v", fit.Threshold)
+	}
+	if fit.Lower > fit.Threshold || fit.Upper < fit.Threshold {
+		t.Errorf("Threshold %v outside interval [%v, %v]", fit.Threshold, fit.Lower, fit.Upper)
+	}
+	if len(fit.Below) != 2 || len(fit.Above) != 2 {
+		t.Fatalf("Expected 2 coefficients per regime, got %d and %d", len(fit.Below), len(fit.Above))
+	}
+	if fit.Above[1]-fit.Below[1] < 1 {
+		t.Errorf("Expected slope increase near 2, got %v to %v", fit.Below[1], fit.Above[1])
+	}
+
+	pred, err := fit.PredictThreshold([][]float64{{1}}, []float64{8})
+	if err != nil {
+		t.Fatalf("Failed to predict: %v", err)
+	}
+	if math.Abs(pred[0]-9) > 1.5 {
+		t.Errorf("Expected prediction near 9, got %v", pred[0])
+	}
+
+	// A jump in level is a threshold effect
+	for i := range y {
+		y[i] = 1 + 0.2*math.Sin(float64(11*i))
+		if q[i] > 4 {
+			y[i] += 3
+		}
+	}
+	jump, err := RQThreshold(y, x, q, 0.5, ThresholdOptions{Type: ThresholdModel})
+	if err != nil {
+		t.Fatalf("Failed to fit threshold model: %v", err)
+	}
+	if math.Abs(jump.Threshold-4) > 0.5 {
+		t.Errorf("Expected threshold near 4, got %v", jump.Threshold)
+	}
+	if d := jump.Above[0] - jump.Below[0]; math.Abs(d-3) > 0.5 {
+		t.Errorf("Expected jump near 3, got %v", d)
+	}
+
+	if _, err := RQThreshold(y, x, q, 0.5, ThresholdOptions{Type: "step"}); err == nil {
+		t.Error("Expected error for unknown model type")
+	}
+}
@@ -0,0 +1,103 @@
+package quantreg
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Tidy returns one row per coefficient with Powell kernel inference
+func (fit *RQFit) Tidy() []CoefRow {
+	se, _ := fit.StdErrors(SEKer)
+	return coefRows(fit.Tau, fit.Coefficients, se)
+}
+
+// Tidy returns the coefficient rows named by the design columns of the schema
+func (f *SchemaFit) Tidy() []CoefRow {
+	rows := f.RQFit.Tidy()
+	for j, name := range f.Schema.Columns() {
+		rows[j].Term = name
+	}
+	return rows
+}
+
+// Tidy returns the rows of the parametric coefficients
+func (f *PartiallyLinearFit) Tidy() []CoefRow {
+	return coefRows(f.Tau, f.Coefficients[:f.Linear], f.StdErrors)
+}
+
+// Tidy returns the coefficient rows of every tau, in tau order
+func (m *MultiRQFit) Tidy() []CoefRow {
+	var rows []CoefRow
+	for _, tau := range m.Taus {
+		rows = append(rows, m.Fits[tau].Tidy()...)
+	}
+	return rows
+}
+
+// Tidy returns one row per parameter with sandwich inference; inference fields
+// are NaN when the covariance cannot be estimated
+func (fit *NLRQFit) Tidy() []CoefRow {
+	se, _ := fit.StdErrors()
+	return coefRows(fit.Tau, fit.Coefficients, se)
+}
+
+// Tidy returns the parameter rows of every tau, in tau order
+func (m *MultiNLRQFit) Tidy() []CoefRow {
+	var rows []CoefRow
+	for _, tau := range m.Taus {
+		rows = append(rows, m.Fits[tau].Tidy()...)
+	}
+	return rows
+}
+
+// Tidy returns the debiased coefficients with their standard errors
+func (d *DebiasedFit) Tidy() []CoefRow {
+	return coefRows(d.Tau, d.Coefficients, d.StdErrors)
+}
+
+// Tidy returns the pooled coefficients with Rubin's total standard errors
+func (pf *PooledFit) Tidy() []CoefRow {
+	return coefRows(pf.Tau, pf.Coefficients, pf.StdErrors)
+}
+
+// Tidy returns the combined coefficients with their standard errors
+func (f *DCFit) Tidy() []CoefRow {
+	return coefRows(f.Tau, f.Coefficients, diagSqrt(f.Cov))
+}
+
+// Tidy returns the coefficients with their sandwich standard errors
+func (f *DistributedFit) Tidy() []CoefRow {
+	return coefRows(f.Tau, f.Coefficients, diagSqrt(f.Cov))
+}
+
+// diagSqrt returns the square roots of the diagonal of cov, or nil for no covariance
+func diagSqrt(cov [][]float64) []float64 {
+	if cov == nil {
+		return nil
+	}
+	se := make([]float64, len(cov))
+	for j := range cov {
+		se[j] = math.Sqrt(math.Max(cov[j][j], 0))
+	}
+	return se
+}
+
+// WriteTidyCSV writes the rows as CSV with a header line
+func WriteTidyCSV(w io.Writer, rows []CoefRow) error {
+	cw := csv.NewWriter(w)
+	header := []string{"term", "tau", "estimate", "std_err", "statistic", "p_value", "conf_low", "conf_high"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	f := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	for _, r := range rows {
+		rec := []string{r.Term, f(r.Tau), f(r.Estimate), f(r.StdErr), f(r.Statistic), f(r.PValue), f(r.ConfLow), f(r.ConfHigh)}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
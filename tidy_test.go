@@ -0,0 +1,54 @@
+package quantreg
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+)
+
+func TestTidy(t *testing.T) {
+	y, x := inferenceData()
+	multi, err := RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit process: %v", err)
+	}
+	rows := multi.Tidy()
+	if len(rows) != 6 {
+		t.Fatalf("Expected 6 rows, got %d", len(rows))
+	}
+	for k, row := range rows {
+		tau := multi.Taus[k/2]
+		if row.Tau != tau || row.Estimate != multi.Fits[tau].Coefficients[k%2] {
+			t.Errorf("Unexpected row %d: %+v", k, row)
+		}
+		if math.Abs(row.Statistic-row.Estimate/row.StdErr) > 1e-12 {
+			t.Errorf("Expected statistic estimate/stderr in row %d, got %f", k, row.Statistic)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTidyCSV(&buf, rows); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(records) != 7 || records[0][0] != "term" || records[1][0] != "Beta[0]" {
+		t.Errorf("Unexpected CSV: %v", records)
+	}
+
+	// Schema fits name their terms
+	frame := Frame{Numeric: map[string][]float64{"x": make([]float64, len(y))}}
+	for i := range y {
+		frame.Numeric["x"][i] = x[i][1]
+	}
+	sf, err := RQFrame(y, frame, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit frame: %v", err)
+	}
+	if named := sf.Tidy(); named[0].Term != "(Intercept)" || named[1].Term != "x" {
+		t.Errorf("Expected named terms, got %q and %q", named[0].Term, named[1].Term)
+	}
+}
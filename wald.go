@@ -0,0 +1,99 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+)
+
+// WaldTest reports a Wald test that a set of coefficients is jointly zero
+type WaldTest struct {
+	Tau       float64 // Quantile level
+	Terms     []int   // Tested coefficient indices
+	Statistic float64 // b' V^-1 b for the tested coefficients b
+	DF        int     // Degrees of freedom of the chi-square reference distribution
+	PValue    float64 // Asymptotic p-value
+}
+
+// WaldTest tests the null that the coefficients with the given indices are all
+// zero, using the covariance estimator se
+func (fit *RQFit) WaldTest(terms []int, se string) (*WaldTest, error) {
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("no coefficients to test")
+	}
+	for _, j := range terms {
+		if j < 0 || j >= fit.P {
+			return nil, fmt.Errorf("coefficient index %d out of range [0, %d)", j, fit.P)
+		}
+	}
+
+	cov, err := fit.Vcov(se)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]float64, len(terms))
+	sub := make([][]float64, len(terms))
+	for a, j := range terms {
+		b[a] = fit.Coefficients[j]
+		sub[a] = make([]float64, len(terms))
+		for c, k := range terms {
+			sub[a][c] = cov[j][k]
+		}
+	}
+	subinv, err := invert(sub)
+	if err != nil {
+		return nil, fmt.Errorf("covariance of tested coefficients is singular: %w", err)
+	}
+
+	stat := math.Max(quadForm(subinv, b), 0)
+	return &WaldTest{
+		Tau:       fit.Tau,
+		Terms:     terms,
+		Statistic: stat,
+		DF:        len(terms),
+		PValue:    chiSquareSF(stat, len(terms)),
+	}, nil
+}
+
+// ResetTest checks the functional form at fit.Tau by refitting with powers 2..maxPower
+// of the standardized fitted values added to the design and testing their joint
+// significance with a Wald test using the covariance estimator se. A weighted fit
+// is refitted with its weights. The design must contain an intercept.
+func (fit *RQFit) ResetTest(maxPower int, se string) (*WaldTest, error) {
+	if maxPower < 2 {
+		return nil, fmt.Errorf("maximum power must be at least 2")
+	}
+	if len(fit.X) == 0 || len(fit.Y) == 0 {
+		return nil, fmt.Errorf("fit does not carry its design matrix")
+	}
+	if !hasIntercept(fit.X) {
+		return nil, fmt.Errorf("design must contain an intercept column")
+	}
+
+	fitted := fit.FittedValues()
+	stats := computeStats(fitted)
+	if stats.StdDev == 0 {
+		return nil, fmt.Errorf("fitted values are constant")
+	}
+
+	aug := make([][]float64, fit.N)
+	for i, row := range fit.X {
+		z := (fitted[i] - stats.Mean) / stats.StdDev
+		aug[i] = make([]float64, fit.P, fit.P+maxPower-1)
+		copy(aug[i], row)
+		for k := 2; k <= maxPower; k++ {
+			aug[i] = append(aug[i], math.Pow(z, float64(k)))
+		}
+	}
+
+	augFit, err := (&RQFit{Y: fit.Y, X: aug, Weights: fit.Weights}).refit(fit.Tau)
+	if err != nil {
+		return nil, fmt.Errorf("augmented fit failed: %w", err)
+	}
+
+	terms := make([]int, maxPower-1)
+	for k := range terms {
+		terms[k] = fit.P + k
+	}
+	return augFit.WaldTest(terms, se)
+}
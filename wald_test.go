@@ -0,0 +1,110 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWaldTest(t *testing.T) {
+	y, x := inferenceData()
+
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	res, err := fit.WaldTest([]int{0, 1}, SEIID)
+	if err != nil {
+		t.Fatalf("Failed to run Wald test: %v", err)
+	}
+	if res.DF != 2 || res.Statistic <= 0 {
+		t.Errorf("Unexpected Wald test result: %+v", res)
+	}
+	if res.PValue > 0.05 {
+		t.Errorf("Expected a significant intercept and slope, got p=%f", res.PValue)
+	}
+
+	// Error cases
+	if _, err := fit.WaldTest(nil, SEIID); err == nil {
+		t.Error("Expected error for empty term list")
+	}
+	if _, err := fit.WaldTest([]int{2}, SEIID); err == nil {
+		t.Error("Expected error for out-of-range term")
+	}
+}
+
+func TestResetTest(t *testing.T) {
+	// Strongly convex relationship fitted linearly
+	n := 20
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xi := float64(i) / 4
+		x[i] = []float64{1, xi}
+		y[i] = xi*xi + 0.2*float64(i%3)
+	}
+
+	fit, err := RQ(y, x, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+
+	res, err := fit.ResetTest(3, SEIID)
+	if err != nil {
+		t.Fatalf("Failed to run RESET test: %v", err)
+	}
+	if res.DF != 2 || len(res.Terms) != 2 || res.Terms[0] != 2 {
+		t.Errorf("Unexpected RESET test terms: %+v", res)
+	}
+	if res.PValue > 0.05 {
+		t.Errorf("Expected the omitted curvature to be detected, got p=%f", res.PValue)
+	}
+
+	if _, err := fit.ResetTest(1, SEIID); err == nil {
+		t.Error("Expected error for maximum power below 2")
+	}
+
+	// A weighted fit is refitted with its weights
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 1 + float64(i%4)
+	}
+	weighted, err := RQWeighted(y, x, w, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit weighted model: %v", err)
+	}
+	res, err = weighted.ResetTest(3, SEIID)
+	if err != nil {
+		t.Fatalf("Failed to run weighted RESET test: %v", err)
+	}
+	fitted := weighted.FittedValues()
+	stats := computeStats(fitted)
+	aug := make([][]float64, n)
+	for i := range aug {
+		z := (fitted[i] - stats.Mean) / stats.StdDev
+		aug[i] = []float64{1, x[i][1], z * z, z * z * z}
+	}
+	augFit, err := RQWeighted(y, aug, w, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit augmented model: %v", err)
+	}
+	want, err := augFit.WaldTest([]int{2, 3}, SEIID)
+	if err != nil {
+		t.Fatalf("Failed to run Wald test: %v", err)
+	}
+	if math.Abs(res.Statistic-want.Statistic) > 1e-9*math.Max(1, want.Statistic) {
+		t.Errorf("Expected weighted RESET statistic %f, got %f", want.Statistic, res.Statistic)
+	}
+
+	noIntercept := make([][]float64, n)
+	for i := range x {
+		noIntercept[i] = x[i][1:]
+	}
+	fit, err = RQ(y, noIntercept, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit model: %v", err)
+	}
+	if _, err := fit.ResetTest(3, SEIID); err == nil {
+		t.Error("Expected error for a design without an intercept")
+	}
+}
@@ -0,0 +1,108 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RQWeighted fits a quantile regression minimizing sum w_i rho_tau(y_i - x_i'b).
+// Since rho_tau is positively homogeneous this is the unweighted problem on
+// (w_i y_i, w_i x_i); the returned fit carries the original data and weights.
+func RQWeighted(y []float64, x [][]float64, w []float64, tau float64) (*RQFit, error) {
+	if len(w) != len(y) {
+		return nil, fmt.Errorf("%w: %d weights for %d observations", ErrDimensionMismatch, len(w), len(y))
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("x and y %w", ErrDimensionMismatch)
+	}
+	total := 0.0
+	for _, v := range w {
+		if v < 0 || math.IsNaN(v) {
+			return nil, fmt.Errorf("weights must be non-negative, got %f", v)
+		}
+		total += v
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("all weights are zero")
+	}
+
+	wy := make([]float64, len(y))
+	wx := make([][]float64, len(x))
+	for i := range y {
+		wy[i] = w[i] * y[i]
+		wx[i] = make([]float64, len(x[i]))
+		for j, v := range x[i] {
+			wx[i][j] = w[i] * v
+		}
+	}
+
+	fit, err := RQ(wy, wx, tau)
+	if err != nil {
+		return nil, err
+	}
+	fit.X = x
+	fit.Y = y
+	fit.Weights = w
+	fit.setFitted(y, x, false)
+	return fit, nil
+}
+
+// TimeVaryingFit holds local quantile regression coefficients over time
+type TimeVaryingFit struct {
+	Tau          float64
+	Bandwidth    float64
+	Times        []float64   // Target times
+	Coefficients [][]float64 // Coefficients at each target time
+	EffectiveN   []float64   // Kish effective sample size of the kernel weights at each target
+	Meta         Meta        // Reproducibility metadata
+}
+
+// RQTimeVarying estimates coefficient trajectories by fitting, at each target
+// time, a quantile regression with Gaussian kernel weights exp(-((t_i-t)/h)^2/2)
+// in the observation times. Small bandwidths track drift closely at the cost of
+// noisier estimates.
+func RQTimeVarying(y []float64, x [][]float64, times, targets []float64, tau, bandwidth float64) (*TimeVaryingFit, error) {
+	start := time.Now()
+	if len(times) != len(y) {
+		return nil, fmt.Errorf("%w: %d times for %d observations", ErrDimensionMismatch, len(times), len(y))
+	}
+	if bandwidth <= 0 {
+		return nil, fmt.Errorf("bandwidth must be positive, got %f", bandwidth)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no target times")
+	}
+
+	sorted := append([]float64(nil), targets...)
+	sort.Float64s(sorted)
+	res := &TimeVaryingFit{Tau: tau, Bandwidth: bandwidth, Times: sorted}
+	w := make([]float64, len(y))
+	for _, t0 := range sorted {
+		sum, sumSq := 0.0, 0.0
+		for i, ti := range times {
+			u := (ti - t0) / bandwidth
+			w[i] = math.Exp(-u * u / 2)
+			sum += w[i]
+			sumSq += w[i] * w[i]
+		}
+		fit, err := RQWeighted(y, x, w, tau)
+		if err != nil {
+			return nil, fmt.Errorf("local fit at t=%f failed: %w", t0, err)
+		}
+		res.Coefficients = append(res.Coefficients, fit.Coefficients)
+		res.EffectiveN = append(res.EffectiveN, sum*sum/sumSq)
+	}
+	res.Meta = newMeta("br", map[string]float64{"bandwidth": bandwidth}, []float64{tau}, len(y), len(x[0]), start, y, x)
+	return res, nil
+}
+
+// Trajectory returns coefficient j across the target times
+func (f *TimeVaryingFit) Trajectory(j int) []float64 {
+	out := make([]float64, len(f.Coefficients))
+	for k, c := range f.Coefficients {
+		out[k] = c[j]
+	}
+	return out
+}
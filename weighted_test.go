@@ -0,0 +1,139 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRQWeighted(t *testing.T) {
+	x := [][]float64{{1, 0.5}, {1, 1.0}, {1, 1.5}, {1, 2.0}, {1, 2.5}}
+	y := []float64{1.0, 2.0, 2.5, 3.0, 4.0}
+
+	// Unit weights reproduce the unweighted fit
+	w := []float64{1, 1, 1, 1, 1}
+	fit, err := RQWeighted(y, x, w, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit weighted model: %v", err)
+	}
+	plain, _ := RQ(y, x, 0.5)
+	for j := range fit.Coefficients {
+		if math.Abs(fit.Coefficients[j]-plain.Coefficients[j]) > 1e-12 {
+			t.Errorf("Coefficient %d: got %f, want %f", j, fit.Coefficients[j], plain.Coefficients[j])
+		}
+	}
+
+	w = []float64{2, 0, 1, 1, 0.5}
+	fit, err = RQWeighted(y, x, w, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit weighted model: %v", err)
+	}
+	if fit.Y[1] != y[1] || fit.X[1][1] != x[1][1] || fit.Weights[0] != 2 {
+		t.Error("Expected fit to carry the original data and weights")
+	}
+	for i := range y {
+		if math.Abs(fit.Residuals[i]-(y[i]-fit.Fitted[i])) > 1e-12 {
+			t.Errorf("Residual %d does not refer to the original response", i)
+		}
+	}
+
+	// Error cases
+	if _, err := RQWeighted(y, x, []float64{1, -1, 1, 1, 1}, 0.5); err == nil {
+		t.Error("Expected error for negative weight")
+	}
+	if _, err := RQWeighted(y, x, make([]float64, 5), 0.5); err == nil {
+		t.Error("Expected error for all-zero weights")
+	}
+	if _, err := RQWeighted(y, x, w[:2], 0.5); err == nil {
+		t.Error("Expected error for mismatched weights")
+	}
+}
+
+func TestRQWeightedInference(t *testing.T) {
+	y, x := inferenceData()
+	w := make([]float64, len(y))
+	for i := range w {
+		w[i] = float64(1 + i%3)
+	}
+	fit, err := RQWeighted(y, x, w, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit weighted model: %v", err)
+	}
+
+	// The kernel sandwich carries the weights in H = X'WfX and in X'W^2X
+	f, err := fit.kernelDensities()
+	if err != nil {
+		t.Fatalf("Failed to estimate densities: %v", err)
+	}
+	wf := make([]float64, len(w))
+	w2 := make([]float64, len(w))
+	for i := range w {
+		wf[i], w2[i] = w[i]*f[i], w[i]*w[i]
+	}
+	hinv, _ := invert(crossprod(x, wf))
+	want := scaleMatrix(sandwich(hinv, crossprod(x, w2)), 0.25)
+	cov, err := fit.Vcov(SEKer)
+	if err != nil {
+		t.Fatalf("Failed to compute covariance: %v", err)
+	}
+	for a := range want {
+		for b := range want[a] {
+			if math.Abs(cov[a][b]-want[a][b]) > 1e-12*math.Abs(want[a][b]) {
+				t.Errorf("Covariance [%d][%d]: got %v, want %v", a, b, cov[a][b], want[a][b])
+			}
+		}
+	}
+
+	// Integer weights match replicated observations in the goodness of fit
+	var ry []float64
+	var rx [][]float64
+	for i := range y {
+		for k := 0; k < int(w[i]); k++ {
+			ry = append(ry, y[i])
+			rx = append(rx, x[i])
+		}
+	}
+	rep, err := RQ(ry, rx, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to fit replicated model: %v", err)
+	}
+	r1, err := fit.R1()
+	if err != nil {
+		t.Fatalf("Failed to compute R1: %v", err)
+	}
+	if want, _ := rep.R1(); math.Abs(r1-want) > 1e-9 {
+		t.Errorf("Expected weighted R1 %v, got %v", want, r1)
+	}
+}
+
+func TestRQTimeVarying(t *testing.T) {
+	n := 12
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	times := make([]float64, n)
+	for i := 0; i < n; i++ {
+		times[i] = float64(i)
+		x[i] = []float64{1, float64(i%3) / 2}
+		y[i] = 1 + float64(i)/6*x[i][1]
+	}
+
+	tv, err := RQTimeVarying(y, x, times, []float64{9, 2}, 0.5, 2)
+	if err != nil {
+		t.Fatalf("Failed to fit time-varying model: %v", err)
+	}
+	if tv.Times[0] != 2 || len(tv.Coefficients) != 2 || len(tv.Trajectory(1)) != 2 {
+		t.Errorf("Unexpected result: %+v", tv)
+	}
+	for _, en := range tv.EffectiveN {
+		if en <= 1 || en >= float64(n) {
+			t.Errorf("Expected effective sample size between 1 and %d, got %f", n, en)
+		}
+	}
+
+	// Error cases
+	if _, err := RQTimeVarying(y, x, times, []float64{1}, 0.5, 0); err == nil {
+		t.Error("Expected error for zero bandwidth")
+	}
+	if _, err := RQTimeVarying(y, x, times[:3], []float64{1}, 0.5, 1); err == nil {
+		t.Error("Expected error for mismatched times")
+	}
+}
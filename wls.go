@@ -0,0 +1,158 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Linear solvers for the weighted least-squares subproblems of Newton-type solvers
+const (
+	SolverNormal = "normal" // Invert the normal equations X'WX directly
+	SolverQR     = "qr"     // Factor W^1/2 X by QR and solve the corrected semi-normal equations
+	SolverAuto   = "auto"   // Normal equations, switching to QR when X'WX is ill-conditioned
+)
+
+// qrGramCondition is the 1-norm condition number of X'WX beyond which SolverAuto
+// switches to QR
+const qrGramCondition = 1e10
+
+var (
+	linearSolverMu sync.RWMutex
+	linearSolver   = SolverNormal
+)
+
+// SetLinearSolver selects how the weighted least-squares subproblems inside the
+// Frisch-Newton, one-step, divide-and-conquer and propensity-score Newton
+// iterations are solved (default SolverNormal). SolverQR never forms X'WX and so
+// does not square the condition number of the design, at roughly twice the cost.
+func SetLinearSolver(name string) error {
+	switch name {
+	case SolverNormal, SolverQR, SolverAuto:
+	default:
+		return fmt.Errorf("unknown linear solver %q", name)
+	}
+	linearSolverMu.Lock()
+	linearSolver = name
+	linearSolverMu.Unlock()
+	return nil
+}
+
+// currentLinearSolver returns the selected linear solver
+func currentLinearSolver() string {
+	linearSolverMu.RLock()
+	defer linearSolverMu.RUnlock()
+	return linearSolver
+}
+
+// weightedSolve solves (X'WX) b = g with the selected linear solver and also
+// returns (X'WX)^-1
+func weightedSolve(x [][]float64, w, g []float64) ([]float64, [][]float64, error) {
+	solver := currentLinearSolver()
+	if solver == SolverQR {
+		return weightedSolveQR(x, w, g)
+	}
+	gram := crossprod(x, w)
+	inv, err := invert(gram)
+	if solver == SolverAuto && (err != nil || norm1(gram)*norm1(inv) > qrGramCondition) {
+		return weightedSolveQR(x, w, g)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return matVec(inv, g), inv, nil
+}
+
+// weightedSolveQR solves (X'WX) b = g from the triangular factor R of W^1/2 X,
+// so that X'WX = R'R, followed by one step of iterative refinement whose residual
+// is computed from x rather than from R'R (Björck's corrected semi-normal equations)
+func weightedSolveQR(x [][]float64, w, g []float64) ([]float64, [][]float64, error) {
+	n, p := len(x), len(g)
+	if n < p {
+		return nil, nil, ErrSingularDesign
+	}
+	a := mat.NewDense(n, p, nil)
+	for i, row := range x {
+		s := 1.0
+		if w != nil {
+			s = math.Sqrt(w[i])
+		}
+		for j, v := range row {
+			a.Set(i, j, s*v)
+		}
+	}
+	var qr mat.QR
+	qr.Factorize(a)
+	var r mat.Dense
+	qr.RTo(&r)
+
+	maxDiag := 0.0
+	for j := 0; j < p; j++ {
+		maxDiag = math.Max(maxDiag, math.Abs(r.At(j, j)))
+	}
+	for j := 0; j < p; j++ {
+		if math.Abs(r.At(j, j)) <= 1e-12*math.Max(maxDiag, 1e-300) {
+			return nil, nil, ErrSingularDesign
+		}
+	}
+
+	solve := func(rhs []float64) []float64 {
+		// R'z = rhs by forward substitution, then R b = z by back substitution
+		z := make([]float64, p)
+		for j := 0; j < p; j++ {
+			s := rhs[j]
+			for k := 0; k < j; k++ {
+				s -= r.At(k, j) * z[k]
+			}
+			z[j] = s / r.At(j, j)
+		}
+		b := make([]float64, p)
+		for j := p - 1; j >= 0; j-- {
+			s := z[j]
+			for k := j + 1; k < p; k++ {
+				s -= r.At(j, k) * b[k]
+			}
+			b[j] = s / r.At(j, j)
+		}
+		return b
+	}
+
+	b := solve(g)
+	resid := append([]float64(nil), g...)
+	for i, row := range x {
+		v := dot(row, b)
+		if w != nil {
+			v *= w[i]
+		}
+		for j, xv := range row {
+			resid[j] -= xv * v
+		}
+	}
+	for j, d := range solve(resid) {
+		b[j] += d
+	}
+
+	inv := make([][]float64, p)
+	unit := make([]float64, p)
+	for j := range inv {
+		unit[j] = 1
+		inv[j] = solve(unit)
+		unit[j] = 0
+	}
+	return b, inv, nil
+}
+
+// norm1 returns the maximum absolute column sum of a
+func norm1(a [][]float64) float64 {
+	out := 0.0
+	for j := range a[0] {
+		s := 0.0
+		for i := range a {
+			s += math.Abs(a[i][j])
+		}
+		out = math.Max(out, s)
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package quantreg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightedSolveQR(t *testing.T) {
+	n := 40
+	x := make([][]float64, n)
+	w := make([]float64, n)
+	for i := range x {
+		s := float64(i) / float64(n)
+		x[i] = []float64{1, s, s + 1e-6*math.Sin(float64(3*i))}
+		w[i] = 0.5 + s
+	}
+	want := []float64{1, -2, 3}
+	g := make([]float64, 3)
+	for i, row := range x {
+		v := w[i] * dot(row, want)
+		for j, xv := range row {
+			g[j] += xv * v
+		}
+	}
+
+	// The normal equations are numerically singular, the QR path is not
+	if _, _, err := weightedSolve(x, w, g); err != ErrSingularDesign {
+		t.Errorf("Expected ErrSingularDesign from the normal equations, got %v", err)
+	}
+	b, inv, err := weightedSolveQR(x, w, g)
+	if err != nil {
+		t.Fatalf("Failed to solve by QR: %v", err)
+	}
+	for j := range want {
+		if math.Abs(b[j]-want[j]) > 1e-2 {
+			t.Errorf("Expected coefficient %d to be %f, got %f", j, want[j], b[j])
+		}
+	}
+	if len(inv) != 3 || inv[0][0] <= 0 {
+		t.Errorf("Expected a positive definite inverse, got %v", inv)
+	}
+	SetLinearSolver(SolverAuto)
+	_, _, err = weightedSolve(x, w, g)
+	SetLinearSolver(SolverNormal)
+	if err != nil {
+		t.Errorf("Expected the auto solver to fall back to QR, got %v", err)
+	}
+
+	// On a well-conditioned design both solvers agree
+	for i := range x {
+		x[i][2] = math.Cos(float64(i))
+	}
+	normal, normalInv, err := weightedSolve(x, w, g)
+	if err != nil {
+		t.Fatalf("Failed to solve normal equations: %v", err)
+	}
+	qr, qrInv, err := weightedSolveQR(x, w, g)
+	if err != nil {
+		t.Fatalf("Failed to solve by QR: %v", err)
+	}
+	for j := range normal {
+		if math.Abs(normal[j]-qr[j]) > 1e-9 || math.Abs(normalInv[j][j]-qrInv[j][j]) > 1e-9 {
+			t.Errorf("Expected solvers to agree on coefficient %d, got %f and %f", j, normal[j], qr[j])
+		}
+	}
+
+	if _, _, err := weightedSolveQR([][]float64{{1, 1}, {2, 2}, {3, 3}}, nil, []float64{1, 1}); err != ErrSingularDesign {
+		t.Errorf("Expected ErrSingularDesign, got %v", err)
+	}
+}
+
+func TestSetLinearSolver(t *testing.T) {
+	defer SetLinearSolver(SolverNormal)
+	if err := SetLinearSolver("cholesky"); err == nil {
+		t.Error("Expected error for unknown solver")
+	}
+
+	n := 24
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = []float64{1, float64(i%8) / 2}
+		y[i] = 1 + x[i][1] + 0.5*math.Sin(float64(7*i))
+	}
+	var coefs [][]float64
+	for _, s := range []string{SolverNormal, SolverQR, SolverAuto} {
+		if err := SetLinearSolver(s); err != nil {
+			t.Fatalf("Failed to select %s: %v", s, err)
+		}
+		fit, err := RQDivideConquer(y, x, 0.5, DCOptions{Blocks: 3, Combine: DCOneStep})
+		if err != nil {
+			t.Fatalf("Failed to fit with %s solver: %v", s, err)
+		}
+		coefs = append(coefs, fit.Coefficients)
+	}
+	for _, c := range coefs[1:] {
+		for j := range c {
+			if math.Abs(c[j]-coefs[0][j]) > 1e-8 {
+				t.Errorf("Expected coefficient %d to match normal equations %f, got %f", j, coefs[0][j], c[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package quantreg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// WormData holds detrended normal QQ (worm plot) coordinates for the residuals of one fit
+type WormData struct {
+	Tau         float64   // Quantile level
+	Theoretical []float64 // Unit normal quantiles at the plotting positions
+	Deviation   []float64 // Standardized ordered residuals minus the theoretical quantiles
+	Lower       []float64 // Lower pointwise envelope of the deviation
+	Upper       []float64 // Upper pointwise envelope of the deviation
+}
+
+// WormData computes worm plot coordinates for the residuals. Residuals are centred
+// at their median and scaled by the normalized MAD. The envelope is taken from nsim
+// simulated normal samples standardized the same way, at the given pointwise level;
+// with nsim <= 0 the asymptotic normal order statistic bounds are used instead.
+func (fit *RQFit) WormData(nsim int, level float64, rng *rand.Rand) (*WormData, error) {
+	if level <= 0 || level >= 1 {
+		return nil, fmt.Errorf("envelope level must be between 0 and 1")
+	}
+
+	resid := fit.ResidualValues()
+	n := len(resid)
+	if n < 3 {
+		return nil, fmt.Errorf("need at least 3 residuals, got %d", n)
+	}
+
+	dev, err := standardizedOrder(resid)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WormData{
+		Tau:         fit.Tau,
+		Theoretical: make([]float64, n),
+		Deviation:   make([]float64, n),
+		Lower:       make([]float64, n),
+		Upper:       make([]float64, n),
+	}
+	for i := range dev {
+		w.Theoretical[i] = normQuantile((float64(i) + 0.5) / float64(n))
+		w.Deviation[i] = dev[i] - w.Theoretical[i]
+	}
+
+	if nsim <= 0 {
+		z := normQuantile(1 - (1-level)/2)
+		for i, q := range w.Theoretical {
+			p := (float64(i) + 0.5) / float64(n)
+			se := math.Sqrt(p*(1-p)/float64(n)) / normPDF(q)
+			w.Lower[i] = -z * se
+			w.Upper[i] = z * se
+		}
+		return w, nil
+	}
+
+	// Simulate standardized normal samples and take pointwise quantiles of their worms
+	rng = randOrDefault(rng)
+	sims := make([][]float64, n)
+	for i := range sims {
+		sims[i] = make([]float64, 0, nsim)
+	}
+	sample := make([]float64, n)
+	for s := 0; s < nsim; s++ {
+		for i := range sample {
+			sample[i] = rng.NormFloat64()
+		}
+		ordered, err := standardizedOrder(sample)
+		if err != nil {
+			continue
+		}
+		for i, v := range ordered {
+			sims[i] = append(sims[i], v-w.Theoretical[i])
+		}
+	}
+	for i := range sims {
+		sort.Float64s(sims[i])
+		w.Lower[i] = empiricalQuantile(sims[i], (1-level)/2)
+		w.Upper[i] = empiricalQuantile(sims[i], 1-(1-level)/2)
+	}
+
+	return w, nil
+}
+
+// WormData computes worm plot coordinates for every tau
+func (m *MultiRQFit) WormData(nsim int, level float64, rng *rand.Rand) (map[float64]*WormData, error) {
+	rng = randOrDefault(rng)
+	worms := make(map[float64]*WormData)
+	for _, tau := range m.Taus {
+		w, err := m.Fits[tau].WormData(nsim, level, rng)
+		if err != nil {
+			return nil, fmt.Errorf("worm data failed for tau=%f: %w", tau, err)
+		}
+		worms[tau] = w
+	}
+	return worms, nil
+}
+
+// standardizedOrder returns the sorted values of data centred at the median and
+// scaled by the normalized median absolute deviation
+func standardizedOrder(data []float64) ([]float64, error) {
+	z, err := robustStandardize(data)
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(z)
+	return z, nil
+}
+
+// robustStandardize centres data at its median and scales it by the normalized
+// median absolute deviation, preserving the original order
+func robustStandardize(data []float64) ([]float64, error) {
+	sorted := make([]float64, len(data))
+	copy(sorted, data)
+	sort.Float64s(sorted)
+
+	center := empiricalQuantile(sorted, 0.5)
+	abs := make([]float64, len(sorted))
+	for i, v := range sorted {
+		abs[i] = math.Abs(v - center)
+	}
+	sort.Float64s(abs)
+	scale := 1.4826 * empiricalQuantile(abs, 0.5)
+	if scale == 0 {
+		return nil, fmt.Errorf("residual scale is zero")
+	}
+
+	z := make([]float64, len(data))
+	for i, v := range data {
+		z[i] = (v - center) / scale
+	}
+	return z, nil
+}
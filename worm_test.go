@@ -0,0 +1,78 @@
+package quantreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWormData(t *testing.T) {
+	// Normal scores produce a flat worm
+	fit := &RQFit{Tau: 0.5, Residuals: []float64{-1.2816, -0.5244, 0, 0.5244, 1.2816}}
+
+	w, err := fit.WormData(0, 0.95, nil)
+	if err != nil {
+		t.Fatalf("Failed to compute worm data: %v", err)
+	}
+	if math.Abs(w.Deviation[2]) > 1e-12 {
+		t.Errorf("Expected zero deviation at the median, got %f", w.Deviation[2])
+	}
+	for i := range w.Deviation {
+		if w.Lower[i] >= 0 || w.Upper[i] <= 0 {
+			t.Errorf("Invalid envelope at %d: [%f, %f]", i, w.Lower[i], w.Upper[i])
+		}
+	}
+
+	// Simulated envelopes are reproducible with a seeded source
+	a, err := fit.WormData(200, 0.9, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to compute simulated worm data: %v", err)
+	}
+	b, _ := fit.WormData(200, 0.9, rand.New(rand.NewSource(1)))
+	for i := range a.Lower {
+		if a.Lower[i] != b.Lower[i] || a.Upper[i] != b.Upper[i] {
+			t.Fatal("Expected identical envelopes for identical seeds")
+		}
+		if a.Lower[i] > a.Upper[i] {
+			t.Errorf("Envelope inverted at %d", i)
+		}
+	}
+
+	// Error cases
+	if _, err := fit.WormData(0, 1.5, nil); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+	if _, err := (&RQFit{Residuals: []float64{1, 1, 1}}).WormData(0, 0.95, nil); err == nil {
+		t.Error("Expected error for zero residual scale")
+	}
+}
+
+func TestMultiWormData(t *testing.T) {
+	x := [][]float64{
+		{1, 0.5},
+		{1, 1.0},
+		{1, 1.5},
+		{1, 2.0},
+		{1, 2.5},
+	}
+	// No three points are collinear, so the exact median fit leaves a nonzero MAD
+	y := []float64{1.0, 2.2, 2.5, 3.1, 4.1}
+
+	fits, err := RQProcess(y, x, []float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Fatalf("Failed to fit models: %v", err)
+	}
+
+	worms, err := fits.WormData(50, 0.95, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("Failed to compute worm data: %v", err)
+	}
+	if len(worms) != 3 {
+		t.Errorf("Expected worm data for 3 quantiles, got %d", len(worms))
+	}
+	for tau, w := range worms {
+		if w.Tau != tau || len(w.Deviation) != fits.N {
+			t.Errorf("Unexpected worm data for tau=%f", tau)
+		}
+	}
+}